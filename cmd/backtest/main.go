@@ -0,0 +1,199 @@
+// Command backtest replays a guild's match history through the rating engines and reports
+// how well each engine, seeding strategy and config predicted the results.
+//
+//	go run ./cmd/backtest -discord-guild 123456789 -from 2025-01-01 -weights 1:1.5:1.2,1:2:1
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"usl-server/internal/config"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+
+	"github.com/supabase-community/supabase-go"
+)
+
+const dateLayout = "2006-01-02"
+
+func main() {
+	guildID := flag.Int64("guild", 0, "internal guild ID to replay")
+	discordGuildID := flag.String("discord-guild", "", "Discord guild ID to replay, instead of -guild")
+	from := flag.String("from", "", "replay matches played on or after this date (YYYY-MM-DD)")
+	to := flag.String("to", "", "replay matches played before this date (YYYY-MM-DD)")
+	engines := flag.String("engines", "", "comma-separated engines to compare (default: all)")
+	seedings := flag.String("seeding", "", "comma-separated seeding strategies: tracker, default (default: both)")
+	weights := flag.String("weights", "", "comma-separated ones:twos:threes weight sets to compare against the running config")
+	configFile := flag.String("config", "", "JSON file with a list of {name, overrides} configs to compare")
+	bins := flag.Int("bins", services.DefaultCalibrationBins, "calibration table buckets")
+	asJSON := flag.Bool("json", false, "print the full report as JSON")
+	flag.Parse()
+
+	options := services.BacktestOptions{
+		GuildID:         *guildID,
+		Engines:         splitList(*engines),
+		Seedings:        splitList(*seedings),
+		CalibrationBins: *bins,
+	}
+
+	var err error
+	if *from != "" {
+		if options.From, err = time.Parse(dateLayout, *from); err != nil {
+			log.Fatalf("Invalid -from date: %v", err)
+		}
+	}
+	if *to != "" {
+		until, err := time.Parse(dateLayout, *to)
+		if err != nil {
+			log.Fatalf("Invalid -to date: %v", err)
+		}
+		options.To = &until
+	}
+	if options.Configs, err = loadConfigs(*weights, *configFile); err != nil {
+		log.Fatalf("Invalid configs: %v", err)
+	}
+
+	appConfig, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	client, err := supabase.NewClient(appConfig.Supabase.URL, appConfig.Supabase.ServiceRoleKey, nil)
+	if err != nil {
+		log.Fatalf("Failed to initialize Supabase client: %v", err)
+	}
+
+	if options.GuildID == 0 {
+		if *discordGuildID == "" {
+			log.Fatal("Either -guild or -discord-guild is required")
+		}
+		guild, err := repositories.NewGuildRepository(client, appConfig).FindGuildByDiscordID(*discordGuildID)
+		if err != nil || guild == nil {
+			log.Fatalf("Failed to find guild %s: %v", *discordGuildID, err)
+		}
+		options.GuildID = guild.ID
+	}
+
+	converter := services.NewPercentileConverter(appConfig)
+	rankDistributions := services.NewRankDistributionService(repositories.NewRankDistributionRepository(client, appConfig), converter, appConfig)
+	if err := rankDistributions.Reload(); err != nil {
+		log.Printf("Failed to load uploaded rank distributions, using built-in seasons: %v", err)
+	}
+
+	backtest := services.NewBacktestService(
+		repositories.NewMatchRepository(client, appConfig),
+		repositories.NewUserRepository(client, appConfig),
+		repositories.NewTrackerRepository(client, appConfig),
+		converter,
+		services.NewDataTransformationService(),
+		appConfig,
+	)
+
+	report, err := backtest.Run(options)
+	if err != nil {
+		log.Fatalf("Backtest failed: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to encode report: %v", err)
+		}
+		return
+	}
+	printReport(report)
+}
+
+// splitList reads a comma-separated flag, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadConfigs builds the configs to compare from -weights and -config
+// Any configs given are compared with the running config, which always comes first.
+func loadConfigs(weights, configFile string) ([]services.BacktestConfig, error) {
+	configs := []services.BacktestConfig{{Name: services.BacktestCurrentConfig}}
+
+	for _, set := range splitList(weights) {
+		parts := strings.Split(set, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("weight set %q must be ones:twos:threes", set)
+		}
+		values := make([]float64, 3)
+		for i, part := range parts {
+			value, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, fmt.Errorf("weight set %q: %w", set, err)
+			}
+			values[i] = value
+		}
+		configs = append(configs, services.BacktestConfig{
+			Name: "weights " + set,
+			Overrides: services.SimulationOverrides{
+				OnesWeight:   &values[0],
+				TwosWeight:   &values[1],
+				ThreesWeight: &values[2],
+			},
+		})
+	}
+
+	if configFile != "" {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+		var fileConfigs []services.BacktestConfig
+		if err := json.Unmarshal(data, &fileConfigs); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", configFile, err)
+		}
+		configs = append(configs, fileConfigs...)
+	}
+
+	return configs, nil
+}
+
+// printReport writes the run ranking and each run's calibration table
+func printReport(report *services.BacktestReport) {
+	fmt.Printf("Guild %d: %d matches replayed (%d skipped), %d players\n", report.GuildID, report.Matches, report.Skipped, report.Players)
+	fmt.Println("Tracker seeds use current ranks, so they see further ahead than the history did.")
+	fmt.Println()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "ENGINE\tSEEDING\tCONFIG\tWEIGHTS\tSEEDED\tPREDICTIONS\tDRAWS\tLOG-LOSS\tBRIER\tACCURACY\t")
+	for _, run := range report.Runs {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%.2f:%.2f:%.2f\t%d\t%d\t%d\t%.4f\t%.4f\t%.1f%%\t\n",
+			run.Engine, run.Seeding, run.Config,
+			run.Settings.OnesWeight, run.Settings.TwosWeight, run.Settings.ThreesWeight,
+			run.Seeded, run.Predictions, run.Draws, run.LogLoss, run.BrierScore, run.Accuracy*100)
+	}
+	writer.Flush()
+
+	for _, run := range report.Runs {
+		fmt.Printf("\nCalibration: %s, %s seeding, %s\n", run.Engine, run.Seeding, run.Config)
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(writer, "PREDICTED\tMATCHES\tMEAN PREDICTED\tOBSERVED\t")
+		for _, bin := range run.Calibration {
+			if bin.Predictions == 0 {
+				fmt.Fprintf(writer, "%.0f-%.0f%%\t0\t-\t-\t\n", bin.From*100, bin.To*100)
+				continue
+			}
+			fmt.Fprintf(writer, "%.0f-%.0f%%\t%d\t%.1f%%\t%.1f%%\t\n",
+				bin.From*100, bin.To*100, bin.Predictions, bin.MeanPredicted*100, bin.ObservedWinRate*100)
+		}
+		writer.Flush()
+	}
+}
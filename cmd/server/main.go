@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"usl-server/internal/auth"
 	"usl-server/internal/config"
@@ -37,7 +38,27 @@ type ApplicationContext struct {
 	TrackerRepo *repositories.TrackerRepository
 	GuildRepo   *repositories.GuildRepository
 
-	TrueSkillService *services.UserTrueSkillService
+	TrueSkillService  *services.UserTrueSkillService
+	MatchService      *services.MatchService
+	RatingResolver    *services.PlayerRatingResolver
+	TeamBalancer      *services.TeamBalancer
+	SeasonService     *services.SeasonService
+	BracketService    *services.BracketService
+	ScheduleService   *services.ScheduleService
+	PlacementService  *services.PlacementService
+	RosterService     *services.RosterService
+	DraftService      *services.DraftService
+	StandingsService  *services.StandingsService
+	ReplayService     *services.ReplayImportService
+	StatsService      *services.StatsService
+	RatingEngines     *services.RatingEngineSelector
+	DecayService      *services.DecayService
+	RankDistributions *services.RankDistributionService
+	RatingSimulator   *services.RatingSimulator
+	Recalculations    *services.RecalculationService
+	SmurfDetector     *services.SmurfDetector
+	RankService       *services.RankService
+	PlaylistRatings   *services.PlaylistRatingService
 
 	Templates *template.Template
 }
@@ -47,6 +68,7 @@ func main() {
 	logger.Info("Starting USL server application")
 
 	app := initializeApplication(logger)
+	app.DecayService.Start(time.Duration(app.Config.Decay.JobIntervalHours) * time.Hour)
 	server := setupHTTPServer(app)
 	startServer(server, app.Config, app.Logger)
 }
@@ -75,10 +97,30 @@ func initializeApplication(logger *slog.Logger) *ApplicationContext {
 		Auth:      discordAuth,
 		Templates: templates,
 
-		UserRepo:         repositories.UserRepo,
-		TrackerRepo:      repositories.TrackerRepo,
-		GuildRepo:        repositories.GuildRepo,
-		TrueSkillService: services,
+		UserRepo:          repositories.UserRepo,
+		TrackerRepo:       repositories.TrackerRepo,
+		GuildRepo:         repositories.GuildRepo,
+		TrueSkillService:  services.TrueSkillService,
+		MatchService:      services.MatchService,
+		RatingResolver:    services.RatingResolver,
+		TeamBalancer:      services.TeamBalancer,
+		SeasonService:     services.SeasonService,
+		BracketService:    services.BracketService,
+		ScheduleService:   services.ScheduleService,
+		PlacementService:  services.PlacementService,
+		RosterService:     services.RosterService,
+		DraftService:      services.DraftService,
+		StandingsService:  services.StandingsService,
+		ReplayService:     services.ReplayService,
+		StatsService:      services.StatsService,
+		RatingEngines:     services.RatingEngines,
+		DecayService:      services.DecayService,
+		RankDistributions: services.RankDistributions,
+		RatingSimulator:   services.RatingSimulator,
+		Recalculations:    services.Recalculations,
+		SmurfDetector:     services.SmurfDetector,
+		RankService:       services.RankService,
+		PlaylistRatings:   services.PlaylistRatings,
 	}
 }
 
@@ -109,35 +151,117 @@ func createSupabaseClient(appConfig *config.Config, logger *slog.Logger) *supaba
 }
 
 type RepositoryCollection struct {
-	UserRepo    *repositories.UserRepository
-	TrackerRepo *repositories.TrackerRepository
-	GuildRepo   *repositories.GuildRepository
+	UserRepo             *repositories.UserRepository
+	TrackerRepo          *repositories.TrackerRepository
+	GuildRepo            *repositories.GuildRepository
+	MatchRepo            *repositories.MatchRepository
+	PlayerMMRRepo        *repositories.PlayerMMRRepository
+	SeasonRepo           *repositories.SeasonRepository
+	BracketRepo          *repositories.BracketRepository
+	DivisionRepo         *repositories.DivisionRepository
+	TeamRepo             *repositories.TeamRepository
+	PlacementRepo        *repositories.PlacementRepository
+	DraftRepo            *repositories.DraftRepository
+	ResultRepo           *repositories.LeagueResultRepository
+	RankDistributionRepo *repositories.RankDistributionRepository
+	RecalculationRepo    *repositories.RecalculationJobRepository
+	USLRepo              *usl.USLRepository // TEMPORARY: seed ratings until the USL migration completes
 }
 
 func setupRepositories(client *supabase.Client, appConfig *config.Config, logger *slog.Logger) *RepositoryCollection {
 	logger.Info("Setting up repositories")
 	return &RepositoryCollection{
-		UserRepo:    repositories.NewUserRepository(client, appConfig),
-		TrackerRepo: repositories.NewTrackerRepository(client, appConfig),
-		GuildRepo:   repositories.NewGuildRepository(client, appConfig),
+		UserRepo:             repositories.NewUserRepository(client, appConfig),
+		TrackerRepo:          repositories.NewTrackerRepository(client, appConfig),
+		GuildRepo:            repositories.NewGuildRepository(client, appConfig),
+		MatchRepo:            repositories.NewMatchRepository(client, appConfig),
+		PlayerMMRRepo:        repositories.NewPlayerMMRRepository(client, appConfig),
+		SeasonRepo:           repositories.NewSeasonRepository(client, appConfig),
+		BracketRepo:          repositories.NewBracketRepository(client, appConfig),
+		DivisionRepo:         repositories.NewDivisionRepository(client, appConfig),
+		TeamRepo:             repositories.NewTeamRepository(client, appConfig),
+		PlacementRepo:        repositories.NewPlacementRepository(client, appConfig),
+		DraftRepo:            repositories.NewDraftRepository(client, appConfig),
+		ResultRepo:           repositories.NewLeagueResultRepository(client, appConfig),
+		RankDistributionRepo: repositories.NewRankDistributionRepository(client, appConfig),
+		RecalculationRepo:    repositories.NewRecalculationJobRepository(client, appConfig),
+		USLRepo:              usl.NewUSLRepository(client, appConfig, logger),
 	}
 }
 
-func setupServices(appConfig *config.Config, repos *RepositoryCollection, logger *slog.Logger) *services.UserTrueSkillService {
+type ServiceCollection struct {
+	TrueSkillService  *services.UserTrueSkillService
+	MatchService      *services.MatchService
+	RatingResolver    *services.PlayerRatingResolver
+	TeamBalancer      *services.TeamBalancer
+	SeasonService     *services.SeasonService
+	BracketService    *services.BracketService
+	ScheduleService   *services.ScheduleService
+	PlacementService  *services.PlacementService
+	RosterService     *services.RosterService
+	DraftService      *services.DraftService
+	StandingsService  *services.StandingsService
+	ReplayService     *services.ReplayImportService
+	StatsService      *services.StatsService
+	RatingEngines     *services.RatingEngineSelector
+	DecayService      *services.DecayService
+	RankDistributions *services.RankDistributionService
+	RatingSimulator   *services.RatingSimulator
+	Recalculations    *services.RecalculationService
+	SmurfDetector     *services.SmurfDetector
+	RankService       *services.RankService
+	PlaylistRatings   *services.PlaylistRatingService
+}
+
+func setupServices(appConfig *config.Config, repos *RepositoryCollection, logger *slog.Logger) *ServiceCollection {
 	logger.Info("Setting up services")
 	percentileConverter := services.NewPercentileConverter(appConfig)
+	rankDistributions := services.NewRankDistributionService(repos.RankDistributionRepo, percentileConverter, appConfig)
+	if err := rankDistributions.Reload(); err != nil {
+		logger.Error("Failed to load uploaded rank distributions, using built-in seasons", "error", err)
+	}
 	mmrCalculator := services.NewMMRCalculator(appConfig, percentileConverter)
-	uncertaintyCalculator := services.NewEnhancedUncertaintyCalculator(appConfig)
+	uncertaintyCalculator := services.NewEnhancedUncertaintyCalculator(appConfig, percentileConverter)
 	dataTransformationService := services.NewDataTransformationService()
+	ratingEngines := services.NewRatingEngineSelector(repos.GuildRepo, mmrCalculator, uncertaintyCalculator, appConfig)
 
-	return services.NewUserTrueSkillService(
+	trueSkillService := services.NewUserTrueSkillService(
 		repos.TrackerRepo,
 		repos.UserRepo,
-		mmrCalculator,
-		uncertaintyCalculator,
+		ratingEngines.Default(),
 		dataTransformationService,
 		appConfig,
 	)
+
+	playlistRatings := services.NewPlaylistRatingService(repos.PlayerMMRRepo, repos.UserRepo, repos.TrackerRepo, ratingEngines, dataTransformationService, appConfig)
+	matchService := services.NewMatchService(repos.MatchRepo, repos.PlayerMMRRepo, repos.UserRepo, ratingEngines, playlistRatings, appConfig)
+	ratingResolver := services.NewPlayerRatingResolver(repos.UserRepo, repos.PlayerMMRRepo, repos.USLRepo, playlistRatings, appConfig)
+	decayService := services.NewDecayService(repos.PlayerMMRRepo, repos.GuildRepo, repos.UserRepo, repos.TrackerRepo, appConfig)
+	rosterService := services.NewRosterService(repos.TeamRepo, repos.SeasonRepo, repos.GuildRepo, repos.USLRepo, ratingResolver, appConfig)
+
+	return &ServiceCollection{
+		TrueSkillService:  trueSkillService,
+		MatchService:      matchService,
+		RatingResolver:    ratingResolver,
+		TeamBalancer:      services.NewTeamBalancer(appConfig),
+		SeasonService:     services.NewSeasonService(repos.SeasonRepo, repos.PlayerMMRRepo, appConfig),
+		BracketService:    services.NewBracketService(repos.BracketRepo, ratingResolver, matchService, appConfig),
+		ScheduleService:   services.NewScheduleService(repos.DivisionRepo, repos.TeamRepo, appConfig),
+		PlacementService:  services.NewPlacementService(repos.PlacementRepo, repos.SeasonRepo, repos.GuildRepo, repos.USLRepo, ratingResolver, appConfig),
+		RosterService:     rosterService,
+		DraftService:      services.NewDraftService(repos.DraftRepo, repos.TeamRepo, repos.SeasonRepo, rosterService, repos.USLRepo, ratingResolver, appConfig),
+		StandingsService:  services.NewStandingsService(repos.ResultRepo, repos.DivisionRepo, repos.TeamRepo, repos.GuildRepo, ratingResolver, appConfig),
+		ReplayService:     services.NewReplayImportService(repos.MatchRepo, repos.TrackerRepo, repos.UserRepo, matchService, appConfig),
+		StatsService:      services.NewStatsService(repos.MatchRepo, repos.SeasonRepo, repos.UserRepo, appConfig),
+		RatingEngines:     ratingEngines,
+		DecayService:      decayService,
+		RankDistributions: rankDistributions,
+		RatingSimulator:   services.NewRatingSimulator(repos.UserRepo, repos.PlayerMMRRepo, repos.TrackerRepo, repos.GuildRepo, percentileConverter, dataTransformationService, appConfig),
+		Recalculations:    services.NewRecalculationService(repos.RecalculationRepo, repos.PlayerMMRRepo, repos.UserRepo, repos.TrackerRepo, ratingEngines, dataTransformationService, appConfig),
+		SmurfDetector:     services.NewSmurfDetector(repos.TrackerRepo, repos.UserRepo, dataTransformationService, appConfig),
+		RankService:       services.NewRankService(percentileConverter, repos.TrackerRepo, repos.UserRepo, dataTransformationService),
+		PlaylistRatings:   playlistRatings,
+	}
 }
 
 func createTemplateFunctions() template.FuncMap {
@@ -278,7 +402,7 @@ func setupTrackerRoutes(mux *http.ServeMux, app *ApplicationContext) {
 }
 
 func setupTrueSkillRoutes(mux *http.ServeMux, app *ApplicationContext) {
-	trueskillHandler := handlers.NewTrueSkillHandler(app.TrueSkillService, app.Templates)
+	trueskillHandler := handlers.NewTrueSkillHandler(app.TrueSkillService, app.Recalculations, app.Templates)
 
 	mux.HandleFunc("/trueskill/update-all", app.Auth.RequireAuth(trueskillHandler.UpdateAllUserTrueSkill))
 	mux.HandleFunc("/trueskill/update-user", app.Auth.RequireAuth(trueskillHandler.UpdateUserTrueSkill))
@@ -289,10 +413,27 @@ func setupTrueSkillRoutes(mux *http.ServeMux, app *ApplicationContext) {
 func setupAPIRoutes(mux *http.ServeMux, app *ApplicationContext) {
 	userHandler := handlers.NewUserHandler(app.UserRepo, app.Templates)
 	trackerHandler := handlers.NewTrackerHandler(app.TrackerRepo, app.TrueSkillService, app.Templates)
-	trueskillHandler := handlers.NewTrueSkillHandler(app.TrueSkillService, app.Templates)
+	trueskillHandler := handlers.NewTrueSkillHandler(app.TrueSkillService, app.Recalculations, app.Templates)
 
 	v2UsersHandler := uslHandlers.NewV2UsersHandler(app.UserRepo)
-	v2TrackersHandler := uslHandlers.NewV2TrackersHandler(app.TrackerRepo)
+	v2TrackersHandler := uslHandlers.NewV2TrackersHandler(app.TrackerRepo, app.RankService)
+	v2MatchesHandler := uslHandlers.NewV2MatchesHandler(app.MatchService)
+	v2BalanceHandler := uslHandlers.NewV2BalanceHandler(app.RatingResolver, app.TeamBalancer)
+	v2PredictHandler := uslHandlers.NewV2PredictHandler(app.TrueSkillService, app.RatingResolver)
+	v2ScheduleHandler := uslHandlers.NewV2ScheduleHandler(app.ScheduleService)
+	v2PlacementsHandler := uslHandlers.NewV2PlacementsHandler(app.PlacementService)
+	v2TeamsHandler := uslHandlers.NewV2TeamsHandler(app.RosterService)
+	v2DraftsHandler := uslHandlers.NewV2DraftsHandler(app.DraftService)
+	v2StandingsHandler := uslHandlers.NewV2StandingsHandler(app.StandingsService)
+	v2ReplaysHandler := uslHandlers.NewV2ReplaysHandler(app.ReplayService)
+	v2StatsHandler := uslHandlers.NewV2StatsHandler(app.StatsService)
+	v2RatingHandler := uslHandlers.NewV2RatingHandler(app.RatingEngines, app.RatingResolver, app.TrueSkillService)
+	v2DecayHandler := uslHandlers.NewV2DecayHandler(app.DecayService)
+	v2RankDistributionsHandler := uslHandlers.NewV2RankDistributionsHandler(app.RankDistributions)
+	v2SimulatorHandler := uslHandlers.NewV2SimulatorHandler(app.RatingSimulator)
+	v2RecalculationsHandler := uslHandlers.NewV2RecalculationsHandler(app.Recalculations)
+	v2RanksHandler := uslHandlers.NewV2RanksHandler(app.RankService)
+	v2LeaderboardHandler := uslHandlers.NewV2LeaderboardHandler(app.PlaylistRatings)
 
 	mux.HandleFunc("/api/users", app.Auth.RequireAuth(userHandler.ListUsersAPI))
 	mux.HandleFunc("/api/trackers", app.Auth.RequireAuth(trackerHandler.ListTrackersAPI))
@@ -300,8 +441,35 @@ func setupAPIRoutes(mux *http.ServeMux, app *ApplicationContext) {
 
 	mux.HandleFunc("/api/v2/users", app.Auth.RequireAuth(v2UsersHandler.HandleUsers))
 	mux.HandleFunc("/api/v2/users/bulk", app.Auth.RequireAuth(v2UsersHandler.HandleUsersBulk))
+	mux.HandleFunc("/api/v2/users/", app.Auth.RequireAuth(v2RatingHandler.HandleExplain))
 	mux.HandleFunc("/api/v2/trackers", app.Auth.RequireAuth(v2TrackersHandler.HandleTrackers))
 	mux.HandleFunc("/api/v2/trackers/bulk", app.Auth.RequireAuth(v2TrackersHandler.HandleTrackersBulk))
+	mux.HandleFunc("/api/v2/matches", app.Auth.RequireAuth(v2MatchesHandler.HandleMatches))
+	mux.HandleFunc("/api/v2/matches/replays", app.Auth.RequireAuth(v2ReplaysHandler.HandleReplays))
+	mux.HandleFunc("/api/v2/balance", app.Auth.RequireAuth(v2BalanceHandler.HandleBalance))
+	mux.HandleFunc("/api/v2/predict", app.Auth.RequireAuth(v2PredictHandler.HandlePredict))
+	mux.HandleFunc("/api/v2/schedule", app.Auth.RequireAuth(v2ScheduleHandler.HandleSchedule))
+	mux.HandleFunc("/api/v2/placements", app.Auth.RequireAuth(v2PlacementsHandler.HandlePlacements))
+	mux.HandleFunc("/api/v2/teams", app.Auth.RequireAuth(v2TeamsHandler.HandleTeams))
+	mux.HandleFunc("/api/v2/teams/roster", app.Auth.RequireAuth(v2TeamsHandler.HandleRoster))
+	mux.HandleFunc("/api/v2/drafts", app.Auth.RequireAuth(v2DraftsHandler.HandleDrafts))
+	mux.HandleFunc("/api/v2/standings", app.Auth.RequireAuth(v2StandingsHandler.HandleStandings))
+	mux.HandleFunc("/api/v2/standings/results", app.Auth.RequireAuth(v2StandingsHandler.HandleResults))
+	mux.HandleFunc("/api/v2/stats/leaders", app.Auth.RequireAuth(v2StatsHandler.HandleLeaders))
+	mux.HandleFunc("/api/v2/stats/player", app.Auth.RequireAuth(v2StatsHandler.HandlePlayer))
+	mux.HandleFunc("/api/v2/stats/lines", app.Auth.RequireAuth(v2StatsHandler.HandleStatLines))
+	mux.HandleFunc("/api/v2/rating/engine", app.Auth.RequireAuth(v2RatingHandler.HandleEngine))
+	mux.HandleFunc("/api/v2/rating/compare", app.Auth.RequireAuth(v2RatingHandler.HandleCompare))
+	mux.HandleFunc("/api/v2/rating/simulate", app.Auth.RequireAuth(v2SimulatorHandler.HandleSimulate))
+	mux.HandleFunc("/api/v2/decay/config", app.Auth.RequireAuth(v2DecayHandler.HandleConfig))
+	mux.HandleFunc("/api/v2/decay/run", app.Auth.RequireAuth(v2DecayHandler.HandleRun))
+	mux.HandleFunc("/api/v2/rank-distributions", app.Auth.RequireAuth(v2RankDistributionsHandler.HandleDistributions))
+	mux.HandleFunc("/api/v2/ranks", app.Auth.RequireAuth(v2RanksHandler.HandleRanks))
+	mux.HandleFunc("/api/v2/leaderboard", app.Auth.RequireAuth(v2LeaderboardHandler.HandleLeaderboard))
+	mux.HandleFunc("/api/v2/recalculations", app.Auth.RequireAuth(v2RecalculationsHandler.HandleRecalculations))
+	mux.HandleFunc("/api/v2/recalculations/apply", app.Auth.RequireAuth(v2RecalculationsHandler.HandleApply))
+	mux.HandleFunc("/api/v2/recalculations/revert", app.Auth.RequireAuth(v2RecalculationsHandler.HandleRevert))
+	mux.HandleFunc("/api/v2/recalculations/discard", app.Auth.RequireAuth(v2RecalculationsHandler.HandleDiscard))
 }
 
 func setupUSLRoutes(mux *http.ServeMux, app *ApplicationContext) {
@@ -318,7 +486,19 @@ func setupUSLRoutes(mux *http.ServeMux, app *ApplicationContext) {
 
 	uslRepo := usl.NewUSLRepository(supabaseClient, app.Config, app.Logger)
 	// NOTE: USL handlers no longer need their own auth - they use the unified auth
-	uslHandler := uslHandlers.NewMigrationHandler(uslRepo, app.Templates, app.TrueSkillService, app.Config)
+	uslHandler := uslHandlers.NewMigrationHandler(uslRepo, app.Templates, app.TrueSkillService, app.PlacementService, app.StatsService, app.RankService, app.GuildRepo, app.Config)
+	seasonHandler := uslHandlers.NewSeasonHandler(app.SeasonService, app.GuildRepo, app.UserRepo, app.Templates)
+	bracketHandler := uslHandlers.NewBracketHandler(app.BracketService, app.SeasonService, app.GuildRepo, app.UserRepo, app.Templates)
+	scheduleHandler := uslHandlers.NewScheduleHandler(app.ScheduleService, app.SeasonService, app.GuildRepo, app.Templates)
+	placementHandler := uslHandlers.NewPlacementHandler(app.PlacementService, app.GuildRepo, app.Templates)
+	simulatorHandler := uslHandlers.NewSimulatorHandler(app.RatingSimulator, app.GuildRepo, app.Templates)
+	recalculationHandler := uslHandlers.NewRecalculationHandler(app.Recalculations, app.GuildRepo, app.Config.TrueSkill.InitialMu, app.Templates)
+	integrityHandler := uslHandlers.NewIntegrityHandler(app.SmurfDetector, app.Templates)
+	rosterHandler := uslHandlers.NewRosterHandler(app.RosterService, app.GuildRepo, app.Templates)
+	draftHandler := uslHandlers.NewDraftHandler(app.DraftService, app.RosterService, app.GuildRepo, app.Templates)
+	standingsHandler := uslHandlers.NewStandingsHandler(app.StandingsService, app.ScheduleService, app.GuildRepo, app.Templates)
+	replayHandler := uslHandlers.NewReplayHandler(app.ReplayService, app.GuildRepo, app.Config.Replays.ImportDir, app.Templates)
+	badgeHandler := uslHandlers.NewBadgeHandler(app.RankService)
 
 	// USL Main Routes (redirect to login or admin based on auth status)
 	mux.HandleFunc("/usl/", func(w http.ResponseWriter, r *http.Request) {
@@ -351,6 +531,52 @@ func setupUSLRoutes(mux *http.ServeMux, app *ApplicationContext) {
 
 	// USL Admin Routes (protected by unified Discord OAuth)
 	mux.HandleFunc("/usl/admin", app.Auth.RequireAuth(uslHandler.AdminDashboard))
+	mux.HandleFunc("/usl/admin/seasons", app.Auth.RequireAuth(seasonHandler.Seasons))
+	mux.HandleFunc("/usl/admin/seasons/start", app.Auth.RequireAuth(seasonHandler.StartSeason))
+	mux.HandleFunc("/usl/admin/seasons/close", app.Auth.RequireAuth(seasonHandler.CloseSeason))
+	mux.HandleFunc("/usl/admin/seasons/roll-trackers", app.Auth.RequireAuth(seasonHandler.RollTrackers))
+	mux.HandleFunc("/usl/admin/brackets", app.Auth.RequireAuth(bracketHandler.Brackets))
+	mux.HandleFunc("/usl/admin/brackets/detail", app.Auth.RequireAuth(bracketHandler.BracketDetail))
+	mux.HandleFunc("/usl/admin/brackets/create", app.Auth.RequireAuth(bracketHandler.CreateBracket))
+	mux.HandleFunc("/usl/admin/brackets/report", app.Auth.RequireAuth(bracketHandler.ReportResult))
+	mux.HandleFunc("/usl/admin/schedule", app.Auth.RequireAuth(scheduleHandler.Schedule))
+	mux.HandleFunc("/usl/admin/schedule/divisions", app.Auth.RequireAuth(scheduleHandler.CreateDivision))
+	mux.HandleFunc("/usl/admin/schedule/teams", app.Auth.RequireAuth(scheduleHandler.AddTeam))
+	mux.HandleFunc("/usl/admin/schedule/generate", app.Auth.RequireAuth(scheduleHandler.GenerateSchedule))
+	mux.HandleFunc("/usl/admin/placements", app.Auth.RequireAuth(placementHandler.Placements))
+	mux.HandleFunc("/usl/admin/placements/preview", app.Auth.RequireAuth(placementHandler.PreviewPlacements))
+	mux.HandleFunc("/usl/admin/placements/commit", app.Auth.RequireAuth(placementHandler.CommitPlacements))
+	mux.HandleFunc("/usl/admin/simulator", app.Auth.RequireAuth(simulatorHandler.Simulator))
+	mux.HandleFunc("/usl/admin/recalculations", app.Auth.RequireAuth(recalculationHandler.Recalculations))
+	mux.HandleFunc("/usl/admin/recalculations/detail", app.Auth.RequireAuth(recalculationHandler.RecalculationDetail))
+	mux.HandleFunc("/usl/admin/recalculations/create", app.Auth.RequireAuth(recalculationHandler.CreateRecalculation))
+	mux.HandleFunc("/usl/admin/recalculations/apply", app.Auth.RequireAuth(recalculationHandler.ApplyRecalculation))
+	mux.HandleFunc("/usl/admin/recalculations/revert", app.Auth.RequireAuth(recalculationHandler.RevertRecalculation))
+	mux.HandleFunc("/usl/admin/recalculations/discard", app.Auth.RequireAuth(recalculationHandler.DiscardRecalculation))
+	mux.HandleFunc("/usl/admin/integrity", app.Auth.RequireAuth(integrityHandler.Integrity))
+	mux.HandleFunc("/usl/admin/teams", app.Auth.RequireAuth(rosterHandler.Teams))
+	mux.HandleFunc("/usl/admin/teams/create", app.Auth.RequireAuth(rosterHandler.CreateTeam))
+	mux.HandleFunc("/usl/admin/teams/sign", app.Auth.RequireAuth(rosterHandler.SignPlayer))
+	mux.HandleFunc("/usl/admin/teams/release", app.Auth.RequireAuth(rosterHandler.ReleasePlayer))
+	mux.HandleFunc("/usl/admin/teams/captain", app.Auth.RequireAuth(rosterHandler.SetCaptain))
+	mux.HandleFunc("/usl/admin/teams/settings", app.Auth.RequireAuth(rosterHandler.UpdateSettings))
+	mux.HandleFunc("/usl/admin/drafts", app.Auth.RequireAuth(draftHandler.Drafts))
+	mux.HandleFunc("/usl/admin/drafts/board", app.Auth.RequireAuth(draftHandler.Board))
+	mux.HandleFunc("/usl/admin/drafts/create", app.Auth.RequireAuth(draftHandler.CreateDraft))
+	mux.HandleFunc("/usl/admin/drafts/start", app.Auth.RequireAuth(draftHandler.StartDraft))
+	mux.HandleFunc("/usl/admin/drafts/pick", app.Auth.RequireAuth(draftHandler.MakePick))
+	mux.HandleFunc("/usl/admin/drafts/undo", app.Auth.RequireAuth(draftHandler.UndoPick))
+	mux.HandleFunc("/usl/admin/standings", app.Auth.RequireAuth(standingsHandler.Standings))
+	mux.HandleFunc("/usl/admin/standings/results", app.Auth.RequireAuth(standingsHandler.RecordResult))
+	mux.HandleFunc("/usl/admin/standings/settings", app.Auth.RequireAuth(standingsHandler.UpdateSettings))
+	mux.HandleFunc("/usl/admin/replays", app.Auth.RequireAuth(replayHandler.Replays))
+	mux.HandleFunc("/usl/admin/replays/import", app.Auth.RequireAuth(replayHandler.ImportReplays))
+
+	// Public team calendar feeds (subscribed to by calendar clients without a session)
+	mux.HandleFunc("/calendar/teams/", scheduleHandler.TeamCalendar)
+
+	// Public rank badges (embedded in Discord and on profile pages without a session)
+	mux.HandleFunc("/badges/", badgeHandler.RankBadge)
 
 	// USL API Routes (protected by unified Discord OAuth)
 	mux.HandleFunc("/usl/api/users", app.Auth.RequireAuth(uslHandler.ListUsersAPI))
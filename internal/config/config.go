@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strconv"
@@ -17,6 +18,11 @@ type Config struct {
 	Supabase  SupabaseConfig  `json:"supabase"`
 	TrueSkill TrueSkillConfig `json:"trueskill"`
 	MMR       MMRConfig       `json:"mmr"`
+	Season    SeasonConfig    `json:"season"`
+	Rating    RatingConfig    `json:"rating"`
+	Decay     DecayConfig     `json:"decay"`
+	Replays   ReplayConfig    `json:"replays"`
+	Integrity IntegrityConfig `json:"integrity"`
 	USL       USLConfig       `json:"usl"`
 }
 
@@ -43,6 +49,12 @@ type TrueSkillConfig struct {
 	SigmaMin             float64 `json:"sigma_min"`
 	SigmaMax             float64 `json:"sigma_max"`
 	GamesForMaxCertainty int     `json:"games_for_max_certainty"`
+	// Match rating parameters. Beta and Tau are expressed in sigma units;
+	// MuScale converts stored mu (0-2000) into the same units.
+	Beta            float64 `json:"beta"`
+	Tau             float64 `json:"tau"`
+	DrawProbability float64 `json:"draw_probability"`
+	MuScale         float64 `json:"mu_scale"`
 }
 
 // MMRConfig matches the configuration from MMRConfig.js
@@ -53,6 +65,53 @@ type MMRConfig struct {
 	MinGamesThreshold    int     `json:"min_games_threshold"`
 	CurrentSeasonWeight  float64 `json:"current_season_weight"`
 	PreviousSeasonWeight float64 `json:"previous_season_weight"`
+	// TrackerAggregation combines a player's trackers before seeding:
+	// "max" per playlist, "pool" by games played, or "primary" for the flagged tracker
+	TrackerAggregation string `json:"tracker_aggregation"`
+	// SeasonPooling combines a playlist's current and previous season peaks: "games" by games
+	// played, "season" by the season weights, or "hybrid" by games played with previous-season
+	// games halved every SeasonHalfLifeDays since that season ended
+	SeasonPooling      string  `json:"season_pooling"`
+	SeasonHalfLifeDays float64 `json:"season_half_life_days"`
+}
+
+// SeasonConfig controls the soft reset applied when a league season closes
+// Each factor is the fraction of the distance moved toward the target:
+// mu toward TrueSkill.InitialMu and sigma toward TrueSkill.SigmaMax
+type SeasonConfig struct {
+	SoftResetMuFactor    float64 `json:"soft_reset_mu_factor"`
+	SoftResetSigmaFactor float64 `json:"soft_reset_sigma_factor"`
+}
+
+// RatingConfig holds the parameters of the alternative rating engines
+// Ratings share the stored mu (0-2000) and sigma; Glicko-2 reads its rating deviation
+// as sigma * TrueSkill.MuScale, and Elo leaves sigma unchanged.
+type RatingConfig struct {
+	EloKFactor        float64 `json:"elo_k_factor"`
+	Glicko2Volatility float64 `json:"glicko2_volatility"` // starting volatility, not stored between matches
+	Glicko2Tau        float64 `json:"glicko2_tau"`        // system constant limiting volatility changes
+}
+
+// DecayConfig controls how often the inactivity decay job runs
+// The decay policy itself is a per-guild setting; an interval of 0 disables the job.
+type DecayConfig struct {
+	JobIntervalHours int `json:"job_interval_hours"`
+}
+
+// ReplayConfig controls where replay exports are imported from on disk
+// Directory imports are limited to ImportDir and its subdirectories.
+type ReplayConfig struct {
+	ImportDir string `json:"import_dir"`
+}
+
+// IntegrityConfig sets how far tracker data has to stray before an account is flagged
+// for moderator review as a possible smurf or sandbagger. All values are in tracker MMR
+// except LowGames.
+type IntegrityConfig struct {
+	PeakDrop      int `json:"peak_drop"`      // current-season peak this far below the all-time peak
+	HighPeak      int `json:"high_peak"`      // peaks from here up are suspicious on few games
+	LowGames      int `json:"low_games"`      // playlist games below this count as few
+	AccountSpread int `json:"account_spread"` // difference between one player's accounts in a playlist
 }
 
 // USLConfig holds USL-specific configuration for temporary migration
@@ -93,6 +152,10 @@ func Load() (*Config, error) {
 			SigmaMin:             getEnvFloat("TRUESKILL_SIGMA_MIN", 2.5),
 			SigmaMax:             getEnvFloat("TRUESKILL_SIGMA_MAX", 8.333),
 			GamesForMaxCertainty: getEnvInt("TRUESKILL_GAMES_FOR_MAX_CERTAINTY", 1000),
+			Beta:                 getEnvFloat("TRUESKILL_BETA", 4.1667),
+			Tau:                  getEnvFloat("TRUESKILL_TAU", 0.0833),
+			DrawProbability:      getEnvFloat("TRUESKILL_DRAW_PROBABILITY", 0.0),
+			MuScale:              getEnvFloat("TRUESKILL_MU_SCALE", 40.0),
 		},
 		MMR: MMRConfig{
 			OnesWeight:           getEnvFloat("MMR_ONES_WEIGHT", 1.0),
@@ -101,15 +164,74 @@ func Load() (*Config, error) {
 			MinGamesThreshold:    getEnvInt("MMR_MIN_GAMES_THRESHOLD", 10),
 			CurrentSeasonWeight:  getEnvFloat("MMR_CURRENT_SEASON_WEIGHT", 0.7),
 			PreviousSeasonWeight: getEnvFloat("MMR_PREVIOUS_SEASON_WEIGHT", 0.3),
+			TrackerAggregation:   getEnv("MMR_TRACKER_AGGREGATION", "max"),
+			SeasonPooling:        getEnv("MMR_SEASON_POOLING", "games"),
+			SeasonHalfLifeDays:   getEnvFloat("MMR_SEASON_HALF_LIFE_DAYS", 45),
+		},
+		Season: SeasonConfig{
+			SoftResetMuFactor:    getEnvFloat("SEASON_SOFT_RESET_MU_FACTOR", 0.25),
+			SoftResetSigmaFactor: getEnvFloat("SEASON_SOFT_RESET_SIGMA_FACTOR", 0.5),
+		},
+		Rating: RatingConfig{
+			EloKFactor:        getEnvFloat("RATING_ELO_K_FACTOR", 32.0),
+			Glicko2Volatility: getEnvFloat("RATING_GLICKO2_VOLATILITY", 0.06),
+			Glicko2Tau:        getEnvFloat("RATING_GLICKO2_TAU", 0.5),
+		},
+		Decay: DecayConfig{
+			JobIntervalHours: getEnvInt("DECAY_JOB_INTERVAL_HOURS", 24),
+		},
+		Replays: ReplayConfig{
+			ImportDir: getEnv("REPLAY_IMPORT_DIR", "replays"),
+		},
+		Integrity: IntegrityConfig{
+			PeakDrop:      getEnvInt("INTEGRITY_PEAK_DROP", 300),
+			HighPeak:      getEnvInt("INTEGRITY_HIGH_PEAK", 1200),
+			LowGames:      getEnvInt("INTEGRITY_LOW_GAMES", 50),
+			AccountSpread: getEnvInt("INTEGRITY_ACCOUNT_SPREAD", 400),
 		},
 		USL: USLConfig{
 			AdminDiscordIDs: getEnvStringSlice("USL_ADMIN_DISCORD_IDS", []string{"679038415576104971", "354474826192388127"}),
 		},
 	}
 
+	if err := config.MMR.Validate(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
+// trackerAggregations lists the MMR_TRACKER_AGGREGATION values tracker aggregation understands
+var trackerAggregations = []string{"max", "pool", "primary"}
+
+// seasonPoolings lists the MMR_SEASON_POOLING values the MMR calculator understands
+var seasonPoolings = []string{"games", "season", "hybrid"}
+
+// Validate rejects strategy names the rating code does not know, so a typo stops the server
+// at startup instead of quietly seeding every player from the defaults
+func (m MMRConfig) Validate() error {
+	if !containsString(trackerAggregations, m.TrackerAggregation) {
+		return fmt.Errorf("invalid MMR_TRACKER_AGGREGATION %q, expected one of %s", m.TrackerAggregation, strings.Join(trackerAggregations, ", "))
+	}
+	if !containsString(seasonPoolings, m.SeasonPooling) {
+		return fmt.Errorf("invalid MMR_SEASON_POOLING %q, expected one of %s", m.SeasonPooling, strings.Join(seasonPoolings, ", "))
+	}
+	if m.SeasonHalfLifeDays < 0 {
+		return fmt.Errorf("invalid MMR_SEASON_HALF_LIFE_DAYS %g, must not be negative", m.SeasonHalfLifeDays)
+	}
+	return nil
+}
+
+// containsString checks if a value is in a list
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadRejectsUnknownStrategies(t *testing.T) {
+	t.Setenv("MMR_SEASON_POOLING", "hybird")
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "MMR_SEASON_POOLING") {
+		t.Errorf("expected an unknown season pooling to fail the load, got %v", err)
+	}
+
+	t.Setenv("MMR_SEASON_POOLING", "hybrid")
+	t.Setenv("MMR_TRACKER_AGGREGATION", "average")
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "MMR_TRACKER_AGGREGATION") {
+		t.Errorf("expected an unknown tracker aggregation to fail the load, got %v", err)
+	}
+
+	t.Setenv("MMR_TRACKER_AGGREGATION", "pool")
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("expected a known season pooling to load, got %v", err)
+	}
+	if config.MMR.SeasonPooling != "hybrid" || config.MMR.TrackerAggregation != "pool" {
+		t.Errorf("expected hybrid season pooling and pool aggregation, got %+v", config.MMR)
+	}
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"strconv"
+	"usl-server/internal/middleware"
 	"usl-server/internal/services"
 )
 
@@ -15,55 +18,63 @@ const (
 	TrueSkillStatsTemplate      = "trueskill_stats.html"
 
 	// Page titles
-	TrueSkillUpdateCompleteTitle = "TrueSkill Update Complete"
-	TrueSkillRecalculationTitle  = "TrueSkill Recalculation Complete"
-	UserTrueSkillUpdateTitle     = "User TrueSkill Update"
-	TrueSkillStatsTitle          = "TrueSkill Statistics"
+	UserTrueSkillUpdateTitle = "User TrueSkill Update"
+	TrueSkillStatsTitle      = "TrueSkill Statistics"
+
+	// Recalculation job pages and endpoints that batch updates hand over to
+	RecalculationsPath         = "/usl/admin/recalculations"
+	RecalculationDetailPath    = "/usl/admin/recalculations/detail"
+	RecalculationApplyAPIPath  = "/api/v2/recalculations/apply"
+	RecalculationRevertAPIPath = "/api/v2/recalculations/revert"
 )
 
 // TrueSkillHandler handles HTTP requests for TrueSkill calculations
 type TrueSkillHandler struct {
-	trueSkillService *services.UserTrueSkillService
-	templates        *template.Template
+	trueSkillService     *services.UserTrueSkillService
+	recalculationService *services.RecalculationService
+	templates            *template.Template
 }
 
 // NewTrueSkillHandler creates a new TrueSkill handler
-func NewTrueSkillHandler(trueSkillService *services.UserTrueSkillService, templates *template.Template) *TrueSkillHandler {
+func NewTrueSkillHandler(trueSkillService *services.UserTrueSkillService, recalculationService *services.RecalculationService, templates *template.Template) *TrueSkillHandler {
 	return &TrueSkillHandler{
-		trueSkillService: trueSkillService,
-		templates:        templates,
+		trueSkillService:     trueSkillService,
+		recalculationService: recalculationService,
+		templates:            templates,
 	}
 }
 
 // UpdateAllUserTrueSkill handles batch TrueSkill updates for all users (HTML response)
+// Ratings are stored per guild in player_effective_mmr, so the update is created as a pending
+// recalculation job and the admin is sent to its diff to apply or discard it. Without a guild
+// the admin lands on the recalculations page, which starts one for the USL guild.
 func (h *TrueSkillHandler) UpdateAllUserTrueSkill(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	result, err := h.trueSkillService.UpdateAllUserTrueSkill()
+	guildID, err := recalculationGuildID(r)
 	if err != nil {
-		log.Printf("Error updating TrueSkill for all users: %v", err)
-		http.Error(w, "Failed to update TrueSkill values: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	log.Printf("Batch TrueSkill update completed: %d users processed, %d calculated from trackers, %d given defaults",
-		result.ProcessedCount, result.TrackerBasedCount, result.DefaultCount)
-
-	data := struct {
-		Title  string
-		Result *services.BatchUpdateResult
-	}{
-		Title:  TrueSkillUpdateCompleteTitle,
-		Result: result,
+	if guildID == 0 {
+		http.Redirect(w, r, RecalculationsPath, http.StatusSeeOther)
+		return
 	}
 
-	if err := h.templates.ExecuteTemplate(w, "base", data); err != nil {
-		log.Printf("Error executing template: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	job, err := h.recalculationService.CreateJob(guildID, nil)
+	if err != nil {
+		log.Printf("Error creating recalculation for guild %d: %v", guildID, err)
+		http.Error(w, "Failed to create recalculation: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
+
+	log.Printf("Batch TrueSkill update created recalculation %d for guild %d: %d changes pending review",
+		job.ID, guildID, len(job.Changes))
+
+	http.Redirect(w, r, fmt.Sprintf("%s?id=%d", RecalculationDetailPath, job.ID), http.StatusSeeOther)
 }
 
 // UpdateUserTrueSkill handles TrueSkill update for a single user
@@ -98,33 +109,9 @@ func (h *TrueSkillHandler) UpdateUserTrueSkill(w http.ResponseWriter, r *http.Re
 }
 
 // RecalculateAllUserTrueSkill handles recalculation of TrueSkill for all users
+// Same as UpdateAllUserTrueSkill: the recalculation is previewed as a job before anything is written.
 func (h *TrueSkillHandler) RecalculateAllUserTrueSkill(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	result, err := h.trueSkillService.RecalculateAllUserTrueSkill()
-	if err != nil {
-		log.Printf("Error recalculating TrueSkill for all users: %v", err)
-		http.Error(w, "Failed to recalculate TrueSkill values: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	log.Printf("TrueSkill recalculation completed: %d users processed", result.ProcessedCount)
-
-	data := struct {
-		Title  string
-		Result *services.BatchUpdateResult
-	}{
-		Title:  "TrueSkill Recalculation Complete",
-		Result: result,
-	}
-
-	if err := h.templates.ExecuteTemplate(w, "base", data); err != nil {
-		log.Printf("Error executing template: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-	}
+	h.UpdateAllUserTrueSkill(w, r)
 }
 
 // GetTrueSkillStats displays TrueSkill service statistics
@@ -151,36 +138,70 @@ func (h *TrueSkillHandler) GetTrueSkillStats(w http.ResponseWriter, r *http.Requ
 }
 
 // UpdateAllUserTrueSkillAPI handles batch TrueSkill updates via API (JSON response)
+// Answers 202 with the pending recalculation job; nothing is written until the job is applied.
 func (h *TrueSkillHandler) UpdateAllUserTrueSkillAPI(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	result, err := h.trueSkillService.UpdateAllUserTrueSkill()
+	guildID, err := recalculationGuildID(r)
+	if err == nil && guildID == 0 {
+		err = fmt.Errorf("guild_id is required")
+	}
 	if err != nil {
-		log.Printf("API: Error updating TrueSkill for all users: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		if encodeErr := json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   err.Error(),
-		}); encodeErr != nil {
-			log.Printf("Failed to encode error response: %v", encodeErr)
-		}
+		writeTrueSkillAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	job, err := h.recalculationService.CreateJob(guildID, nil)
+	if err != nil {
+		log.Printf("API: Error creating recalculation for guild %d: %v", guildID, err)
+		writeTrueSkillAPIError(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	log.Printf("API: Batch TrueSkill update completed: %d users processed", result.ProcessedCount)
+	log.Printf("API: Batch TrueSkill update created recalculation %d for guild %d", job.ID, guildID)
 
 	response := map[string]interface{}{
 		"success": true,
-		"result":  result,
+		"job":     job,
+		"apply":   RecalculationApplyAPIPath,
+		"revert":  RecalculationRevertAPIPath,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		log.Printf("Error encoding JSON response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
+
+// writeTrueSkillAPIError writes the API's {"success": false, "error": ...} body
+func writeTrueSkillAPIError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if encodeErr := json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   err.Error(),
+	}); encodeErr != nil {
+		log.Printf("Failed to encode error response: %v", encodeErr)
+	}
+}
+
+// recalculationGuildID reads the guild to recalculate from guild_id or the guild context
+// Returns 0 without an error when the request names no guild.
+func recalculationGuildID(r *http.Request) (int64, error) {
+	if guildIDParam := r.FormValue("guild_id"); guildIDParam != "" {
+		guildID, err := strconv.ParseInt(guildIDParam, ParseIntBase, ParseIntBitSize)
+		if err != nil || guildID <= 0 {
+			return 0, fmt.Errorf("invalid guild_id: %s", guildIDParam)
+		}
+		return guildID, nil
+	}
+
+	if guild, ok := middleware.GetGuildFromRequest(r); ok && guild != nil {
+		return guild.ID, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBatchTrueSkillUpdatesNeedAGuildForTheirJob(t *testing.T) {
+	// No recalculation service: none of these requests may reach it
+	handler := NewTrueSkillHandler(nil, nil, nil)
+
+	tests := []struct {
+		name     string
+		handle   http.HandlerFunc
+		target   string
+		status   int
+		location string
+	}{
+		{"page without a guild goes to the recalculations page", handler.UpdateAllUserTrueSkill, "/trueskill/update-all", http.StatusSeeOther, RecalculationsPath},
+		{"recalculate without a guild goes to the recalculations page", handler.RecalculateAllUserTrueSkill, "/trueskill/recalculate", http.StatusSeeOther, RecalculationsPath},
+		{"page with a bad guild", handler.UpdateAllUserTrueSkill, "/trueskill/update-all?guild_id=abc", http.StatusBadRequest, ""},
+		{"api without a guild", handler.UpdateAllUserTrueSkillAPI, "/api/trueskill/update-all", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handle(w, httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader("")))
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if location := w.Header().Get("Location"); location != tt.location {
+				t.Errorf("expected redirect to %q, got %q", tt.location, location)
+			}
+		})
+	}
+}
@@ -106,6 +106,9 @@ func shouldSkipGuildContext(slug string) bool {
 		".well-known",
 		"robots.txt",
 		"sitemap.xml",
+		"v2",       // Versioned API routes take the guild from the request instead of the path
+		"calendar", // Public iCal feeds are addressed by team ID
+		"badges",   // Public rank badges are addressed by Discord ID
 	}
 
 	for _, skipRoute := range skipRoutes {
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bracket formats
+const (
+	BracketFormatSingleElimination = "single_elimination"
+	BracketFormatDoubleElimination = "double_elimination"
+	BracketFormatSwiss             = "swiss"
+)
+
+// Bracket seeding modes
+const (
+	BracketSeedingSkillEstimate = "skill_estimate" // mean conservative estimate (mu - 3*sigma) of the entrant's players
+	BracketSeedingTeamAverage   = "team_average"   // mean mu of the entrant's players
+	BracketSeedingManual        = "manual"         // entrants are seeded in the order given
+)
+
+// Bracket status values
+const (
+	BracketStatusInProgress = "in_progress"
+	BracketStatusCompleted  = "completed"
+)
+
+// Bracket match sides
+const (
+	BracketSideWinners    = "winners"
+	BracketSideLosers     = "losers"
+	BracketSideGrandFinal = "grand_final"
+	BracketSideSwiss      = "swiss"
+)
+
+// Bracket match status values
+const (
+	BracketMatchPending   = "pending"   // waiting for an earlier match to decide an entrant
+	BracketMatchReady     = "ready"     // both entrants known, result not yet reported
+	BracketMatchCompleted = "completed" // result reported
+	BracketMatchBye       = "bye"       // at most one entrant, decided without playing
+)
+
+// Bracket represents a tournament in a guild
+type Bracket struct {
+	ID              int64     `json:"id" db:"id"`
+	GuildID         int64     `json:"guild_id" db:"guild_id"`
+	SeasonID        *int64    `json:"season_id" db:"season_id"`
+	Name            string    `json:"name" db:"name"`
+	Format          string    `json:"format" db:"format"`
+	Seeding         string    `json:"seeding" db:"seeding"`
+	SwissRounds     int       `json:"swiss_rounds" db:"swiss_rounds"`
+	RecordRatings   bool      `json:"record_ratings" db:"record_ratings"`
+	Status          string    `json:"status" db:"status"`
+	CreatedByUserID *int64    `json:"created_by_user_id" db:"created_by_user_id"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+
+	Entrants []BracketEntrant `json:"entrants" db:"-"`
+	Matches  []BracketMatch   `json:"matches" db:"-"`
+}
+
+// BracketEntrant is a seeded player or team in a bracket
+type BracketEntrant struct {
+	ID         int64     `json:"id" db:"id"`
+	BracketID  int64     `json:"bracket_id" db:"bracket_id"`
+	Name       string    `json:"name" db:"name"`
+	Seed       int       `json:"seed" db:"seed"`
+	SeedRating float64   `json:"seed_rating" db:"seed_rating"`
+	DiscordIDs []string  `json:"discord_ids" db:"discord_ids"`
+	UserIDs    []int64   `json:"user_ids" db:"user_ids"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// BracketMatch is one match in a bracket, addressed by side, round and position
+type BracketMatch struct {
+	ID              int64      `json:"id" db:"id"`
+	BracketID       int64      `json:"bracket_id" db:"bracket_id"`
+	Side            string     `json:"side" db:"side"`
+	Round           int        `json:"round" db:"round"`
+	Position        int        `json:"position" db:"position"`
+	EntrantAID      *int64     `json:"entrant_a_id" db:"entrant_a_id"`
+	EntrantBID      *int64     `json:"entrant_b_id" db:"entrant_b_id"`
+	ScoreA          *int       `json:"score_a" db:"score_a"`
+	ScoreB          *int       `json:"score_b" db:"score_b"`
+	WinnerEntrantID *int64     `json:"winner_entrant_id" db:"winner_entrant_id"`
+	Status          string     `json:"status" db:"status"`
+	MatchID         *int64     `json:"match_id" db:"match_id"`
+	ReportedAt      *time.Time `json:"reported_at" db:"reported_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// BracketEntrantRequest is a player or team to enter into a new bracket
+type BracketEntrantRequest struct {
+	Name       string   `json:"name"`
+	DiscordIDs []string `json:"discord_ids" validate:"required,min=1"`
+}
+
+// BracketCreateRequest represents data needed to create a new bracket
+type BracketCreateRequest struct {
+	GuildID         int64                   `json:"guild_id" validate:"required"`
+	SeasonID        *int64                  `json:"season_id"`
+	Name            string                  `json:"name" validate:"required,max=100"`
+	Format          string                  `json:"format" validate:"required,oneof=single_elimination double_elimination swiss"`
+	Seeding         string                  `json:"seeding" validate:"required,oneof=skill_estimate team_average manual"`
+	SwissRounds     int                     `json:"swiss_rounds" validate:"min=0"`
+	RecordRatings   bool                    `json:"record_ratings"`
+	Entrants        []BracketEntrantRequest `json:"entrants" validate:"required,min=2"`
+	CreatedByUserID *int64                  `json:"created_by_user_id"`
+}
+
+// Validate checks the request for an unknown format or seeding, too few entrants and duplicate players
+func (r *BracketCreateRequest) Validate() error {
+	if r.GuildID == 0 {
+		return fmt.Errorf("guild_id is required")
+	}
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return fmt.Errorf("bracket name is required")
+	}
+
+	switch r.Format {
+	case BracketFormatSingleElimination, BracketFormatDoubleElimination, BracketFormatSwiss:
+	default:
+		return fmt.Errorf("unknown bracket format %q", r.Format)
+	}
+
+	switch r.Seeding {
+	case BracketSeedingSkillEstimate, BracketSeedingTeamAverage, BracketSeedingManual:
+	default:
+		return fmt.Errorf("unknown seeding mode %q", r.Seeding)
+	}
+
+	if r.SwissRounds < 0 {
+		return fmt.Errorf("swiss rounds cannot be negative")
+	}
+	if len(r.Entrants) < 2 {
+		return fmt.Errorf("a bracket needs at least 2 entrants")
+	}
+
+	seen := make(map[string]bool)
+	for i, entrant := range r.Entrants {
+		if len(entrant.DiscordIDs) == 0 {
+			return fmt.Errorf("entrant %d has no players", i+1)
+		}
+		for _, discordID := range entrant.DiscordIDs {
+			if seen[discordID] {
+				return fmt.Errorf("player %s is entered more than once", discordID)
+			}
+			seen[discordID] = true
+		}
+	}
+
+	return nil
+}
+
+// IsCompleted checks if every match in the bracket has been decided
+func (b *Bracket) IsCompleted() bool {
+	return b.Status == BracketStatusCompleted
+}
+
+// EntrantByID returns the entrant with the given ID, or nil
+func (b *Bracket) EntrantByID(entrantID int64) *BracketEntrant {
+	for i := range b.Entrants {
+		if b.Entrants[i].ID == entrantID {
+			return &b.Entrants[i]
+		}
+	}
+	return nil
+}
+
+// IsDecided checks if the match no longer needs a result
+func (m *BracketMatch) IsDecided() bool {
+	return m.Status == BracketMatchCompleted || m.Status == BracketMatchBye
+}
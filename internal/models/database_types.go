@@ -24,6 +24,7 @@ type PublicUserTrackersSelect struct {
 	UpdatedAt                 string `json:"updated_at"`
 	Url                       string `json:"url"`
 	Valid                     bool   `json:"valid"`
+	IsPrimary                 bool   `json:"is_primary"`
 }
 
 type PublicUserTrackersInsert struct {
@@ -50,6 +51,7 @@ type PublicUserTrackersInsert struct {
 	UpdatedAt                 *string `json:"updated_at"`
 	Url                       string  `json:"url"`
 	Valid                     *bool   `json:"valid"`
+	IsPrimary                 *bool   `json:"is_primary"`
 }
 
 type PublicUserTrackersUpdate struct {
@@ -76,6 +78,7 @@ type PublicUserTrackersUpdate struct {
 	UpdatedAt                 *string `json:"updated_at"`
 	Url                       *string `json:"url"`
 	Valid                     *bool   `json:"valid"`
+	IsPrimary                 *bool   `json:"is_primary,omitempty"`
 }
 
 type PublicUsersSelect struct {
@@ -216,6 +219,32 @@ type PublicPlayerEffectiveMmrUpdate struct {
 	UserId         *int64   `json:"user_id"`
 }
 
+type PublicPlayerPlaylistRatingsSelect struct {
+	CreatedAt      string  `json:"created_at"`
+	GamesPlayed    int32   `json:"games_played"`
+	GuildId        int64   `json:"guild_id"`
+	Id             int64   `json:"id"`
+	LastUpdated    string  `json:"last_updated"`
+	Playlist       string  `json:"playlist"`
+	TrueskillMu    float64 `json:"trueskill_mu"`
+	TrueskillSigma float64 `json:"trueskill_sigma"`
+	UpdatedAt      string  `json:"updated_at"`
+	UserId         int64   `json:"user_id"`
+}
+
+type PublicPlayerPlaylistRatingsInsert struct {
+	CreatedAt      *string  `json:"created_at"`
+	GamesPlayed    *int32   `json:"games_played"`
+	GuildId        int64    `json:"guild_id"`
+	Id             *int64   `json:"id"`
+	LastUpdated    *string  `json:"last_updated"`
+	Playlist       string   `json:"playlist"`
+	TrueskillMu    *float64 `json:"trueskill_mu"`
+	TrueskillSigma *float64 `json:"trueskill_sigma"`
+	UpdatedAt      *string  `json:"updated_at"`
+	UserId         int64    `json:"user_id"`
+}
+
 type PublicPlayerHistoricalMmrSelect struct {
 	ChangeReason         string   `json:"change_reason"`
 	ChangedByUserId      *int64   `json:"changed_by_user_id"`
@@ -225,6 +254,7 @@ type PublicPlayerHistoricalMmrSelect struct {
 	MatchId              *int64   `json:"match_id"`
 	MmrAfter             int32    `json:"mmr_after"`
 	MmrBefore            *int32   `json:"mmr_before"`
+	RecalculationJobId   *int64   `json:"recalculation_job_id"`
 	TrueskillMuAfter     float64  `json:"trueskill_mu_after"`
 	TrueskillMuBefore    *float64 `json:"trueskill_mu_before"`
 	TrueskillSigmaAfter  float64  `json:"trueskill_sigma_after"`
@@ -241,6 +271,7 @@ type PublicPlayerHistoricalMmrInsert struct {
 	MatchId              *int64   `json:"match_id"`
 	MmrAfter             int32    `json:"mmr_after"`
 	MmrBefore            *int32   `json:"mmr_before"`
+	RecalculationJobId   *int64   `json:"recalculation_job_id"`
 	TrueskillMuAfter     float64  `json:"trueskill_mu_after"`
 	TrueskillMuBefore    *float64 `json:"trueskill_mu_before"`
 	TrueskillSigmaAfter  float64  `json:"trueskill_sigma_after"`
@@ -257,9 +288,494 @@ type PublicPlayerHistoricalMmrUpdate struct {
 	MatchId              *int64   `json:"match_id"`
 	MmrAfter             *int32   `json:"mmr_after"`
 	MmrBefore            *int32   `json:"mmr_before"`
+	RecalculationJobId   *int64   `json:"recalculation_job_id"`
 	TrueskillMuAfter     *float64 `json:"trueskill_mu_after"`
 	TrueskillMuBefore    *float64 `json:"trueskill_mu_before"`
 	TrueskillSigmaAfter  *float64 `json:"trueskill_sigma_after"`
 	TrueskillSigmaBefore *float64 `json:"trueskill_sigma_before"`
 	UserId               *int64   `json:"user_id"`
 }
+
+type PublicMatchesSelect struct {
+	CreatedAt        string  `json:"created_at"`
+	GuildId          int64   `json:"guild_id"`
+	Id               int64   `json:"id"`
+	PlayedAt         string  `json:"played_at"`
+	Playlist         *string `json:"playlist"`
+	ReplayId         *string `json:"replay_id"`
+	ReportedByUserId *int64  `json:"reported_by_user_id"`
+	TeamAScore       int32   `json:"team_a_score"`
+	TeamBScore       int32   `json:"team_b_score"`
+	UpdatedAt        string  `json:"updated_at"`
+}
+
+type PublicMatchesInsert struct {
+	CreatedAt        *string `json:"created_at"`
+	GuildId          int64   `json:"guild_id"`
+	Id               *int64  `json:"id"`
+	PlayedAt         *string `json:"played_at"`
+	Playlist         *string `json:"playlist"`
+	ReplayId         *string `json:"replay_id"`
+	ReportedByUserId *int64  `json:"reported_by_user_id"`
+	TeamAScore       int32   `json:"team_a_score"`
+	TeamBScore       int32   `json:"team_b_score"`
+	UpdatedAt        *string `json:"updated_at"`
+}
+
+type PublicMatchPlayersSelect struct {
+	Assists   *int32 `json:"assists"`
+	CreatedAt string `json:"created_at"`
+	Goals     *int32 `json:"goals"`
+	Id        int64  `json:"id"`
+	MatchId   int64  `json:"match_id"`
+	Mvp       *bool  `json:"mvp"`
+	Saves     *int32 `json:"saves"`
+	Score     *int32 `json:"score"`
+	Shots     *int32 `json:"shots"`
+	Team      int16  `json:"team"`
+	UserId    int64  `json:"user_id"`
+}
+
+type PublicMatchPlayersInsert struct {
+	Assists   *int32  `json:"assists"`
+	CreatedAt *string `json:"created_at"`
+	Goals     *int32  `json:"goals"`
+	Id        *int64  `json:"id"`
+	MatchId   int64   `json:"match_id"`
+	Mvp       *bool   `json:"mvp"`
+	Saves     *int32  `json:"saves"`
+	Score     *int32  `json:"score"`
+	Shots     *int32  `json:"shots"`
+	Team      int16   `json:"team"`
+	UserId    int64   `json:"user_id"`
+}
+
+type PublicSeasonsSelect struct {
+	CreatedAt            string   `json:"created_at"`
+	EndedAt              *string  `json:"ended_at"`
+	GuildId              int64    `json:"guild_id"`
+	Id                   int64    `json:"id"`
+	Name                 string   `json:"name"`
+	Number               int32    `json:"number"`
+	SoftResetMuFactor    *float64 `json:"soft_reset_mu_factor"`
+	SoftResetSigmaFactor *float64 `json:"soft_reset_sigma_factor"`
+	StartedAt            string   `json:"started_at"`
+	Status               string   `json:"status"`
+	UpdatedAt            string   `json:"updated_at"`
+}
+
+type PublicSeasonsInsert struct {
+	CreatedAt            *string  `json:"created_at"`
+	EndedAt              *string  `json:"ended_at"`
+	GuildId              int64    `json:"guild_id"`
+	Id                   *int64   `json:"id"`
+	Name                 string   `json:"name"`
+	Number               int32    `json:"number"`
+	SoftResetMuFactor    *float64 `json:"soft_reset_mu_factor"`
+	SoftResetSigmaFactor *float64 `json:"soft_reset_sigma_factor"`
+	StartedAt            *string  `json:"started_at"`
+	Status               *string  `json:"status"`
+	UpdatedAt            *string  `json:"updated_at"`
+}
+
+type PublicTrackerSeasonRolloversSelect struct {
+	CreatedAt      string `json:"created_at"`
+	GameSeason     int32  `json:"game_season"`
+	RolledAt       string `json:"rolled_at"`
+	RolledByUserId *int64 `json:"rolled_by_user_id"`
+	TrackersRolled int32  `json:"trackers_rolled"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+type PublicTrackerSeasonRolloversInsert struct {
+	CreatedAt      *string `json:"created_at"`
+	GameSeason     int32   `json:"game_season"`
+	RolledAt       *string `json:"rolled_at"`
+	RolledByUserId *int64  `json:"rolled_by_user_id"`
+	TrackersRolled *int32  `json:"trackers_rolled"`
+	UpdatedAt      *string `json:"updated_at"`
+}
+
+type PublicSeasonLeaderboardSnapshotsSelect struct {
+	CreatedAt      string  `json:"created_at"`
+	GamesPlayed    int32   `json:"games_played"`
+	Id             int64   `json:"id"`
+	Mmr            int32   `json:"mmr"`
+	Rank           int32   `json:"rank"`
+	SeasonId       int64   `json:"season_id"`
+	TrueskillMu    float64 `json:"trueskill_mu"`
+	TrueskillSigma float64 `json:"trueskill_sigma"`
+	UserId         int64   `json:"user_id"`
+}
+
+type PublicSeasonLeaderboardSnapshotsInsert struct {
+	CreatedAt      *string `json:"created_at"`
+	GamesPlayed    *int32  `json:"games_played"`
+	Id             *int64  `json:"id"`
+	Mmr            *int32  `json:"mmr"`
+	Rank           int32   `json:"rank"`
+	SeasonId       int64   `json:"season_id"`
+	TrueskillMu    float64 `json:"trueskill_mu"`
+	TrueskillSigma float64 `json:"trueskill_sigma"`
+	UserId         int64   `json:"user_id"`
+}
+
+type PublicBracketsSelect struct {
+	CreatedAt       string `json:"created_at"`
+	CreatedByUserId *int64 `json:"created_by_user_id"`
+	Format          string `json:"format"`
+	GuildId         int64  `json:"guild_id"`
+	Id              int64  `json:"id"`
+	Name            string `json:"name"`
+	RecordRatings   bool   `json:"record_ratings"`
+	SeasonId        *int64 `json:"season_id"`
+	Seeding         string `json:"seeding"`
+	Status          string `json:"status"`
+	SwissRounds     int32  `json:"swiss_rounds"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+type PublicBracketsInsert struct {
+	CreatedAt       *string `json:"created_at"`
+	CreatedByUserId *int64  `json:"created_by_user_id"`
+	Format          string  `json:"format"`
+	GuildId         int64   `json:"guild_id"`
+	Id              *int64  `json:"id"`
+	Name            string  `json:"name"`
+	RecordRatings   *bool   `json:"record_ratings"`
+	SeasonId        *int64  `json:"season_id"`
+	Seeding         string  `json:"seeding"`
+	Status          *string `json:"status"`
+	SwissRounds     *int32  `json:"swiss_rounds"`
+	UpdatedAt       *string `json:"updated_at"`
+}
+
+type PublicBracketEntrantsSelect struct {
+	BracketId  int64     `json:"bracket_id"`
+	CreatedAt  string    `json:"created_at"`
+	DiscordIds []*string `json:"discord_ids"`
+	Id         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Seed       int32     `json:"seed"`
+	SeedRating float64   `json:"seed_rating"`
+	UserIds    []*int64  `json:"user_ids"`
+}
+
+type PublicBracketEntrantsInsert struct {
+	BracketId  int64     `json:"bracket_id"`
+	CreatedAt  *string   `json:"created_at"`
+	DiscordIds []*string `json:"discord_ids"`
+	Id         *int64    `json:"id"`
+	Name       string    `json:"name"`
+	Seed       int32     `json:"seed"`
+	SeedRating *float64  `json:"seed_rating"`
+	UserIds    []*int64  `json:"user_ids"`
+}
+
+type PublicBracketMatchesSelect struct {
+	BracketId       int64   `json:"bracket_id"`
+	CreatedAt       string  `json:"created_at"`
+	EntrantAId      *int64  `json:"entrant_a_id"`
+	EntrantBId      *int64  `json:"entrant_b_id"`
+	Id              int64   `json:"id"`
+	MatchId         *int64  `json:"match_id"`
+	Position        int32   `json:"position"`
+	ReportedAt      *string `json:"reported_at"`
+	Round           int32   `json:"round"`
+	ScoreA          *int32  `json:"score_a"`
+	ScoreB          *int32  `json:"score_b"`
+	Side            string  `json:"side"`
+	Status          string  `json:"status"`
+	UpdatedAt       string  `json:"updated_at"`
+	WinnerEntrantId *int64  `json:"winner_entrant_id"`
+}
+
+type PublicBracketMatchesInsert struct {
+	BracketId       int64   `json:"bracket_id"`
+	CreatedAt       *string `json:"created_at"`
+	EntrantAId      *int64  `json:"entrant_a_id"`
+	EntrantBId      *int64  `json:"entrant_b_id"`
+	Id              *int64  `json:"id"`
+	MatchId         *int64  `json:"match_id"`
+	Position        int32   `json:"position"`
+	ReportedAt      *string `json:"reported_at"`
+	Round           int32   `json:"round"`
+	ScoreA          *int32  `json:"score_a"`
+	ScoreB          *int32  `json:"score_b"`
+	Side            string  `json:"side"`
+	Status          *string `json:"status"`
+	UpdatedAt       *string `json:"updated_at"`
+	WinnerEntrantId *int64  `json:"winner_entrant_id"`
+}
+
+type PublicDivisionsSelect struct {
+	CreatedAt string `json:"created_at"`
+	GuildId   int64  `json:"guild_id"`
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	SeasonId  *int64 `json:"season_id"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type PublicDivisionsInsert struct {
+	CreatedAt *string `json:"created_at"`
+	GuildId   int64   `json:"guild_id"`
+	Id        *int64  `json:"id"`
+	Name      string  `json:"name"`
+	SeasonId  *int64  `json:"season_id"`
+	UpdatedAt *string `json:"updated_at"`
+}
+
+type PublicTeamsSelect struct {
+	CaptainDiscordId *string `json:"captain_discord_id"`
+	CreatedAt        string  `json:"created_at"`
+	DivisionId       *int64  `json:"division_id"`
+	GuildId          int64   `json:"guild_id"`
+	Id               int64   `json:"id"`
+	Name             string  `json:"name"`
+	SeasonId         *int64  `json:"season_id"`
+	UpdatedAt        string  `json:"updated_at"`
+}
+
+type PublicTeamsInsert struct {
+	CaptainDiscordId *string `json:"captain_discord_id"`
+	CreatedAt        *string `json:"created_at"`
+	DivisionId       *int64  `json:"division_id"`
+	GuildId          int64   `json:"guild_id"`
+	Id               *int64  `json:"id"`
+	Name             string  `json:"name"`
+	SeasonId         *int64  `json:"season_id"`
+	UpdatedAt        *string `json:"updated_at"`
+}
+
+type PublicScheduledMatchesSelect struct {
+	AwayTeamId *int64 `json:"away_team_id"`
+	CreatedAt  string `json:"created_at"`
+	DivisionId int64  `json:"division_id"`
+	GuildId    int64  `json:"guild_id"`
+	HomeTeamId int64  `json:"home_team_id"`
+	Id         int64  `json:"id"`
+	MatchNight string `json:"match_night"`
+	Round      int32  `json:"round"`
+	Status     string `json:"status"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type PublicScheduledMatchesInsert struct {
+	AwayTeamId *int64  `json:"away_team_id"`
+	CreatedAt  *string `json:"created_at"`
+	DivisionId int64   `json:"division_id"`
+	GuildId    int64   `json:"guild_id"`
+	HomeTeamId int64   `json:"home_team_id"`
+	Id         *int64  `json:"id"`
+	MatchNight string  `json:"match_night"`
+	Round      int32   `json:"round"`
+	Status     *string `json:"status"`
+	UpdatedAt  *string `json:"updated_at"`
+}
+
+type PublicTierPlacementsSelect struct {
+	CreatedAt      string  `json:"created_at"`
+	DiscordId      string  `json:"discord_id"`
+	GuildId        int64   `json:"guild_id"`
+	Id             int64   `json:"id"`
+	PlayerName     string  `json:"player_name"`
+	Rank           int32   `json:"rank"`
+	SeasonId       int64   `json:"season_id"`
+	SkillEstimate  float64 `json:"skill_estimate"`
+	TierName       string  `json:"tier_name"`
+	TierNumber     int32   `json:"tier_number"`
+	TrueskillMu    float64 `json:"trueskill_mu"`
+	TrueskillSigma float64 `json:"trueskill_sigma"`
+}
+
+type PublicTierPlacementsInsert struct {
+	CreatedAt      *string `json:"created_at"`
+	DiscordId      string  `json:"discord_id"`
+	GuildId        int64   `json:"guild_id"`
+	Id             *int64  `json:"id"`
+	PlayerName     *string `json:"player_name"`
+	Rank           int32   `json:"rank"`
+	SeasonId       int64   `json:"season_id"`
+	SkillEstimate  float64 `json:"skill_estimate"`
+	TierName       string  `json:"tier_name"`
+	TierNumber     int32   `json:"tier_number"`
+	TrueskillMu    float64 `json:"trueskill_mu"`
+	TrueskillSigma float64 `json:"trueskill_sigma"`
+}
+
+type PublicTeamMembersSelect struct {
+	DiscordId string `json:"discord_id"`
+	GuildId   int64  `json:"guild_id"`
+	Id        int64  `json:"id"`
+	SeasonId  *int64 `json:"season_id"`
+	SignedAt  string `json:"signed_at"`
+	TeamId    int64  `json:"team_id"`
+}
+
+type PublicTeamMembersInsert struct {
+	DiscordId string  `json:"discord_id"`
+	GuildId   int64   `json:"guild_id"`
+	Id        *int64  `json:"id"`
+	SeasonId  *int64  `json:"season_id"`
+	SignedAt  *string `json:"signed_at"`
+	TeamId    int64   `json:"team_id"`
+}
+
+type PublicRosterTransactionsSelect struct {
+	Action          string  `json:"action"`
+	CapBasis        string  `json:"cap_basis"`
+	ChangedByUserId *int64  `json:"changed_by_user_id"`
+	CreatedAt       string  `json:"created_at"`
+	DiscordId       string  `json:"discord_id"`
+	GuildId         int64   `json:"guild_id"`
+	Id              int64   `json:"id"`
+	Salary          float64 `json:"salary"`
+	SeasonId        *int64  `json:"season_id"`
+	TeamId          int64   `json:"team_id"`
+	TeamSalaryAfter float64 `json:"team_salary_after"`
+}
+
+type PublicRosterTransactionsInsert struct {
+	Action          string   `json:"action"`
+	CapBasis        *string  `json:"cap_basis"`
+	ChangedByUserId *int64   `json:"changed_by_user_id"`
+	CreatedAt       *string  `json:"created_at"`
+	DiscordId       string   `json:"discord_id"`
+	GuildId         int64    `json:"guild_id"`
+	Id              *int64   `json:"id"`
+	Salary          *float64 `json:"salary"`
+	SeasonId        *int64   `json:"season_id"`
+	TeamId          int64    `json:"team_id"`
+	TeamSalaryAfter *float64 `json:"team_salary_after"`
+}
+
+type PublicDraftsSelect struct {
+	CreatedAt     string  `json:"created_at"`
+	CurrentPick   int32   `json:"current_pick"`
+	GuildId       int64   `json:"guild_id"`
+	Id            int64   `json:"id"`
+	Name          string  `json:"name"`
+	PickSeconds   int32   `json:"pick_seconds"`
+	PickStartedAt *string `json:"pick_started_at"`
+	Rounds        int32   `json:"rounds"`
+	SeasonId      *int64  `json:"season_id"`
+	Status        string  `json:"status"`
+	TeamOrder     []int64 `json:"team_order"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+type PublicDraftsInsert struct {
+	CreatedAt     *string `json:"created_at"`
+	CurrentPick   *int32  `json:"current_pick"`
+	GuildId       int64   `json:"guild_id"`
+	Id            *int64  `json:"id"`
+	Name          string  `json:"name"`
+	PickSeconds   *int32  `json:"pick_seconds"`
+	PickStartedAt *string `json:"pick_started_at"`
+	Rounds        int32   `json:"rounds"`
+	SeasonId      *int64  `json:"season_id"`
+	Status        *string `json:"status"`
+	TeamOrder     []int64 `json:"team_order"`
+	UpdatedAt     *string `json:"updated_at"`
+}
+
+type PublicDraftPicksSelect struct {
+	AutoPick   bool   `json:"auto_pick"`
+	CreatedAt  string `json:"created_at"`
+	DiscordId  string `json:"discord_id"`
+	DraftId    int64  `json:"draft_id"`
+	Id         int64  `json:"id"`
+	PickNumber int32  `json:"pick_number"`
+	Round      int32  `json:"round"`
+	TeamId     int64  `json:"team_id"`
+}
+
+type PublicDraftPicksInsert struct {
+	AutoPick   *bool   `json:"auto_pick"`
+	CreatedAt  *string `json:"created_at"`
+	DiscordId  string  `json:"discord_id"`
+	DraftId    int64   `json:"draft_id"`
+	Id         *int64  `json:"id"`
+	PickNumber int32   `json:"pick_number"`
+	Round      int32   `json:"round"`
+	TeamId     int64   `json:"team_id"`
+}
+
+type PublicLeagueResultsSelect struct {
+	AwayGoals        []int32 `json:"away_goals"`
+	AwayTeamId       int64   `json:"away_team_id"`
+	CreatedAt        string  `json:"created_at"`
+	DivisionId       int64   `json:"division_id"`
+	GuildId          int64   `json:"guild_id"`
+	HomeGoals        []int32 `json:"home_goals"`
+	HomeTeamId       int64   `json:"home_team_id"`
+	Id               int64   `json:"id"`
+	PlayedAt         string  `json:"played_at"`
+	ScheduledMatchId *int64  `json:"scheduled_match_id"`
+}
+
+type PublicLeagueResultsInsert struct {
+	AwayGoals        []int32 `json:"away_goals"`
+	AwayTeamId       int64   `json:"away_team_id"`
+	CreatedAt        *string `json:"created_at"`
+	DivisionId       int64   `json:"division_id"`
+	GuildId          int64   `json:"guild_id"`
+	HomeGoals        []int32 `json:"home_goals"`
+	HomeTeamId       int64   `json:"home_team_id"`
+	Id               *int64  `json:"id"`
+	PlayedAt         *string `json:"played_at"`
+	ScheduledMatchId *int64  `json:"scheduled_match_id"`
+}
+
+type PublicRankDistributionsSelect struct {
+	CreatedAt       string      `json:"created_at"`
+	CreatedByUserId *int64      `json:"created_by_user_id"`
+	GameSeason      int32       `json:"game_season"`
+	Id              int64       `json:"id"`
+	Name            string      `json:"name"`
+	Playlists       interface{} `json:"playlists"`
+	StartsAt        string      `json:"starts_at"`
+}
+
+type PublicRankDistributionsInsert struct {
+	CreatedAt       *string     `json:"created_at"`
+	CreatedByUserId *int64      `json:"created_by_user_id"`
+	GameSeason      int32       `json:"game_season"`
+	Id              *int64      `json:"id"`
+	Name            string      `json:"name"`
+	Playlists       interface{} `json:"playlists"`
+	StartsAt        string      `json:"starts_at"`
+}
+
+type PublicRecalculationJobsSelect struct {
+	AppliedAt        *string     `json:"applied_at"`
+	AppliedByUserId  *int64      `json:"applied_by_user_id"`
+	Changes          interface{} `json:"changes"`
+	CreatedAt        string      `json:"created_at"`
+	CreatedByUserId  *int64      `json:"created_by_user_id"`
+	Errors           interface{} `json:"errors"`
+	GuildId          int64       `json:"guild_id"`
+	Id               int64       `json:"id"`
+	Players          int32       `json:"players"`
+	RevertedAt       *string     `json:"reverted_at"`
+	RevertedByUserId *int64      `json:"reverted_by_user_id"`
+	Status           string      `json:"status"`
+	Unchanged        int32       `json:"unchanged"`
+}
+
+type PublicRecalculationJobsInsert struct {
+	AppliedAt        *string     `json:"applied_at"`
+	AppliedByUserId  *int64      `json:"applied_by_user_id"`
+	Changes          interface{} `json:"changes"`
+	CreatedAt        *string     `json:"created_at"`
+	CreatedByUserId  *int64      `json:"created_by_user_id"`
+	Errors           interface{} `json:"errors"`
+	GuildId          int64       `json:"guild_id"`
+	Id               *int64      `json:"id"`
+	Players          int32       `json:"players"`
+	RevertedAt       *string     `json:"reverted_at"`
+	RevertedByUserId *int64      `json:"reverted_by_user_id"`
+	Status           *string     `json:"status"`
+	Unchanged        int32       `json:"unchanged"`
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Draft status values
+const (
+	DraftStatusPending   = "pending"
+	DraftStatusActive    = "active"
+	DraftStatusCompleted = "completed"
+)
+
+// Draft is a snake draft of free agents onto a guild's teams
+type Draft struct {
+	ID            int64      `json:"id" db:"id"`
+	GuildID       int64      `json:"guild_id" db:"guild_id"`
+	SeasonID      *int64     `json:"season_id" db:"season_id"`
+	Name          string     `json:"name" db:"name"`
+	Status        string     `json:"status" db:"status"`
+	Rounds        int        `json:"rounds" db:"rounds"`
+	PickSeconds   int        `json:"pick_seconds" db:"pick_seconds"` // 0 for no pick timer
+	TeamOrder     []int64    `json:"team_order" db:"team_order"`     // first-round order; even rounds run in reverse
+	CurrentPick   int        `json:"current_pick" db:"current_pick"` // overall number of the pick on the clock
+	PickStartedAt *time.Time `json:"pick_started_at" db:"pick_started_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// DraftPick is one player taken in a draft
+type DraftPick struct {
+	ID         int64     `json:"id" db:"id"`
+	DraftID    int64     `json:"draft_id" db:"draft_id"`
+	PickNumber int       `json:"pick_number" db:"pick_number"`
+	Round      int       `json:"round" db:"round"`
+	TeamID     int64     `json:"team_id" db:"team_id"`
+	DiscordID  string    `json:"discord_id" db:"discord_id"`
+	AutoPick   bool      `json:"auto_pick" db:"auto_pick"` // made for the team when its pick timer ran out
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// DraftCreateRequest represents data needed to set up a draft
+type DraftCreateRequest struct {
+	GuildID     int64   `json:"guild_id" validate:"required"`
+	SeasonID    *int64  `json:"season_id"`
+	Name        string  `json:"name" validate:"required,max=100"`
+	Rounds      int     `json:"rounds" validate:"min=1"`
+	PickSeconds int     `json:"pick_seconds" validate:"min=0"`
+	TeamOrder   []int64 `json:"team_order" validate:"min=2"`
+}
+
+// Validate checks the request for a name, rounds and at least two distinct teams
+func (r *DraftCreateRequest) Validate() error {
+	if r.GuildID == 0 {
+		return fmt.Errorf("guild_id is required")
+	}
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return fmt.Errorf("draft name is required")
+	}
+	if len(r.Name) > 100 {
+		return fmt.Errorf("draft name must be at most 100 characters")
+	}
+	if r.Rounds < 1 {
+		return fmt.Errorf("a draft needs at least one round")
+	}
+	if r.PickSeconds < 0 {
+		return fmt.Errorf("pick timer must not be negative")
+	}
+	if len(r.TeamOrder) < 2 {
+		return fmt.Errorf("a draft needs at least two teams")
+	}
+
+	seen := make(map[int64]bool, len(r.TeamOrder))
+	for _, teamID := range r.TeamOrder {
+		if seen[teamID] {
+			return fmt.Errorf("team %d appears twice in the draft order", teamID)
+		}
+		seen[teamID] = true
+	}
+	return nil
+}
+
+// TotalPicks returns the number of picks in the draft
+func (d *Draft) TotalPicks() int {
+	return d.Rounds * len(d.TeamOrder)
+}
+
+// PickSlot returns the round and team for an overall pick number, snaking on even rounds
+func (d *Draft) PickSlot(pickNumber int) (round int, teamID int64) {
+	teams := len(d.TeamOrder)
+	round = (pickNumber-1)/teams + 1
+	slot := (pickNumber - 1) % teams
+	if round%2 == 0 {
+		slot = teams - 1 - slot
+	}
+	return round, d.TeamOrder[slot]
+}
+
+// IsActive checks if picks are being made
+func (d *Draft) IsActive() bool {
+	return d.Status == DraftStatusActive
+}
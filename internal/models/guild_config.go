@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 const (
@@ -14,12 +15,56 @@ const (
 
 	// Discord snowflake ID validation pattern (17-19 digits)
 	DiscordSnowflakePattern = `^\d{17,19}$`
+
+	// Default multiple of sigma subtracted from mu when placing players in tiers
+	DefaultPlacementSigmaMultiplier = 3.0
+)
+
+// Standings tiebreakers, applied in the configured order to teams level on series wins and losses
+const (
+	TiebreakHeadToHead         = "head_to_head"         // series wins against the other tied teams
+	TiebreakGameDifferential   = "game_differential"    // games won minus games lost
+	TiebreakGoalDifferential   = "goal_differential"    // goals scored minus goals conceded
+	TiebreakGoalsFor           = "goals_for"            // goals scored
+	TiebreakStrengthOfSchedule = "strength_of_schedule" // average mu of the opponents played
+)
+
+// DefaultStandingsTiebreakers is the tiebreak order used until a guild sets its own
+var DefaultStandingsTiebreakers = []string{
+	TiebreakHeadToHead,
+	TiebreakGameDifferential,
+	TiebreakGoalDifferential,
+	TiebreakStrengthOfSchedule,
+}
+
+// Salary cap bases
+const (
+	RosterCapBasisMu  = "mu"  // a player's salary is their TrueSkill mu
+	RosterCapBasisMMR = "mmr" // a player's salary is their best valid tracker MMR
+)
+
+// Placement methods
+const (
+	PlacementMethodSkillCutoffs = "skill_cutoffs" // place by mu - k*sigma against each tier's minimum
+	PlacementMethodFixedSizes   = "fixed_sizes"   // fill tiers top-down by mu - k*sigma
+)
+
+// Rating engines a guild can rate its matches with
+const (
+	RatingEngineTrueSkill = "trueskill"
+	RatingEngineGlicko2   = "glicko2"
+	RatingEngineElo       = "elo"
 )
 
 // GuildConfig represents the JSONB configuration for a Discord guild
 type GuildConfig struct {
 	Discord     DiscordConfig    `json:"discord"`
 	Permissions PermissionConfig `json:"permissions"`
+	Placement   PlacementConfig  `json:"placement"`
+	Roster      RosterConfig     `json:"roster"`
+	Standings   StandingsConfig  `json:"standings"`
+	Rating      RatingConfig     `json:"rating"`
+	Decay       DecayConfig      `json:"decay"`
 }
 
 // DiscordConfig contains Discord-specific integration settings
@@ -35,6 +80,56 @@ type PermissionConfig struct {
 	ModeratorRoleIDs []string `json:"moderator_role_ids"`
 }
 
+// PlacementConfig defines the guild's tiers and how players are placed in them
+// Tiers are listed best first; the last tier takes every player left over.
+type PlacementConfig struct {
+	Method          string       `json:"method"`
+	SigmaMultiplier float64      `json:"sigma_multiplier"` // k in mu - k*sigma
+	Tiers           []TierConfig `json:"tiers"`
+}
+
+// TierConfig is one tier of a placement
+type TierConfig struct {
+	Name     string  `json:"name"`
+	MinSkill float64 `json:"min_skill,omitempty"` // skill_cutoffs: lowest mu - k*sigma admitted
+	Size     int     `json:"size,omitempty"`      // fixed_sizes: number of players in the tier
+}
+
+// RosterConfig defines the salary cap and size limit applied to team rosters
+type RosterConfig struct {
+	CapBasis      string  `json:"cap_basis"`       // mu or mmr
+	SalaryCap     float64 `json:"salary_cap"`      // summed salaries allowed per team, 0 for no cap
+	MaxRosterSize int     `json:"max_roster_size"` // 0 for no limit
+}
+
+// StandingsConfig defines how division standings break ties
+type StandingsConfig struct {
+	Tiebreakers []string `json:"tiebreakers"` // applied in order; see the Tiebreak constants
+}
+
+// RatingConfig selects the engine that rates the guild's matches
+type RatingConfig struct {
+	Engine string `json:"engine"` // see the RatingEngine constants
+}
+
+// Inactivity decay defaults
+const (
+	DefaultDecayInactiveDays      = 30
+	DefaultDecaySigmaGrowthPerDay = 0.5
+)
+
+// DecayConfig defines how ratings of inactive players lose certainty
+// After InactiveDays without a match or tracker refresh, sigma grows by SigmaGrowthPerDay
+// (added in variance, capped at TrueSkill.SigmaMax) and, when MuDecayPerDay is set, mu moves
+// that fraction of the way toward MuFloor each day. A MuFloor of 0 uses TrueSkill.InitialMu.
+type DecayConfig struct {
+	Enabled           bool    `json:"enabled"`
+	InactiveDays      int     `json:"inactive_days"`
+	SigmaGrowthPerDay float64 `json:"sigma_growth_per_day"`
+	MuDecayPerDay     float64 `json:"mu_decay_per_day"`
+	MuFloor           float64 `json:"mu_floor"`
+}
+
 // Discord snowflake ID validation regex
 var discordSnowflakeRegex = regexp.MustCompile(DiscordSnowflakePattern)
 
@@ -50,7 +145,27 @@ func (gc *GuildConfig) Validate() error {
 		return err
 	}
 
-	return gc.validateBotCommandPrefix()
+	if err := gc.validateBotCommandPrefix(); err != nil {
+		return err
+	}
+
+	if err := gc.Placement.Validate(); err != nil {
+		return err
+	}
+
+	if err := gc.Roster.Validate(); err != nil {
+		return err
+	}
+
+	if err := gc.Standings.Validate(); err != nil {
+		return err
+	}
+
+	if err := gc.Rating.Validate(); err != nil {
+		return err
+	}
+
+	return gc.Decay.Validate()
 }
 
 // setDefaults applies sensible default values to the configuration
@@ -58,6 +173,11 @@ func (gc *GuildConfig) setDefaults() {
 	if gc.Discord.BotCommandPrefix == "" {
 		gc.Discord.BotCommandPrefix = DefaultBotCommandPrefix
 	}
+	gc.Placement.setDefaults()
+	gc.Roster.setDefaults()
+	gc.Standings.setDefaults()
+	gc.Rating.setDefaults()
+	gc.Decay.setDefaults()
 }
 
 // validateRoleIDs validates all role IDs in the configuration
@@ -102,6 +222,158 @@ func isValidDiscordSnowflake(id string) bool {
 	return discordSnowflakeRegex.MatchString(id)
 }
 
+// setDefaults fills in the placement method and sigma multiplier when unset
+func (pc *PlacementConfig) setDefaults() {
+	if pc.Method == "" {
+		pc.Method = PlacementMethodSkillCutoffs
+	}
+	if pc.SigmaMultiplier == 0 {
+		pc.SigmaMultiplier = DefaultPlacementSigmaMultiplier
+	}
+}
+
+// Validate checks the placement method and tiers; a config without tiers is valid but unused
+// Cutoffs must strictly decrease and fixed sizes must be positive, except on the last tier.
+func (pc *PlacementConfig) Validate() error {
+	pc.setDefaults()
+
+	if pc.Method != PlacementMethodSkillCutoffs && pc.Method != PlacementMethodFixedSizes {
+		return fmt.Errorf("invalid placement method: %s", pc.Method)
+	}
+	if pc.SigmaMultiplier < 0 {
+		return fmt.Errorf("placement sigma multiplier must not be negative, got %.2f", pc.SigmaMultiplier)
+	}
+
+	for i, tier := range pc.Tiers {
+		if strings.TrimSpace(tier.Name) == "" {
+			return fmt.Errorf("tier %d needs a name", i+1)
+		}
+		if i == len(pc.Tiers)-1 {
+			break
+		}
+
+		switch pc.Method {
+		case PlacementMethodSkillCutoffs:
+			if i > 0 && tier.MinSkill >= pc.Tiers[i-1].MinSkill {
+				return fmt.Errorf("tier %s cutoff %.1f must be below tier %s cutoff %.1f",
+					tier.Name, tier.MinSkill, pc.Tiers[i-1].Name, pc.Tiers[i-1].MinSkill)
+			}
+		case PlacementMethodFixedSizes:
+			if tier.Size <= 0 {
+				return fmt.Errorf("tier %s needs a size of at least 1", tier.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// IsConfigured checks if the guild has defined any tiers
+func (pc *PlacementConfig) IsConfigured() bool {
+	return len(pc.Tiers) > 0
+}
+
+// setDefaults caps rosters on summed mu when no basis is set
+func (rc *RosterConfig) setDefaults() {
+	if rc.CapBasis == "" {
+		rc.CapBasis = RosterCapBasisMu
+	}
+}
+
+// Validate checks the cap basis and that the cap and roster size are not negative
+func (rc *RosterConfig) Validate() error {
+	rc.setDefaults()
+
+	if rc.CapBasis != RosterCapBasisMu && rc.CapBasis != RosterCapBasisMMR {
+		return fmt.Errorf("invalid salary cap basis: %s", rc.CapBasis)
+	}
+	if rc.SalaryCap < 0 {
+		return fmt.Errorf("salary cap must not be negative, got %.1f", rc.SalaryCap)
+	}
+	if rc.MaxRosterSize < 0 {
+		return fmt.Errorf("max roster size must not be negative, got %d", rc.MaxRosterSize)
+	}
+	return nil
+}
+
+// HasSalaryCap checks if team salaries are capped
+func (rc *RosterConfig) HasSalaryCap() bool {
+	return rc.SalaryCap > 0
+}
+
+// setDefaults uses the default tiebreak order when none is set
+func (sc *StandingsConfig) setDefaults() {
+	if len(sc.Tiebreakers) == 0 {
+		sc.Tiebreakers = append([]string(nil), DefaultStandingsTiebreakers...)
+	}
+}
+
+// Validate checks that every tiebreaker is known and listed once
+func (sc *StandingsConfig) Validate() error {
+	sc.setDefaults()
+
+	seen := make(map[string]bool, len(sc.Tiebreakers))
+	for _, tiebreaker := range sc.Tiebreakers {
+		switch tiebreaker {
+		case TiebreakHeadToHead, TiebreakGameDifferential, TiebreakGoalDifferential, TiebreakGoalsFor, TiebreakStrengthOfSchedule:
+		default:
+			return fmt.Errorf("invalid standings tiebreaker: %s", tiebreaker)
+		}
+		if seen[tiebreaker] {
+			return fmt.Errorf("standings tiebreaker %s is listed twice", tiebreaker)
+		}
+		seen[tiebreaker] = true
+	}
+	return nil
+}
+
+// setDefaults rates matches with TrueSkill when no engine is set
+func (rc *RatingConfig) setDefaults() {
+	if rc.Engine == "" {
+		rc.Engine = RatingEngineTrueSkill
+	}
+}
+
+// Validate checks that the rating engine is known
+func (rc *RatingConfig) Validate() error {
+	rc.setDefaults()
+
+	switch rc.Engine {
+	case RatingEngineTrueSkill, RatingEngineGlicko2, RatingEngineElo:
+		return nil
+	default:
+		return fmt.Errorf("invalid rating engine: %s", rc.Engine)
+	}
+}
+
+// setDefaults starts decay after a month and grows sigma at the default rate
+func (dc *DecayConfig) setDefaults() {
+	if dc.InactiveDays == 0 {
+		dc.InactiveDays = DefaultDecayInactiveDays
+	}
+	if dc.SigmaGrowthPerDay == 0 {
+		dc.SigmaGrowthPerDay = DefaultDecaySigmaGrowthPerDay
+	}
+}
+
+// Validate checks the grace period and that the daily rates are in range
+func (dc *DecayConfig) Validate() error {
+	dc.setDefaults()
+
+	if dc.InactiveDays < 1 {
+		return fmt.Errorf("decay inactive days must be at least 1, got %d", dc.InactiveDays)
+	}
+	if dc.SigmaGrowthPerDay < 0 {
+		return fmt.Errorf("decay sigma growth must not be negative, got %.3f", dc.SigmaGrowthPerDay)
+	}
+	if dc.MuDecayPerDay < 0 || dc.MuDecayPerDay >= 1 {
+		return fmt.Errorf("decay mu rate must be at least 0 and below 1, got %.3f", dc.MuDecayPerDay)
+	}
+	if dc.MuFloor < 0 {
+		return fmt.Errorf("decay mu floor must not be negative, got %.1f", dc.MuFloor)
+	}
+	return nil
+}
+
 // HasAdminRole checks if any of the provided role IDs match admin roles
 func (gc *GuildConfig) HasAdminRole(userRoleIDs []string) bool {
 	return hasAnyRole(userRoleIDs, gc.Permissions.AdminRoleIDs)
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// LeagueResult is a played series between two league teams
+type LeagueResult struct {
+	ID               int64        `json:"id" db:"id"`
+	GuildID          int64        `json:"guild_id" db:"guild_id"`
+	DivisionID       int64        `json:"division_id" db:"division_id"`
+	ScheduledMatchID *int64       `json:"scheduled_match_id" db:"scheduled_match_id"`
+	HomeTeamID       int64        `json:"home_team_id" db:"home_team_id"`
+	AwayTeamID       int64        `json:"away_team_id" db:"away_team_id"`
+	Games            []LeagueGame `json:"games" db:"-"` // stored as home_goals and away_goals arrays
+	PlayedAt         time.Time    `json:"played_at" db:"played_at"`
+	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
+}
+
+// LeagueGame is the score of one game in a series
+type LeagueGame struct {
+	HomeGoals int `json:"home_goals"`
+	AwayGoals int `json:"away_goals"`
+}
+
+// LeagueResultCreateRequest represents a series result to record
+type LeagueResultCreateRequest struct {
+	GuildID          int64        `json:"guild_id" validate:"required"`
+	DivisionID       int64        `json:"division_id" validate:"required"`
+	ScheduledMatchID *int64       `json:"scheduled_match_id"` // the fixture this result completes, if any
+	HomeTeamID       int64        `json:"home_team_id" validate:"required"`
+	AwayTeamID       int64        `json:"away_team_id" validate:"required"`
+	Games            []LeagueGame `json:"games" validate:"required,min=1"`
+	PlayedAt         *time.Time   `json:"played_at"`
+}
+
+// Validate checks the request for two different teams and a decided series of decided games
+func (r *LeagueResultCreateRequest) Validate() error {
+	if r.GuildID == 0 {
+		return fmt.Errorf("guild_id is required")
+	}
+	if r.DivisionID == 0 {
+		return fmt.Errorf("division_id is required")
+	}
+	if r.HomeTeamID == 0 || r.AwayTeamID == 0 {
+		return fmt.Errorf("home_team_id and away_team_id are required")
+	}
+	if r.HomeTeamID == r.AwayTeamID {
+		return fmt.Errorf("a team cannot play itself")
+	}
+	if len(r.Games) == 0 {
+		return fmt.Errorf("at least one game score is required")
+	}
+
+	for i, game := range r.Games {
+		if game.HomeGoals < 0 || game.AwayGoals < 0 {
+			return fmt.Errorf("game %d has a negative score", i+1)
+		}
+		if game.HomeGoals == game.AwayGoals {
+			return fmt.Errorf("game %d is tied %d-%d; games are decided in overtime", i+1, game.HomeGoals, game.AwayGoals)
+		}
+	}
+
+	result := LeagueResult{Games: r.Games}
+	if home, away := result.SeriesScore(); home == away {
+		return fmt.Errorf("series is tied %d-%d", home, away)
+	}
+	return nil
+}
+
+// SeriesScore returns the games won by the home and away teams
+func (r *LeagueResult) SeriesScore() (home, away int) {
+	for _, game := range r.Games {
+		if game.HomeGoals > game.AwayGoals {
+			home++
+		} else {
+			away++
+		}
+	}
+	return home, away
+}
+
+// Goals returns the goals scored by the home and away teams across the series
+func (r *LeagueResult) Goals() (home, away int) {
+	for _, game := range r.Games {
+		home += game.HomeGoals
+		away += game.AwayGoals
+	}
+	return home, away
+}
+
+// WinnerID returns the team that won the series
+func (r *LeagueResult) WinnerID() int64 {
+	if home, away := r.SeriesScore(); home > away {
+		return r.HomeTeamID
+	}
+	return r.AwayTeamID
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Match team identifiers
+const (
+	MatchTeamA = 0
+	MatchTeamB = 1
+)
+
+// MatchDrawWinner is returned by Match.Winner when both teams scored the same
+const MatchDrawWinner = -1
+
+// Match represents a completed match between two teams in a guild
+type Match struct {
+	ID               int64         `json:"id" db:"id"`
+	GuildID          int64         `json:"guild_id" db:"guild_id"`
+	TeamAScore       int           `json:"team_a_score" db:"team_a_score"`
+	TeamBScore       int           `json:"team_b_score" db:"team_b_score"`
+	PlayedAt         time.Time     `json:"played_at" db:"played_at"`
+	ReplayID         *string       `json:"replay_id,omitempty" db:"replay_id"` // set for matches imported from a replay
+	Playlist         string        `json:"playlist,omitempty" db:"playlist"`   // rating track the match counted for, empty for other formats
+	ReportedByUserID *int64        `json:"reported_by_user_id" db:"reported_by_user_id"`
+	CreatedAt        time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at" db:"updated_at"`
+	Players          []MatchPlayer `json:"players" db:"-"`
+}
+
+// MatchPlayer links a user to the team they played on in a match
+type MatchPlayer struct {
+	ID      int64             `json:"id" db:"id"`
+	MatchID int64             `json:"match_id" db:"match_id"`
+	UserID  int64             `json:"user_id" db:"user_id"`
+	Team    int               `json:"team" db:"team"`
+	Stats   *MatchPlayerStats `json:"stats,omitempty" db:"-"` // nil unless the match came with a stat line
+}
+
+// MatchPlayerStats is a player's stat line for one match
+type MatchPlayerStats struct {
+	Goals   int  `json:"goals"`
+	Assists int  `json:"assists"`
+	Saves   int  `json:"saves"`
+	Shots   int  `json:"shots"`
+	Score   int  `json:"score"`
+	MVP     bool `json:"mvp"`
+}
+
+// MatchCreateRequest represents data needed to record a new match
+type MatchCreateRequest struct {
+	GuildID          int64      `json:"guild_id" validate:"required"`
+	TeamAUserIDs     []int64    `json:"team_a_user_ids" validate:"required,min=1"`
+	TeamBUserIDs     []int64    `json:"team_b_user_ids" validate:"required,min=1"`
+	TeamAScore       int        `json:"team_a_score" validate:"min=0"`
+	TeamBScore       int        `json:"team_b_score" validate:"min=0"`
+	PlayedAt         *time.Time `json:"played_at"`
+	ReportedByUserID *int64     `json:"reported_by_user_id"`
+	// Playlist is the game mode (ones, 2v2, doubles...); empty picks it by team size
+	Playlist string `json:"playlist,omitempty"`
+
+	// Optional, for matches imported from replays
+	ReplayID    *string                    `json:"replay_id,omitempty"`
+	PlayerStats map[int64]MatchPlayerStats `json:"player_stats,omitempty"` // by user ID
+}
+
+// RatedMatchRecord is a match with every rating write it causes, stored together or not at all
+// History rows leave MatchID unset; it is filled in with the new match's ID.
+type RatedMatchRecord struct {
+	Match           MatchCreateRequest
+	Ratings         []*PlayerEffectiveMMR
+	History         []PlayerHistoricalMMRCreateRequest
+	PlaylistRatings []*PlayerPlaylistRating
+}
+
+// Validate checks the request for missing teams, negative scores and duplicate players
+func (r *MatchCreateRequest) Validate() error {
+	if r.GuildID == 0 {
+		return fmt.Errorf("guild_id is required")
+	}
+	if len(r.TeamAUserIDs) == 0 || len(r.TeamBUserIDs) == 0 {
+		return fmt.Errorf("both teams need at least one player")
+	}
+	if r.TeamAScore < 0 || r.TeamBScore < 0 {
+		return fmt.Errorf("scores cannot be negative")
+	}
+	if _, ok := RatingPlaylistFor(r.Playlist); r.Playlist != "" && !ok {
+		return fmt.Errorf("unknown playlist %q: expected ones, twos or threes", r.Playlist)
+	}
+
+	seen := make(map[int64]bool, len(r.TeamAUserIDs)+len(r.TeamBUserIDs))
+	for _, userID := range append(append([]int64{}, r.TeamAUserIDs...), r.TeamBUserIDs...) {
+		if seen[userID] {
+			return fmt.Errorf("user %d appears more than once in the match", userID)
+		}
+		seen[userID] = true
+	}
+
+	for userID, stats := range r.PlayerStats {
+		if !seen[userID] {
+			return fmt.Errorf("stats given for user %d who is not in the match", userID)
+		}
+		if err := stats.Validate(); err != nil {
+			return fmt.Errorf("stats for user %d: %w", userID, err)
+		}
+	}
+
+	return nil
+}
+
+// RatingPlaylist returns the rating track the match counts for: the requested playlist, or
+// the one matching the team size when both teams are 1, 2 or 3 players. Empty for other formats.
+func (r *MatchCreateRequest) RatingPlaylist() string {
+	if r.Playlist != "" {
+		playlist, _ := RatingPlaylistFor(r.Playlist)
+		return playlist
+	}
+	if len(r.TeamAUserIDs) != len(r.TeamBUserIDs) {
+		return ""
+	}
+	playlist, _ := RatingPlaylistForTeamSize(len(r.TeamAUserIDs))
+	return playlist
+}
+
+// Validate checks the stat line for negative counts
+func (s MatchPlayerStats) Validate() error {
+	if s.Goals < 0 || s.Assists < 0 || s.Saves < 0 || s.Shots < 0 || s.Score < 0 {
+		return fmt.Errorf("stats cannot be negative")
+	}
+	return nil
+}
+
+// Winner returns the winning team, or MatchDrawWinner for a draw
+func (m *Match) Winner() int {
+	switch {
+	case m.TeamAScore > m.TeamBScore:
+		return MatchTeamA
+	case m.TeamBScore > m.TeamAScore:
+		return MatchTeamB
+	default:
+		return MatchDrawWinner
+	}
+}
+
+// IsDraw checks if both teams finished with the same score
+func (m *Match) IsDraw() bool {
+	return m.Winner() == MatchDrawWinner
+}
+
+// TeamUserIDs returns the user IDs of every player on the given team
+func (m *Match) TeamUserIDs(team int) []int64 {
+	userIDs := make([]int64, 0, len(m.Players))
+	for _, player := range m.Players {
+		if player.Team == team {
+			userIDs = append(userIDs, player.UserID)
+		}
+	}
+	return userIDs
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// TierPlacement is the tier a player was placed in for a season
+type TierPlacement struct {
+	ID             int64     `json:"id" db:"id"`
+	GuildID        int64     `json:"guild_id" db:"guild_id"`
+	SeasonID       int64     `json:"season_id" db:"season_id"`
+	DiscordID      string    `json:"discord_id" db:"discord_id"`
+	PlayerName     string    `json:"player_name" db:"player_name"`
+	TierNumber     int       `json:"tier_number" db:"tier_number"`
+	TierName       string    `json:"tier_name" db:"tier_name"`
+	TierLabel      string    `json:"tier_label" db:"-"` // e.g. "Tier 2 – Premier", for the leaderboard and bot
+	Rank           int       `json:"rank" db:"rank"`    // overall rank by skill estimate across all tiers
+	TrueSkillMu    float64   `json:"trueskill_mu" db:"trueskill_mu"`
+	TrueSkillSigma float64   `json:"trueskill_sigma" db:"trueskill_sigma"`
+	SkillEstimate  float64   `json:"skill_estimate" db:"skill_estimate"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// FormatTierLabel returns the display form of a tier, e.g. "Tier 2 – Premier"
+func FormatTierLabel(tierNumber int, tierName string) string {
+	return fmt.Sprintf("Tier %d – %s", tierNumber, tierName)
+}
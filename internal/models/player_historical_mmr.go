@@ -18,17 +18,20 @@ type PlayerHistoricalMMR struct {
 	TrueSkillSigmaAfter  float64   `json:"trueskill_sigma_after" db:"trueskill_sigma_after"`
 	ChangeReason         string    `json:"change_reason" db:"change_reason"`
 	MatchID              *int64    `json:"match_id" db:"match_id"`
+	RecalculationJobID   *int64    `json:"recalculation_job_id" db:"recalculation_job_id"`
 	ChangedByUserID      *int64    `json:"changed_by_user_id" db:"changed_by_user_id"`
 	CreatedAt            time.Time `json:"created_at" db:"created_at"`
 }
 
 // ChangeReason constants for different types of MMR changes
 const (
-	ChangeReasonMatchResult      = "match_result"
-	ChangeReasonManualAdjustment = "manual_adjustment"
-	ChangeReasonSeasonReset      = "season_reset"
-	ChangeReasonInitialSetup     = "initial_setup"
-	ChangeReasonRecalculation    = "recalculation"
+	ChangeReasonMatchResult         = "match_result"
+	ChangeReasonManualAdjustment    = "manual_adjustment"
+	ChangeReasonSeasonReset         = "season_reset"
+	ChangeReasonInitialSetup        = "initial_setup"
+	ChangeReasonRecalculation       = "recalculation"
+	ChangeReasonInactivityDecay     = "inactivity_decay"
+	ChangeReasonRecalculationRevert = "recalculation_revert"
 )
 
 // PlayerHistoricalMMRCreateRequest represents data needed to create a new historical MMR record
@@ -41,8 +44,9 @@ type PlayerHistoricalMMRCreateRequest struct {
 	TrueSkillMuAfter     float64  `json:"trueskill_mu_after" validate:"min=0,max=5000"`
 	TrueSkillSigmaBefore *float64 `json:"trueskill_sigma_before"`
 	TrueSkillSigmaAfter  float64  `json:"trueskill_sigma_after" validate:"min=0,max=20"`
-	ChangeReason         string   `json:"change_reason" validate:"required,oneof=match_result manual_adjustment season_reset initial_setup recalculation"`
+	ChangeReason         string   `json:"change_reason" validate:"required,oneof=match_result manual_adjustment season_reset initial_setup recalculation inactivity_decay recalculation_revert"`
 	MatchID              *int64   `json:"match_id"`
+	RecalculationJobID   *int64   `json:"recalculation_job_id"`
 	ChangedByUserID      *int64   `json:"changed_by_user_id"`
 }
 
@@ -96,6 +100,15 @@ func (p *PlayerHistoricalMMR) GetChangeDescription() string {
 
 	switch p.ChangeReason {
 	case ChangeReasonMatchResult:
+		// Match updates only move TrueSkill, so fall back to mu when MMR is unchanged
+		if mmrChange == 0 && p.TrueSkillMuBefore != nil {
+			muChange := p.GetTrueSkillMuChange()
+			if muChange > 0 {
+				return "Won match"
+			} else if muChange < 0 {
+				return "Lost match"
+			}
+		}
 		if mmrChange > 0 {
 			return "Won match"
 		} else if mmrChange < 0 {
@@ -110,6 +123,10 @@ func (p *PlayerHistoricalMMR) GetChangeDescription() string {
 		return "Initial setup"
 	case ChangeReasonRecalculation:
 		return "Recalculation"
+	case ChangeReasonInactivityDecay:
+		return "Inactivity decay"
+	case ChangeReasonRecalculationRevert:
+		return "Recalculation reverted"
 	default:
 		return "Unknown change"
 	}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Game modes with their own rating track, keyed as in tracker data
+const (
+	RatingPlaylistOnes   = "ones"
+	RatingPlaylistTwos   = "twos"
+	RatingPlaylistThrees = "threes"
+)
+
+// RatingPlaylists lists every playlist with a rating track, smallest team first
+var RatingPlaylists = []string{RatingPlaylistOnes, RatingPlaylistTwos, RatingPlaylistThrees}
+
+// ratingPlaylistsByRank maps rank distribution keys to rating playlists
+var ratingPlaylistsByRank = map[string]string{
+	RankPlaylistSoloDuel: RatingPlaylistOnes,
+	RankPlaylistDoubles:  RatingPlaylistTwos,
+	RankPlaylistStandard: RatingPlaylistThrees,
+}
+
+// RatingPlaylistFor returns the rating playlist for a playlist given as ones, 1v1 or soloDuel
+func RatingPlaylistFor(playlist string) (string, bool) {
+	rankPlaylist, ok := RankPlaylistFor(strings.TrimSpace(playlist))
+	if !ok {
+		return "", false
+	}
+	return ratingPlaylistsByRank[rankPlaylist], true
+}
+
+// RatingPlaylistForTeamSize returns the rating playlist played with teams of the given size
+func RatingPlaylistForTeamSize(size int) (string, bool) {
+	if size < 1 || size > len(RatingPlaylists) {
+		return "", false
+	}
+	return RatingPlaylists[size-1], true
+}
+
+// PlayerPlaylistRating is a player's rating in one game mode of a guild
+// It is seeded from the matching tracker playlist and only moved by matches of that mode;
+// PlayerEffectiveMMR remains the combined rating across modes.
+type PlayerPlaylistRating struct {
+	ID             int64     `json:"id" db:"id"`
+	UserID         int64     `json:"user_id" db:"user_id"`
+	GuildID        int64     `json:"guild_id" db:"guild_id"`
+	Playlist       string    `json:"playlist" db:"playlist"`
+	TrueSkillMu    float64   `json:"trueskill_mu" db:"trueskill_mu"`
+	TrueSkillSigma float64   `json:"trueskill_sigma" db:"trueskill_sigma"`
+	GamesPlayed    int       `json:"games_played" db:"games_played"`
+	LastUpdated    time.Time `json:"last_updated" db:"last_updated"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// GetSkillEstimate returns the conservative skill estimate (mu - 3*sigma)
+func (p *PlayerPlaylistRating) GetSkillEstimate() float64 {
+	return p.TrueSkillMu - (3.0 * p.TrueSkillSigma)
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Playlists a rank distribution covers, keyed as in the percentile tables
+const (
+	RankPlaylistSoloDuel = "soloDuel"
+	RankPlaylistDoubles  = "doubles"
+	RankPlaylistStandard = "standard"
+)
+
+// RankPlaylists lists every playlist a rank distribution must cover
+var RankPlaylists = []string{RankPlaylistSoloDuel, RankPlaylistDoubles, RankPlaylistStandard}
+
+// rankPlaylistAliases maps tracker keys and match formats to distribution keys
+var rankPlaylistAliases = map[string]string{
+	"ones": RankPlaylistSoloDuel, "1v1": RankPlaylistSoloDuel, "soloduel": RankPlaylistSoloDuel,
+	"twos": RankPlaylistDoubles, "2v2": RankPlaylistDoubles, "doubles": RankPlaylistDoubles,
+	"threes": RankPlaylistStandard, "3v3": RankPlaylistStandard, "standard": RankPlaylistStandard,
+}
+
+// RankPlaylistFor returns the distribution key for a playlist given as ones, 1v1 or soloDuel
+func RankPlaylistFor(playlist string) (string, bool) {
+	key, ok := rankPlaylistAliases[strings.ToLower(strings.TrimSpace(playlist))]
+	return key, ok
+}
+
+// PlaylistRanks names the rank held in each playlist, e.g. "Champion 2 Div III"
+// Playlists without ranked data are left empty.
+type PlaylistRanks struct {
+	Ones   string `json:"ones,omitempty"`
+	Twos   string `json:"twos,omitempty"`
+	Threes string `json:"threes,omitempty"`
+}
+
+// rankPercentTolerance is how far a playlist's percentages may stray from 100 after rounding
+const rankPercentTolerance = 1.0
+
+// RankBand is one rank of a playlist: its MMR range and share of the ranked population
+type RankBand struct {
+	Rank    string  `json:"rank"`
+	Percent float64 `json:"percent"`
+	MinMMR  float64 `json:"min_mmr"`
+	MaxMMR  float64 `json:"max_mmr"`
+}
+
+// RankDistribution is the rank population and MMR ranges of one Rocket League game season
+// Bands are listed lowest rank first. Tracker data is converted with the distribution of the
+// latest game season that started on or before the tracker was last updated.
+type RankDistribution struct {
+	ID              int64                 `json:"id,omitempty" db:"id"`
+	GameSeason      int                   `json:"game_season" db:"game_season"`
+	Name            string                `json:"name" db:"name"`
+	StartsAt        time.Time             `json:"starts_at" db:"starts_at"`
+	Playlists       map[string][]RankBand `json:"playlists" db:"playlists"`
+	CreatedByUserID *int64                `json:"created_by_user_id,omitempty" db:"created_by_user_id"`
+	CreatedAt       time.Time             `json:"created_at,omitempty" db:"created_at"`
+}
+
+// Validate checks the season number and that every playlist has contiguous, ascending bands
+func (d *RankDistribution) Validate() error {
+	if d.GameSeason <= 0 {
+		return fmt.Errorf("game season must be positive, got %d", d.GameSeason)
+	}
+	if d.StartsAt.IsZero() {
+		return fmt.Errorf("starts_at is required")
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		d.Name = fmt.Sprintf("Season %d", d.GameSeason)
+	}
+
+	for _, playlist := range RankPlaylists {
+		if err := validateRankBands(playlist, d.Playlists[playlist]); err != nil {
+			return err
+		}
+	}
+	for playlist := range d.Playlists {
+		if !isRankPlaylist(playlist) {
+			return fmt.Errorf("unknown playlist %q: expected one of %s", playlist, strings.Join(RankPlaylists, ", "))
+		}
+	}
+	return nil
+}
+
+// validateRankBands checks one playlist's bands
+func validateRankBands(playlist string, bands []RankBand) error {
+	if len(bands) == 0 {
+		return fmt.Errorf("playlist %s has no ranks", playlist)
+	}
+
+	total := 0.0
+	seen := make(map[string]bool, len(bands))
+	for i, band := range bands {
+		if strings.TrimSpace(band.Rank) == "" {
+			return fmt.Errorf("playlist %s rank %d needs a name", playlist, i+1)
+		}
+		if seen[band.Rank] {
+			return fmt.Errorf("playlist %s lists %s twice", playlist, band.Rank)
+		}
+		seen[band.Rank] = true
+
+		if band.Percent < 0 {
+			return fmt.Errorf("playlist %s %s has a negative percent", playlist, band.Rank)
+		}
+		if band.MinMMR < 0 || band.MaxMMR < band.MinMMR {
+			return fmt.Errorf("playlist %s %s has an invalid MMR range %.0f-%.0f", playlist, band.Rank, band.MinMMR, band.MaxMMR)
+		}
+		if i > 0 && band.MinMMR <= bands[i-1].MaxMMR {
+			return fmt.Errorf("playlist %s %s must start above %s (%.0f)", playlist, band.Rank, bands[i-1].Rank, bands[i-1].MaxMMR)
+		}
+		total += band.Percent
+	}
+
+	if math.Abs(total-100) > rankPercentTolerance {
+		return fmt.Errorf("playlist %s percentages add up to %.3f, expected 100", playlist, total)
+	}
+	return nil
+}
+
+// isRankPlaylist checks if a playlist key is one a distribution may cover
+func isRankPlaylist(playlist string) bool {
+	for _, known := range RankPlaylists {
+		if playlist == known {
+			return true
+		}
+	}
+	return false
+}
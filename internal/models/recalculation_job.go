@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// Recalculation job status values
+const (
+	RecalculationStatusPending   = "pending"
+	RecalculationStatusApplied   = "applied"
+	RecalculationStatusReverted  = "reverted"
+	RecalculationStatusDiscarded = "discarded"
+)
+
+// RecalculationJob is a recalculation of a guild's ratings from tracker data, stored as a
+// changeset so it can be reviewed before it is applied and reverted afterwards
+type RecalculationJob struct {
+	ID               int64                 `json:"id" db:"id"`
+	GuildID          int64                 `json:"guild_id" db:"guild_id"`
+	Status           string                `json:"status" db:"status"`
+	Changes          []RecalculationChange `json:"changes" db:"changes"`
+	Players          int                   `json:"players" db:"players"`     // active players considered
+	Unchanged        int                   `json:"unchanged" db:"unchanged"` // players whose rating would not move
+	Errors           []string              `json:"errors" db:"errors"`       // players that could not be seeded
+	CreatedByUserID  *int64                `json:"created_by_user_id" db:"created_by_user_id"`
+	AppliedByUserID  *int64                `json:"applied_by_user_id" db:"applied_by_user_id"`
+	RevertedByUserID *int64                `json:"reverted_by_user_id" db:"reverted_by_user_id"`
+	CreatedAt        time.Time             `json:"created_at" db:"created_at"`
+	AppliedAt        *time.Time            `json:"applied_at" db:"applied_at"`
+	RevertedAt       *time.Time            `json:"reverted_at" db:"reverted_at"`
+}
+
+// RecalculationChange is one player's rating before and after a recalculation
+// MuBefore and SigmaBefore are nil when the player had no rating in the guild yet.
+type RecalculationChange struct {
+	UserID      int64    `json:"user_id"`
+	DiscordID   string   `json:"discord_id"`
+	Name        string   `json:"name"`
+	MuBefore    *float64 `json:"mu_before"`
+	SigmaBefore *float64 `json:"sigma_before"`
+	MuAfter     float64  `json:"mu_after"`
+	SigmaAfter  float64  `json:"sigma_after"`
+	Applied     bool     `json:"applied"`  // false when the rating moved after the preview and was left alone
+	Reverted    bool     `json:"reverted"` // false when the rating moved after the apply and was left alone
+}
+
+// MuChange returns the difference in mu, measured from the default for new ratings
+func (c RecalculationChange) MuChange(initialMu float64) float64 {
+	if c.MuBefore == nil {
+		return c.MuAfter - initialMu
+	}
+	return c.MuAfter - *c.MuBefore
+}
+
+// AppliedCount returns how many changes were written when the job was applied
+func (j *RecalculationJob) AppliedCount() int {
+	count := 0
+	for _, change := range j.Changes {
+		if change.Applied {
+			count++
+		}
+	}
+	return count
+}
+
+// RevertedCount returns how many changes were undone when the job was reverted
+func (j *RecalculationJob) RevertedCount() int {
+	count := 0
+	for _, change := range j.Changes {
+		if change.Reverted {
+			count++
+		}
+	}
+	return count
+}
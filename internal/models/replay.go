@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BallchasingReplay is a replay in ballchasing.com's export format (GET /api/replays/{id})
+// Only the fields used to record a match are decoded.
+type BallchasingReplay struct {
+	ID         string          `json:"id"`
+	Title      string          `json:"title"`
+	Date       string          `json:"date"`
+	PlaylistID string          `json:"playlist_id"`
+	Blue       BallchasingTeam `json:"blue"`
+	Orange     BallchasingTeam `json:"orange"`
+}
+
+// BallchasingTeam is one side of a replay
+type BallchasingTeam struct {
+	Name    string              `json:"name"`
+	Players []BallchasingPlayer `json:"players"`
+	Stats   struct {
+		Core BallchasingCoreStats `json:"core"`
+	} `json:"stats"`
+}
+
+// BallchasingPlayer is a player in a replay with their platform account
+type BallchasingPlayer struct {
+	Name  string              `json:"name"`
+	ID    BallchasingPlayerID `json:"id"`
+	MVP   bool                `json:"mvp"`
+	Stats struct {
+		Core BallchasingCoreStats `json:"core"`
+	} `json:"stats"`
+}
+
+// BallchasingPlayerID identifies a player's platform account, e.g. {"platform": "steam", "id": "7656..."}
+type BallchasingPlayerID struct {
+	Platform string `json:"platform"`
+	ID       string `json:"id"`
+}
+
+// BallchasingCoreStats are the core stats ballchasing reports for a player or team
+type BallchasingCoreStats struct {
+	Shots   int  `json:"shots"`
+	Goals   int  `json:"goals"`
+	Saves   int  `json:"saves"`
+	Assists int  `json:"assists"`
+	Score   int  `json:"score"`
+	MVP     bool `json:"mvp"`
+}
+
+// Validate checks the replay has an ID and players on both teams
+func (r *BallchasingReplay) Validate() error {
+	if strings.TrimSpace(r.ID) == "" {
+		return fmt.Errorf("replay has no id")
+	}
+	if len(r.Blue.Players) == 0 || len(r.Orange.Players) == 0 {
+		return fmt.Errorf("replay %s needs players on both teams", r.ID)
+	}
+	return nil
+}
+
+// PlayedAt returns when the replay was played, or nil if the date is missing or unreadable
+func (r *BallchasingReplay) PlayedAt() *time.Time {
+	if r.Date == "" {
+		return nil
+	}
+	playedAt, err := time.Parse(time.RFC3339, r.Date)
+	if err != nil {
+		return nil
+	}
+	playedAt = playedAt.UTC()
+	return &playedAt
+}
+
+// TotalGoals returns the team's goals, summing its players when the team stats are missing
+func (t *BallchasingTeam) TotalGoals() int {
+	if t.Stats.Core.Goals > 0 {
+		return t.Stats.Core.Goals
+	}
+
+	var goals int
+	for _, player := range t.Players {
+		goals += player.Stats.Core.Goals
+	}
+	return goals
+}
+
+// MatchStats converts the player's core stats into a match stat line
+func (p *BallchasingPlayer) MatchStats() MatchPlayerStats {
+	core := p.Stats.Core
+	return MatchPlayerStats{
+		Goals:   core.Goals,
+		Assists: core.Assists,
+		Saves:   core.Saves,
+		Shots:   core.Shots,
+		Score:   core.Score,
+		MVP:     p.MVP || core.MVP,
+	}
+}
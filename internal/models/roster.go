@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Roster transaction actions
+const (
+	RosterActionSigned   = "signed"
+	RosterActionReleased = "released"
+	RosterActionCaptain  = "captain" // player named team captain
+)
+
+// TeamMember is a player on a team's current roster
+type TeamMember struct {
+	ID        int64     `json:"id" db:"id"`
+	TeamID    int64     `json:"team_id" db:"team_id"`
+	GuildID   int64     `json:"guild_id" db:"guild_id"`
+	SeasonID  *int64    `json:"season_id" db:"season_id"`
+	DiscordID string    `json:"discord_id" db:"discord_id"`
+	SignedAt  time.Time `json:"signed_at" db:"signed_at"`
+}
+
+// RosterTransaction is one entry in a team's signing and release history
+type RosterTransaction struct {
+	ID              int64     `json:"id" db:"id"`
+	TeamID          int64     `json:"team_id" db:"team_id"`
+	GuildID         int64     `json:"guild_id" db:"guild_id"`
+	SeasonID        *int64    `json:"season_id" db:"season_id"`
+	DiscordID       string    `json:"discord_id" db:"discord_id"`
+	Action          string    `json:"action" db:"action"`
+	Salary          float64   `json:"salary" db:"salary"`                       // player's salary at the time of the change
+	CapBasis        string    `json:"cap_basis" db:"cap_basis"`                 // mu or mmr
+	TeamSalaryAfter float64   `json:"team_salary_after" db:"team_salary_after"` // summed roster salary after the change
+	ChangedByUserID *int64    `json:"changed_by_user_id" db:"changed_by_user_id"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// RosterChangeRequest represents a signing, release or captaincy change made through the API
+type RosterChangeRequest struct {
+	TeamID          int64  `json:"team_id" validate:"required"`
+	DiscordID       string `json:"discord_id" validate:"required"`
+	Action          string `json:"action" validate:"required"` // signed, released or captain
+	ChangedByUserID *int64 `json:"changed_by_user_id"`
+}
+
+// Validate checks the request for a team, a player and a known action
+func (r *RosterChangeRequest) Validate() error {
+	if r.TeamID <= 0 {
+		return fmt.Errorf("team_id is required")
+	}
+	if r.DiscordID == "" {
+		return fmt.Errorf("discord_id is required")
+	}
+	switch r.Action {
+	case RosterActionSigned, RosterActionReleased, RosterActionCaptain:
+		return nil
+	default:
+		return fmt.Errorf("action must be %s, %s or %s", RosterActionSigned, RosterActionReleased, RosterActionCaptain)
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Scheduled match status values
+const (
+	ScheduledMatchScheduled = "scheduled"
+	ScheduledMatchCompleted = "completed"
+)
+
+// Division is a group of teams that play a round-robin against each other
+type Division struct {
+	ID        int64     `json:"id" db:"id"`
+	GuildID   int64     `json:"guild_id" db:"guild_id"`
+	SeasonID  *int64    `json:"season_id" db:"season_id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ScheduledMatch is a fixture on a match night; a bye has no away team
+type ScheduledMatch struct {
+	ID         int64     `json:"id" db:"id"`
+	GuildID    int64     `json:"guild_id" db:"guild_id"`
+	DivisionID int64     `json:"division_id" db:"division_id"`
+	Round      int       `json:"round" db:"round"`
+	MatchNight time.Time `json:"match_night" db:"match_night"`
+	HomeTeamID int64     `json:"home_team_id" db:"home_team_id"`
+	AwayTeamID *int64    `json:"away_team_id" db:"away_team_id"`
+	Status     string    `json:"status" db:"status"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DivisionCreateRequest represents data needed to create a new division
+type DivisionCreateRequest struct {
+	GuildID  int64  `json:"guild_id" validate:"required"`
+	SeasonID *int64 `json:"season_id"`
+	Name     string `json:"name" validate:"required,max=100"`
+}
+
+// ScheduleGenerateRequest describes the round-robin to generate for a division
+type ScheduleGenerateRequest struct {
+	DivisionID  int64       `json:"division_id" validate:"required"`
+	MatchNights []time.Time `json:"match_nights" validate:"required,min=1"`
+	Double      bool        `json:"double"`  // Play every opponent twice, home and away
+	Replace     bool        `json:"replace"` // Replace an existing schedule that has no completed fixtures
+}
+
+// Validate checks the request for a missing guild or name
+func (r *DivisionCreateRequest) Validate() error {
+	if r.GuildID == 0 {
+		return fmt.Errorf("guild_id is required")
+	}
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return fmt.Errorf("division name is required")
+	}
+	if len(r.Name) > 100 {
+		return fmt.Errorf("division name must be at most 100 characters")
+	}
+	return nil
+}
+
+// Validate checks the request for a missing division or match nights
+func (r *ScheduleGenerateRequest) Validate() error {
+	if r.DivisionID == 0 {
+		return fmt.Errorf("division_id is required")
+	}
+	if len(r.MatchNights) == 0 {
+		return fmt.Errorf("at least one match night is required")
+	}
+	return nil
+}
+
+// IsBye checks if the home team sits out this round
+func (m *ScheduledMatch) IsBye() bool {
+	return m.AwayTeamID == nil
+}
+
+// Involves checks if the team plays in (or has the bye of) this fixture
+func (m *ScheduledMatch) Involves(teamID int64) bool {
+	return m.HomeTeamID == teamID || (m.AwayTeamID != nil && *m.AwayTeamID == teamID)
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Season status values
+const (
+	SeasonStatusActive = "active"
+	SeasonStatusClosed = "closed"
+)
+
+// Season represents a league season in a guild
+type Season struct {
+	ID                   int64      `json:"id" db:"id"`
+	GuildID              int64      `json:"guild_id" db:"guild_id"`
+	Number               int        `json:"number" db:"number"`
+	Name                 string     `json:"name" db:"name"`
+	Status               string     `json:"status" db:"status"`
+	StartedAt            time.Time  `json:"started_at" db:"started_at"`
+	EndedAt              *time.Time `json:"ended_at" db:"ended_at"`
+	SoftResetMuFactor    *float64   `json:"soft_reset_mu_factor" db:"soft_reset_mu_factor"`
+	SoftResetSigmaFactor *float64   `json:"soft_reset_sigma_factor" db:"soft_reset_sigma_factor"`
+	CreatedAt            time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// SeasonCreateRequest represents data needed to start a new season
+type SeasonCreateRequest struct {
+	GuildID int64  `json:"guild_id" validate:"required"`
+	Number  int    `json:"number" validate:"min=1"`
+	Name    string `json:"name" validate:"required,max=100"`
+}
+
+// SeasonCloseRecord is a season close with its final standings and soft reset, stored together or not at all
+// Nil factors and empty ratings close the season without a reset.
+type SeasonCloseRecord struct {
+	SeasonID    int64
+	MuFactor    *float64
+	SigmaFactor *float64
+	Snapshot    []SeasonLeaderboardEntry
+	Ratings     []*PlayerEffectiveMMR
+	History     []PlayerHistoricalMMRCreateRequest
+
+	PlaylistRatings []*PlayerPlaylistRating // per-playlist ratings after the same reset
+}
+
+// SeasonLeaderboardEntry is one row of the standings frozen when a season closes
+type SeasonLeaderboardEntry struct {
+	ID             int64     `json:"id" db:"id"`
+	SeasonID       int64     `json:"season_id" db:"season_id"`
+	UserID         int64     `json:"user_id" db:"user_id"`
+	Rank           int       `json:"rank" db:"rank"`
+	MMR            int       `json:"mmr" db:"mmr"`
+	TrueSkillMu    float64   `json:"trueskill_mu" db:"trueskill_mu"`
+	TrueSkillSigma float64   `json:"trueskill_sigma" db:"trueskill_sigma"`
+	GamesPlayed    int       `json:"games_played" db:"games_played"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// TrackerSeasonRollover records the Rocket League season every tracker was rolled into
+// Rolling is global across guilds, so each game season is rolled at most once.
+type TrackerSeasonRollover struct {
+	GameSeason     int       `json:"game_season" db:"game_season"`
+	TrackersRolled int       `json:"trackers_rolled" db:"trackers_rolled"`
+	RolledByUserID *int64    `json:"rolled_by_user_id" db:"rolled_by_user_id"`
+	RolledAt       time.Time `json:"rolled_at" db:"rolled_at"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks the request for a missing guild, number or name
+func (r *SeasonCreateRequest) Validate() error {
+	if r.GuildID == 0 {
+		return fmt.Errorf("guild_id is required")
+	}
+	if r.Number < 1 {
+		return fmt.Errorf("season number must be at least 1")
+	}
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return fmt.Errorf("season name is required")
+	}
+	if len(r.Name) > 100 {
+		return fmt.Errorf("season name must be at most 100 characters")
+	}
+	return nil
+}
+
+// IsActive checks if the season is still running
+func (s *Season) IsActive() bool {
+	return s.Status == SeasonStatusActive
+}
+
+// GetDuration returns how long the season ran, or has been running so far
+func (s *Season) GetDuration() time.Duration {
+	if s.EndedAt != nil {
+		return s.EndedAt.Sub(s.StartedAt)
+	}
+	return time.Since(s.StartedAt)
+}
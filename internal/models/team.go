@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Team represents a league team in a guild
+type Team struct {
+	ID               int64     `json:"id" db:"id"`
+	GuildID          int64     `json:"guild_id" db:"guild_id"`
+	SeasonID         *int64    `json:"season_id" db:"season_id"`
+	DivisionID       *int64    `json:"division_id" db:"division_id"`
+	Name             string    `json:"name" db:"name"`
+	CaptainDiscordID *string   `json:"captain_discord_id" db:"captain_discord_id"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TeamCreateRequest represents data needed to create a new team
+type TeamCreateRequest struct {
+	GuildID          int64   `json:"guild_id" validate:"required"`
+	SeasonID         *int64  `json:"season_id"`
+	DivisionID       *int64  `json:"division_id"`
+	Name             string  `json:"name" validate:"required,max=100"`
+	CaptainDiscordID *string `json:"captain_discord_id"`
+}
+
+// Validate checks the request for a missing guild or name
+func (r *TeamCreateRequest) Validate() error {
+	if r.GuildID == 0 {
+		return fmt.Errorf("guild_id is required")
+	}
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return fmt.Errorf("team name is required")
+	}
+	if len(r.Name) > 100 {
+		return fmt.Errorf("team name must be at most 100 characters")
+	}
+	return nil
+}
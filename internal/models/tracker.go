@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql/driver"
+	neturl "net/url"
 	"strings"
 	"time"
 )
@@ -28,13 +29,15 @@ type UserTracker struct {
 	ThreesPreviousSeasonGames int       `json:"threes_previous_season_games" db:"threes_previous_season_games"`
 	LastUpdated               time.Time `json:"last_updated" db:"last_updated"`
 	Valid                     bool      `json:"valid" db:"valid"`
+	IsPrimary                 bool      `json:"is_primary" db:"is_primary"` // main account when a player has alts
 	CalculatedMMR             int       `json:"calculated_mmr" db:"calculated_mmr"`
 	CreatedAt                 time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt                 time.Time `json:"updated_at" db:"updated_at"`
 
 	// Computed fields (not stored in database)
-	PlatformInfo string `json:"platform_info,omitempty"`
-	DisplayText  string `json:"display_text,omitempty"`
+	PlatformInfo string         `json:"platform_info,omitempty"`
+	DisplayText  string         `json:"display_text,omitempty"`
+	Ranks        *PlaylistRanks `json:"ranks,omitempty"`
 }
 
 // TrackerCreateRequest matches the form data from AddUserTrackerForm.html
@@ -57,6 +60,7 @@ type TrackerCreateRequest struct {
 	ThreesCurrentSeasonGames  int    `json:"threes_current_season_games"`
 	ThreesPreviousSeasonGames int    `json:"threes_previous_season_games"`
 	Valid                     bool   `json:"valid"`
+	IsPrimary                 bool   `json:"is_primary"`
 }
 
 // TrackerUpdateRequest matches the form data from UpdateUserTrackerForm.html
@@ -79,6 +83,7 @@ type TrackerUpdateRequest struct {
 	ThreesCurrentSeasonGames  int    `json:"threes_current_season_games"`
 	ThreesPreviousSeasonGames int    `json:"threes_previous_season_games"`
 	Valid                     bool   `json:"valid"`
+	IsPrimary                 *bool  `json:"is_primary,omitempty"` // nil leaves the flag unchanged
 }
 
 // TrackerStats represents tracker statistics, matching JavaScript getTrackerStats() output
@@ -117,6 +122,46 @@ func (ut *UserTracker) ParsePlatformInfo() string {
 	return "unknown"
 }
 
+// platformAliases maps the platform names used in tracker and replay URLs to one name per platform
+var platformAliases = map[string]string{
+	"steam":  "steam",
+	"epic":   "epic",
+	"psn":    "psn",
+	"ps4":    "psn",
+	"xbl":    "xbox",
+	"xbox":   "xbox",
+	"switch": "switch",
+}
+
+// NormalizePlatform returns the canonical name for a platform, or "" if it is not recognised
+func NormalizePlatform(platform string) string {
+	return platformAliases[strings.ToLower(strings.TrimSpace(platform))]
+}
+
+// ParsePlatformAccount extracts the platform and account from the tracker URL,
+// e.g. ".../profile/steam/76561198000000000/overview" or "ballchasing.com/player/epic/name"
+// The account is lowercased so it can be compared with replay player IDs and names.
+func (ut *UserTracker) ParsePlatformAccount() (platform, account string, ok bool) {
+	parsed, err := neturl.Parse(strings.TrimSpace(ut.URL))
+	if err != nil {
+		return "", "", false
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		platform = NormalizePlatform(segments[i])
+		if platform == "" {
+			continue
+		}
+		account, err := neturl.PathUnescape(segments[i+1])
+		if err != nil || account == "" {
+			return "", "", false
+		}
+		return platform, strings.ToLower(account), true
+	}
+	return "", "", false
+}
+
 // GenerateDisplayText creates display text for UI purposes
 // Matches JavaScript tracker.displayText generation
 func (ut *UserTracker) GenerateDisplayText() string {
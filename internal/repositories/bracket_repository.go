@@ -0,0 +1,355 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	BracketsTable        = "brackets"
+	BracketEntrantsTable = "bracket_entrants"
+	BracketMatchesTable  = "bracket_matches"
+)
+
+// BracketRepository handles tournament brackets, their entrants and matches
+type BracketRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewBracketRepository(client *supabase.Client, cfg *config.Config) *BracketRepository {
+	return &BracketRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CreateBracket inserts the bracket row; entrants and matches are created separately
+func (r *BracketRepository) CreateBracket(bracket *models.Bracket) (*models.Bracket, error) {
+	status := models.BracketStatusInProgress
+	swissRounds := int32(bracket.SwissRounds)
+	insertData := models.PublicBracketsInsert{
+		CreatedByUserId: bracket.CreatedByUserID,
+		Format:          bracket.Format,
+		GuildId:         bracket.GuildID,
+		Name:            bracket.Name,
+		RecordRatings:   &bracket.RecordRatings,
+		SeasonId:        bracket.SeasonID,
+		Seeding:         bracket.Seeding,
+		Status:          &status,
+		SwissRounds:     &swissRounds,
+	}
+
+	data, _, err := r.client.From(BracketsTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bracket: %w", err)
+	}
+
+	var result []models.PublicBracketsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created bracket: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no bracket returned after creation")
+	}
+
+	return r.convertToBracket(result[0]), nil
+}
+
+// CreateEntrants inserts the seeded entrants of a bracket in a single insert
+func (r *BracketRepository) CreateEntrants(bracketID int64, entrants []models.BracketEntrant) ([]models.BracketEntrant, error) {
+	insertData := make([]models.PublicBracketEntrantsInsert, 0, len(entrants))
+	for _, entrant := range entrants {
+		seedRating := entrant.SeedRating
+		discordIDs := make([]*string, 0, len(entrant.DiscordIDs))
+		for i := range entrant.DiscordIDs {
+			discordIDs = append(discordIDs, &entrant.DiscordIDs[i])
+		}
+		userIDs := make([]*int64, 0, len(entrant.UserIDs))
+		for i := range entrant.UserIDs {
+			userIDs = append(userIDs, &entrant.UserIDs[i])
+		}
+
+		insertData = append(insertData, models.PublicBracketEntrantsInsert{
+			BracketId:  bracketID,
+			DiscordIds: discordIDs,
+			Name:       entrant.Name,
+			Seed:       int32(entrant.Seed),
+			SeedRating: &seedRating,
+			UserIds:    userIDs,
+		})
+	}
+
+	data, _, err := r.client.From(BracketEntrantsTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entrants for bracket %d: %w", bracketID, err)
+	}
+
+	var result []models.PublicBracketEntrantsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created entrants: %w", err)
+	}
+
+	return r.convertToEntrants(result), nil
+}
+
+// CreateBracketMatches inserts bracket matches in a single insert
+func (r *BracketRepository) CreateBracketMatches(matches []models.BracketMatch) ([]models.BracketMatch, error) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	insertData := make([]models.PublicBracketMatchesInsert, 0, len(matches))
+	for i := range matches {
+		match := &matches[i]
+		status := match.Status
+		insertData = append(insertData, models.PublicBracketMatchesInsert{
+			BracketId:       match.BracketID,
+			EntrantAId:      match.EntrantAID,
+			EntrantBId:      match.EntrantBID,
+			Position:        int32(match.Position),
+			Round:           int32(match.Round),
+			Side:            match.Side,
+			Status:          &status,
+			WinnerEntrantId: match.WinnerEntrantID,
+		})
+	}
+
+	data, _, err := r.client.From(BracketMatchesTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bracket matches: %w", err)
+	}
+
+	var result []models.PublicBracketMatchesSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created bracket matches: %w", err)
+	}
+
+	return r.convertToBracketMatches(result), nil
+}
+
+// UpdateBracketMatch saves the entrants, result and status of a bracket match
+func (r *BracketRepository) UpdateBracketMatch(match *models.BracketMatch) error {
+	updateData := map[string]interface{}{
+		"entrant_a_id":      match.EntrantAID,
+		"entrant_b_id":      match.EntrantBID,
+		"score_a":           match.ScoreA,
+		"score_b":           match.ScoreB,
+		"winner_entrant_id": match.WinnerEntrantID,
+		"status":            match.Status,
+		"match_id":          match.MatchID,
+	}
+	if match.ReportedAt != nil {
+		updateData["reported_at"] = match.ReportedAt.Format(time.RFC3339)
+	}
+
+	_, _, err := r.client.From(BracketMatchesTable).
+		Update(updateData, "", "").
+		Eq("id", strconv.FormatInt(match.ID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to update bracket match %d: %w", match.ID, err)
+	}
+
+	return nil
+}
+
+// UpdateBracketStatus marks a bracket in progress or completed
+func (r *BracketRepository) UpdateBracketStatus(bracketID int64, status string) error {
+	_, _, err := r.client.From(BracketsTable).
+		Update(map[string]interface{}{"status": status}, "", "").
+		Eq("id", strconv.FormatInt(bracketID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to update bracket %d status: %w", bracketID, err)
+	}
+
+	return nil
+}
+
+// FindBracketByID finds a bracket with its entrants (by seed) and matches
+func (r *BracketRepository) FindBracketByID(bracketID int64) (*models.Bracket, error) {
+	id := strconv.FormatInt(bracketID, 10)
+
+	data, _, err := r.client.From(BracketsTable).
+		Select("*", "", false).
+		Eq("id", id).
+		Single().
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.PublicBracketsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse bracket data: %w", err)
+	}
+
+	bracket := r.convertToBracket(result)
+
+	data, _, err = r.client.From(BracketEntrantsTable).
+		Select("*", "", false).
+		Eq("bracket_id", id).
+		Order("seed", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bracket entrants: %w", err)
+	}
+
+	var entrants []models.PublicBracketEntrantsSelect
+	if err := json.Unmarshal(data, &entrants); err != nil {
+		return nil, fmt.Errorf("failed to parse bracket entrants: %w", err)
+	}
+	bracket.Entrants = r.convertToEntrants(entrants)
+
+	data, _, err = r.client.From(BracketMatchesTable).
+		Select("*", "", false).
+		Eq("bracket_id", id).
+		Order("round", &postgrest.OrderOpts{Ascending: true}).
+		Order("position", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bracket matches: %w", err)
+	}
+
+	var matches []models.PublicBracketMatchesSelect
+	if err := json.Unmarshal(data, &matches); err != nil {
+		return nil, fmt.Errorf("failed to parse bracket matches: %w", err)
+	}
+	bracket.Matches = r.convertToBracketMatches(matches)
+
+	return bracket, nil
+}
+
+// GetBracketsByGuild returns every bracket in a guild, newest first, without entrants or matches
+func (r *BracketRepository) GetBracketsByGuild(guildID int64) ([]*models.Bracket, error) {
+	data, _, err := r.client.From(BracketsTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brackets: %w", err)
+	}
+
+	var result []models.PublicBracketsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse brackets: %w", err)
+	}
+
+	brackets := make([]*models.Bracket, 0, len(result))
+	for _, row := range result {
+		brackets = append(brackets, r.convertToBracket(row))
+	}
+
+	return brackets, nil
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *BracketRepository) convertToBracket(row models.PublicBracketsSelect) *models.Bracket {
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	return &models.Bracket{
+		ID:              row.Id,
+		GuildID:         row.GuildId,
+		SeasonID:        row.SeasonId,
+		Name:            row.Name,
+		Format:          row.Format,
+		Seeding:         row.Seeding,
+		SwissRounds:     int(row.SwissRounds),
+		RecordRatings:   row.RecordRatings,
+		Status:          row.Status,
+		CreatedByUserID: row.CreatedByUserId,
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
+	}
+}
+
+// convertToEntrants converts entrant rows to internal models
+func (r *BracketRepository) convertToEntrants(rows []models.PublicBracketEntrantsSelect) []models.BracketEntrant {
+	entrants := make([]models.BracketEntrant, 0, len(rows))
+	for _, row := range rows {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+
+		discordIDs := make([]string, 0, len(row.DiscordIds))
+		for _, discordID := range row.DiscordIds {
+			if discordID != nil {
+				discordIDs = append(discordIDs, *discordID)
+			}
+		}
+		userIDs := make([]int64, 0, len(row.UserIds))
+		for _, userID := range row.UserIds {
+			if userID != nil {
+				userIDs = append(userIDs, *userID)
+			}
+		}
+
+		entrants = append(entrants, models.BracketEntrant{
+			ID:         row.Id,
+			BracketID:  row.BracketId,
+			Name:       row.Name,
+			Seed:       int(row.Seed),
+			SeedRating: row.SeedRating,
+			DiscordIDs: discordIDs,
+			UserIDs:    userIDs,
+			CreatedAt:  createdAt,
+		})
+	}
+	return entrants
+}
+
+// convertToBracketMatches converts bracket match rows to internal models
+func (r *BracketRepository) convertToBracketMatches(rows []models.PublicBracketMatchesSelect) []models.BracketMatch {
+	matches := make([]models.BracketMatch, 0, len(rows))
+	for _, row := range rows {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+		match := models.BracketMatch{
+			ID:              row.Id,
+			BracketID:       row.BracketId,
+			Side:            row.Side,
+			Round:           int(row.Round),
+			Position:        int(row.Position),
+			EntrantAID:      row.EntrantAId,
+			EntrantBID:      row.EntrantBId,
+			WinnerEntrantID: row.WinnerEntrantId,
+			Status:          row.Status,
+			MatchID:         row.MatchId,
+			CreatedAt:       createdAt,
+			UpdatedAt:       updatedAt,
+		}
+		if row.ScoreA != nil {
+			scoreA := int(*row.ScoreA)
+			match.ScoreA = &scoreA
+		}
+		if row.ScoreB != nil {
+			scoreB := int(*row.ScoreB)
+			match.ScoreB = &scoreB
+		}
+		if row.ReportedAt != nil {
+			if reportedAt, err := time.Parse(time.RFC3339, *row.ReportedAt); err == nil {
+				match.ReportedAt = &reportedAt
+			}
+		}
+
+		matches = append(matches, match)
+	}
+	return matches
+}
@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	DivisionsTable        = "divisions"
+	ScheduledMatchesTable = "scheduled_matches"
+
+	// Postgres functions that write several rows in one transaction
+	ReplaceDivisionScheduleFunction = "replace_division_schedule"
+)
+
+// DivisionRepository handles league divisions and their scheduled matches
+type DivisionRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewDivisionRepository(client *supabase.Client, cfg *config.Config) *DivisionRepository {
+	return &DivisionRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CreateDivision creates a new division
+func (r *DivisionRepository) CreateDivision(request models.DivisionCreateRequest) (*models.Division, error) {
+	insertData := models.PublicDivisionsInsert{
+		GuildId:  request.GuildID,
+		Name:     request.Name,
+		SeasonId: request.SeasonID,
+	}
+
+	data, _, err := r.client.From(DivisionsTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create division: %w", err)
+	}
+
+	var result []models.PublicDivisionsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created division: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no division returned after creation")
+	}
+
+	return r.convertToDivision(result[0]), nil
+}
+
+// FindDivisionByID finds a division by ID
+func (r *DivisionRepository) FindDivisionByID(divisionID int64) (*models.Division, error) {
+	data, _, err := r.client.From(DivisionsTable).
+		Select("*", "", false).
+		Eq("id", strconv.FormatInt(divisionID, 10)).
+		Single().
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.PublicDivisionsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse division data: %w", err)
+	}
+
+	return r.convertToDivision(result), nil
+}
+
+// GetDivisionsByGuild returns every division in a guild by name
+func (r *DivisionRepository) GetDivisionsByGuild(guildID int64) ([]*models.Division, error) {
+	data, _, err := r.client.From(DivisionsTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("name", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get divisions: %w", err)
+	}
+
+	var result []models.PublicDivisionsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse divisions: %w", err)
+	}
+
+	divisions := make([]*models.Division, 0, len(result))
+	for _, row := range result {
+		divisions = append(divisions, r.convertToDivision(row))
+	}
+
+	return divisions, nil
+}
+
+// CreateScheduledMatches inserts a division's fixtures in a single insert
+func (r *DivisionRepository) CreateScheduledMatches(matches []models.ScheduledMatch) ([]models.ScheduledMatch, error) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	insertData := r.convertToScheduledMatchInserts(matches)
+	data, _, err := r.client.From(ScheduledMatchesTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduled matches: %w", err)
+	}
+
+	var result []models.PublicScheduledMatchesSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created scheduled matches: %w", err)
+	}
+
+	return r.convertToScheduledMatches(result), nil
+}
+
+// GetScheduledMatches returns a division's fixtures by round
+func (r *DivisionRepository) GetScheduledMatches(divisionID int64) ([]models.ScheduledMatch, error) {
+	data, _, err := r.client.From(ScheduledMatchesTable).
+		Select("*", "", false).
+		Eq("division_id", strconv.FormatInt(divisionID, 10)).
+		Order("round", &postgrest.OrderOpts{Ascending: true}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled matches: %w", err)
+	}
+
+	var result []models.PublicScheduledMatchesSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse scheduled matches: %w", err)
+	}
+
+	return r.convertToScheduledMatches(result), nil
+}
+
+// ReplaceScheduledMatches swaps a division's unplayed fixtures for new ones in one transaction,
+// so a failed insert keeps the old schedule instead of leaving the division without one
+func (r *DivisionRepository) ReplaceScheduledMatches(divisionID int64, matches []models.ScheduledMatch) ([]models.ScheduledMatch, error) {
+	response := r.client.Rpc(ReplaceDivisionScheduleFunction, "", map[string]interface{}{
+		"p_division_id": divisionID,
+		"p_matches":     r.convertToScheduledMatchInserts(matches),
+	})
+	if response == "" {
+		return nil, fmt.Errorf("failed to replace schedule for division %d: no response from %s", divisionID, ReplaceDivisionScheduleFunction)
+	}
+
+	// PostgREST answers a failed call with an error object instead of the fixtures
+	var result []models.PublicScheduledMatchesSelect
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		var rpcErr postgrest.ExecuteError
+		if json.Unmarshal([]byte(response), &rpcErr) == nil && rpcErr.Message != "" {
+			return nil, fmt.Errorf("failed to replace schedule for division %d: (%s) %s", divisionID, rpcErr.Code, rpcErr.Message)
+		}
+		return nil, fmt.Errorf("failed to parse replaced schedule for division %d: %w", divisionID, err)
+	}
+
+	return r.convertToScheduledMatches(result), nil
+}
+
+// UpdateScheduledMatchStatus marks a fixture as scheduled or completed
+func (r *DivisionRepository) UpdateScheduledMatchStatus(matchID int64, status string) error {
+	updateData := map[string]interface{}{
+		"status":     status,
+		"updated_at": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	_, _, err := r.client.From(ScheduledMatchesTable).
+		Update(updateData, "", "").
+		Eq("id", strconv.FormatInt(matchID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled match %d: %w", matchID, err)
+	}
+
+	return nil
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *DivisionRepository) convertToDivision(row models.PublicDivisionsSelect) *models.Division {
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	return &models.Division{
+		ID:        row.Id,
+		GuildID:   row.GuildId,
+		SeasonID:  row.SeasonId,
+		Name:      row.Name,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// convertToScheduledMatchInserts converts fixtures to insert rows, defaulting them to scheduled
+func (r *DivisionRepository) convertToScheduledMatchInserts(matches []models.ScheduledMatch) []models.PublicScheduledMatchesInsert {
+	insertData := make([]models.PublicScheduledMatchesInsert, 0, len(matches))
+	for _, match := range matches {
+		status := match.Status
+		if status == "" {
+			status = models.ScheduledMatchScheduled
+		}
+		insertData = append(insertData, models.PublicScheduledMatchesInsert{
+			AwayTeamId: match.AwayTeamID,
+			DivisionId: match.DivisionID,
+			GuildId:    match.GuildID,
+			HomeTeamId: match.HomeTeamID,
+			MatchNight: match.MatchNight.Format(time.RFC3339),
+			Round:      int32(match.Round),
+			Status:     &status,
+		})
+	}
+
+	return insertData
+}
+
+// convertToScheduledMatches converts fixture rows to internal models
+func (r *DivisionRepository) convertToScheduledMatches(rows []models.PublicScheduledMatchesSelect) []models.ScheduledMatch {
+	matches := make([]models.ScheduledMatch, 0, len(rows))
+	for _, row := range rows {
+		matchNight, _ := time.Parse(time.RFC3339, row.MatchNight)
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+		matches = append(matches, models.ScheduledMatch{
+			ID:         row.Id,
+			GuildID:    row.GuildId,
+			DivisionID: row.DivisionId,
+			Round:      int(row.Round),
+			MatchNight: matchNight,
+			HomeTeamID: row.HomeTeamId,
+			AwayTeamID: row.AwayTeamId,
+			Status:     row.Status,
+			CreatedAt:  createdAt,
+			UpdatedAt:  updatedAt,
+		})
+	}
+	return matches
+}
@@ -0,0 +1,248 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	DraftsTable     = "drafts"
+	DraftPicksTable = "draft_picks"
+)
+
+// DraftRepository handles snake drafts and their picks
+type DraftRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewDraftRepository(client *supabase.Client, cfg *config.Config) *DraftRepository {
+	return &DraftRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CreateDraft creates a pending draft with its first-round order
+func (r *DraftRepository) CreateDraft(request models.DraftCreateRequest) (*models.Draft, error) {
+	pickSeconds := int32(request.PickSeconds)
+	insertData := models.PublicDraftsInsert{
+		GuildId:     request.GuildID,
+		Name:        request.Name,
+		PickSeconds: &pickSeconds,
+		Rounds:      int32(request.Rounds),
+		SeasonId:    request.SeasonID,
+		TeamOrder:   request.TeamOrder,
+	}
+
+	data, _, err := r.client.From(DraftsTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create draft: %w", err)
+	}
+
+	var result []models.PublicDraftsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created draft: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no draft returned after creation")
+	}
+
+	return r.convertToDraft(result[0]), nil
+}
+
+// FindDraftByID finds a draft by ID, returning nil when there is none
+func (r *DraftRepository) FindDraftByID(draftID int64) (*models.Draft, error) {
+	data, _, err := r.client.From(DraftsTable).
+		Select("*", "", false).
+		Eq("id", strconv.FormatInt(draftID, 10)).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get draft %d: %w", draftID, err)
+	}
+
+	var result []models.PublicDraftsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse draft data: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return r.convertToDraft(result[0]), nil
+}
+
+// GetDraftsByGuild returns a guild's drafts, newest first
+func (r *DraftRepository) GetDraftsByGuild(guildID int64) ([]*models.Draft, error) {
+	data, _, err := r.client.From(DraftsTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drafts: %w", err)
+	}
+
+	var result []models.PublicDraftsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse drafts: %w", err)
+	}
+
+	drafts := make([]*models.Draft, 0, len(result))
+	for _, row := range result {
+		drafts = append(drafts, r.convertToDraft(row))
+	}
+	return drafts, nil
+}
+
+// UpdateDraftProgress stores the draft's status, the pick on the clock and when its timer started
+func (r *DraftRepository) UpdateDraftProgress(draftID int64, status string, currentPick int, pickStartedAt *time.Time) error {
+	var startedAt *string
+	if pickStartedAt != nil {
+		formatted := pickStartedAt.UTC().Format(time.RFC3339)
+		startedAt = &formatted
+	}
+
+	updateData := map[string]interface{}{
+		"status":          status,
+		"current_pick":    currentPick,
+		"pick_started_at": startedAt,
+	}
+
+	_, _, err := r.client.From(DraftsTable).
+		Update(updateData, "", "").
+		Eq("id", strconv.FormatInt(draftID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to update draft %d: %w", draftID, err)
+	}
+
+	return nil
+}
+
+// CreateDraftPick records a pick; the pick number and player are unique within a draft
+func (r *DraftRepository) CreateDraftPick(pick models.DraftPick) (*models.DraftPick, error) {
+	autoPick := pick.AutoPick
+	insertData := models.PublicDraftPicksInsert{
+		AutoPick:   &autoPick,
+		DiscordId:  pick.DiscordID,
+		DraftId:    pick.DraftID,
+		PickNumber: int32(pick.PickNumber),
+		Round:      int32(pick.Round),
+		TeamId:     pick.TeamID,
+	}
+
+	data, _, err := r.client.From(DraftPicksTable).
+		Insert(insertData, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to record pick %d: %w", pick.PickNumber, err)
+	}
+
+	picks, err := r.parseDraftPicks(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(picks) == 0 {
+		return nil, fmt.Errorf("no pick returned after creation")
+	}
+
+	return &picks[0], nil
+}
+
+// GetDraftPicks returns a draft's picks in pick order
+func (r *DraftRepository) GetDraftPicks(draftID int64) ([]models.DraftPick, error) {
+	data, _, err := r.client.From(DraftPicksTable).
+		Select("*", "", false).
+		Eq("draft_id", strconv.FormatInt(draftID, 10)).
+		Order("pick_number", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get picks for draft %d: %w", draftID, err)
+	}
+
+	return r.parseDraftPicks(data)
+}
+
+// DeleteDraftPick removes a pick
+func (r *DraftRepository) DeleteDraftPick(pickID int64) error {
+	_, _, err := r.client.From(DraftPicksTable).
+		Delete("", "").
+		Eq("id", strconv.FormatInt(pickID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to delete pick %d: %w", pickID, err)
+	}
+
+	return nil
+}
+
+// parseDraftPicks converts a pick query response into internal models
+func (r *DraftRepository) parseDraftPicks(data []byte) ([]models.DraftPick, error) {
+	var result []models.PublicDraftPicksSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse draft picks: %w", err)
+	}
+
+	picks := make([]models.DraftPick, 0, len(result))
+	for _, row := range result {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		picks = append(picks, models.DraftPick{
+			ID:         row.Id,
+			DraftID:    row.DraftId,
+			PickNumber: int(row.PickNumber),
+			Round:      int(row.Round),
+			TeamID:     row.TeamId,
+			DiscordID:  row.DiscordId,
+			AutoPick:   row.AutoPick,
+			CreatedAt:  createdAt,
+		})
+	}
+
+	return picks, nil
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *DraftRepository) convertToDraft(row models.PublicDraftsSelect) *models.Draft {
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	var pickStartedAt *time.Time
+	if row.PickStartedAt != nil {
+		if parsed, err := time.Parse(time.RFC3339, *row.PickStartedAt); err == nil {
+			pickStartedAt = &parsed
+		}
+	}
+
+	return &models.Draft{
+		ID:            row.Id,
+		GuildID:       row.GuildId,
+		SeasonID:      row.SeasonId,
+		Name:          row.Name,
+		Status:        row.Status,
+		Rounds:        int(row.Rounds),
+		PickSeconds:   int(row.PickSeconds),
+		TeamOrder:     row.TeamOrder,
+		CurrentPick:   int(row.CurrentPick),
+		PickStartedAt: pickStartedAt,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	LeagueResultsTable = "league_results"
+)
+
+// LeagueResultRepository handles series results between league teams
+type LeagueResultRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewLeagueResultRepository(client *supabase.Client, cfg *config.Config) *LeagueResultRepository {
+	return &LeagueResultRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CreateResult records a series result with the goals of each game
+func (r *LeagueResultRepository) CreateResult(request models.LeagueResultCreateRequest) (*models.LeagueResult, error) {
+	homeGoals := make([]int32, 0, len(request.Games))
+	awayGoals := make([]int32, 0, len(request.Games))
+	for _, game := range request.Games {
+		homeGoals = append(homeGoals, int32(game.HomeGoals))
+		awayGoals = append(awayGoals, int32(game.AwayGoals))
+	}
+
+	insertData := models.PublicLeagueResultsInsert{
+		AwayGoals:        awayGoals,
+		AwayTeamId:       request.AwayTeamID,
+		DivisionId:       request.DivisionID,
+		GuildId:          request.GuildID,
+		HomeGoals:        homeGoals,
+		HomeTeamId:       request.HomeTeamID,
+		ScheduledMatchId: request.ScheduledMatchID,
+	}
+	if request.PlayedAt != nil {
+		playedAt := request.PlayedAt.UTC().Format(time.RFC3339)
+		insertData.PlayedAt = &playedAt
+	}
+
+	data, _, err := r.client.From(LeagueResultsTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to record league result: %w", err)
+	}
+
+	results, err := r.parseResults(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no league result returned after creation")
+	}
+
+	return &results[0], nil
+}
+
+// GetResultsByDivision returns a division's results in the order they were played
+func (r *LeagueResultRepository) GetResultsByDivision(divisionID int64) ([]models.LeagueResult, error) {
+	data, _, err := r.client.From(LeagueResultsTable).
+		Select("*", "", false).
+		Eq("division_id", strconv.FormatInt(divisionID, 10)).
+		Order("played_at", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league results: %w", err)
+	}
+
+	return r.parseResults(data)
+}
+
+// parseResults converts a result query response into internal models, pairing up the goal arrays
+func (r *LeagueResultRepository) parseResults(data []byte) ([]models.LeagueResult, error) {
+	var result []models.PublicLeagueResultsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse league results: %w", err)
+	}
+
+	results := make([]models.LeagueResult, 0, len(result))
+	for _, row := range result {
+		playedAt, _ := time.Parse(time.RFC3339, row.PlayedAt)
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+
+		games := make([]models.LeagueGame, 0, len(row.HomeGoals))
+		for i := range row.HomeGoals {
+			if i >= len(row.AwayGoals) {
+				break
+			}
+			games = append(games, models.LeagueGame{HomeGoals: int(row.HomeGoals[i]), AwayGoals: int(row.AwayGoals[i])})
+		}
+
+		results = append(results, models.LeagueResult{
+			ID:               row.Id,
+			GuildID:          row.GuildId,
+			DivisionID:       row.DivisionId,
+			ScheduledMatchID: row.ScheduledMatchId,
+			HomeTeamID:       row.HomeTeamId,
+			AwayTeamID:       row.AwayTeamId,
+			Games:            games,
+			PlayedAt:         playedAt,
+			CreatedAt:        createdAt,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,423 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	MatchesTable      = "matches"
+	MatchPlayersTable = "match_players"
+
+	// Postgres function that writes a match and its rating changes in one transaction
+	RecordRatedMatchFunction = "record_rated_match"
+
+	// Default page size when listing matches
+	DefaultMatchListLimit = 50
+)
+
+// ErrRatingChanged is returned by RecordRatedMatch when a player's stored rating is no longer the
+// one the match was rated from, because another match for them was recorded in the meantime
+var ErrRatingChanged = errors.New("rating changed since the match was rated")
+
+// serializationFailureCode is the Postgres error code record_rated_match raises ErrRatingChanged with
+const serializationFailureCode = "40001"
+
+// MatchRepository handles match and match roster data access using Supabase Go client
+type MatchRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewMatchRepository(client *supabase.Client, cfg *config.Config) *MatchRepository {
+	return &MatchRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CreateMatch inserts a match and its team rosters
+func (r *MatchRepository) CreateMatch(request models.MatchCreateRequest) (*models.Match, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid match: %w", err)
+	}
+
+	data, _, err := r.client.From(MatchesTable).Insert(r.matchInsert(request), false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create match: %w", err)
+	}
+
+	var result []models.PublicMatchesSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created match: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no match returned after creation")
+	}
+
+	match := r.convertToMatch(result[0])
+
+	players := make([]models.PublicMatchPlayersInsert, 0, len(request.TeamAUserIDs)+len(request.TeamBUserIDs))
+	for _, userID := range request.TeamAUserIDs {
+		players = append(players, r.matchPlayerInsert(match.ID, userID, models.MatchTeamA, request.PlayerStats))
+	}
+	for _, userID := range request.TeamBUserIDs {
+		players = append(players, r.matchPlayerInsert(match.ID, userID, models.MatchTeamB, request.PlayerStats))
+	}
+
+	data, _, err = r.client.From(MatchPlayersTable).Insert(players, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create match players for match %d: %w", match.ID, err)
+	}
+
+	var playerResult []models.PublicMatchPlayersSelect
+	if err := json.Unmarshal(data, &playerResult); err != nil {
+		return nil, fmt.Errorf("failed to parse created match players: %w", err)
+	}
+
+	match.Players = r.convertToMatchPlayers(playerResult)
+	return match, nil
+}
+
+// RecordRatedMatch inserts a match, its rosters and every rating and history row it produces in
+// one transaction through the record_rated_match function, so a failure writes nothing.
+// The history rows' before values must match the stored ratings, otherwise ErrRatingChanged.
+func (r *MatchRepository) RecordRatedMatch(record models.RatedMatchRecord) (*models.Match, error) {
+	request := record.Match
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid match: %w", err)
+	}
+
+	players := make([]models.PublicMatchPlayersInsert, 0, len(request.TeamAUserIDs)+len(request.TeamBUserIDs))
+	for _, userID := range request.TeamAUserIDs {
+		players = append(players, r.matchPlayerInsert(0, userID, models.MatchTeamA, request.PlayerStats))
+	}
+	for _, userID := range request.TeamBUserIDs {
+		players = append(players, r.matchPlayerInsert(0, userID, models.MatchTeamB, request.PlayerStats))
+	}
+
+	ratings := make([]map[string]interface{}, 0, len(record.Ratings))
+	for _, rating := range record.Ratings {
+		ratings = append(ratings, effectiveMMRRow(rating))
+	}
+	history := make([]models.PublicPlayerHistoricalMmrInsert, 0, len(record.History))
+	for _, entry := range record.History {
+		history = append(history, historicalMMRInsert(entry))
+	}
+	playlistRatings := make([]map[string]interface{}, 0, len(record.PlaylistRatings))
+	for _, rating := range record.PlaylistRatings {
+		playlistRatings = append(playlistRatings, playlistRatingRow(rating))
+	}
+
+	response := r.client.Rpc(RecordRatedMatchFunction, "", map[string]interface{}{
+		"p_match":            r.matchInsert(request),
+		"p_players":          players,
+		"p_ratings":          ratings,
+		"p_history":          history,
+		"p_playlist_ratings": playlistRatings,
+	})
+	if response == "" {
+		return nil, fmt.Errorf("failed to record match: no response from %s", RecordRatedMatchFunction)
+	}
+
+	// PostgREST answers a failed call with an error object instead of the match
+	var result struct {
+		models.PublicMatchesSelect
+		Players []models.PublicMatchPlayersSelect `json:"players"`
+	}
+	if err := json.Unmarshal([]byte(response), &result); err != nil || result.Id == 0 {
+		var rpcErr postgrest.ExecuteError
+		if json.Unmarshal([]byte(response), &rpcErr) == nil && rpcErr.Message != "" {
+			if rpcErr.Code == serializationFailureCode {
+				return nil, fmt.Errorf("%w: %s", ErrRatingChanged, rpcErr.Message)
+			}
+			return nil, fmt.Errorf("failed to record match: (%s) %s", rpcErr.Code, rpcErr.Message)
+		}
+		return nil, fmt.Errorf("failed to parse recorded match: %s", response)
+	}
+
+	match := r.convertToMatch(result.PublicMatchesSelect)
+	match.Players = r.convertToMatchPlayers(result.Players)
+	return match, nil
+}
+
+// matchInsert converts a match request to the matches insert type
+func (r *MatchRepository) matchInsert(request models.MatchCreateRequest) models.PublicMatchesInsert {
+	insertData := models.PublicMatchesInsert{
+		GuildId:          request.GuildID,
+		ReplayId:         request.ReplayID,
+		ReportedByUserId: request.ReportedByUserID,
+		TeamAScore:       int32(request.TeamAScore),
+		TeamBScore:       int32(request.TeamBScore),
+	}
+	if request.PlayedAt != nil {
+		playedAt := request.PlayedAt.Format(time.RFC3339)
+		insertData.PlayedAt = &playedAt
+	}
+	if playlist := request.RatingPlaylist(); playlist != "" {
+		insertData.Playlist = &playlist
+	}
+	return insertData
+}
+
+// FindMatchByID finds a match and its players by internal ID
+func (r *MatchRepository) FindMatchByID(matchID int64) (*models.Match, error) {
+	data, _, err := r.client.From(MatchesTable).
+		Select("*", "", false).
+		Eq("id", strconv.FormatInt(matchID, 10)).
+		Single().
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.PublicMatchesSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse match data: %w", err)
+	}
+
+	match := r.convertToMatch(result)
+
+	players, err := r.getMatchPlayers([]string{strconv.FormatInt(matchID, 10)})
+	if err != nil {
+		return nil, err
+	}
+	match.Players = players
+
+	return match, nil
+}
+
+// FindMatchByReplayID finds the match imported from a replay, or nil if the replay has not been imported
+func (r *MatchRepository) FindMatchByReplayID(guildID int64, replayID string) (*models.Match, error) {
+	data, _, err := r.client.From(MatchesTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Eq("replay_id", replayID).
+		Limit(1, "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find match for replay %s: %w", replayID, err)
+	}
+
+	var result []models.PublicMatchesSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse match data: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return r.convertToMatch(result[0]), nil
+}
+
+// GetMatchesByGuild returns the most recent matches in a guild with their players
+func (r *MatchRepository) GetMatchesByGuild(guildID int64, limit int) ([]*models.Match, error) {
+	if limit <= 0 {
+		limit = DefaultMatchListLimit
+	}
+
+	data, _, err := r.client.From(MatchesTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("played_at", &postgrest.OrderOpts{Ascending: false}).
+		Limit(limit, "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	var result []models.PublicMatchesSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse matches: %w", err)
+	}
+
+	return r.attachPlayers(result)
+}
+
+// GetMatchesByGuildBetween returns a guild's matches played from from up to (not including) to, oldest first
+// A nil to leaves the window open-ended
+func (r *MatchRepository) GetMatchesByGuildBetween(guildID int64, from time.Time, to *time.Time) ([]*models.Match, error) {
+	query := r.client.From(MatchesTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Gte("played_at", from.UTC().Format(time.RFC3339))
+
+	if to != nil {
+		query = query.Lt("played_at", to.UTC().Format(time.RFC3339))
+	}
+
+	data, _, err := query.
+		Order("played_at", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+
+	var result []models.PublicMatchesSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse matches: %w", err)
+	}
+
+	return r.attachPlayers(result)
+}
+
+// UpdateMatchPlayerStats sets or replaces a player's stat line in a match
+func (r *MatchRepository) UpdateMatchPlayerStats(matchID, userID int64, stats models.MatchPlayerStats) error {
+	updateData := map[string]interface{}{
+		"goals":   stats.Goals,
+		"assists": stats.Assists,
+		"saves":   stats.Saves,
+		"shots":   stats.Shots,
+		"score":   stats.Score,
+		"mvp":     stats.MVP,
+	}
+
+	_, _, err := r.client.From(MatchPlayersTable).
+		Update(updateData, "", "").
+		Eq("match_id", strconv.FormatInt(matchID, 10)).
+		Eq("user_id", strconv.FormatInt(userID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to update stats for user %d in match %d: %w", userID, matchID, err)
+	}
+
+	return nil
+}
+
+// attachPlayers converts match rows and loads their rosters in a single query
+func (r *MatchRepository) attachPlayers(rows []models.PublicMatchesSelect) ([]*models.Match, error) {
+	matches := make([]*models.Match, 0, len(rows))
+	matchIDs := make([]string, 0, len(rows))
+	byID := make(map[int64]*models.Match, len(rows))
+
+	for _, row := range rows {
+		match := r.convertToMatch(row)
+		matches = append(matches, match)
+		matchIDs = append(matchIDs, strconv.FormatInt(match.ID, 10))
+		byID[match.ID] = match
+	}
+
+	if len(matchIDs) == 0 {
+		return matches, nil
+	}
+
+	players, err := r.getMatchPlayers(matchIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, player := range players {
+		if match, ok := byID[player.MatchID]; ok {
+			match.Players = append(match.Players, player)
+		}
+	}
+
+	return matches, nil
+}
+
+// getMatchPlayers loads the rosters for a set of matches
+func (r *MatchRepository) getMatchPlayers(matchIDs []string) ([]models.MatchPlayer, error) {
+	data, _, err := r.client.From(MatchPlayersTable).
+		Select("*", "", false).
+		In("match_id", matchIDs).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match players: %w", err)
+	}
+
+	var result []models.PublicMatchPlayersSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse match players: %w", err)
+	}
+
+	return r.convertToMatchPlayers(result), nil
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *MatchRepository) convertToMatch(row models.PublicMatchesSelect) *models.Match {
+	playedAt, _ := time.Parse(time.RFC3339, row.PlayedAt)
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	match := &models.Match{
+		ID:               row.Id,
+		GuildID:          row.GuildId,
+		TeamAScore:       int(row.TeamAScore),
+		TeamBScore:       int(row.TeamBScore),
+		PlayedAt:         playedAt,
+		ReplayID:         row.ReplayId,
+		ReportedByUserID: row.ReportedByUserId,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+	}
+	if row.Playlist != nil {
+		match.Playlist = *row.Playlist
+	}
+	return match
+}
+
+// convertToMatchPlayers converts roster rows to internal models
+func (r *MatchRepository) convertToMatchPlayers(rows []models.PublicMatchPlayersSelect) []models.MatchPlayer {
+	players := make([]models.MatchPlayer, 0, len(rows))
+	for _, row := range rows {
+		player := models.MatchPlayer{
+			ID:      row.Id,
+			MatchID: row.MatchId,
+			UserID:  row.UserId,
+			Team:    int(row.Team),
+		}
+		if row.Goals != nil {
+			player.Stats = &models.MatchPlayerStats{
+				Goals:   int32Value(row.Goals),
+				Assists: int32Value(row.Assists),
+				Saves:   int32Value(row.Saves),
+				Shots:   int32Value(row.Shots),
+				Score:   int32Value(row.Score),
+				MVP:     row.Mvp != nil && *row.Mvp,
+			}
+		}
+		players = append(players, player)
+	}
+	return players
+}
+
+// matchPlayerInsert builds a roster row, with the player's stat line when the request has one
+func (r *MatchRepository) matchPlayerInsert(matchID, userID int64, team int, playerStats map[int64]models.MatchPlayerStats) models.PublicMatchPlayersInsert {
+	row := models.PublicMatchPlayersInsert{MatchId: matchID, UserId: userID, Team: int16(team)}
+
+	stats, ok := playerStats[userID]
+	if !ok {
+		return row
+	}
+
+	goals, assists, saves := int32(stats.Goals), int32(stats.Assists), int32(stats.Saves)
+	shots, score, mvp := int32(stats.Shots), int32(stats.Score), stats.MVP
+	row.Goals, row.Assists, row.Saves = &goals, &assists, &saves
+	row.Shots, row.Score, row.Mvp = &shots, &score, &mvp
+	return row
+}
+
+// int32Value dereferences a nullable integer column, treating null as 0
+func int32Value(value *int32) int {
+	if value == nil {
+		return 0
+	}
+	return int(*value)
+}
@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	TierPlacementsTable = "tier_placements"
+)
+
+// PlacementRepository handles per-season tier placements
+type PlacementRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewPlacementRepository(client *supabase.Client, cfg *config.Config) *PlacementRepository {
+	return &PlacementRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// ReplacePlacements removes a season's placements and stores the new ones in a single insert
+func (r *PlacementRepository) ReplacePlacements(seasonID int64, placements []models.TierPlacement) error {
+	_, _, err := r.client.From(TierPlacementsTable).
+		Delete("", "").
+		Eq("season_id", strconv.FormatInt(seasonID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to clear placements for season %d: %w", seasonID, err)
+	}
+
+	if len(placements) == 0 {
+		return nil
+	}
+
+	insertData := make([]models.PublicTierPlacementsInsert, 0, len(placements))
+	for _, placement := range placements {
+		playerName := placement.PlayerName
+		insertData = append(insertData, models.PublicTierPlacementsInsert{
+			DiscordId:      placement.DiscordID,
+			GuildId:        placement.GuildID,
+			PlayerName:     &playerName,
+			Rank:           int32(placement.Rank),
+			SeasonId:       seasonID,
+			SkillEstimate:  placement.SkillEstimate,
+			TierName:       placement.TierName,
+			TierNumber:     int32(placement.TierNumber),
+			TrueskillMu:    placement.TrueSkillMu,
+			TrueskillSigma: placement.TrueSkillSigma,
+		})
+	}
+
+	_, _, err = r.client.From(TierPlacementsTable).
+		Insert(insertData, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to save placements for season %d: %w", seasonID, err)
+	}
+
+	return nil
+}
+
+// GetPlacements returns a season's placements ordered by overall rank
+func (r *PlacementRepository) GetPlacements(seasonID int64) ([]models.TierPlacement, error) {
+	data, _, err := r.client.From(TierPlacementsTable).
+		Select("*", "", false).
+		Eq("season_id", strconv.FormatInt(seasonID, 10)).
+		Order("rank", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placements: %w", err)
+	}
+
+	return r.parsePlacements(data)
+}
+
+// GetLatestPlacements returns the placements of the most recent season in a guild that has any
+// Returns an empty slice when the guild has never been placed.
+func (r *PlacementRepository) GetLatestPlacements(guildID int64) ([]models.TierPlacement, error) {
+	data, _, err := r.client.From(TierPlacementsTable).
+		Select("season_id", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("season_id", &postgrest.OrderOpts{Ascending: false}).
+		Limit(1, "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest placement season: %w", err)
+	}
+
+	var result []struct {
+		SeasonId int64 `json:"season_id"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse latest placement season: %w", err)
+	}
+
+	if len(result) == 0 {
+		return []models.TierPlacement{}, nil
+	}
+
+	return r.GetPlacements(result[0].SeasonId)
+}
+
+// FindLatestPlacement returns a player's most recent placement in a guild, or nil when never placed
+func (r *PlacementRepository) FindLatestPlacement(guildID int64, discordID string) (*models.TierPlacement, error) {
+	data, _, err := r.client.From(TierPlacementsTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Eq("discord_id", discordID).
+		Order("season_id", &postgrest.OrderOpts{Ascending: false}).
+		Limit(1, "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find placement for %s: %w", discordID, err)
+	}
+
+	placements, err := r.parsePlacements(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(placements) == 0 {
+		return nil, nil
+	}
+
+	return &placements[0], nil
+}
+
+// parsePlacements converts a placement query response into internal models
+func (r *PlacementRepository) parsePlacements(data []byte) ([]models.TierPlacement, error) {
+	var result []models.PublicTierPlacementsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse placements: %w", err)
+	}
+
+	placements := make([]models.TierPlacement, 0, len(result))
+	for _, row := range result {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		placements = append(placements, models.TierPlacement{
+			ID:             row.Id,
+			GuildID:        row.GuildId,
+			SeasonID:       row.SeasonId,
+			DiscordID:      row.DiscordId,
+			PlayerName:     row.PlayerName,
+			TierNumber:     int(row.TierNumber),
+			TierName:       row.TierName,
+			TierLabel:      models.FormatTierLabel(int(row.TierNumber), row.TierName),
+			Rank:           int(row.Rank),
+			TrueSkillMu:    row.TrueskillMu,
+			TrueSkillSigma: row.TrueskillSigma,
+			SkillEstimate:  row.SkillEstimate,
+			CreatedAt:      createdAt,
+		})
+	}
+
+	return placements, nil
+}
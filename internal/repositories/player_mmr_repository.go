@@ -0,0 +1,433 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	PlayerEffectiveMMRTable   = "player_effective_mmr"
+	PlayerHistoricalMMRTable  = "player_historical_mmr"
+	PlayerPlaylistRatingTable = "player_playlist_ratings"
+
+	// Postgres functions that write a rating and its history row in one transaction
+	RecordRatingDecayFunction         = "record_rating_decay"
+	RecordRecalculationChangeFunction = "record_recalculation_change"
+)
+
+// PlayerMMRRepository handles per-guild rating data in player_effective_mmr, player_historical_mmr
+// and the per-playlist tracks in player_playlist_ratings
+type PlayerMMRRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewPlayerMMRRepository(client *supabase.Client, cfg *config.Config) *PlayerMMRRepository {
+	return &PlayerMMRRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// FindEffectiveMMR returns the current rating for a user in a guild
+// Returns nil without an error when the user has no rating in the guild yet
+func (r *PlayerMMRRepository) FindEffectiveMMR(userID, guildID int64) (*models.PlayerEffectiveMMR, error) {
+	data, _, err := r.client.From(PlayerEffectiveMMRTable).
+		Select("*", "", false).
+		Eq("user_id", strconv.FormatInt(userID, 10)).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get effective MMR: %w", err)
+	}
+
+	var result []models.PublicPlayerEffectiveMmrSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse effective MMR: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	effective := r.convertToEffectiveMMR(result[0])
+	return &effective, nil
+}
+
+// GetGuildEffectiveMMRs returns every rating in a guild ordered by mu
+func (r *PlayerMMRRepository) GetGuildEffectiveMMRs(guildID int64) ([]*models.PlayerEffectiveMMR, error) {
+	data, _, err := r.client.From(PlayerEffectiveMMRTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("trueskill_mu", &postgrest.OrderOpts{Ascending: false}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild effective MMRs: %w", err)
+	}
+
+	var result []models.PublicPlayerEffectiveMmrSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse guild effective MMRs: %w", err)
+	}
+
+	ratings := make([]*models.PlayerEffectiveMMR, 0, len(result))
+	for _, row := range result {
+		effective := r.convertToEffectiveMMR(row)
+		ratings = append(ratings, &effective)
+	}
+
+	return ratings, nil
+}
+
+// UpsertEffectiveMMR creates or replaces the rating for a user in a guild
+func (r *PlayerMMRRepository) UpsertEffectiveMMR(effective *models.PlayerEffectiveMMR) error {
+	_, _, err := r.client.From(PlayerEffectiveMMRTable).
+		Upsert(effectiveMMRRow(effective), "user_id,guild_id", "", "").
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert effective MMR for user %d: %w", effective.UserID, err)
+	}
+
+	return nil
+}
+
+// FindPlaylistRating returns a user's rating track for one playlist in a guild
+// Returns nil without an error when the user has no rating in that playlist yet
+func (r *PlayerMMRRepository) FindPlaylistRating(userID, guildID int64, playlist string) (*models.PlayerPlaylistRating, error) {
+	data, _, err := r.client.From(PlayerPlaylistRatingTable).
+		Select("*", "", false).
+		Eq("user_id", strconv.FormatInt(userID, 10)).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Eq("playlist", playlist).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s rating: %w", playlist, err)
+	}
+
+	var result []models.PublicPlayerPlaylistRatingsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse %s rating: %w", playlist, err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	rating := r.convertToPlaylistRating(result[0])
+	return &rating, nil
+}
+
+// GetGuildPlaylistRatings returns every rating in one playlist of a guild ordered by mu
+func (r *PlayerMMRRepository) GetGuildPlaylistRatings(guildID int64, playlist string) ([]*models.PlayerPlaylistRating, error) {
+	data, _, err := r.client.From(PlayerPlaylistRatingTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Eq("playlist", playlist).
+		Order("trueskill_mu", &postgrest.OrderOpts{Ascending: false}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild %s ratings: %w", playlist, err)
+	}
+
+	var result []models.PublicPlayerPlaylistRatingsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse guild %s ratings: %w", playlist, err)
+	}
+
+	ratings := make([]*models.PlayerPlaylistRating, 0, len(result))
+	for _, row := range result {
+		rating := r.convertToPlaylistRating(row)
+		ratings = append(ratings, &rating)
+	}
+
+	return ratings, nil
+}
+
+// UpsertPlaylistRating creates or replaces a user's rating track for one playlist in a guild
+func (r *PlayerMMRRepository) UpsertPlaylistRating(rating *models.PlayerPlaylistRating) error {
+	_, _, err := r.client.From(PlayerPlaylistRatingTable).
+		Upsert(playlistRatingRow(rating), "user_id,guild_id,playlist", "", "").
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert %s rating for user %d: %w", rating.Playlist, rating.UserID, err)
+	}
+
+	return nil
+}
+
+// CreateHistoricalMMR records a single rating change in the audit history
+func (r *PlayerMMRRepository) CreateHistoricalMMR(request models.PlayerHistoricalMMRCreateRequest) error {
+	_, _, err := r.client.From(PlayerHistoricalMMRTable).
+		Insert(historicalMMRInsert(request), false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to create MMR history for user %d: %w", request.UserID, err)
+	}
+
+	return nil
+}
+
+// RecordRatingDecay writes a decayed rating, its history row and the player's decayed playlist ratings
+// in one transaction through the record_rating_decay function, so the decay is never applied
+// without the row that records it
+func (r *PlayerMMRRepository) RecordRatingDecay(effective *models.PlayerEffectiveMMR, history models.PlayerHistoricalMMRCreateRequest, playlistRatings []*models.PlayerPlaylistRating) error {
+	playlistRows := make([]map[string]interface{}, 0, len(playlistRatings))
+	for _, rating := range playlistRatings {
+		playlistRows = append(playlistRows, playlistRatingRow(rating))
+	}
+
+	response := r.client.Rpc(RecordRatingDecayFunction, "", map[string]interface{}{
+		"p_rating":           effectiveMMRRow(effective),
+		"p_history":          historicalMMRInsert(history),
+		"p_playlist_ratings": playlistRows,
+	})
+	if response == "" {
+		return fmt.Errorf("failed to record decay for user %d: no response from %s", effective.UserID, RecordRatingDecayFunction)
+	}
+
+	// PostgREST answers a failed call with an error object instead of the rating
+	var result struct {
+		UserID int64 `json:"user_id"`
+	}
+	if err := json.Unmarshal([]byte(response), &result); err != nil || result.UserID == 0 {
+		var rpcErr postgrest.ExecuteError
+		if json.Unmarshal([]byte(response), &rpcErr) == nil && rpcErr.Message != "" {
+			return fmt.Errorf("failed to record decay for user %d: (%s) %s", effective.UserID, rpcErr.Code, rpcErr.Message)
+		}
+		return fmt.Errorf("failed to parse recorded decay: %s", response)
+	}
+
+	return nil
+}
+
+// RecordRecalculationChange writes a recalculated rating, or removes it when effective is nil, together
+// with its history row in one transaction through the record_recalculation_change function
+func (r *PlayerMMRRepository) RecordRecalculationChange(effective *models.PlayerEffectiveMMR, history models.PlayerHistoricalMMRCreateRequest) error {
+	var rating map[string]interface{}
+	if effective != nil {
+		rating = effectiveMMRRow(effective)
+	}
+
+	response := r.client.Rpc(RecordRecalculationChangeFunction, "", map[string]interface{}{
+		"p_user_id":  history.UserID,
+		"p_guild_id": history.GuildID,
+		"p_rating":   rating,
+		"p_history":  historicalMMRInsert(history),
+	})
+	if response == "" {
+		return fmt.Errorf("failed to record recalculation for user %d: no response from %s", history.UserID, RecordRecalculationChangeFunction)
+	}
+
+	// PostgREST answers a failed call with an error object instead of the history row
+	var result struct {
+		UserID int64 `json:"user_id"`
+	}
+	if err := json.Unmarshal([]byte(response), &result); err != nil || result.UserID == 0 {
+		var rpcErr postgrest.ExecuteError
+		if json.Unmarshal([]byte(response), &rpcErr) == nil && rpcErr.Message != "" {
+			return fmt.Errorf("failed to record recalculation for user %d: (%s) %s", history.UserID, rpcErr.Code, rpcErr.Message)
+		}
+		return fmt.Errorf("failed to parse recorded recalculation: %s", response)
+	}
+
+	return nil
+}
+
+// GetHistoryByMatch returns all rating changes produced by a match
+func (r *PlayerMMRRepository) GetHistoryByMatch(matchID int64) ([]*models.PlayerHistoricalMMR, error) {
+	data, _, err := r.client.From(PlayerHistoricalMMRTable).
+		Select("*", "", false).
+		Eq("match_id", strconv.FormatInt(matchID, 10)).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MMR history for match %d: %w", matchID, err)
+	}
+
+	return r.parseHistory(data)
+}
+
+// GetHistoryByRecalculationJob returns all rating changes written by a recalculation job, oldest first
+func (r *PlayerMMRRepository) GetHistoryByRecalculationJob(jobID int64) ([]*models.PlayerHistoricalMMR, error) {
+	data, _, err := r.client.From(PlayerHistoricalMMRTable).
+		Select("*", "", false).
+		Eq("recalculation_job_id", strconv.FormatInt(jobID, 10)).
+		Order("created_at", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MMR history for recalculation %d: %w", jobID, err)
+	}
+
+	return r.parseHistory(data)
+}
+
+// GetUserHistory returns the rating history for a user in a guild, newest first
+func (r *PlayerMMRRepository) GetUserHistory(userID, guildID int64, limit int) ([]*models.PlayerHistoricalMMR, error) {
+	query := r.client.From(PlayerHistoricalMMRTable).
+		Select("*", "", false).
+		Eq("user_id", strconv.FormatInt(userID, 10)).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false})
+
+	if limit > 0 {
+		query = query.Limit(limit, "")
+	}
+
+	data, _, err := query.Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MMR history for user %d: %w", userID, err)
+	}
+
+	return r.parseHistory(data)
+}
+
+// parseHistory converts raw history rows into models
+func (r *PlayerMMRRepository) parseHistory(data []byte) ([]*models.PlayerHistoricalMMR, error) {
+	var result []models.PublicPlayerHistoricalMmrSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse MMR history: %w", err)
+	}
+
+	history := make([]*models.PlayerHistoricalMMR, 0, len(result))
+	for _, row := range result {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+
+		entry := &models.PlayerHistoricalMMR{
+			ID:                   row.Id,
+			UserID:               row.UserId,
+			GuildID:              row.GuildId,
+			MMRAfter:             int(row.MmrAfter),
+			TrueSkillMuBefore:    row.TrueskillMuBefore,
+			TrueSkillMuAfter:     row.TrueskillMuAfter,
+			TrueSkillSigmaBefore: row.TrueskillSigmaBefore,
+			TrueSkillSigmaAfter:  row.TrueskillSigmaAfter,
+			ChangeReason:         row.ChangeReason,
+			MatchID:              row.MatchId,
+			RecalculationJobID:   row.RecalculationJobId,
+			ChangedByUserID:      row.ChangedByUserId,
+			CreatedAt:            createdAt,
+		}
+		if row.MmrBefore != nil {
+			mmrBefore := int(*row.MmrBefore)
+			entry.MMRBefore = &mmrBefore
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *PlayerMMRRepository) convertToEffectiveMMR(row models.PublicPlayerEffectiveMmrSelect) models.PlayerEffectiveMMR {
+	lastUpdated, _ := time.Parse(time.RFC3339, row.LastUpdated)
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	return models.PlayerEffectiveMMR{
+		ID:             row.Id,
+		UserID:         row.UserId,
+		GuildID:        row.GuildId,
+		MMR:            int(row.Mmr),
+		TrueSkillMu:    row.TrueskillMu,
+		TrueSkillSigma: row.TrueskillSigma,
+		GamesPlayed:    int(row.GamesPlayed),
+		LastUpdated:    lastUpdated,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+// convertToPlaylistRating converts a Supabase playlist rating row to the internal model
+func (r *PlayerMMRRepository) convertToPlaylistRating(row models.PublicPlayerPlaylistRatingsSelect) models.PlayerPlaylistRating {
+	lastUpdated, _ := time.Parse(time.RFC3339, row.LastUpdated)
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	return models.PlayerPlaylistRating{
+		ID:             row.Id,
+		UserID:         row.UserId,
+		GuildID:        row.GuildId,
+		Playlist:       row.Playlist,
+		TrueSkillMu:    row.TrueskillMu,
+		TrueSkillSigma: row.TrueskillSigma,
+		GamesPlayed:    int(row.GamesPlayed),
+		LastUpdated:    lastUpdated,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+// effectiveMMRRow builds the player_effective_mmr row for an upsert, stamping unset update times with now
+func effectiveMMRRow(effective *models.PlayerEffectiveMMR) map[string]interface{} {
+	lastUpdated := effective.LastUpdated
+	if lastUpdated.IsZero() {
+		lastUpdated = time.Now()
+	}
+
+	return map[string]interface{}{
+		"user_id":         effective.UserID,
+		"guild_id":        effective.GuildID,
+		"mmr":             effective.MMR,
+		"trueskill_mu":    effective.TrueSkillMu,
+		"trueskill_sigma": effective.TrueSkillSigma,
+		"games_played":    effective.GamesPlayed,
+		"last_updated":    lastUpdated.Format(time.RFC3339),
+	}
+}
+
+// playlistRatingRow builds the player_playlist_ratings row for an upsert, stamping unset update times with now
+func playlistRatingRow(rating *models.PlayerPlaylistRating) map[string]interface{} {
+	lastUpdated := rating.LastUpdated
+	if lastUpdated.IsZero() {
+		lastUpdated = time.Now()
+	}
+
+	return map[string]interface{}{
+		"user_id":         rating.UserID,
+		"guild_id":        rating.GuildID,
+		"playlist":        rating.Playlist,
+		"trueskill_mu":    rating.TrueSkillMu,
+		"trueskill_sigma": rating.TrueSkillSigma,
+		"games_played":    rating.GamesPlayed,
+		"last_updated":    lastUpdated.Format(time.RFC3339),
+	}
+}
+
+// historicalMMRInsert converts a history request to the player_historical_mmr insert type
+func historicalMMRInsert(request models.PlayerHistoricalMMRCreateRequest) models.PublicPlayerHistoricalMmrInsert {
+	insertData := models.PublicPlayerHistoricalMmrInsert{
+		ChangeReason:         request.ChangeReason,
+		ChangedByUserId:      request.ChangedByUserID,
+		GuildId:              request.GuildID,
+		MatchId:              request.MatchID,
+		MmrAfter:             int32(request.MMRAfter),
+		RecalculationJobId:   request.RecalculationJobID,
+		TrueskillMuAfter:     request.TrueSkillMuAfter,
+		TrueskillMuBefore:    request.TrueSkillMuBefore,
+		TrueskillSigmaAfter:  request.TrueSkillSigmaAfter,
+		TrueskillSigmaBefore: request.TrueSkillSigmaBefore,
+		UserId:               request.UserID,
+	}
+	if request.MMRBefore != nil {
+		mmrBefore := int32(*request.MMRBefore)
+		insertData.MmrBefore = &mmrBefore
+	}
+	return insertData
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	RankDistributionsTable = "rank_distributions"
+)
+
+// RankDistributionRepository handles uploaded rank distributions, one per game season
+type RankDistributionRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewRankDistributionRepository(client *supabase.Client, cfg *config.Config) *RankDistributionRepository {
+	return &RankDistributionRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// GetDistributions returns every uploaded distribution, oldest season first
+func (r *RankDistributionRepository) GetDistributions() ([]*models.RankDistribution, error) {
+	data, _, err := r.client.From(RankDistributionsTable).
+		Select("*", "", false).
+		Order("game_season", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rank distributions: %w", err)
+	}
+
+	var result []models.PublicRankDistributionsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse rank distributions: %w", err)
+	}
+
+	distributions := make([]*models.RankDistribution, 0, len(result))
+	for _, row := range result {
+		distribution, err := r.convertToDistribution(row)
+		if err != nil {
+			return nil, err
+		}
+		distributions = append(distributions, distribution)
+	}
+
+	return distributions, nil
+}
+
+// UpsertDistribution stores a game season's distribution, replacing any earlier upload for it
+func (r *RankDistributionRepository) UpsertDistribution(distribution *models.RankDistribution) (*models.RankDistribution, error) {
+	upsertData := map[string]interface{}{
+		"game_season":        distribution.GameSeason,
+		"name":               distribution.Name,
+		"starts_at":          distribution.StartsAt.UTC().Format(time.RFC3339),
+		"playlists":          distribution.Playlists,
+		"created_by_user_id": distribution.CreatedByUserID,
+	}
+
+	data, _, err := r.client.From(RankDistributionsTable).
+		Upsert(upsertData, "game_season", "", "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to save rank distribution for season %d: %w", distribution.GameSeason, err)
+	}
+
+	var result []models.PublicRankDistributionsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse saved rank distribution: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no rank distribution returned for season %d", distribution.GameSeason)
+	}
+
+	return r.convertToDistribution(result[0])
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *RankDistributionRepository) convertToDistribution(row models.PublicRankDistributionsSelect) (*models.RankDistribution, error) {
+	startsAt, _ := time.Parse(time.RFC3339, row.StartsAt)
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+
+	var playlists map[string][]models.RankBand
+	playlistBytes, err := json.Marshal(row.Playlists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal playlists interface: %w", err)
+	}
+	if err := json.Unmarshal(playlistBytes, &playlists); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal playlists for season %d: %w", row.GameSeason, err)
+	}
+
+	return &models.RankDistribution{
+		ID:              row.Id,
+		GameSeason:      int(row.GameSeason),
+		Name:            row.Name,
+		StartsAt:        startsAt,
+		Playlists:       playlists,
+		CreatedByUserID: row.CreatedByUserId,
+		CreatedAt:       createdAt,
+	}, nil
+}
@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	RecalculationJobsTable = "recalculation_jobs"
+)
+
+// RecalculationJobRepository handles stored rating recalculations and their changesets
+type RecalculationJobRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewRecalculationJobRepository(client *supabase.Client, cfg *config.Config) *RecalculationJobRepository {
+	return &RecalculationJobRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CreateJob stores a pending recalculation with its changeset
+func (r *RecalculationJobRepository) CreateJob(job *models.RecalculationJob) (*models.RecalculationJob, error) {
+	status := models.RecalculationStatusPending
+	insertData := models.PublicRecalculationJobsInsert{
+		Changes:         job.Changes,
+		CreatedByUserId: job.CreatedByUserID,
+		Errors:          job.Errors,
+		GuildId:         job.GuildID,
+		Players:         int32(job.Players),
+		Status:          &status,
+		Unchanged:       int32(job.Unchanged),
+	}
+	if insertData.Changes == nil {
+		insertData.Changes = []models.RecalculationChange{}
+	}
+	if insertData.Errors == nil {
+		insertData.Errors = []string{}
+	}
+
+	data, _, err := r.client.From(RecalculationJobsTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recalculation: %w", err)
+	}
+
+	var result []models.PublicRecalculationJobsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created recalculation: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no recalculation returned after creation")
+	}
+
+	return r.convertToJob(result[0])
+}
+
+// FindJob returns a recalculation by ID
+// Returns nil without an error when there is no such recalculation
+func (r *RecalculationJobRepository) FindJob(jobID int64) (*models.RecalculationJob, error) {
+	data, _, err := r.client.From(RecalculationJobsTable).
+		Select("*", "", false).
+		Eq("id", strconv.FormatInt(jobID, 10)).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recalculation %d: %w", jobID, err)
+	}
+
+	var result []models.PublicRecalculationJobsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse recalculation: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return r.convertToJob(result[0])
+}
+
+// GetJobs returns a guild's recalculations, newest first
+func (r *RecalculationJobRepository) GetJobs(guildID int64, limit int) ([]*models.RecalculationJob, error) {
+	query := r.client.From(RecalculationJobsTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false})
+
+	if limit > 0 {
+		query = query.Limit(limit, "")
+	}
+
+	data, _, err := query.Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recalculations: %w", err)
+	}
+
+	var result []models.PublicRecalculationJobsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse recalculations: %w", err)
+	}
+
+	jobs := make([]*models.RecalculationJob, 0, len(result))
+	for _, row := range result {
+		job, err := r.convertToJob(row)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// UpdateJob stores a recalculation's status, changeset and who applied or reverted it
+func (r *RecalculationJobRepository) UpdateJob(job *models.RecalculationJob) error {
+	updateData := map[string]interface{}{
+		"status":              job.Status,
+		"changes":             job.Changes,
+		"applied_by_user_id":  job.AppliedByUserID,
+		"reverted_by_user_id": job.RevertedByUserID,
+		"applied_at":          formatOptionalTime(job.AppliedAt),
+		"reverted_at":         formatOptionalTime(job.RevertedAt),
+	}
+
+	_, _, err := r.client.From(RecalculationJobsTable).
+		Update(updateData, "", "").
+		Eq("id", strconv.FormatInt(job.ID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to update recalculation %d: %w", job.ID, err)
+	}
+
+	return nil
+}
+
+// formatOptionalTime formats a timestamp for the database, keeping nil as NULL
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *RecalculationJobRepository) convertToJob(row models.PublicRecalculationJobsSelect) (*models.RecalculationJob, error) {
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+
+	var changes []models.RecalculationChange
+	changeBytes, err := json.Marshal(row.Changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal changes interface: %w", err)
+	}
+	if err := json.Unmarshal(changeBytes, &changes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal changes for recalculation %d: %w", row.Id, err)
+	}
+
+	var jobErrors []string
+	errorBytes, err := json.Marshal(row.Errors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal errors interface: %w", err)
+	}
+	if err := json.Unmarshal(errorBytes, &jobErrors); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal errors for recalculation %d: %w", row.Id, err)
+	}
+
+	return &models.RecalculationJob{
+		ID:               row.Id,
+		GuildID:          row.GuildId,
+		Status:           row.Status,
+		Changes:          changes,
+		Players:          int(row.Players),
+		Unchanged:        int(row.Unchanged),
+		Errors:           jobErrors,
+		CreatedByUserID:  row.CreatedByUserId,
+		AppliedByUserID:  row.AppliedByUserId,
+		RevertedByUserID: row.RevertedByUserId,
+		CreatedAt:        createdAt,
+		AppliedAt:        parseOptionalTime(row.AppliedAt),
+		RevertedAt:       parseOptionalTime(row.RevertedAt),
+	}, nil
+}
+
+// parseOptionalTime parses a nullable timestamp column
+func parseOptionalTime(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	SeasonsTable                    = "seasons"
+	SeasonLeaderboardSnapshotsTable = "season_leaderboard_snapshots"
+	TrackerSeasonRolloversTable     = "tracker_season_rollovers"
+
+	// Postgres functions that write several tables in one transaction
+	CloseSeasonFunction        = "close_season"
+	RollTrackerSeasonsFunction = "roll_tracker_seasons"
+)
+
+// SeasonRepository handles league seasons and their final leaderboard snapshots
+type SeasonRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewSeasonRepository(client *supabase.Client, cfg *config.Config) *SeasonRepository {
+	return &SeasonRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CreateSeason inserts a new active season
+func (r *SeasonRepository) CreateSeason(request models.SeasonCreateRequest) (*models.Season, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid season: %w", err)
+	}
+
+	status := models.SeasonStatusActive
+	startedAt := time.Now().Format(time.RFC3339)
+	insertData := models.PublicSeasonsInsert{
+		GuildId:   request.GuildID,
+		Name:      request.Name,
+		Number:    int32(request.Number),
+		StartedAt: &startedAt,
+		Status:    &status,
+	}
+
+	data, _, err := r.client.From(SeasonsTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create season: %w", err)
+	}
+
+	var result []models.PublicSeasonsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created season: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no season returned after creation")
+	}
+
+	return r.convertToSeason(result[0]), nil
+}
+
+// FindActiveSeason returns the running season in a guild
+// Returns nil without an error when no season is active
+func (r *SeasonRepository) FindActiveSeason(guildID int64) (*models.Season, error) {
+	data, _, err := r.client.From(SeasonsTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Eq("status", models.SeasonStatusActive).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active season: %w", err)
+	}
+
+	var result []models.PublicSeasonsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse active season: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return r.convertToSeason(result[0]), nil
+}
+
+// GetSeasonsByGuild returns every season in a guild, newest first
+func (r *SeasonRepository) GetSeasonsByGuild(guildID int64) ([]*models.Season, error) {
+	data, _, err := r.client.From(SeasonsTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("number", &postgrest.OrderOpts{Ascending: false}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seasons: %w", err)
+	}
+
+	var result []models.PublicSeasonsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse seasons: %w", err)
+	}
+
+	seasons := make([]*models.Season, 0, len(result))
+	for _, row := range result {
+		seasons = append(seasons, r.convertToSeason(row))
+	}
+
+	return seasons, nil
+}
+
+// CloseSeason writes the final standings, closes the season and applies its soft reset in one
+// transaction through the close_season function, so a failure leaves the season open and ratings untouched
+func (r *SeasonRepository) CloseSeason(record models.SeasonCloseRecord) (*models.Season, error) {
+	snapshot := make([]models.PublicSeasonLeaderboardSnapshotsInsert, 0, len(record.Snapshot))
+	for _, entry := range record.Snapshot {
+		mmr := int32(entry.MMR)
+		gamesPlayed := int32(entry.GamesPlayed)
+		snapshot = append(snapshot, models.PublicSeasonLeaderboardSnapshotsInsert{
+			GamesPlayed:    &gamesPlayed,
+			Mmr:            &mmr,
+			Rank:           int32(entry.Rank),
+			SeasonId:       entry.SeasonID,
+			TrueskillMu:    entry.TrueSkillMu,
+			TrueskillSigma: entry.TrueSkillSigma,
+			UserId:         entry.UserID,
+		})
+	}
+	ratings := make([]map[string]interface{}, 0, len(record.Ratings))
+	for _, rating := range record.Ratings {
+		ratings = append(ratings, effectiveMMRRow(rating))
+	}
+	history := make([]models.PublicPlayerHistoricalMmrInsert, 0, len(record.History))
+	for _, entry := range record.History {
+		history = append(history, historicalMMRInsert(entry))
+	}
+	playlistRatings := make([]map[string]interface{}, 0, len(record.PlaylistRatings))
+	for _, rating := range record.PlaylistRatings {
+		playlistRatings = append(playlistRatings, playlistRatingRow(rating))
+	}
+
+	response := r.client.Rpc(CloseSeasonFunction, "", map[string]interface{}{
+		"p_season_id":        record.SeasonID,
+		"p_mu_factor":        record.MuFactor,
+		"p_sigma_factor":     record.SigmaFactor,
+		"p_snapshot":         snapshot,
+		"p_ratings":          ratings,
+		"p_history":          history,
+		"p_playlist_ratings": playlistRatings,
+	})
+	if response == "" {
+		return nil, fmt.Errorf("failed to close season %d: no response from %s", record.SeasonID, CloseSeasonFunction)
+	}
+
+	// PostgREST answers a failed call with an error object instead of the season
+	var result models.PublicSeasonsSelect
+	if err := json.Unmarshal([]byte(response), &result); err != nil || result.Id == 0 {
+		var rpcErr postgrest.ExecuteError
+		if json.Unmarshal([]byte(response), &rpcErr) == nil && rpcErr.Message != "" {
+			return nil, fmt.Errorf("failed to close season %d: (%s) %s", record.SeasonID, rpcErr.Code, rpcErr.Message)
+		}
+		return nil, fmt.Errorf("failed to parse closed season: %s", response)
+	}
+
+	return r.convertToSeason(result), nil
+}
+
+// GetLeaderboardSnapshot returns the final standings of a closed season ordered by rank
+func (r *SeasonRepository) GetLeaderboardSnapshot(seasonID int64) ([]models.SeasonLeaderboardEntry, error) {
+	data, _, err := r.client.From(SeasonLeaderboardSnapshotsTable).
+		Select("*", "", false).
+		Eq("season_id", strconv.FormatInt(seasonID, 10)).
+		Order("rank", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard snapshot: %w", err)
+	}
+
+	var result []models.PublicSeasonLeaderboardSnapshotsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse leaderboard snapshot: %w", err)
+	}
+
+	entries := make([]models.SeasonLeaderboardEntry, 0, len(result))
+	for _, row := range result {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		entries = append(entries, models.SeasonLeaderboardEntry{
+			ID:             row.Id,
+			SeasonID:       row.SeasonId,
+			UserID:         row.UserId,
+			Rank:           int(row.Rank),
+			MMR:            int(row.Mmr),
+			TrueSkillMu:    row.TrueskillMu,
+			TrueSkillSigma: row.TrueskillSigma,
+			GamesPlayed:    int(row.GamesPlayed),
+			CreatedAt:      createdAt,
+		})
+	}
+
+	return entries, nil
+}
+
+// FindLatestTrackerRollover returns the newest game season trackers were rolled into
+// Returns nil without an error when trackers have never been rolled
+func (r *SeasonRepository) FindLatestTrackerRollover() (*models.TrackerSeasonRollover, error) {
+	data, _, err := r.client.From(TrackerSeasonRolloversTable).
+		Select("*", "", false).
+		Order("game_season", &postgrest.OrderOpts{Ascending: false}).
+		Limit(1, "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest tracker rollover: %w", err)
+	}
+
+	var result []models.PublicTrackerSeasonRolloversSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse tracker rollover: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return r.convertToTrackerRollover(result[0]), nil
+}
+
+// RollTrackerSeasons claims a game season and rolls every tracker into it in one transaction
+// through the roll_tracker_seasons function, so a failure leaves the game season unclaimed
+func (r *SeasonRepository) RollTrackerSeasons(gameSeason int, rolledByUserID *int64) (*models.TrackerSeasonRollover, error) {
+	response := r.client.Rpc(RollTrackerSeasonsFunction, "", map[string]interface{}{
+		"p_game_season":       gameSeason,
+		"p_rolled_by_user_id": rolledByUserID,
+	})
+	if response == "" {
+		return nil, fmt.Errorf("failed to roll trackers into game season %d: no response from %s", gameSeason, RollTrackerSeasonsFunction)
+	}
+
+	// PostgREST answers a failed call with an error object instead of the rollover
+	var result models.PublicTrackerSeasonRolloversSelect
+	if err := json.Unmarshal([]byte(response), &result); err != nil || result.GameSeason == 0 {
+		var rpcErr postgrest.ExecuteError
+		if json.Unmarshal([]byte(response), &rpcErr) == nil && rpcErr.Message != "" {
+			return nil, fmt.Errorf("failed to roll trackers into game season %d: (%s) %s", gameSeason, rpcErr.Code, rpcErr.Message)
+		}
+		return nil, fmt.Errorf("failed to parse tracker rollover: %s", response)
+	}
+
+	return r.convertToTrackerRollover(result), nil
+}
+
+// convertToTrackerRollover converts a tracker_season_rollovers row to the internal model
+func (r *SeasonRepository) convertToTrackerRollover(row models.PublicTrackerSeasonRolloversSelect) *models.TrackerSeasonRollover {
+	rolledAt, _ := time.Parse(time.RFC3339, row.RolledAt)
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	return &models.TrackerSeasonRollover{
+		GameSeason:     int(row.GameSeason),
+		TrackersRolled: int(row.TrackersRolled),
+		RolledByUserID: row.RolledByUserId,
+		RolledAt:       rolledAt,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *SeasonRepository) convertToSeason(row models.PublicSeasonsSelect) *models.Season {
+	startedAt, _ := time.Parse(time.RFC3339, row.StartedAt)
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	season := &models.Season{
+		ID:                   row.Id,
+		GuildID:              row.GuildId,
+		Number:               int(row.Number),
+		Name:                 row.Name,
+		Status:               row.Status,
+		StartedAt:            startedAt,
+		SoftResetMuFactor:    row.SoftResetMuFactor,
+		SoftResetSigmaFactor: row.SoftResetSigmaFactor,
+		CreatedAt:            createdAt,
+		UpdatedAt:            updatedAt,
+	}
+
+	if row.EndedAt != nil {
+		if endedAt, err := time.Parse(time.RFC3339, *row.EndedAt); err == nil {
+			season.EndedAt = &endedAt
+		}
+	}
+
+	return season
+}
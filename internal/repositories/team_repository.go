@@ -0,0 +1,362 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/supabase-community/supabase-go"
+)
+
+const (
+	// Database table names
+	TeamsTable              = "teams"
+	TeamMembersTable        = "team_members"
+	RosterTransactionsTable = "roster_transactions"
+)
+
+// TeamRepository handles league teams, their rosters and roster history
+type TeamRepository struct {
+	client *supabase.Client
+	config *config.Config
+}
+
+func NewTeamRepository(client *supabase.Client, cfg *config.Config) *TeamRepository {
+	return &TeamRepository{
+		client: client,
+		config: cfg,
+	}
+}
+
+// CreateTeam creates a new team
+func (r *TeamRepository) CreateTeam(request models.TeamCreateRequest) (*models.Team, error) {
+	insertData := models.PublicTeamsInsert{
+		CaptainDiscordId: request.CaptainDiscordID,
+		DivisionId:       request.DivisionID,
+		GuildId:          request.GuildID,
+		Name:             request.Name,
+		SeasonId:         request.SeasonID,
+	}
+
+	data, _, err := r.client.From(TeamsTable).Insert(insertData, false, "", "", "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create team: %w", err)
+	}
+
+	var result []models.PublicTeamsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created team: %w", err)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no team returned after creation")
+	}
+
+	return r.convertToTeam(result[0]), nil
+}
+
+// FindTeamByID finds a team by ID
+func (r *TeamRepository) FindTeamByID(teamID int64) (*models.Team, error) {
+	data, _, err := r.client.From(TeamsTable).
+		Select("*", "", false).
+		Eq("id", strconv.FormatInt(teamID, 10)).
+		Single().
+		Execute()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.PublicTeamsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse team data: %w", err)
+	}
+
+	return r.convertToTeam(result), nil
+}
+
+// GetTeamsByDivision returns the teams in a division by name
+func (r *TeamRepository) GetTeamsByDivision(divisionID int64) ([]*models.Team, error) {
+	data, _, err := r.client.From(TeamsTable).
+		Select("*", "", false).
+		Eq("division_id", strconv.FormatInt(divisionID, 10)).
+		Order("name", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get division teams: %w", err)
+	}
+
+	return r.parseTeams(data)
+}
+
+// GetTeamsByGuild returns every team in a guild by name
+func (r *TeamRepository) GetTeamsByGuild(guildID int64) ([]*models.Team, error) {
+	data, _, err := r.client.From(TeamsTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Order("name", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	return r.parseTeams(data)
+}
+
+// UpdateTeamCaptain sets a team's captain, or clears it when captainDiscordID is nil
+func (r *TeamRepository) UpdateTeamCaptain(teamID int64, captainDiscordID *string) error {
+	updateData := map[string]interface{}{
+		"captain_discord_id": captainDiscordID,
+		"updated_at":         time.Now().UTC().Format(time.RFC3339),
+	}
+
+	_, _, err := r.client.From(TeamsTable).
+		Update(updateData, "", "").
+		Eq("id", strconv.FormatInt(teamID, 10)).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to update captain for team %d: %w", teamID, err)
+	}
+
+	return nil
+}
+
+// GetTeamMembers returns a team's roster in signing order
+func (r *TeamRepository) GetTeamMembers(teamID int64) ([]models.TeamMember, error) {
+	data, _, err := r.client.From(TeamMembersTable).
+		Select("*", "", false).
+		Eq("team_id", strconv.FormatInt(teamID, 10)).
+		Order("signed_at", &postgrest.OrderOpts{Ascending: true}).
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster for team %d: %w", teamID, err)
+	}
+
+	return r.parseTeamMembers(data)
+}
+
+// FindSeasonMember returns the roster spot a player holds in a guild's season, or nil when unsigned
+// A nil season matches teams that do not belong to a season.
+func (r *TeamRepository) FindSeasonMember(guildID int64, seasonID *int64, discordID string) (*models.TeamMember, error) {
+	query := r.client.From(TeamMembersTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10)).
+		Eq("discord_id", discordID)
+
+	if seasonID != nil {
+		query = query.Eq("season_id", strconv.FormatInt(*seasonID, 10))
+	} else {
+		query = query.Is("season_id", "null")
+	}
+
+	data, _, err := query.Limit(1, "").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find roster spot for %s: %w", discordID, err)
+	}
+
+	members, err := r.parseTeamMembers(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	return &members[0], nil
+}
+
+// GetSeasonMembers returns every roster spot in a guild's season
+// A nil season matches teams that do not belong to a season.
+func (r *TeamRepository) GetSeasonMembers(guildID int64, seasonID *int64) ([]models.TeamMember, error) {
+	query := r.client.From(TeamMembersTable).
+		Select("*", "", false).
+		Eq("guild_id", strconv.FormatInt(guildID, 10))
+
+	if seasonID != nil {
+		query = query.Eq("season_id", strconv.FormatInt(*seasonID, 10))
+	} else {
+		query = query.Is("season_id", "null")
+	}
+
+	data, _, err := query.Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season rosters: %w", err)
+	}
+
+	return r.parseTeamMembers(data)
+}
+
+// AddTeamMember puts a player on a team's roster
+func (r *TeamRepository) AddTeamMember(team *models.Team, discordID string) (*models.TeamMember, error) {
+	insertData := models.PublicTeamMembersInsert{
+		DiscordId: discordID,
+		GuildId:   team.GuildID,
+		SeasonId:  team.SeasonID,
+		TeamId:    team.ID,
+	}
+
+	data, _, err := r.client.From(TeamMembersTable).
+		Insert(insertData, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign %s to team %d: %w", discordID, team.ID, err)
+	}
+
+	members, err := r.parseTeamMembers(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no roster spot returned after signing")
+	}
+
+	return &members[0], nil
+}
+
+// RemoveTeamMember takes a player off a team's roster
+func (r *TeamRepository) RemoveTeamMember(teamID int64, discordID string) error {
+	_, _, err := r.client.From(TeamMembersTable).
+		Delete("", "").
+		Eq("team_id", strconv.FormatInt(teamID, 10)).
+		Eq("discord_id", discordID).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to release %s from team %d: %w", discordID, teamID, err)
+	}
+
+	return nil
+}
+
+// CreateRosterTransaction records a signing, release or captaincy change
+func (r *TeamRepository) CreateRosterTransaction(transaction models.RosterTransaction) error {
+	capBasis := transaction.CapBasis
+	salary := transaction.Salary
+	teamSalaryAfter := transaction.TeamSalaryAfter
+
+	insertData := models.PublicRosterTransactionsInsert{
+		Action:          transaction.Action,
+		CapBasis:        &capBasis,
+		ChangedByUserId: transaction.ChangedByUserID,
+		DiscordId:       transaction.DiscordID,
+		GuildId:         transaction.GuildID,
+		Salary:          &salary,
+		SeasonId:        transaction.SeasonID,
+		TeamId:          transaction.TeamID,
+		TeamSalaryAfter: &teamSalaryAfter,
+	}
+
+	_, _, err := r.client.From(RosterTransactionsTable).
+		Insert(insertData, false, "", "", "").
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to record roster transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetRosterTransactions returns a team's roster history, newest first
+func (r *TeamRepository) GetRosterTransactions(teamID int64, limit int) ([]models.RosterTransaction, error) {
+	data, _, err := r.client.From(RosterTransactionsTable).
+		Select("*", "", false).
+		Eq("team_id", strconv.FormatInt(teamID, 10)).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Limit(limit, "").
+		Execute()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster history for team %d: %w", teamID, err)
+	}
+
+	var result []models.PublicRosterTransactionsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse roster history: %w", err)
+	}
+
+	transactions := make([]models.RosterTransaction, 0, len(result))
+	for _, row := range result {
+		createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+		transactions = append(transactions, models.RosterTransaction{
+			ID:              row.Id,
+			TeamID:          row.TeamId,
+			GuildID:         row.GuildId,
+			SeasonID:        row.SeasonId,
+			DiscordID:       row.DiscordId,
+			Action:          row.Action,
+			Salary:          row.Salary,
+			CapBasis:        row.CapBasis,
+			TeamSalaryAfter: row.TeamSalaryAfter,
+			ChangedByUserID: row.ChangedByUserId,
+			CreatedAt:       createdAt,
+		})
+	}
+
+	return transactions, nil
+}
+
+// parseTeamMembers converts a roster query response into internal models
+func (r *TeamRepository) parseTeamMembers(data []byte) ([]models.TeamMember, error) {
+	var result []models.PublicTeamMembersSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse roster: %w", err)
+	}
+
+	members := make([]models.TeamMember, 0, len(result))
+	for _, row := range result {
+		signedAt, _ := time.Parse(time.RFC3339, row.SignedAt)
+		members = append(members, models.TeamMember{
+			ID:        row.Id,
+			TeamID:    row.TeamId,
+			GuildID:   row.GuildId,
+			SeasonID:  row.SeasonId,
+			DiscordID: row.DiscordId,
+			SignedAt:  signedAt,
+		})
+	}
+
+	return members, nil
+}
+
+// parseTeams converts a team result set to internal models
+func (r *TeamRepository) parseTeams(data []byte) ([]*models.Team, error) {
+	var result []models.PublicTeamsSelect
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse teams: %w", err)
+	}
+
+	teams := make([]*models.Team, 0, len(result))
+	for _, row := range result {
+		teams = append(teams, r.convertToTeam(row))
+	}
+	return teams, nil
+}
+
+// Helper function to convert Supabase generated type to internal model
+func (r *TeamRepository) convertToTeam(row models.PublicTeamsSelect) *models.Team {
+	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+
+	return &models.Team{
+		ID:               row.Id,
+		GuildID:          row.GuildId,
+		SeasonID:         row.SeasonId,
+		DivisionID:       row.DivisionId,
+		Name:             row.Name,
+		CaptainDiscordID: row.CaptainDiscordId,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 	"usl-server/internal/config"
 	"usl-server/internal/models"
@@ -28,10 +29,14 @@ func NewTrackerRepository(client *supabase.Client, cfg *config.Config) *TrackerR
 
 // CreateTracker creates a new tracker, matching JavaScript createTracker()
 func (r *TrackerRepository) CreateTracker(trackerData models.TrackerCreateRequest) (*models.UserTracker, error) {
-	// Check for existing tracker by Discord ID
+	// Check for the same account already linked to this Discord ID; other accounts are alts
 	existingTrackers, err := r.GetTrackersByDiscordID(trackerData.DiscordID, false)
-	if err == nil && len(existingTrackers) > 0 {
-		return nil, fmt.Errorf("tracker with Discord ID %s already exists", trackerData.DiscordID)
+	if err == nil {
+		for _, existing := range existingTrackers {
+			if strings.EqualFold(existing.URL, trackerData.URL) {
+				return nil, fmt.Errorf("tracker with Discord ID %s already exists", trackerData.DiscordID)
+			}
+		}
 	}
 
 	// Prepare insert data using generated types
@@ -54,6 +59,7 @@ func (r *TrackerRepository) CreateTracker(trackerData models.TrackerCreateReques
 		ThreesCurrentSeasonGames:  r.intToInt32Ptr(trackerData.ThreesCurrentSeasonGames),
 		ThreesPreviousSeasonGames: r.intToInt32Ptr(trackerData.ThreesPreviousSeasonGames),
 		Valid:                     &trackerData.Valid,
+		IsPrimary:                 &trackerData.IsPrimary,
 		LastUpdated:               r.currentTimeStringPtr(),
 	}
 
@@ -131,6 +137,7 @@ func (r *TrackerRepository) UpdateTracker(trackerID int, trackerData models.Trac
 		ThreesCurrentSeasonGames:  r.intToInt32Ptr(trackerData.ThreesCurrentSeasonGames),
 		ThreesPreviousSeasonGames: r.intToInt32Ptr(trackerData.ThreesPreviousSeasonGames),
 		Valid:                     &trackerData.Valid,
+		IsPrimary:                 trackerData.IsPrimary,
 		LastUpdated:               r.currentTimeStringPtr(),
 	}
 
@@ -565,6 +572,7 @@ func (r *TrackerRepository) updateSingleTracker(trackerID int, updates map[strin
 		"threes_current_season_games":  true,
 		"threes_previous_season_games": true,
 		"valid":                        true,
+		"is_primary":                   true,
 	}
 
 	sanitizedUpdates := make(map[string]interface{})
@@ -623,6 +631,7 @@ func (r *TrackerRepository) convertToUserTracker(trackerSelect models.PublicUser
 		ThreesPreviousSeasonGames: int(trackerSelect.ThreesPreviousSeasonGames),
 		CalculatedMMR:             int(trackerSelect.CalculatedMmr),
 		Valid:                     trackerSelect.Valid,
+		IsPrimary:                 trackerSelect.IsPrimary,
 		LastUpdated:               lastUpdated,
 		CreatedAt:                 createdAt,
 		UpdatedAt:                 updatedAt,
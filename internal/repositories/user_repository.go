@@ -177,6 +177,40 @@ func (r *UserRepository) DeleteUser(discordID string) (*models.User, error) {
 	return &deletedUser, nil
 }
 
+// FindUsersByIDs gets the users with the given IDs in one request; unknown IDs are left out
+func (r *UserRepository) FindUsersByIDs(userIDs []int64) ([]*models.User, error) {
+	if len(userIDs) == 0 {
+		return []*models.User{}, nil
+	}
+
+	ids := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		ids[i] = fmt.Sprintf("%d", userID)
+	}
+
+	data, _, err := r.client.From("users").
+		Select("*", "", false).
+		In("id", ids).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	var result []models.PublicUsersSelect
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse users: %w", err)
+	}
+
+	users := make([]*models.User, len(result))
+	for i, userSelect := range result {
+		user := r.convertToUser(userSelect)
+		users[i] = &user
+	}
+
+	return users, nil
+}
+
 // GetAllUsers gets all users with optional active filter using Supabase client
 func (r *UserRepository) GetAllUsers(activeOnly bool) ([]*models.User, error) {
 	query := r.client.From("users").Select("*", "", false).Order("name", nil)
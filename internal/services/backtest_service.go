@@ -0,0 +1,436 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidBacktest is returned when backtest options cannot be run
+var ErrInvalidBacktest = errors.New("invalid backtest")
+
+// Backtest seeding strategies
+const (
+	BacktestSeedingTracker = "tracker" // seed every player from their trackers, as placement does
+	BacktestSeedingDefault = "default" // start every player at the initial mu and sigma
+)
+
+// DefaultCalibrationBins is how many probability buckets the calibration table has
+const DefaultCalibrationBins = 10
+
+// BacktestCurrentConfig names the running config in backtest reports
+const BacktestCurrentConfig = "current"
+
+// backtestProbabilityEpsilon keeps log-loss finite when an engine is certain and wrong
+const backtestProbabilityEpsilon = 1e-6
+
+// BacktestMatchSource interface for reading a guild's match history in play order
+type BacktestMatchSource interface {
+	GetMatchesByGuildBetween(guildID int64, from time.Time, to *time.Time) ([]*models.Match, error)
+}
+
+// BacktestUserSource interface for mapping match players to their Discord IDs
+type BacktestUserSource interface {
+	GetAllUsers(activeOnly bool) ([]*models.User, error)
+}
+
+// BacktestTrackerSource interface for listing the trackers seeds are calculated from
+type BacktestTrackerSource interface {
+	GetAllTrackers(validOnly bool) ([]*models.UserTracker, error)
+}
+
+// BacktestConfig is one named set of config overrides to replay the history with
+type BacktestConfig struct {
+	Name      string              `json:"name"`
+	Overrides SimulationOverrides `json:"overrides"`
+}
+
+// BacktestOptions selects the matches to replay and the combinations to compare
+// Empty Engines, Seedings and Configs run every engine, both seeding strategies and the
+// running config.
+type BacktestOptions struct {
+	GuildID         int64
+	From            time.Time  // zero replays from the guild's first match
+	To              *time.Time // nil replays up to the latest match
+	Engines         []string
+	Seedings        []string
+	Configs         []BacktestConfig
+	CalibrationBins int
+}
+
+// CalibrationBin compares the predicted and observed team A win rates for one probability range
+type CalibrationBin struct {
+	From            float64 `json:"from"`
+	To              float64 `json:"to"`
+	Predictions     int     `json:"predictions"`
+	MeanPredicted   float64 `json:"mean_predicted"`
+	ObservedWinRate float64 `json:"observed_win_rate"`
+}
+
+// BacktestRun is how well one engine, seeding strategy and config predicted the history
+// Draws are rated but not scored, since every engine predicts a win probability.
+type BacktestRun struct {
+	Engine      string             `json:"engine"`
+	Seeding     string             `json:"seeding"`
+	Config      string             `json:"config"`
+	Settings    SimulationSettings `json:"settings"`
+	Seeded      int                `json:"seeded"` // players seeded from trackers
+	Predictions int                `json:"predictions"`
+	Draws       int                `json:"draws"`
+	LogLoss     float64            `json:"log_loss"`
+	BrierScore  float64            `json:"brier_score"`
+	Accuracy    float64            `json:"accuracy"`
+	Calibration []CalibrationBin   `json:"calibration"`
+}
+
+// BacktestReport holds every run of a backtest, best log-loss first
+type BacktestReport struct {
+	GuildID int64         `json:"guild_id"`
+	Matches int           `json:"matches"`
+	Skipped int           `json:"skipped"` // matches missing a team
+	Players int           `json:"players"`
+	Runs    []BacktestRun `json:"runs"`
+}
+
+// BacktestService replays a guild's match history to measure how well ratings predict results.
+// Service Responsibilities:
+// - Seeding every player before their first match, from trackers or the config defaults
+// - Replaying matches oldest first, predicting each before rating it
+// - Scoring the predictions with log-loss, Brier score, accuracy and a calibration table
+//
+// Trackers hold current ranks, so tracker seeds know more than they would have when the
+// history was played; the comparison between configs is still fair.
+type BacktestService struct {
+	matchRepo                 BacktestMatchSource
+	userRepo                  BacktestUserSource
+	trackerRepo               BacktestTrackerSource
+	converter                 *PercentileConverter
+	dataTransformationService *DataTransformationService
+	config                    *config.Config
+}
+
+func NewBacktestService(
+	matchRepo *repositories.MatchRepository,
+	userRepo *repositories.UserRepository,
+	trackerRepo *repositories.TrackerRepository,
+	converter *PercentileConverter,
+	dataTransformationService *DataTransformationService,
+	config *config.Config,
+) *BacktestService {
+	return &BacktestService{
+		matchRepo:                 matchRepo,
+		userRepo:                  userRepo,
+		trackerRepo:               trackerRepo,
+		converter:                 converter,
+		dataTransformationService: dataTransformationService,
+		config:                    config,
+	}
+}
+
+// backtestSetup is one config ready to replay: its engines and its tracker seeds
+type backtestSetup struct {
+	name     string
+	config   *config.Config
+	settings SimulationSettings
+	seeder   *trackerSeeder
+	engines  map[string]RatingEngine
+	seeds    map[int64]TrueSkillRating // by user ID, players whose trackers seeded
+}
+
+// Run replays the guild's matches once per engine, seeding strategy and config
+// Default seeding does not read the seeding weights, so it only runs with the running config.
+func (s *BacktestService) Run(options BacktestOptions) (*BacktestReport, error) {
+	if options.CalibrationBins == 0 {
+		options.CalibrationBins = DefaultCalibrationBins
+	}
+	if options.CalibrationBins < 1 || options.CalibrationBins > 100 {
+		return nil, fmt.Errorf("%w: calibration bins must be between 1 and 100, got %d", ErrInvalidBacktest, options.CalibrationBins)
+	}
+	if len(options.Seedings) == 0 {
+		options.Seedings = []string{BacktestSeedingTracker, BacktestSeedingDefault}
+	}
+	seen := make(map[string]bool, len(options.Seedings))
+	for _, seeding := range options.Seedings {
+		if seeding != BacktestSeedingTracker && seeding != BacktestSeedingDefault {
+			return nil, fmt.Errorf("%w: unknown seeding %q", ErrInvalidBacktest, seeding)
+		}
+		if seen[seeding] {
+			return nil, fmt.Errorf("%w: seeding %q is listed twice", ErrInvalidBacktest, seeding)
+		}
+		seen[seeding] = true
+	}
+	if len(options.Configs) == 0 {
+		options.Configs = []BacktestConfig{{Name: BacktestCurrentConfig}}
+	}
+
+	setups := make([]*backtestSetup, 0, len(options.Configs))
+	names := make(map[string]bool, len(options.Configs))
+	for i, backtestConfig := range options.Configs {
+		if backtestConfig.Name == "" {
+			backtestConfig.Name = fmt.Sprintf("config-%d", i+1)
+		}
+		if names[backtestConfig.Name] {
+			return nil, fmt.Errorf("%w: config %q is listed twice", ErrInvalidBacktest, backtestConfig.Name)
+		}
+		names[backtestConfig.Name] = true
+
+		setup, err := s.newSetup(backtestConfig)
+		if err != nil {
+			return nil, err
+		}
+		setups = append(setups, setup)
+	}
+	current, err := s.newSetup(BacktestConfig{Name: BacktestCurrentConfig})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(options.Engines) == 0 {
+		for name := range current.engines {
+			options.Engines = append(options.Engines, name)
+		}
+		sort.Strings(options.Engines)
+	}
+	for _, engine := range options.Engines {
+		if _, ok := current.engines[engine]; !ok {
+			return nil, fmt.Errorf("%w: unknown engine %q", ErrInvalidBacktest, engine)
+		}
+	}
+
+	matches, err := s.matchRepo.GetMatchesByGuildBetween(options.GuildID, options.From, options.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BacktestReport{GuildID: options.GuildID, Runs: []BacktestRun{}}
+	replayed := make([]*models.Match, 0, len(matches))
+	players := make(map[int64]bool)
+	decisive := 0
+	for _, match := range matches {
+		if len(match.TeamUserIDs(models.MatchTeamA)) == 0 || len(match.TeamUserIDs(models.MatchTeamB)) == 0 {
+			report.Skipped++
+			continue
+		}
+		replayed = append(replayed, match)
+		for _, player := range match.Players {
+			players[player.UserID] = true
+		}
+		if !match.IsDraw() {
+			decisive++
+		}
+	}
+	report.Matches = len(replayed)
+	report.Players = len(players)
+	if decisive == 0 {
+		return nil, fmt.Errorf("%w: guild %d has no decided matches in the window", ErrInvalidBacktest, options.GuildID)
+	}
+
+	seedingRuns := make(map[string][]*backtestSetup, len(options.Seedings))
+	for _, seeding := range options.Seedings {
+		switch seeding {
+		case BacktestSeedingTracker:
+			if err := s.seedSetups(setups, players); err != nil {
+				return nil, err
+			}
+			seedingRuns[seeding] = setups
+		case BacktestSeedingDefault:
+			seedingRuns[seeding] = []*backtestSetup{current}
+		}
+	}
+
+	for _, engine := range options.Engines {
+		for _, seeding := range options.Seedings {
+			for _, setup := range seedingRuns[seeding] {
+				report.Runs = append(report.Runs, s.replay(replayed, setup, engine, seeding, options.CalibrationBins))
+			}
+		}
+	}
+
+	sort.SliceStable(report.Runs, func(i, j int) bool {
+		return report.Runs[i].LogLoss < report.Runs[j].LogLoss
+	})
+
+	log.Printf("BacktestService: Replayed %d matches in guild %d through %d runs (%d skipped)",
+		report.Matches, options.GuildID, len(report.Runs), report.Skipped)
+
+	return report, nil
+}
+
+// newSetup applies a config's overrides and builds its engines around a seeder for them
+func (s *BacktestService) newSetup(backtestConfig BacktestConfig) (*backtestSetup, error) {
+	cfg, err := applySimulationOverrides(s.config, s.converter, backtestConfig.Overrides)
+	if err != nil {
+		return nil, fmt.Errorf("%w: config %q: %v", ErrInvalidBacktest, backtestConfig.Name, err)
+	}
+	seeder := &trackerSeeder{
+		mmrCalculator:         NewMMRCalculator(cfg, s.converter),
+		uncertaintyCalculator: NewEnhancedUncertaintyCalculator(cfg, s.converter),
+		rankSeason:            backtestConfig.Overrides.RankSeason,
+	}
+	return &backtestSetup{
+		name:     backtestConfig.Name,
+		config:   cfg,
+		settings: simulationSettings(cfg, backtestConfig.Overrides.RankSeason),
+		seeder:   seeder,
+		engines:  newRatingEngines(seeder, cfg),
+	}, nil
+}
+
+// seedSetups calculates every player's tracker seed under each config
+// Players without trackers, or whose trackers cannot be seeded, start at the defaults.
+func (s *BacktestService) seedSetups(setups []*backtestSetup, players map[int64]bool) error {
+	users, err := s.userRepo.GetAllUsers(false)
+	if err != nil {
+		return err
+	}
+	trackers, err := s.trackerRepo.GetAllTrackers(true)
+	if err != nil {
+		return err
+	}
+	trackersByPlayer := make(map[string][]*models.UserTracker)
+	for _, tracker := range trackers {
+		trackersByPlayer[tracker.DiscordID] = append(trackersByPlayer[tracker.DiscordID], tracker)
+	}
+
+	for _, setup := range setups {
+		setup.seeds = make(map[int64]TrueSkillRating)
+		for _, user := range users {
+			userID := int64(user.ID)
+			playerTrackers := trackersByPlayer[user.DiscordID]
+			if !players[userID] || len(playerTrackers) == 0 {
+				continue
+			}
+			rating, err := seedFromTrackers(s.dataTransformationService, setup.seeder, playerTrackers, setup.config.MMR.TrackerAggregation)
+			if err != nil {
+				log.Printf("BacktestService: Starting %s at the defaults under config %s: %v", user.DiscordID, setup.name, err)
+				continue
+			}
+			setup.seeds[userID] = rating
+		}
+	}
+	return nil
+}
+
+// replay predicts and rates every match in order with one engine and seeding strategy
+func (s *BacktestService) replay(matches []*models.Match, setup *backtestSetup, engineName, seeding string, bins int) BacktestRun {
+	engine := setup.engines[engineName]
+	initialMu, initialSigma := setup.config.GetTrueSkillDefaults()
+
+	ratings := make(map[int64]TrueSkillRating)
+	if seeding == BacktestSeedingTracker {
+		for userID, seed := range setup.seeds {
+			ratings[userID] = seed
+		}
+	}
+	run := BacktestRun{
+		Engine:   engineName,
+		Seeding:  seeding,
+		Config:   setup.name,
+		Settings: setup.settings,
+		Seeded:   len(ratings),
+	}
+
+	teamRatings := func(userIDs []int64) []TrueSkillRating {
+		team := make([]TrueSkillRating, 0, len(userIDs))
+		for _, userID := range userIDs {
+			rating, ok := ratings[userID]
+			if !ok {
+				rating = TrueSkillRating{Mu: initialMu, Sigma: initialSigma}
+			}
+			team = append(team, rating)
+		}
+		return team
+	}
+
+	calibration := newCalibrationTable(bins)
+	var logLoss, brier, correct float64
+	for _, match := range matches {
+		teamAIDs, teamBIDs := match.TeamUserIDs(models.MatchTeamA), match.TeamUserIDs(models.MatchTeamB)
+		teamA, teamB := teamRatings(teamAIDs), teamRatings(teamBIDs)
+		outcome := matchOutcome(match)
+
+		if outcome == MatchOutcomeDraw {
+			run.Draws++
+		} else {
+			probability := math.Min(math.Max(engine.WinProbability(teamA, teamB), backtestProbabilityEpsilon), 1-backtestProbabilityEpsilon)
+			observed := 0.0
+			if outcome == MatchOutcomeTeamAWins {
+				observed = 1
+			}
+
+			run.Predictions++
+			logLoss -= observed*math.Log(probability) + (1-observed)*math.Log(1-probability)
+			brier += (probability - observed) * (probability - observed)
+			switch {
+			case probability == 0.5:
+				correct += 0.5
+			case (probability > 0.5) == (observed == 1):
+				correct++
+			}
+			calibration.add(probability, observed)
+		}
+
+		newTeamA, newTeamB := engine.RateMatch(teamA, teamB, outcome)
+		for i, userID := range teamAIDs {
+			ratings[userID] = newTeamA[i]
+		}
+		for i, userID := range teamBIDs {
+			ratings[userID] = newTeamB[i]
+		}
+	}
+
+	if run.Predictions > 0 {
+		predictions := float64(run.Predictions)
+		run.LogLoss = logLoss / predictions
+		run.BrierScore = brier / predictions
+		run.Accuracy = correct / predictions
+	}
+	run.Calibration = calibration.bins()
+	return run
+}
+
+// calibrationTable accumulates predictions into equal-width probability buckets
+type calibrationTable struct {
+	counts    []int
+	predicted []float64
+	observed  []float64
+}
+
+func newCalibrationTable(bins int) *calibrationTable {
+	return &calibrationTable{
+		counts:    make([]int, bins),
+		predicted: make([]float64, bins),
+		observed:  make([]float64, bins),
+	}
+}
+
+// add records one prediction of team A's win probability and whether team A won
+func (c *calibrationTable) add(probability, observed float64) {
+	bin := int(probability * float64(len(c.counts)))
+	if bin >= len(c.counts) {
+		bin = len(c.counts) - 1
+	}
+	c.counts[bin]++
+	c.predicted[bin] += probability
+	c.observed[bin] += observed
+}
+
+// bins returns every bucket; empty buckets report zero rates
+func (c *calibrationTable) bins() []CalibrationBin {
+	width := 1 / float64(len(c.counts))
+	bins := make([]CalibrationBin, len(c.counts))
+	for i, count := range c.counts {
+		bins[i] = CalibrationBin{From: float64(i) * width, To: float64(i+1) * width, Predictions: count}
+		if count > 0 {
+			bins[i].MeanPredicted = c.predicted[i] / float64(count)
+			bins[i].ObservedWinRate = c.observed[i] / float64(count)
+		}
+	}
+	return bins
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+// backtestMatch builds a 1v1 match played on day; a zero userB leaves team B empty
+func backtestMatch(id int64, day int, userA, userB int64, scoreA, scoreB int) *models.Match {
+	match := &models.Match{ID: id, GuildID: 1, TeamAScore: scoreA, TeamBScore: scoreB, PlayedAt: time.Date(2025, 4, day, 20, 0, 0, 0, time.UTC)}
+	match.Players = append(match.Players, models.MatchPlayer{MatchID: id, UserID: userA, Team: models.MatchTeamA})
+	if userB != 0 {
+		match.Players = append(match.Players, models.MatchPlayer{MatchID: id, UserID: userB, Team: models.MatchTeamB})
+	}
+	return match
+}
+
+func newTestBacktestService() *BacktestService {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333},
+		MMR:       config.MMRConfig{OnesWeight: 1.0, TwosWeight: 1.5, ThreesWeight: 1.2, TrackerAggregation: TrackerAggregationMax},
+	}
+	now := time.Now()
+
+	return &BacktestService{
+		matchRepo: &fakeStatsMatchStore{matches: []*models.Match{
+			backtestMatch(1, 1, 1, 2, 3, 1),
+			backtestMatch(2, 2, 2, 1, 0, 2),
+			backtestMatch(3, 3, 1, 2, 2, 2),
+			backtestMatch(4, 4, 1, 0, 1, 0),
+			backtestMatch(5, 5, 1, 2, 4, 2),
+			backtestMatch(6, 6, 1, 2, 1, 0),
+		}},
+		userRepo: &fakeStatsUsers{users: []*models.User{
+			{ID: 1, DiscordID: playerID(1), Name: "Strong", Active: true},
+			{ID: 2, DiscordID: playerID(2), Name: "Weak", Active: true},
+		}},
+		trackerRepo: &fakeTrackerSource{trackers: []*models.UserTracker{
+			{DiscordID: playerID(1), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/strong/overview", TwosCurrentSeasonPeak: 1500, TwosCurrentSeasonGames: 400, LastUpdated: now},
+			{DiscordID: playerID(2), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/weak/overview", TwosCurrentSeasonPeak: 700, TwosCurrentSeasonGames: 400, LastUpdated: now},
+		}},
+		converter:                 NewPercentileConverter(cfg),
+		dataTransformationService: NewDataTransformationService(),
+		config:                    cfg,
+	}
+}
+
+func TestBacktestScoresSeedingStrategies(t *testing.T) {
+	service := newTestBacktestService()
+
+	report, err := service.Run(BacktestOptions{GuildID: 1, Engines: []string{models.RatingEngineTrueSkill}, CalibrationBins: 4})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if report.Matches != 5 || report.Skipped != 1 || report.Players != 2 {
+		t.Fatalf("expected 5 replayed matches, 1 skipped and 2 players, got %+v", report)
+	}
+	if len(report.Runs) != 2 {
+		t.Fatalf("expected a tracker and a default run, got %d", len(report.Runs))
+	}
+
+	best, baseline := report.Runs[0], report.Runs[1]
+	if best.Seeding != BacktestSeedingTracker || baseline.Seeding != BacktestSeedingDefault {
+		t.Fatalf("expected tracker seeding to predict the stronger player's wins best, got %s then %s", best.Seeding, baseline.Seeding)
+	}
+	if best.Seeded != 2 || baseline.Seeded != 0 {
+		t.Errorf("expected both players seeded from trackers only in the tracker run, got %d and %d", best.Seeded, baseline.Seeded)
+	}
+	if best.Accuracy != 1 {
+		t.Errorf("expected tracker seeding to call every decided match, got accuracy %.3f", best.Accuracy)
+	}
+
+	for _, run := range report.Runs {
+		if run.Predictions != 4 || run.Draws != 1 {
+			t.Errorf("%s: expected 4 predictions and 1 draw, got %d and %d", run.Seeding, run.Predictions, run.Draws)
+		}
+		if run.LogLoss <= 0 || run.BrierScore <= 0 || run.BrierScore >= 1 {
+			t.Errorf("%s: expected positive log-loss and a Brier score below 1, got %.4f and %.4f", run.Seeding, run.LogLoss, run.BrierScore)
+		}
+		if len(run.Calibration) != 4 {
+			t.Fatalf("%s: expected 4 calibration bins, got %d", run.Seeding, len(run.Calibration))
+		}
+		binned := 0
+		for _, bin := range run.Calibration {
+			binned += bin.Predictions
+		}
+		if binned != run.Predictions {
+			t.Errorf("%s: expected every prediction in a calibration bin, got %d of %d", run.Seeding, binned, run.Predictions)
+		}
+	}
+
+	// Both players start level, so the opening coin flip counts as half a correct call
+	if baseline.Accuracy != 0.875 {
+		t.Errorf("expected 3.5 of 4 calls right without seeds, got accuracy %.4f", baseline.Accuracy)
+	}
+}
+
+func TestBacktestComparesConfigsAndRejectsBadOptions(t *testing.T) {
+	service := newTestBacktestService()
+	twosOnly, none := 1.0, 0.0
+
+	report, err := service.Run(BacktestOptions{
+		GuildID:  1,
+		Engines:  []string{models.RatingEngineElo},
+		Seedings: []string{BacktestSeedingTracker},
+		Configs: []BacktestConfig{
+			{Name: "current"},
+			{Name: "twos-only", Overrides: SimulationOverrides{OnesWeight: &none, TwosWeight: &twosOnly, ThreesWeight: &none}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Runs) != 2 {
+		t.Fatalf("expected one run per config, got %d", len(report.Runs))
+	}
+	for _, run := range report.Runs {
+		if run.Config == "twos-only" && (run.Settings.OnesWeight != 0 || run.Settings.TwosWeight != 1) {
+			t.Errorf("expected the overridden weights in the run settings, got %+v", run.Settings)
+		}
+		if len(run.Calibration) != DefaultCalibrationBins {
+			t.Errorf("expected %d calibration bins by default, got %d", DefaultCalibrationBins, len(run.Calibration))
+		}
+	}
+	if report.Runs[0].LogLoss > report.Runs[1].LogLoss {
+		t.Errorf("expected runs ordered by log-loss, got %.4f then %.4f", report.Runs[0].LogLoss, report.Runs[1].LogLoss)
+	}
+
+	negative := -1.0
+	drawnUntil := time.Date(2025, 4, 4, 0, 0, 0, 0, time.UTC)
+	for name, options := range map[string]BacktestOptions{
+		"unknown engine":   {GuildID: 1, Engines: []string{"whr"}},
+		"unknown seeding":  {GuildID: 1, Seedings: []string{"random"}},
+		"negative weight":  {GuildID: 1, Configs: []BacktestConfig{{Name: "bad", Overrides: SimulationOverrides{OnesWeight: &negative}}}},
+		"duplicate config": {GuildID: 1, Configs: []BacktestConfig{{Name: "same"}, {Name: "same"}}},
+		"no decided games": {GuildID: 1, From: time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC), To: &drawnUntil},
+	} {
+		if _, err := service.Run(options); !errors.Is(err, ErrInvalidBacktest) {
+			t.Errorf("%s: expected ErrInvalidBacktest, got %v", name, err)
+		}
+	}
+}
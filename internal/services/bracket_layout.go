@@ -0,0 +1,411 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"usl-server/internal/models"
+)
+
+// bracketCoord addresses a bracket match by side, round and position
+type bracketCoord struct {
+	Side     string
+	Round    int
+	Position int
+}
+
+// slotSource is where one side of an elimination match gets its entrant from
+// Seed > 0 takes the entrant with that seed; otherwise the winner (or loser) of From
+type slotSource struct {
+	Seed  int
+	From  bracketCoord
+	Loser bool
+}
+
+// bracketNode is an elimination match and the sources of its two entrants
+// A node with ResetOf is a bracket reset: it has the same sources as that match and is only
+// played when B wins it; otherwise it is a bye won by that match's winner.
+type bracketNode struct {
+	Coord   bracketCoord
+	A, B    slotSource
+	ResetOf *bracketCoord
+}
+
+// slotValue is a resolved slot: an entrant, a dead slot (no one will ever arrive) or still pending
+type slotValue struct {
+	EntrantID int64
+	Dead      bool
+	Known     bool
+}
+
+func liveSlot(entrantID int64) slotValue { return slotValue{EntrantID: entrantID, Known: true} }
+
+var (
+	deadSlot    = slotValue{Dead: true, Known: true}
+	pendingSlot = slotValue{}
+)
+
+// bracketSize returns the smallest power of two that fits every entrant
+func bracketSize(entrants int) int {
+	size := 1
+	for size < entrants {
+		size *= 2
+	}
+	return size
+}
+
+// seedOrder returns the standard seed placement for a bracket of the given size,
+// so seed 1 and seed 2 can only meet in the final: 8 -> [1 8 4 5 2 7 3 6]
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		total := len(order)*2 + 1
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, total-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// eliminationNodes builds every match of an elimination bracket in evaluation order:
+// winners bracket, then losers bracket, then the grand final and its reset
+// Losers round 2j takes the loser of winners round j+1 in reverse order to delay rematches.
+// The reset (grand final round 2) gives the winners bracket entrant the same second life
+// everyone else had: it is only played when the losers bracket entrant wins round 1.
+// Double elimination with two entrants has no losers bracket and plays as single elimination.
+func eliminationNodes(format string, entrants int) []bracketNode {
+	size := bracketSize(entrants)
+	order := seedOrder(size)
+	winnerRounds := int(math.Round(math.Log2(float64(size))))
+
+	var nodes []bracketNode
+	for position := 0; position < size/2; position++ {
+		nodes = append(nodes, bracketNode{
+			Coord: bracketCoord{models.BracketSideWinners, 1, position},
+			A:     slotSource{Seed: order[2*position]},
+			B:     slotSource{Seed: order[2*position+1]},
+		})
+	}
+	for round := 2; round <= winnerRounds; round++ {
+		for position := 0; position < size>>round; position++ {
+			nodes = append(nodes, bracketNode{
+				Coord: bracketCoord{models.BracketSideWinners, round, position},
+				A:     slotSource{From: bracketCoord{models.BracketSideWinners, round - 1, 2 * position}},
+				B:     slotSource{From: bracketCoord{models.BracketSideWinners, round - 1, 2*position + 1}},
+			})
+		}
+	}
+
+	if format != models.BracketFormatDoubleElimination || winnerRounds < 2 {
+		return nodes
+	}
+
+	for position := 0; position < size/4; position++ {
+		nodes = append(nodes, bracketNode{
+			Coord: bracketCoord{models.BracketSideLosers, 1, position},
+			A:     slotSource{From: bracketCoord{models.BracketSideWinners, 1, 2 * position}, Loser: true},
+			B:     slotSource{From: bracketCoord{models.BracketSideWinners, 1, 2*position + 1}, Loser: true},
+		})
+	}
+	for j := 1; j < winnerRounds; j++ {
+		dropRound := 2 * j
+		count := size >> (j + 1)
+		for position := 0; position < count; position++ {
+			nodes = append(nodes, bracketNode{
+				Coord: bracketCoord{models.BracketSideLosers, dropRound, position},
+				A:     slotSource{From: bracketCoord{models.BracketSideLosers, dropRound - 1, position}},
+				B:     slotSource{From: bracketCoord{models.BracketSideWinners, j + 1, count - 1 - position}, Loser: true},
+			})
+		}
+		if j == winnerRounds-1 {
+			break
+		}
+		for position := 0; position < count/2; position++ {
+			nodes = append(nodes, bracketNode{
+				Coord: bracketCoord{models.BracketSideLosers, dropRound + 1, position},
+				A:     slotSource{From: bracketCoord{models.BracketSideLosers, dropRound, 2 * position}},
+				B:     slotSource{From: bracketCoord{models.BracketSideLosers, dropRound, 2*position + 1}},
+			})
+		}
+	}
+
+	grandFinal := bracketNode{
+		Coord: bracketCoord{models.BracketSideGrandFinal, 1, 0},
+		A:     slotSource{From: bracketCoord{models.BracketSideWinners, winnerRounds, 0}},
+		B:     slotSource{From: bracketCoord{models.BracketSideLosers, 2 * (winnerRounds - 1), 0}},
+	}
+	reset := grandFinal
+	reset.Coord = bracketCoord{models.BracketSideGrandFinal, 2, 0}
+	reset.ResetOf = &grandFinal.Coord
+	return append(nodes, grandFinal, reset)
+}
+
+// layoutElimination resolves every match of an elimination bracket from the seeded entrants
+// and the winners reported so far. Byes advance automatically; matches whose entrants are
+// both known and have no result are ready. The last match returned is the final; a grand
+// final reset that is not needed is a bye with no entrants, won by the grand final winner.
+func layoutElimination(format string, entrants []models.BracketEntrant, results map[bracketCoord]int64) []models.BracketMatch {
+	bySeed := make(map[int]int64, len(entrants))
+	for _, entrant := range entrants {
+		bySeed[entrant.Seed] = entrant.ID
+	}
+
+	type outputs struct{ winner, loser slotValue }
+	decided := make(map[bracketCoord]outputs)
+
+	slot := func(source slotSource) slotValue {
+		if source.Seed > 0 {
+			if entrantID, ok := bySeed[source.Seed]; ok {
+				return liveSlot(entrantID)
+			}
+			return deadSlot
+		}
+		out := decided[source.From]
+		if source.Loser {
+			return out.loser
+		}
+		return out.winner
+	}
+
+	nodes := eliminationNodes(format, len(entrants))
+	matches := make([]models.BracketMatch, 0, len(nodes))
+	for _, node := range nodes {
+		a, b := slot(node.A), slot(node.B)
+		if node.ResetOf != nil {
+			first := decided[*node.ResetOf]
+			switch {
+			case !first.winner.Known:
+				a, b = pendingSlot, pendingSlot
+			case first.winner == a:
+				matches = append(matches, models.BracketMatch{
+					Side:            node.Coord.Side,
+					Round:           node.Coord.Round,
+					Position:        node.Coord.Position,
+					Status:          models.BracketMatchBye,
+					WinnerEntrantID: int64Ptr(a.EntrantID),
+				})
+				decided[node.Coord] = first
+				continue
+			}
+		}
+
+		match := models.BracketMatch{
+			Side:     node.Coord.Side,
+			Round:    node.Coord.Round,
+			Position: node.Coord.Position,
+			Status:   models.BracketMatchPending,
+		}
+		if a.Known && !a.Dead {
+			match.EntrantAID = int64Ptr(a.EntrantID)
+		}
+		if b.Known && !b.Dead {
+			match.EntrantBID = int64Ptr(b.EntrantID)
+		}
+
+		out := outputs{winner: pendingSlot, loser: pendingSlot}
+		switch {
+		case !a.Known || !b.Known:
+			// Waiting on an earlier match
+		case a.Dead && b.Dead:
+			match.Status = models.BracketMatchBye
+			out = outputs{winner: deadSlot, loser: deadSlot}
+		case a.Dead || b.Dead:
+			advancing := a
+			if a.Dead {
+				advancing = b
+			}
+			match.Status = models.BracketMatchBye
+			match.WinnerEntrantID = int64Ptr(advancing.EntrantID)
+			out = outputs{winner: advancing, loser: deadSlot}
+		default:
+			winnerID, reported := results[node.Coord]
+			if !reported || (winnerID != a.EntrantID && winnerID != b.EntrantID) {
+				match.Status = models.BracketMatchReady
+				break
+			}
+			match.Status = models.BracketMatchCompleted
+			match.WinnerEntrantID = int64Ptr(winnerID)
+			if winnerID == a.EntrantID {
+				out = outputs{winner: a, loser: b}
+			} else {
+				out = outputs{winner: b, loser: a}
+			}
+		}
+
+		decided[node.Coord] = out
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// SwissStanding is an entrant's record in a Swiss bracket
+// Buchholz is the sum of the wins of every opponent played; byes count as a win with no opponent.
+type SwissStanding struct {
+	Entrant  models.BracketEntrant `json:"entrant"`
+	Wins     int                   `json:"wins"`
+	Losses   int                   `json:"losses"`
+	Buchholz int                   `json:"buchholz"`
+	HadBye   bool                  `json:"had_bye"`
+}
+
+// defaultSwissRounds returns ceil(log2(entrants)), enough rounds to leave one undefeated entrant
+func defaultSwissRounds(entrants int) int {
+	rounds := int(math.Ceil(math.Log2(float64(entrants))))
+	if rounds < 1 {
+		rounds = 1
+	}
+	return rounds
+}
+
+// swissStandings ranks entrants by wins, then Buchholz, then seed
+func swissStandings(entrants []models.BracketEntrant, matches []models.BracketMatch) []SwissStanding {
+	index := make(map[int64]int, len(entrants))
+	standings := make([]SwissStanding, len(entrants))
+	for i, entrant := range entrants {
+		index[entrant.ID] = i
+		standings[i] = SwissStanding{Entrant: entrant}
+	}
+
+	opponents := make(map[int64][]int64)
+	for _, match := range matches {
+		if match.Side != models.BracketSideSwiss || !match.IsDecided() || match.WinnerEntrantID == nil {
+			continue
+		}
+		winner := *match.WinnerEntrantID
+		if i, ok := index[winner]; ok {
+			standings[i].Wins++
+			if match.Status == models.BracketMatchBye {
+				standings[i].HadBye = true
+			}
+		}
+		if match.EntrantAID == nil || match.EntrantBID == nil {
+			continue
+		}
+		loser := *match.EntrantAID
+		if loser == winner {
+			loser = *match.EntrantBID
+		}
+		if i, ok := index[loser]; ok {
+			standings[i].Losses++
+		}
+		opponents[winner] = append(opponents[winner], loser)
+		opponents[loser] = append(opponents[loser], winner)
+	}
+
+	for i := range standings {
+		for _, opponent := range opponents[standings[i].Entrant.ID] {
+			standings[i].Buchholz += standings[index[opponent]].Wins
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Wins != standings[j].Wins {
+			return standings[i].Wins > standings[j].Wins
+		}
+		if standings[i].Buchholz != standings[j].Buchholz {
+			return standings[i].Buchholz > standings[j].Buchholz
+		}
+		return standings[i].Entrant.Seed < standings[j].Entrant.Seed
+	})
+	return standings
+}
+
+// pairSwissRound pairs the next Swiss round
+// Round 1 sets the top half of the seeds against the bottom half. Later rounds pair down the
+// standings, skipping opponents already played where possible. With an odd field the
+// lowest-ranked entrant without a bye sits out and is awarded the win.
+func pairSwissRound(round int, entrants []models.BracketEntrant, matches []models.BracketMatch) []models.BracketMatch {
+	var ranked []models.BracketEntrant
+	hadBye := make(map[int64]bool)
+	if round == 1 {
+		ranked = make([]models.BracketEntrant, len(entrants))
+		copy(ranked, entrants)
+		sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Seed < ranked[j].Seed })
+	} else {
+		for _, standing := range swissStandings(entrants, matches) {
+			ranked = append(ranked, standing.Entrant)
+			hadBye[standing.Entrant.ID] = standing.HadBye
+		}
+	}
+
+	var bye *models.BracketEntrant
+	if len(ranked)%2 == 1 {
+		byeIndex := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if !hadBye[ranked[i].ID] {
+				byeIndex = i
+				break
+			}
+		}
+		sitting := ranked[byeIndex]
+		bye = &sitting
+		ranked = append(ranked[:byeIndex:byeIndex], ranked[byeIndex+1:]...)
+	}
+
+	pairings := make([]models.BracketMatch, 0, len(ranked)/2+1)
+	addPairing := func(a, b models.BracketEntrant) {
+		pairings = append(pairings, models.BracketMatch{
+			Side:       models.BracketSideSwiss,
+			Round:      round,
+			Position:   len(pairings),
+			EntrantAID: int64Ptr(a.ID),
+			EntrantBID: int64Ptr(b.ID),
+			Status:     models.BracketMatchReady,
+		})
+	}
+
+	if round == 1 {
+		half := len(ranked) / 2
+		for i := 0; i < half; i++ {
+			addPairing(ranked[i], ranked[i+half])
+		}
+	} else {
+		played := make(map[[2]int64]bool)
+		for _, match := range matches {
+			if match.EntrantAID != nil && match.EntrantBID != nil {
+				played[[2]int64{*match.EntrantAID, *match.EntrantBID}] = true
+				played[[2]int64{*match.EntrantBID, *match.EntrantAID}] = true
+			}
+		}
+
+		paired := make([]bool, len(ranked))
+		for i := range ranked {
+			if paired[i] {
+				continue
+			}
+			opponent := -1
+			for j := i + 1; j < len(ranked); j++ {
+				if paired[j] {
+					continue
+				}
+				if opponent == -1 {
+					opponent = j // Fall back to a rematch if everyone left has been played
+				}
+				if !played[[2]int64{ranked[i].ID, ranked[j].ID}] {
+					opponent = j
+					break
+				}
+			}
+			paired[i], paired[opponent] = true, true
+			addPairing(ranked[i], ranked[opponent])
+		}
+	}
+
+	if bye != nil {
+		pairings = append(pairings, models.BracketMatch{
+			Side:            models.BracketSideSwiss,
+			Round:           round,
+			Position:        len(pairings),
+			EntrantAID:      int64Ptr(bye.ID),
+			WinnerEntrantID: int64Ptr(bye.ID),
+			Status:          models.BracketMatchBye,
+		})
+	}
+	return pairings
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
@@ -0,0 +1,410 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// Bracket errors
+var (
+	ErrInvalidBracket        = errors.New("invalid bracket")
+	ErrBracketMatchNotReady  = errors.New("bracket match is not ready for a result")
+	ErrBracketAlreadyDecided = errors.New("bracket is already completed")
+)
+
+// BracketStore interface for persisting brackets, entrants and bracket matches
+type BracketStore interface {
+	CreateBracket(bracket *models.Bracket) (*models.Bracket, error)
+	CreateEntrants(bracketID int64, entrants []models.BracketEntrant) ([]models.BracketEntrant, error)
+	CreateBracketMatches(matches []models.BracketMatch) ([]models.BracketMatch, error)
+	UpdateBracketMatch(match *models.BracketMatch) error
+	UpdateBracketStatus(bracketID int64, status string) error
+	FindBracketByID(bracketID int64) (*models.Bracket, error)
+	GetBracketsByGuild(guildID int64) ([]*models.Bracket, error)
+}
+
+// RatingResolver interface for looking up the current rating of Discord IDs in a guild
+type RatingResolver interface {
+	Resolve(guildID int64, discordIDs []string) ([]ResolvedRating, error)
+}
+
+// MatchRecorder interface for recording a rated match
+type MatchRecorder interface {
+	RecordMatch(request models.MatchCreateRequest) (*MatchResult, error)
+}
+
+// BracketReportResult is the outcome of reporting a bracket match
+type BracketReportResult struct {
+	Match     *models.BracketMatch `json:"match"`
+	Rating    *MatchResult         `json:"rating,omitempty"`
+	Completed bool                 `json:"completed"`
+	Errors    []string             `json:"errors,omitempty"`
+}
+
+// BracketService runs tournament brackets.
+// Service Responsibilities:
+// - Seeding entrants by conservative skill estimate or team average mu
+// - Laying out single/double elimination brackets and pairing Swiss rounds
+// - Recording results, advancing winners (and losers in double elimination)
+// - Optionally recording each result as a rated match with history rows
+type BracketService struct {
+	bracketRepo BracketStore
+	resolver    RatingResolver
+	recorder    MatchRecorder
+	config      *config.Config
+}
+
+// NewBracketService creates a new bracket service
+func NewBracketService(
+	bracketRepo *repositories.BracketRepository,
+	resolver *PlayerRatingResolver,
+	matchService *MatchService,
+	config *config.Config,
+) *BracketService {
+	return &BracketService{
+		bracketRepo: bracketRepo,
+		resolver:    resolver,
+		recorder:    matchService,
+		config:      config,
+	}
+}
+
+// GetBrackets returns every bracket in a guild, newest first
+func (s *BracketService) GetBrackets(guildID int64) ([]*models.Bracket, error) {
+	return s.bracketRepo.GetBracketsByGuild(guildID)
+}
+
+// GetBracket returns a bracket with its entrants and matches
+func (s *BracketService) GetBracket(bracketID int64) (*models.Bracket, error) {
+	return s.bracketRepo.FindBracketByID(bracketID)
+}
+
+// SwissStandings returns the current standings of a Swiss bracket
+func (s *BracketService) SwissStandings(bracket *models.Bracket) []SwissStanding {
+	return swissStandings(bracket.Entrants, bracket.Matches)
+}
+
+// CreateBracket seeds the entrants and creates the bracket with its opening matches
+// Elimination brackets create every match up front; Swiss brackets create round 1.
+func (s *BracketService) CreateBracket(request models.BracketCreateRequest) (*models.Bracket, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBracket, err)
+	}
+
+	entrants, err := s.seedEntrants(request)
+	if err != nil {
+		return nil, err
+	}
+
+	swissRounds := 0
+	if request.Format == models.BracketFormatSwiss {
+		swissRounds = request.SwissRounds
+		if swissRounds == 0 {
+			swissRounds = defaultSwissRounds(len(entrants))
+		}
+		if swissRounds > len(entrants)-1 {
+			swissRounds = len(entrants) - 1
+		}
+	}
+
+	bracket, err := s.bracketRepo.CreateBracket(&models.Bracket{
+		GuildID:         request.GuildID,
+		SeasonID:        request.SeasonID,
+		Name:            request.Name,
+		Format:          request.Format,
+		Seeding:         request.Seeding,
+		SwissRounds:     swissRounds,
+		RecordRatings:   request.RecordRatings,
+		CreatedByUserID: request.CreatedByUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bracket.Entrants, err = s.bracketRepo.CreateEntrants(bracket.ID, entrants)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(bracket.Entrants, func(i, j int) bool {
+		return bracket.Entrants[i].Seed < bracket.Entrants[j].Seed
+	})
+
+	var matches []models.BracketMatch
+	if bracket.Format == models.BracketFormatSwiss {
+		matches = pairSwissRound(1, bracket.Entrants, nil)
+	} else {
+		matches = layoutElimination(bracket.Format, bracket.Entrants, nil)
+	}
+	for i := range matches {
+		matches[i].BracketID = bracket.ID
+	}
+
+	bracket.Matches, err = s.bracketRepo.CreateBracketMatches(matches)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("BracketService: Created %s bracket %d (%s) with %d entrants and %d matches",
+		bracket.Format, bracket.ID, bracket.Name, len(bracket.Entrants), len(bracket.Matches))
+
+	return bracket, nil
+}
+
+// ReportResult records the score of a ready bracket match and advances the bracket
+// Draws are not allowed. The bracket result is written before the rated match is recorded,
+// so a failed write can be reported again without rating the same game twice. A rating
+// failure after that is returned in Errors and the bracket still advances.
+func (s *BracketService) ReportResult(bracketID, bracketMatchID int64, scoreA, scoreB int, reportedBy *int64) (*BracketReportResult, error) {
+	if scoreA < 0 || scoreB < 0 {
+		return nil, fmt.Errorf("%w: scores cannot be negative", ErrInvalidBracket)
+	}
+	if scoreA == scoreB {
+		return nil, fmt.Errorf("%w: bracket matches cannot end in a draw", ErrInvalidBracket)
+	}
+
+	bracket, err := s.bracketRepo.FindBracketByID(bracketID)
+	if err != nil {
+		return nil, err
+	}
+	if bracket.IsCompleted() {
+		return nil, ErrBracketAlreadyDecided
+	}
+
+	var match *models.BracketMatch
+	for i := range bracket.Matches {
+		if bracket.Matches[i].ID == bracketMatchID {
+			match = &bracket.Matches[i]
+			break
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: match %d is not part of bracket %d", ErrInvalidBracket, bracketMatchID, bracketID)
+	}
+	if match.Status != models.BracketMatchReady || match.EntrantAID == nil || match.EntrantBID == nil {
+		return nil, ErrBracketMatchNotReady
+	}
+
+	result := &BracketReportResult{Match: match}
+
+	now := time.Now()
+	winnerID := *match.EntrantAID
+	if scoreB > scoreA {
+		winnerID = *match.EntrantBID
+	}
+	match.ScoreA = &scoreA
+	match.ScoreB = &scoreB
+	match.WinnerEntrantID = &winnerID
+	match.Status = models.BracketMatchCompleted
+	match.ReportedAt = &now
+
+	if err := s.bracketRepo.UpdateBracketMatch(match); err != nil {
+		return nil, err
+	}
+
+	if bracket.RecordRatings {
+		s.rateReportedMatch(bracket, match, scoreA, scoreB, reportedBy, result)
+	}
+
+	completed, err := s.advance(bracket)
+	if err != nil {
+		return nil, err
+	}
+
+	if completed {
+		if err := s.bracketRepo.UpdateBracketStatus(bracket.ID, models.BracketStatusCompleted); err != nil {
+			return nil, err
+		}
+		result.Completed = true
+		log.Printf("BracketService: Bracket %d (%s) completed", bracket.ID, bracket.Name)
+	}
+
+	return result, nil
+}
+
+// seedEntrants rates each entrant and orders them into seeds
+func (s *BracketService) seedEntrants(request models.BracketCreateRequest) ([]models.BracketEntrant, error) {
+	entrants := make([]models.BracketEntrant, 0, len(request.Entrants))
+	for _, entrantRequest := range request.Entrants {
+		ratings, err := s.resolver.Resolve(request.GuildID, entrantRequest.DiscordIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		var skillTotal, muTotal float64
+		userIDs := make([]int64, 0, len(ratings))
+		for _, rating := range ratings {
+			player := models.PlayerEffectiveMMR{TrueSkillMu: rating.Rating.Mu, TrueSkillSigma: rating.Rating.Sigma}
+			skillTotal += player.GetSkillEstimate()
+			muTotal += rating.Rating.Mu
+			if rating.UserID != 0 {
+				userIDs = append(userIDs, rating.UserID)
+			}
+		}
+
+		seedRating := muTotal / float64(len(ratings))
+		if request.Seeding == models.BracketSeedingSkillEstimate {
+			seedRating = skillTotal / float64(len(ratings))
+		}
+
+		name := strings.TrimSpace(entrantRequest.Name)
+		if name == "" {
+			name = strings.Join(entrantRequest.DiscordIDs, ", ")
+		}
+
+		entrants = append(entrants, models.BracketEntrant{
+			Name:       name,
+			SeedRating: roundRating(seedRating),
+			DiscordIDs: entrantRequest.DiscordIDs,
+			UserIDs:    userIDs,
+		})
+	}
+
+	if request.Seeding != models.BracketSeedingManual {
+		sort.SliceStable(entrants, func(i, j int) bool {
+			return entrants[i].SeedRating > entrants[j].SeedRating
+		})
+	}
+	for i := range entrants {
+		entrants[i].Seed = i + 1
+	}
+
+	return entrants, nil
+}
+
+// rateReportedMatch records a reported bracket match as a rated match and links the two
+// The bracket match is already completed, so failures are added to the result instead of returned.
+func (s *BracketService) rateReportedMatch(bracket *models.Bracket, match *models.BracketMatch, scoreA, scoreB int, reportedBy *int64, result *BracketReportResult) {
+	rating, err := s.recordRatedMatch(bracket, match, scoreA, scoreB, reportedBy)
+	if err != nil {
+		log.Printf("BracketService: Failed to rate bracket match %d: %v", match.ID, err)
+		result.Errors = append(result.Errors, err.Error())
+		return
+	}
+	if rating == nil {
+		return
+	}
+
+	result.Rating = rating
+	match.MatchID = &rating.Match.ID
+	if err := s.bracketRepo.UpdateBracketMatch(match); err != nil {
+		log.Printf("BracketService: Failed to link bracket match %d to rated match %d: %v", match.ID, rating.Match.ID, err)
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to link rated match %d: %v", rating.Match.ID, err))
+	}
+}
+
+// recordRatedMatch stores the result as a rated match between the two entrants' players
+// Entrants without registered users cannot be rated; the bracket result is still recorded.
+func (s *BracketService) recordRatedMatch(bracket *models.Bracket, match *models.BracketMatch, scoreA, scoreB int, reportedBy *int64) (*MatchResult, error) {
+	entrantA := bracket.EntrantByID(*match.EntrantAID)
+	entrantB := bracket.EntrantByID(*match.EntrantBID)
+	if entrantA == nil || entrantB == nil || len(entrantA.UserIDs) == 0 || len(entrantB.UserIDs) == 0 {
+		log.Printf("BracketService: Skipping rating for bracket match %d, an entrant has no registered players", match.ID)
+		return nil, nil
+	}
+
+	rating, err := s.recorder.RecordMatch(models.MatchCreateRequest{
+		GuildID:          bracket.GuildID,
+		TeamAUserIDs:     entrantA.UserIDs,
+		TeamBUserIDs:     entrantB.UserIDs,
+		TeamAScore:       scoreA,
+		TeamBScore:       scoreB,
+		ReportedByUserID: reportedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record rated match: %w", err)
+	}
+	return rating, nil
+}
+
+// advance moves the bracket forward after a result and reports whether it is finished
+func (s *BracketService) advance(bracket *models.Bracket) (bool, error) {
+	if bracket.Format == models.BracketFormatSwiss {
+		return s.advanceSwiss(bracket)
+	}
+	return s.advanceElimination(bracket)
+}
+
+// advanceElimination re-resolves the layout and saves every match whose entrants or status changed
+func (s *BracketService) advanceElimination(bracket *models.Bracket) (bool, error) {
+	results := make(map[bracketCoord]int64)
+	stored := make(map[bracketCoord]*models.BracketMatch, len(bracket.Matches))
+	for i := range bracket.Matches {
+		match := &bracket.Matches[i]
+		coord := bracketCoord{match.Side, match.Round, match.Position}
+		stored[coord] = match
+		if match.Status == models.BracketMatchCompleted && match.WinnerEntrantID != nil {
+			results[coord] = *match.WinnerEntrantID
+		}
+	}
+
+	layout := layoutElimination(bracket.Format, bracket.Entrants, results)
+	for _, resolved := range layout {
+		current, ok := stored[bracketCoord{resolved.Side, resolved.Round, resolved.Position}]
+		if !ok {
+			return false, fmt.Errorf("bracket %d is missing match %s round %d position %d",
+				bracket.ID, resolved.Side, resolved.Round, resolved.Position)
+		}
+		if current.Status == resolved.Status &&
+			equalInt64Ptr(current.EntrantAID, resolved.EntrantAID) &&
+			equalInt64Ptr(current.EntrantBID, resolved.EntrantBID) &&
+			equalInt64Ptr(current.WinnerEntrantID, resolved.WinnerEntrantID) {
+			continue
+		}
+
+		current.EntrantAID = resolved.EntrantAID
+		current.EntrantBID = resolved.EntrantBID
+		current.WinnerEntrantID = resolved.WinnerEntrantID
+		current.Status = resolved.Status
+		if err := s.bracketRepo.UpdateBracketMatch(current); err != nil {
+			return false, err
+		}
+	}
+
+	return len(layout) > 0 && layout[len(layout)-1].IsDecided(), nil
+}
+
+// advanceSwiss pairs the next round once every match of the current round is decided
+func (s *BracketService) advanceSwiss(bracket *models.Bracket) (bool, error) {
+	currentRound := 0
+	for _, match := range bracket.Matches {
+		if match.Round > currentRound {
+			currentRound = match.Round
+		}
+	}
+	for _, match := range bracket.Matches {
+		if match.Round == currentRound && !match.IsDecided() {
+			return false, nil
+		}
+	}
+
+	if currentRound >= bracket.SwissRounds {
+		return true, nil
+	}
+
+	next := pairSwissRound(currentRound+1, bracket.Entrants, bracket.Matches)
+	for i := range next {
+		next[i].BracketID = bracket.ID
+	}
+	created, err := s.bracketRepo.CreateBracketMatches(next)
+	if err != nil {
+		return false, err
+	}
+	bracket.Matches = append(bracket.Matches, created...)
+
+	log.Printf("BracketService: Paired Swiss round %d of bracket %d", currentRound+1, bracket.ID)
+	return false, nil
+}
+
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
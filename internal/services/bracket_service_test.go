@@ -0,0 +1,512 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakeBracketStore struct {
+	brackets    map[int64]*models.Bracket
+	nextID      int64
+	updatesFail int
+}
+
+func newFakeBracketStore() *fakeBracketStore {
+	return &fakeBracketStore{brackets: make(map[int64]*models.Bracket)}
+}
+
+func (f *fakeBracketStore) id() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeBracketStore) CreateBracket(bracket *models.Bracket) (*models.Bracket, error) {
+	created := *bracket
+	created.ID = f.id()
+	created.Status = models.BracketStatusInProgress
+	f.brackets[created.ID] = &created
+	result := created
+	return &result, nil
+}
+
+func (f *fakeBracketStore) CreateEntrants(bracketID int64, entrants []models.BracketEntrant) ([]models.BracketEntrant, error) {
+	bracket := f.brackets[bracketID]
+	for _, entrant := range entrants {
+		entrant.ID = f.id()
+		entrant.BracketID = bracketID
+		bracket.Entrants = append(bracket.Entrants, entrant)
+	}
+	return append([]models.BracketEntrant{}, bracket.Entrants...), nil
+}
+
+func (f *fakeBracketStore) CreateBracketMatches(matches []models.BracketMatch) ([]models.BracketMatch, error) {
+	created := make([]models.BracketMatch, 0, len(matches))
+	for _, match := range matches {
+		match.ID = f.id()
+		bracket := f.brackets[match.BracketID]
+		bracket.Matches = append(bracket.Matches, match)
+		created = append(created, match)
+	}
+	return created, nil
+}
+
+func (f *fakeBracketStore) UpdateBracketMatch(match *models.BracketMatch) error {
+	if f.updatesFail > 0 {
+		f.updatesFail--
+		return errors.New("connection reset")
+	}
+	bracket := f.brackets[match.BracketID]
+	for i := range bracket.Matches {
+		if bracket.Matches[i].ID == match.ID {
+			bracket.Matches[i] = *match
+			return nil
+		}
+	}
+	return errors.New("bracket match not found")
+}
+
+func (f *fakeBracketStore) UpdateBracketStatus(bracketID int64, status string) error {
+	f.brackets[bracketID].Status = status
+	return nil
+}
+
+func (f *fakeBracketStore) FindBracketByID(bracketID int64) (*models.Bracket, error) {
+	bracket, ok := f.brackets[bracketID]
+	if !ok {
+		return nil, errors.New("bracket not found")
+	}
+	copied := *bracket
+	copied.Entrants = append([]models.BracketEntrant{}, bracket.Entrants...)
+	copied.Matches = append([]models.BracketMatch{}, bracket.Matches...)
+	return &copied, nil
+}
+
+func (f *fakeBracketStore) GetBracketsByGuild(guildID int64) ([]*models.Bracket, error) {
+	var brackets []*models.Bracket
+	for _, bracket := range f.brackets {
+		if bracket.GuildID == guildID {
+			brackets = append(brackets, bracket)
+		}
+	}
+	return brackets, nil
+}
+
+type fakeRatingResolver struct {
+	ratings map[string]TrueSkillRating
+	userIDs map[string]int64
+}
+
+func (f *fakeRatingResolver) Resolve(guildID int64, discordIDs []string) ([]ResolvedRating, error) {
+	resolved := make([]ResolvedRating, 0, len(discordIDs))
+	for _, discordID := range discordIDs {
+		resolved = append(resolved, ResolvedRating{
+			DiscordID: discordID,
+			UserID:    f.userIDs[discordID],
+			Rating:    f.ratings[discordID],
+			Source:    RatingSourceGuild,
+		})
+	}
+	return resolved, nil
+}
+
+type fakeMatchRecorder struct {
+	requests []models.MatchCreateRequest
+	fail     bool
+}
+
+func (f *fakeMatchRecorder) RecordMatch(request models.MatchCreateRequest) (*MatchResult, error) {
+	if f.fail {
+		return nil, errors.New("rating store unavailable")
+	}
+	f.requests = append(f.requests, request)
+	return &MatchResult{Match: &models.Match{ID: int64(100 + len(f.requests))}}, nil
+}
+
+// newTestBracketService rates player N ("pa", "pb", ...) at mu 1000-10N with sigma 2, so lower N seeds higher
+func newTestBracketService(players int) (*BracketService, *fakeBracketStore, *fakeMatchRecorder) {
+	resolver := &fakeRatingResolver{ratings: make(map[string]TrueSkillRating), userIDs: make(map[string]int64)}
+	for i := 1; i <= players; i++ {
+		discordID := playerID(i)
+		resolver.ratings[discordID] = TrueSkillRating{Mu: 1000 - float64(10*i), Sigma: 2}
+		resolver.userIDs[discordID] = int64(i)
+	}
+
+	store := newFakeBracketStore()
+	recorder := &fakeMatchRecorder{}
+	return &BracketService{
+		bracketRepo: store,
+		resolver:    resolver,
+		recorder:    recorder,
+		config:      &config.Config{},
+	}, store, recorder
+}
+
+func playerID(n int) string {
+	return "p" + string(rune('a'+n-1))
+}
+
+func soloEntrants(players int) []models.BracketEntrantRequest {
+	entrants := make([]models.BracketEntrantRequest, 0, players)
+	for i := players; i >= 1; i-- {
+		entrants = append(entrants, models.BracketEntrantRequest{DiscordIDs: []string{playerID(i)}})
+	}
+	return entrants
+}
+
+// playOut reports every ready match with entrant A winning until the bracket completes
+func playOut(t *testing.T, service *BracketService, store *fakeBracketStore, bracketID int64) int {
+	t.Helper()
+	reported := 0
+	for i := 0; i < 100; i++ {
+		bracket, _ := store.FindBracketByID(bracketID)
+		if bracket.IsCompleted() {
+			return reported
+		}
+
+		var ready *models.BracketMatch
+		for j := range bracket.Matches {
+			if bracket.Matches[j].Status == models.BracketMatchReady {
+				ready = &bracket.Matches[j]
+				break
+			}
+		}
+		if ready == nil {
+			t.Fatalf("bracket %d has no ready match but is not completed", bracketID)
+		}
+		if _, err := service.ReportResult(bracketID, ready.ID, 3, 1, nil); err != nil {
+			t.Fatalf("ReportResult returned error: %v", err)
+		}
+		reported++
+	}
+	t.Fatalf("bracket %d did not complete", bracketID)
+	return reported
+}
+
+func findBracketMatch(bracket *models.Bracket, side string, round, position int) *models.BracketMatch {
+	for i := range bracket.Matches {
+		match := &bracket.Matches[i]
+		if match.Side == side && match.Round == round && match.Position == position {
+			return match
+		}
+	}
+	return nil
+}
+
+func TestSeedOrder(t *testing.T) {
+	if got, want := seedOrder(8), []int{1, 8, 4, 5, 2, 7, 3, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("seedOrder(8) = %v, want %v", got, want)
+	}
+}
+
+func TestCreateBracketSeedsBySkillEstimate(t *testing.T) {
+	service, _, _ := newTestBracketService(2)
+	// pc has the highest mu but is so uncertain that its conservative estimate ranks last
+	service.resolver.(*fakeRatingResolver).ratings["pc"] = TrueSkillRating{Mu: 1100, Sigma: 50}
+
+	bracket, err := service.CreateBracket(models.BracketCreateRequest{
+		GuildID: 1,
+		Name:    "Cup",
+		Format:  models.BracketFormatSingleElimination,
+		Seeding: models.BracketSeedingSkillEstimate,
+		Entrants: []models.BracketEntrantRequest{
+			{DiscordIDs: []string{"pc"}},
+			{DiscordIDs: []string{"pb"}},
+			{DiscordIDs: []string{"pa"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateBracket returned error: %v", err)
+	}
+
+	var order []string
+	for _, entrant := range bracket.Entrants {
+		order = append(order, entrant.Name)
+	}
+	if want := []string{"pa", "pb", "pc"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("seeding = %v, want %v", order, want)
+	}
+	if bracket.Entrants[0].SeedRating != 984 {
+		t.Errorf("top seed rating = %.3f, want 984 (990 - 3*2)", bracket.Entrants[0].SeedRating)
+	}
+}
+
+func TestSingleEliminationAdvancesByes(t *testing.T) {
+	service, store, _ := newTestBracketService(5)
+
+	bracket, err := service.CreateBracket(models.BracketCreateRequest{
+		GuildID:  1,
+		Name:     "Cup",
+		Format:   models.BracketFormatSingleElimination,
+		Seeding:  models.BracketSeedingTeamAverage,
+		Entrants: soloEntrants(5),
+	})
+	if err != nil {
+		t.Fatalf("CreateBracket returned error: %v", err)
+	}
+
+	// 8-slot bracket: seeds 1-3 have byes, seed 4 plays seed 5
+	if len(bracket.Matches) != 7 {
+		t.Fatalf("expected 7 matches, got %d", len(bracket.Matches))
+	}
+	byes, ready := 0, 0
+	for _, match := range bracket.Matches {
+		if match.Round != 1 {
+			continue
+		}
+		switch match.Status {
+		case models.BracketMatchBye:
+			byes++
+		case models.BracketMatchReady:
+			ready++
+		}
+	}
+	if byes != 3 || ready != 1 {
+		t.Errorf("round 1 byes=%d ready=%d, want 3 and 1", byes, ready)
+	}
+
+	topSeed := bracket.Entrants[0].ID
+	semi := findBracketMatch(bracket, models.BracketSideWinners, 2, 0)
+	if semi.EntrantAID == nil || *semi.EntrantAID != topSeed || semi.EntrantBID != nil {
+		t.Errorf("seed 1 should wait in round 2 for the 4/5 winner, got %+v", semi)
+	}
+
+	if reported := playOut(t, service, store, bracket.ID); reported != 4 {
+		t.Errorf("expected 4 played matches, got %d", reported)
+	}
+	final, _ := store.FindBracketByID(bracket.ID)
+	champion := findBracketMatch(final, models.BracketSideWinners, 3, 0)
+	if champion.WinnerEntrantID == nil || *champion.WinnerEntrantID != topSeed {
+		t.Errorf("expected seed 1 to win, got %+v", champion.WinnerEntrantID)
+	}
+}
+
+func TestDoubleEliminationSendsLosersToGrandFinal(t *testing.T) {
+	service, store, _ := newTestBracketService(4)
+
+	bracket, err := service.CreateBracket(models.BracketCreateRequest{
+		GuildID:  1,
+		Name:     "Major",
+		Format:   models.BracketFormatDoubleElimination,
+		Seeding:  models.BracketSeedingTeamAverage,
+		Entrants: soloEntrants(4),
+	})
+	if err != nil {
+		t.Fatalf("CreateBracket returned error: %v", err)
+	}
+
+	// 3 winners matches, 2 losers matches, the grand final and its reset
+	if len(bracket.Matches) != 7 {
+		t.Fatalf("expected 7 matches, got %d", len(bracket.Matches))
+	}
+
+	if reported := playOut(t, service, store, bracket.ID); reported != 6 {
+		t.Errorf("expected 6 played matches, got %d", reported)
+	}
+
+	final, _ := store.FindBracketByID(bracket.ID)
+	grandFinal := findBracketMatch(final, models.BracketSideGrandFinal, 1, 0)
+	loserFinal := findBracketMatch(final, models.BracketSideLosers, 2, 0)
+	if grandFinal.EntrantBID == nil || loserFinal.WinnerEntrantID == nil || *grandFinal.EntrantBID != *loserFinal.WinnerEntrantID {
+		t.Errorf("grand final should host the losers bracket winner, got %+v", grandFinal)
+	}
+	if !final.IsCompleted() {
+		t.Errorf("expected bracket to be completed")
+	}
+	reset := findBracketMatch(final, models.BracketSideGrandFinal, 2, 0)
+	if reset.Status != models.BracketMatchBye || reset.EntrantAID != nil || reset.EntrantBID != nil ||
+		reset.WinnerEntrantID == nil || *reset.WinnerEntrantID != *grandFinal.WinnerEntrantID {
+		t.Errorf("the reset should not be played when the winners bracket entrant wins, got %+v", reset)
+	}
+}
+
+// playToGrandFinal reports every match before the grand final with entrant A winning
+func playToGrandFinal(t *testing.T, service *BracketService, store *fakeBracketStore, bracketID int64) *models.BracketMatch {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		bracket, _ := store.FindBracketByID(bracketID)
+		grandFinal := findBracketMatch(bracket, models.BracketSideGrandFinal, 1, 0)
+		if grandFinal.Status == models.BracketMatchReady {
+			return grandFinal
+		}
+		for j := range bracket.Matches {
+			if bracket.Matches[j].Status == models.BracketMatchReady {
+				if _, err := service.ReportResult(bracketID, bracket.Matches[j].ID, 3, 1, nil); err != nil {
+					t.Fatalf("ReportResult returned error: %v", err)
+				}
+				break
+			}
+		}
+	}
+	t.Fatalf("bracket %d never reached its grand final", bracketID)
+	return nil
+}
+
+func TestDoubleEliminationResetsWhenTheLosersBracketWinsTheGrandFinal(t *testing.T) {
+	service, store, _ := newTestBracketService(4)
+
+	bracket, err := service.CreateBracket(models.BracketCreateRequest{
+		GuildID:  1,
+		Name:     "Major",
+		Format:   models.BracketFormatDoubleElimination,
+		Seeding:  models.BracketSeedingTeamAverage,
+		Entrants: soloEntrants(4),
+	})
+	if err != nil {
+		t.Fatalf("CreateBracket returned error: %v", err)
+	}
+
+	grandFinal := playToGrandFinal(t, service, store, bracket.ID)
+	winnersEntrant, losersEntrant := *grandFinal.EntrantAID, *grandFinal.EntrantBID
+	result, err := service.ReportResult(bracket.ID, grandFinal.ID, 1, 3, nil)
+	if err != nil {
+		t.Fatalf("ReportResult returned error: %v", err)
+	}
+	if result.Completed {
+		t.Fatalf("the bracket should not finish when the losers bracket entrant takes the first grand final")
+	}
+
+	current, _ := store.FindBracketByID(bracket.ID)
+	reset := findBracketMatch(current, models.BracketSideGrandFinal, 2, 0)
+	if reset.Status != models.BracketMatchReady || reset.EntrantAID == nil || reset.EntrantBID == nil ||
+		*reset.EntrantAID != winnersEntrant || *reset.EntrantBID != losersEntrant {
+		t.Fatalf("expected a ready reset between %d and %d, got %+v", winnersEntrant, losersEntrant, reset)
+	}
+
+	result, err = service.ReportResult(bracket.ID, reset.ID, 3, 2, nil)
+	if err != nil {
+		t.Fatalf("ReportResult returned error: %v", err)
+	}
+	final, _ := store.FindBracketByID(bracket.ID)
+	champion := findBracketMatch(final, models.BracketSideGrandFinal, 2, 0)
+	if !result.Completed || !final.IsCompleted() || *champion.WinnerEntrantID != winnersEntrant {
+		t.Errorf("expected the reset to decide the bracket for %d, got %+v", winnersEntrant, champion)
+	}
+}
+
+func TestSwissPairsWinnersWithoutRematches(t *testing.T) {
+	service, store, _ := newTestBracketService(4)
+
+	bracket, err := service.CreateBracket(models.BracketCreateRequest{
+		GuildID:  1,
+		Name:     "Swiss",
+		Format:   models.BracketFormatSwiss,
+		Seeding:  models.BracketSeedingTeamAverage,
+		Entrants: soloEntrants(4),
+	})
+	if err != nil {
+		t.Fatalf("CreateBracket returned error: %v", err)
+	}
+	if bracket.SwissRounds != 2 {
+		t.Fatalf("expected 2 default rounds for 4 entrants, got %d", bracket.SwissRounds)
+	}
+
+	// Round 1 is 1v3 and 2v4; entrant A wins both
+	for _, match := range bracket.Matches {
+		if _, err := service.ReportResult(bracket.ID, match.ID, 2, 0, nil); err != nil {
+			t.Fatalf("ReportResult returned error: %v", err)
+		}
+	}
+
+	current, _ := store.FindBracketByID(bracket.ID)
+	winnersMatch := findBracketMatch(current, models.BracketSideSwiss, 2, 0)
+	if winnersMatch == nil {
+		t.Fatalf("expected round 2 to be paired")
+	}
+	seeds := map[int64]int{}
+	for _, entrant := range current.Entrants {
+		seeds[entrant.ID] = entrant.Seed
+	}
+	if seeds[*winnersMatch.EntrantAID] != 1 || seeds[*winnersMatch.EntrantBID] != 2 {
+		t.Errorf("round 2 should pair the two 1-0 entrants, got seeds %d and %d",
+			seeds[*winnersMatch.EntrantAID], seeds[*winnersMatch.EntrantBID])
+	}
+
+	playOut(t, service, store, bracket.ID)
+	final, _ := store.FindBracketByID(bracket.ID)
+	standings := service.SwissStandings(final)
+	if standings[0].Entrant.Seed != 1 || standings[0].Wins != 2 {
+		t.Errorf("expected seed 1 to top the standings at 2-0, got %+v", standings[0])
+	}
+}
+
+func TestReportResultRecordsRatedMatch(t *testing.T) {
+	service, store, recorder := newTestBracketService(2)
+
+	bracket, err := service.CreateBracket(models.BracketCreateRequest{
+		GuildID:       1,
+		Name:          "Showmatch",
+		Format:        models.BracketFormatSingleElimination,
+		Seeding:       models.BracketSeedingManual,
+		RecordRatings: true,
+		Entrants:      soloEntrants(2),
+	})
+	if err != nil {
+		t.Fatalf("CreateBracket returned error: %v", err)
+	}
+	match := bracket.Matches[0]
+
+	if _, err := service.ReportResult(bracket.ID, match.ID, 2, 2, nil); !errors.Is(err, ErrInvalidBracket) {
+		t.Errorf("expected draw to be rejected, got %v", err)
+	}
+
+	result, err := service.ReportResult(bracket.ID, match.ID, 1, 3, nil)
+	if err != nil {
+		t.Fatalf("ReportResult returned error: %v", err)
+	}
+	if !result.Completed {
+		t.Errorf("expected the only match to complete the bracket")
+	}
+	if len(recorder.requests) != 1 || recorder.requests[0].TeamAUserIDs[0] != 2 {
+		t.Errorf("expected one rated match with manual seed 1 (user 2) as team A, got %+v", recorder.requests)
+	}
+
+	stored, _ := store.FindBracketByID(bracket.ID)
+	if stored.Matches[0].MatchID == nil || *stored.Matches[0].MatchID != 101 {
+		t.Errorf("expected bracket match to link rated match 101, got %v", stored.Matches[0].MatchID)
+	}
+	if _, err := service.ReportResult(bracket.ID, match.ID, 3, 0, nil); !errors.Is(err, ErrBracketAlreadyDecided) {
+		t.Errorf("expected completed bracket to reject results, got %v", err)
+	}
+}
+
+func TestReportResultWritesTheBracketBeforeRating(t *testing.T) {
+	service, store, recorder := newTestBracketService(2)
+
+	bracket, err := service.CreateBracket(models.BracketCreateRequest{
+		GuildID:       1,
+		Name:          "Showmatch",
+		Format:        models.BracketFormatSingleElimination,
+		Seeding:       models.BracketSeedingManual,
+		RecordRatings: true,
+		Entrants:      soloEntrants(2),
+	})
+	if err != nil {
+		t.Fatalf("CreateBracket returned error: %v", err)
+	}
+	match := bracket.Matches[0]
+
+	store.updatesFail = 1
+	if _, err := service.ReportResult(bracket.ID, match.ID, 1, 3, nil); err == nil {
+		t.Fatal("expected the failed bracket write to be reported")
+	}
+	if len(recorder.requests) != 0 {
+		t.Fatalf("a failed bracket write must not rate the match, got %+v", recorder.requests)
+	}
+
+	recorder.fail = true
+	result, err := service.ReportResult(bracket.ID, match.ID, 1, 3, nil)
+	if err != nil {
+		t.Fatalf("retrying the report should succeed, got %v", err)
+	}
+	if !result.Completed || len(result.Errors) != 1 {
+		t.Errorf("expected the bracket to complete with the rating failure reported, got %+v", result)
+	}
+
+	stored, _ := store.FindBracketByID(bracket.ID)
+	if stored.Matches[0].Status != models.BracketMatchCompleted || stored.Matches[0].MatchID != nil {
+		t.Errorf("expected a completed, unrated bracket match, got %+v", stored.Matches[0])
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"time"
 	"usl-server/internal/models"
@@ -39,6 +40,29 @@ type TrackerData struct {
 	ThreesCurrentGames  int       `json:"threesCurrentGames"`
 	ThreesPreviousGames int       `json:"threesPreviousGames"`
 	LastUpdated         time.Time `json:"lastUpdated"`
+	// Set when built from a player's trackers: the aggregation used and the tracker
+	// URLs each playlist's numbers came from, keyed ones/twos/threes
+	Aggregation string              `json:"aggregation,omitempty"`
+	Sources     map[string][]string `json:"sources,omitempty"`
+}
+
+// Tracker aggregation strategies for players with more than one tracker
+const (
+	TrackerAggregationMax     = "max"     // best account per playlist
+	TrackerAggregationPool    = "pool"    // games-weighted MMR across accounts
+	TrackerAggregationPrimary = "primary" // the tracker flagged as the player's main account
+)
+
+// trackerPlaylists lists the playlist keys used in TrackerData sources
+var trackerPlaylists = []string{"ones", "twos", "threes"}
+
+// trackerPlaylist is one playlist's columns of a tracker
+type trackerPlaylist struct {
+	CurrentPeak   int
+	PreviousPeak  int
+	AllTimePeak   int
+	CurrentGames  int
+	PreviousGames int
 }
 
 // NewDataTransformationService creates a new data transformation service
@@ -127,6 +151,232 @@ func (s *DataTransformationService) PrepareTrackerDataForCalculation(trackerObje
 	}, nil
 }
 
+// AggregateTrackers combines all of a player's valid trackers into one set of numbers
+// max takes each playlist from the account with the highest games-weighted MMR, pool sums
+// games and averages MMR by games played, and primary uses the flagged tracker, falling back
+// to max when none is flagged. An empty strategy means max.
+func (s *DataTransformationService) AggregateTrackers(trackers []*models.UserTracker, strategy string) (*TrackerData, error) {
+	if len(trackers) == 0 {
+		return nil, fmt.Errorf("at least one tracker is required")
+	}
+
+	switch strategy {
+	case "", TrackerAggregationMax, TrackerAggregationPool, TrackerAggregationPrimary:
+	default:
+		return nil, fmt.Errorf("unknown tracker aggregation %q: expected %s, %s or %s",
+			strategy, TrackerAggregationMax, TrackerAggregationPool, TrackerAggregationPrimary)
+	}
+
+	if strategy == TrackerAggregationPrimary {
+		if primary := s.primaryTracker(trackers); primary != nil {
+			data, err := s.PrepareTrackerDataForCalculation(primary)
+			if err != nil {
+				return nil, err
+			}
+			data.Aggregation = TrackerAggregationPrimary
+			data.Sources = make(map[string][]string, len(trackerPlaylists))
+			for _, playlist := range trackerPlaylists {
+				if s.trackerPlaylist(primary, playlist).hasData() {
+					data.Sources[playlist] = []string{primary.URL}
+				}
+			}
+			return data, nil
+		}
+		strategy = TrackerAggregationMax
+	}
+	if strategy == "" {
+		strategy = TrackerAggregationMax
+	}
+
+	account := trackers[0]
+	if primary := s.primaryTracker(trackers); primary != nil {
+		account = primary
+	}
+	data := &TrackerData{
+		DiscordID:   account.DiscordID,
+		URL:         account.URL,
+		Aggregation: strategy,
+		Sources:     make(map[string][]string, len(trackerPlaylists)),
+	}
+	for _, tracker := range trackers {
+		if tracker.LastUpdated.After(data.LastUpdated) {
+			data.LastUpdated = tracker.LastUpdated
+		}
+	}
+
+	for _, playlist := range trackerPlaylists {
+		var combined trackerPlaylist
+		var sources []string
+		if strategy == TrackerAggregationPool {
+			combined, sources = s.poolPlaylist(trackers, playlist)
+		} else {
+			combined, sources = s.maxPlaylist(trackers, playlist)
+		}
+		data.setPlaylist(playlist, combined)
+		if len(sources) > 0 {
+			data.Sources[playlist] = sources
+		}
+	}
+
+	return data, nil
+}
+
+// primaryTracker returns the tracker flagged as primary, the most recently updated if several are
+func (s *DataTransformationService) primaryTracker(trackers []*models.UserTracker) *models.UserTracker {
+	var primary *models.UserTracker
+	for _, tracker := range trackers {
+		if tracker.IsPrimary && (primary == nil || tracker.LastUpdated.After(primary.LastUpdated)) {
+			primary = tracker
+		}
+	}
+	return primary
+}
+
+// maxPlaylist picks the account with the best games-weighted MMR in a playlist
+// Ties go to the account with more games, then the higher current peak.
+func (s *DataTransformationService) maxPlaylist(trackers []*models.UserTracker, playlist string) (trackerPlaylist, []string) {
+	var best trackerPlaylist
+	var bestURL string
+	for _, tracker := range trackers {
+		candidate := s.trackerPlaylist(tracker, playlist)
+		if !candidate.hasData() {
+			continue
+		}
+		if bestURL == "" || candidate.betterThan(best) {
+			best = candidate
+			bestURL = tracker.URL
+		}
+	}
+	if bestURL == "" {
+		return trackerPlaylist{}, nil
+	}
+	return best, []string{bestURL}
+}
+
+// poolPlaylist sums a playlist's games across accounts and averages each season's MMR by games played
+// A season nobody played keeps the highest peak so an unplayed alt does not drag it to zero.
+func (s *DataTransformationService) poolPlaylist(trackers []*models.UserTracker, playlist string) (trackerPlaylist, []string) {
+	var pooled trackerPlaylist
+	var sources []string
+	var currentWeighted, previousWeighted int
+	var currentMax, previousMax int
+
+	for _, tracker := range trackers {
+		data := s.trackerPlaylist(tracker, playlist)
+		if !data.hasData() {
+			continue
+		}
+		sources = append(sources, tracker.URL)
+
+		pooled.CurrentGames += data.CurrentGames
+		pooled.PreviousGames += data.PreviousGames
+		currentWeighted += data.CurrentPeak * data.CurrentGames
+		previousWeighted += data.PreviousPeak * data.PreviousGames
+		if data.CurrentPeak > currentMax {
+			currentMax = data.CurrentPeak
+		}
+		if data.PreviousPeak > previousMax {
+			previousMax = data.PreviousPeak
+		}
+		if data.AllTimePeak > pooled.AllTimePeak {
+			pooled.AllTimePeak = data.AllTimePeak
+		}
+	}
+
+	pooled.CurrentPeak = currentMax
+	if pooled.CurrentGames > 0 {
+		pooled.CurrentPeak = int(math.Round(float64(currentWeighted) / float64(pooled.CurrentGames)))
+	}
+	pooled.PreviousPeak = previousMax
+	if pooled.PreviousGames > 0 {
+		pooled.PreviousPeak = int(math.Round(float64(previousWeighted) / float64(pooled.PreviousGames)))
+	}
+
+	return pooled, sources
+}
+
+// trackerPlaylist reads one playlist's columns from a tracker
+func (s *DataTransformationService) trackerPlaylist(tracker *models.UserTracker, playlist string) trackerPlaylist {
+	switch playlist {
+	case "ones":
+		return trackerPlaylist{tracker.OnesCurrentSeasonPeak, tracker.OnesPreviousSeasonPeak, tracker.OnesAllTimePeak, tracker.OnesCurrentSeasonGames, tracker.OnesPreviousSeasonGames}
+	case "twos":
+		return trackerPlaylist{tracker.TwosCurrentSeasonPeak, tracker.TwosPreviousSeasonPeak, tracker.TwosAllTimePeak, tracker.TwosCurrentSeasonGames, tracker.TwosPreviousSeasonGames}
+	case "threes":
+		return trackerPlaylist{tracker.ThreesCurrentSeasonPeak, tracker.ThreesPreviousSeasonPeak, tracker.ThreesAllTimePeak, tracker.ThreesCurrentSeasonGames, tracker.ThreesPreviousSeasonGames}
+	}
+	return trackerPlaylist{}
+}
+
+// playlist reads one playlist's columns from the tracker data
+func (d *TrackerData) playlist(playlist string) trackerPlaylist {
+	switch playlist {
+	case "ones":
+		return trackerPlaylist{d.OnesCurrentPeak, d.OnesPreviousPeak, d.OnesAllTimePeak, d.OnesCurrentGames, d.OnesPreviousGames}
+	case "twos":
+		return trackerPlaylist{d.TwosCurrentPeak, d.TwosPreviousPeak, d.TwosAllTimePeak, d.TwosCurrentGames, d.TwosPreviousGames}
+	case "threes":
+		return trackerPlaylist{d.ThreesCurrentPeak, d.ThreesPreviousPeak, d.ThreesAllTimePeak, d.ThreesCurrentGames, d.ThreesPreviousGames}
+	}
+	return trackerPlaylist{}
+}
+
+// onlyPlaylist returns a copy of the tracker data with every other playlist left empty,
+// so seeding it rates the player on that playlist alone
+func (d *TrackerData) onlyPlaylist(playlist string) *TrackerData {
+	single := &TrackerData{
+		DiscordID:   d.DiscordID,
+		URL:         d.URL,
+		LastUpdated: d.LastUpdated,
+		Aggregation: d.Aggregation,
+	}
+	single.setPlaylist(playlist, d.playlist(playlist))
+	if sources, ok := d.Sources[playlist]; ok {
+		single.Sources = map[string][]string{playlist: sources}
+	}
+	return single
+}
+
+// setPlaylist writes one playlist's columns into the tracker data
+func (d *TrackerData) setPlaylist(playlist string, data trackerPlaylist) {
+	switch playlist {
+	case "ones":
+		d.OnesCurrentPeak, d.OnesPreviousPeak, d.OnesAllTimePeak = data.CurrentPeak, data.PreviousPeak, data.AllTimePeak
+		d.OnesCurrentGames, d.OnesPreviousGames = data.CurrentGames, data.PreviousGames
+	case "twos":
+		d.TwosCurrentPeak, d.TwosPreviousPeak, d.TwosAllTimePeak = data.CurrentPeak, data.PreviousPeak, data.AllTimePeak
+		d.TwosCurrentGames, d.TwosPreviousGames = data.CurrentGames, data.PreviousGames
+	case "threes":
+		d.ThreesCurrentPeak, d.ThreesPreviousPeak, d.ThreesAllTimePeak = data.CurrentPeak, data.PreviousPeak, data.AllTimePeak
+		d.ThreesCurrentGames, d.ThreesPreviousGames = data.CurrentGames, data.PreviousGames
+	}
+}
+
+// hasData reports whether the account has any games or rank in the playlist
+func (p trackerPlaylist) hasData() bool {
+	return p.CurrentGames+p.PreviousGames > 0 || p.CurrentPeak > 0 || p.PreviousPeak > 0
+}
+
+// effectiveMMR is the games-weighted MMR the calculator uses for the playlist
+func (p trackerPlaylist) effectiveMMR() float64 {
+	games := p.CurrentGames + p.PreviousGames
+	if games == 0 {
+		return 0
+	}
+	return float64(p.CurrentPeak*p.CurrentGames+p.PreviousPeak*p.PreviousGames) / float64(games)
+}
+
+// betterThan orders accounts for the max aggregation
+func (p trackerPlaylist) betterThan(other trackerPlaylist) bool {
+	if p.effectiveMMR() != other.effectiveMMR() {
+		return p.effectiveMMR() > other.effectiveMMR()
+	}
+	if games, otherGames := p.CurrentGames+p.PreviousGames, other.CurrentGames+other.PreviousGames; games != otherGames {
+		return games > otherGames
+	}
+	return p.CurrentPeak > other.CurrentPeak
+}
+
 // TransformRowDataToUser transforms raw row data to structured user object
 // Exact port of JavaScript transformRowDataToUser() function
 func (s *DataTransformationService) TransformRowDataToUser(rowData []interface{}) (*models.User, error) {
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+func altAccountTrackers() []*models.UserTracker {
+	now := time.Now()
+	return []*models.UserTracker{
+		{
+			DiscordID: playerID(1), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/main/overview",
+			TwosCurrentSeasonPeak: 1400, TwosCurrentSeasonGames: 100,
+			ThreesCurrentSeasonPeak: 1100, ThreesCurrentSeasonGames: 300,
+			LastUpdated: now.AddDate(0, 0, -3),
+		},
+		{
+			DiscordID: playerID(1), URL: "https://rocketleague.tracker.network/rocket-league/profile/steam/alt/overview",
+			TwosCurrentSeasonPeak: 1200, TwosCurrentSeasonGames: 300,
+			ThreesCurrentSeasonPeak: 1300, ThreesCurrentSeasonGames: 100,
+			LastUpdated: now.AddDate(0, 0, -1),
+			IsPrimary:   true,
+		},
+	}
+}
+
+func TestAggregateTrackersStrategies(t *testing.T) {
+	service := NewDataTransformationService()
+	trackers := altAccountTrackers()
+	epic, steam := trackers[0].URL, trackers[1].URL
+
+	max, err := service.AggregateTrackers(trackers, TrackerAggregationMax)
+	if err != nil {
+		t.Fatalf("max aggregation failed: %v", err)
+	}
+	if max.TwosCurrentPeak != 1400 || max.TwosCurrentGames != 100 || max.ThreesCurrentPeak != 1300 {
+		t.Errorf("max should take twos from epic and threes from steam, got %+v", max)
+	}
+	if max.Sources["twos"][0] != epic || max.Sources["threes"][0] != steam {
+		t.Errorf("unexpected max sources %v", max.Sources)
+	}
+	if _, ok := max.Sources["ones"]; ok {
+		t.Errorf("a playlist nobody played should have no source, got %v", max.Sources["ones"])
+	}
+	if !max.LastUpdated.Equal(trackers[1].LastUpdated) {
+		t.Errorf("expected the latest tracker update, got %v", max.LastUpdated)
+	}
+
+	pooled, err := service.AggregateTrackers(trackers, TrackerAggregationPool)
+	if err != nil {
+		t.Fatalf("pool aggregation failed: %v", err)
+	}
+	if pooled.TwosCurrentGames != 400 || pooled.TwosCurrentPeak != 1250 {
+		t.Errorf("expected 400 twos games at 1250, got %d at %d", pooled.TwosCurrentGames, pooled.TwosCurrentPeak)
+	}
+	if len(pooled.Sources["twos"]) != 2 {
+		t.Errorf("both accounts should contribute to pooled twos, got %v", pooled.Sources["twos"])
+	}
+
+	primary, err := service.AggregateTrackers(trackers, TrackerAggregationPrimary)
+	if err != nil {
+		t.Fatalf("primary aggregation failed: %v", err)
+	}
+	if primary.URL != steam || primary.TwosCurrentPeak != 1200 || primary.Aggregation != TrackerAggregationPrimary {
+		t.Errorf("primary should use the flagged steam tracker, got %+v", primary)
+	}
+
+	trackers[1].IsPrimary = false
+	fallback, err := service.AggregateTrackers(trackers, TrackerAggregationPrimary)
+	if err != nil {
+		t.Fatalf("primary fallback failed: %v", err)
+	}
+	if fallback.Aggregation != TrackerAggregationMax || fallback.TwosCurrentPeak != 1400 {
+		t.Errorf("without a flagged tracker primary should fall back to max, got %+v", fallback)
+	}
+
+	if _, err := service.AggregateTrackers(trackers, "average"); err == nil {
+		t.Error("expected an unknown strategy to be rejected")
+	}
+}
+
+func TestSeedBreakdownShowsContributingAccounts(t *testing.T) {
+	service := NewDataTransformationService()
+	trackers := altAccountTrackers()
+
+	data, err := service.AggregateTrackers(trackers, TrackerAggregationMax)
+	if err != nil {
+		t.Fatalf("aggregation failed: %v", err)
+	}
+
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333, GamesForMaxCertainty: 1000},
+		MMR:       config.MMRConfig{OnesWeight: 1.0, TwosWeight: 1.5, ThreesWeight: 1.2, MinGamesThreshold: 10},
+	}
+	seeder := &trackerSeeder{
+		mmrCalculator:         NewMMRCalculator(cfg, NewPercentileConverter(cfg)),
+		uncertaintyCalculator: NewEnhancedUncertaintyCalculator(cfg, NewPercentileConverter(cfg)),
+	}
+	seed, err := seeder.Seed(data)
+	if err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+
+	breakdown := seed.SkillResult.Breakdown
+	if got := breakdown["twos"].Sources; len(got) != 1 || got[0] != trackers[0].URL {
+		t.Errorf("twos breakdown should credit the epic account, got %v", got)
+	}
+	if got := breakdown["threes"].Sources; len(got) != 1 || got[0] != trackers[1].URL {
+		t.Errorf("threes breakdown should credit the steam account, got %v", got)
+	}
+	if seed.SkillResult.AggregationInfo.TrackerAggregation != TrackerAggregationMax {
+		t.Errorf("expected the aggregation to be recorded, got %q", seed.SkillResult.AggregationInfo.TrackerAggregation)
+	}
+}
+
+func TestConfigAcceptsEveryTrackerAggregation(t *testing.T) {
+	for _, aggregation := range []string{TrackerAggregationMax, TrackerAggregationPool, TrackerAggregationPrimary} {
+		if err := (config.MMRConfig{TrackerAggregation: aggregation, SeasonPooling: SeasonPoolingGames}).Validate(); err != nil {
+			t.Errorf("config should accept tracker aggregation %q: %v", aggregation, err)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidDecayPolicy is returned when a guild's decay settings are out of range
+var ErrInvalidDecayPolicy = errors.New("invalid decay policy")
+
+// minDecayChange is the smallest mu or sigma movement worth a history row
+const minDecayChange = 0.001
+
+// DecayRatingStore interface for reading a guild's ratings and writing decayed ones with history
+// RecordRatingDecay writes the rating, its history row and the playlist ratings together, or none of them
+type DecayRatingStore interface {
+	GuildRatingStore
+	GuildPlaylistRatingSource
+	GetUserHistory(userID, guildID int64, limit int) ([]*models.PlayerHistoricalMMR, error)
+	RecordRatingDecay(effective *models.PlayerEffectiveMMR, history models.PlayerHistoricalMMRCreateRequest, playlistRatings []*models.PlayerPlaylistRating) error
+}
+
+// DecayGuildStore interface for the guilds the decay job visits and their decay settings
+type DecayGuildStore interface {
+	GuildConfigStore
+	GetAllGuilds(activeOnly bool) ([]*models.Guild, error)
+}
+
+// DecayUserStore interface for matching rating rows to Discord IDs
+type DecayUserStore interface {
+	GetAllUsers(activeOnly bool) ([]*models.User, error)
+}
+
+// DecayTrackerSource interface for tracker refreshes, which count as activity
+type DecayTrackerSource interface {
+	GetAllTrackers(validOnly bool) ([]*models.UserTracker, error)
+}
+
+// DecayAdjustment describes how one inactive player's rating moved
+type DecayAdjustment struct {
+	UserID       int64     `json:"user_id"`
+	GuildID      int64     `json:"guild_id"`
+	LastActive   time.Time `json:"last_active"`
+	DaysDecayed  float64   `json:"days_decayed"`
+	MuBefore     float64   `json:"mu_before"`
+	MuAfter      float64   `json:"mu_after"`
+	SigmaBefore  float64   `json:"sigma_before"`
+	SigmaAfter   float64   `json:"sigma_after"`
+	InactiveDays int       `json:"inactive_days"`
+
+	Playlists []PlaylistRatingAdjustment `json:"playlists,omitempty"` // the player's playlist ratings, decayed for the same days
+}
+
+// DecayRunResult summarizes one run of the decay job
+type DecayRunResult struct {
+	RanAt       time.Time         `json:"ran_at"`
+	Guilds      int               `json:"guilds"`
+	Checked     int               `json:"checked"`
+	Adjustments []DecayAdjustment `json:"adjustments"`
+	Errors      []string          `json:"errors,omitempty"`
+}
+
+// DecayService widens the ratings of players who have stopped playing.
+// Service Responsibilities:
+// - Reading each guild's decay policy from its config
+// - Finding players with no match or tracker refresh within the policy's grace period
+// - Growing sigma toward TrueSkill.SigmaMax and optionally pulling mu toward a floor
+// - Writing each adjustment to player_historical_mmr as inactivity_decay
+// - Decaying the player's per-playlist ratings for the same days as their combined rating
+// - Running on a fixed interval in the background
+type DecayService struct {
+	ratingRepo  DecayRatingStore
+	guildRepo   DecayGuildStore
+	userRepo    DecayUserStore
+	trackerRepo DecayTrackerSource
+	config      *config.Config
+
+	running sync.Mutex // one run at a time, whether scheduled or triggered by an admin
+	stop    chan struct{}
+}
+
+func NewDecayService(
+	ratingRepo *repositories.PlayerMMRRepository,
+	guildRepo *repositories.GuildRepository,
+	userRepo *repositories.UserRepository,
+	trackerRepo *repositories.TrackerRepository,
+	config *config.Config,
+) *DecayService {
+	return &DecayService{
+		ratingRepo:  ratingRepo,
+		guildRepo:   guildRepo,
+		userRepo:    userRepo,
+		trackerRepo: trackerRepo,
+		config:      config,
+	}
+}
+
+// GetDecayConfig returns the guild's decay policy with defaults applied
+func (s *DecayService) GetDecayConfig(guildID int64) (models.DecayConfig, error) {
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return models.DecayConfig{}, err
+	}
+
+	decay := guildConfig.Decay
+	if err := decay.Validate(); err != nil {
+		return decay, fmt.Errorf("%w: %v", ErrInvalidDecayPolicy, err)
+	}
+	return decay, nil
+}
+
+// UpdateDecayConfig saves the guild's decay policy
+func (s *DecayService) UpdateDecayConfig(guildID int64, decay models.DecayConfig) error {
+	if err := decay.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDecayPolicy, err)
+	}
+
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	guildConfig.Decay = decay
+	return s.guildRepo.UpdateConfig(guildID, guildConfig)
+}
+
+// Start runs the decay job every interval until Stop is called
+func (s *DecayService) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.RunAll(time.Now()); err != nil {
+					log.Printf("DecayService: Scheduled run failed: %v", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}(s.stop)
+
+	log.Printf("DecayService: Decay job scheduled every %s", interval)
+}
+
+// Stop ends the background job started by Start
+func (s *DecayService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// RunAll applies decay in every active guild that has it enabled
+func (s *DecayService) RunAll(now time.Time) (*DecayRunResult, error) {
+	guilds, err := s.guildRepo.GetAllGuilds(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load guilds: %w", err)
+	}
+
+	activity, err := s.loadTrackerActivity()
+	if err != nil {
+		return nil, err
+	}
+
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	result := &DecayRunResult{RanAt: now, Adjustments: []DecayAdjustment{}}
+	for _, guild := range guilds {
+		policy := guild.Config.Decay
+		if err := policy.Validate(); err != nil {
+			log.Printf("DecayService: Skipping guild %d with %v", guild.ID, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("guild %d: %v", guild.ID, err))
+			continue
+		}
+		if !policy.Enabled {
+			continue
+		}
+
+		result.Guilds++
+		s.decayGuild(guild.ID, policy, activity, now, result)
+	}
+
+	log.Printf("DecayService: Checked %d ratings in %d guilds, decayed %d (%d errors)",
+		result.Checked, result.Guilds, len(result.Adjustments), len(result.Errors))
+	return result, nil
+}
+
+// RunGuild applies the guild's decay policy now, even when the schedule has it disabled
+func (s *DecayService) RunGuild(guildID int64, now time.Time) (*DecayRunResult, error) {
+	policy, err := s.GetDecayConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.loadTrackerActivity()
+	if err != nil {
+		return nil, err
+	}
+
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	result := &DecayRunResult{RanAt: now, Guilds: 1, Adjustments: []DecayAdjustment{}}
+	s.decayGuild(guildID, policy, activity, now, result)
+	return result, nil
+}
+
+// ApplyDecay returns the rating after days of decay under the policy
+// Sigma grows in variance so repeated short runs add up to one long one.
+func (s *DecayService) ApplyDecay(rating TrueSkillRating, days float64, policy models.DecayConfig) TrueSkillRating {
+	if days <= 0 {
+		return rating
+	}
+
+	result := rating
+	sigmaMax := s.config.TrueSkill.SigmaMax
+	if rating.Sigma < sigmaMax {
+		result.Sigma = math.Min(sigmaMax, math.Sqrt(rating.Sigma*rating.Sigma+days*policy.SigmaGrowthPerDay*policy.SigmaGrowthPerDay))
+	}
+
+	floor := policy.MuFloor
+	if floor == 0 {
+		floor = s.config.TrueSkill.InitialMu
+	}
+	if policy.MuDecayPerDay > 0 && rating.Mu > floor {
+		result.Mu = floor + (rating.Mu-floor)*math.Pow(1-policy.MuDecayPerDay, days)
+	}
+
+	return TrueSkillRating{Mu: roundRating(result.Mu), Sigma: roundRating(result.Sigma)}
+}
+
+// decayGuild checks every rating in a guild, recording adjustments and errors in result
+func (s *DecayService) decayGuild(guildID int64, policy models.DecayConfig, activity map[int64]time.Time, now time.Time, result *DecayRunResult) {
+	ratings, err := s.ratingRepo.GetGuildEffectiveMMRs(guildID)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("guild %d: %v", guildID, err))
+		return
+	}
+	playlistRatings, err := loadGuildPlaylistRatings(s.ratingRepo, guildID)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("guild %d: %v", guildID, err))
+		return
+	}
+
+	for _, current := range ratings {
+		result.Checked++
+		if now.Before(current.LastUpdated.AddDate(0, 0, policy.InactiveDays)) {
+			continue
+		}
+
+		lastActive := current.LastUpdated
+		if refreshed, ok := activity[current.UserID]; ok && refreshed.After(lastActive) {
+			lastActive = refreshed
+		}
+
+		from := lastActive.AddDate(0, 0, policy.InactiveDays)
+		if !now.After(from) {
+			continue
+		}
+
+		lastChange, err := s.lastRatingChange(current)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		if lastChange.After(from) {
+			from = lastChange
+		}
+
+		days := now.Sub(from).Hours() / 24
+		updated := s.ApplyDecay(TrueSkillRating{Mu: current.TrueSkillMu, Sigma: current.TrueSkillSigma}, days, policy)
+		if math.Abs(updated.Mu-current.TrueSkillMu) < minDecayChange && math.Abs(updated.Sigma-current.TrueSkillSigma) < minDecayChange {
+			continue
+		}
+
+		adjustment := DecayAdjustment{
+			UserID:       current.UserID,
+			GuildID:      guildID,
+			LastActive:   lastActive,
+			DaysDecayed:  roundRating(days),
+			MuBefore:     current.TrueSkillMu,
+			MuAfter:      updated.Mu,
+			SigmaBefore:  current.TrueSkillSigma,
+			SigmaAfter:   updated.Sigma,
+			InactiveDays: int(now.Sub(lastActive).Hours() / 24),
+		}
+		// Playlist ratings only move with a match, which also moves the combined rating, so the
+		// combined rating's decay period covers them too
+		playlistAdjustments, decayedPlaylists := adjustPlaylistRatings(playlistRatings[current.UserID], func(rating TrueSkillRating) TrueSkillRating {
+			return s.ApplyDecay(rating, days, policy)
+		}, minDecayChange)
+		adjustment.Playlists = playlistAdjustments
+
+		if err := s.saveDecay(current, adjustment, decayedPlaylists); err != nil {
+			log.Printf("DecayService: Failed to decay rating for user %d in guild %d: %v", current.UserID, guildID, err)
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		result.Adjustments = append(result.Adjustments, adjustment)
+	}
+}
+
+// lastRatingChange returns when the player's rating was last written, or the zero time without history
+// Decay resumes from there, so an earlier decay, reset or adjustment is never decayed twice.
+func (s *DecayService) lastRatingChange(current *models.PlayerEffectiveMMR) (time.Time, error) {
+	history, err := s.ratingRepo.GetUserHistory(current.UserID, current.GuildID, 1)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load history for user %d: %w", current.UserID, err)
+	}
+	if len(history) == 0 {
+		return time.Time{}, nil
+	}
+	return history[0].CreatedAt, nil
+}
+
+// saveDecay writes the decayed rating, its audit row and the decayed playlist ratings in one
+// transaction, so a failure leaves none of them and the next run decays the same period exactly once
+// LastUpdated is kept so decay does not count as recent activity
+func (s *DecayService) saveDecay(current *models.PlayerEffectiveMMR, adjustment DecayAdjustment, playlistRatings []*models.PlayerPlaylistRating) error {
+	updated := *current
+	updated.TrueSkillMu = adjustment.MuAfter
+	updated.TrueSkillSigma = adjustment.SigmaAfter
+
+	mmrBefore := current.MMR
+	muBefore := adjustment.MuBefore
+	sigmaBefore := adjustment.SigmaBefore
+
+	return s.ratingRepo.RecordRatingDecay(&updated, models.PlayerHistoricalMMRCreateRequest{
+		UserID:               current.UserID,
+		GuildID:              current.GuildID,
+		MMRBefore:            &mmrBefore,
+		MMRAfter:             updated.MMR,
+		TrueSkillMuBefore:    &muBefore,
+		TrueSkillMuAfter:     adjustment.MuAfter,
+		TrueSkillSigmaBefore: &sigmaBefore,
+		TrueSkillSigmaAfter:  adjustment.SigmaAfter,
+		ChangeReason:         models.ChangeReasonInactivityDecay,
+	}, playlistRatings)
+}
+
+// loadTrackerActivity returns the latest tracker refresh of each user by user ID
+func (s *DecayService) loadTrackerActivity() (map[int64]time.Time, error) {
+	users, err := s.userRepo.GetAllUsers(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+	trackers, err := s.trackerRepo.GetAllTrackers(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trackers: %w", err)
+	}
+
+	refreshed := make(map[string]time.Time, len(trackers))
+	for _, tracker := range trackers {
+		if tracker.LastUpdated.After(refreshed[tracker.DiscordID]) {
+			refreshed[tracker.DiscordID] = tracker.LastUpdated
+		}
+	}
+
+	activity := make(map[int64]time.Time, len(users))
+	for _, user := range users {
+		if last, ok := refreshed[user.DiscordID]; ok {
+			activity[int64(user.ID)] = last
+		}
+	}
+	return activity, nil
+}
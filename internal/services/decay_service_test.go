@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+// fakeDecayRatings keeps the latest history row per user so the decay clock can resume from it
+// RecordRatingDecay writes the rating, history and playlist ratings together like
+// record_rating_decay, or nothing at all when decayFails is set
+type fakeDecayRatings struct {
+	*fakeRatingStore
+	playlists  *fakePlaylistRatings
+	latest     map[int64]*models.PlayerHistoricalMMR
+	decayFails int
+}
+
+func (f *fakeDecayRatings) RecordRatingDecay(effective *models.PlayerEffectiveMMR, history models.PlayerHistoricalMMRCreateRequest, playlistRatings []*models.PlayerPlaylistRating) error {
+	if f.decayFails > 0 {
+		f.decayFails--
+		return errors.New("connection reset")
+	}
+	f.latest[history.UserID] = &models.PlayerHistoricalMMR{UserID: history.UserID, ChangeReason: history.ChangeReason, CreatedAt: time.Now()}
+	f.UpsertEffectiveMMR(effective)
+	for _, rating := range playlistRatings {
+		f.playlists.UpsertPlaylistRating(rating)
+	}
+	return f.CreateHistoricalMMR(history)
+}
+
+func (f *fakeDecayRatings) GetGuildPlaylistRatings(guildID int64, playlist string) ([]*models.PlayerPlaylistRating, error) {
+	return f.playlists.GetGuildPlaylistRatings(guildID, playlist)
+}
+
+func (f *fakeDecayRatings) GetUserHistory(userID, guildID int64, limit int) ([]*models.PlayerHistoricalMMR, error) {
+	if latest, ok := f.latest[userID]; ok {
+		return []*models.PlayerHistoricalMMR{latest}, nil
+	}
+	return nil, nil
+}
+
+type fakeDecayGuilds struct {
+	fakeGuildConfigStore
+}
+
+func (f *fakeDecayGuilds) GetAllGuilds(activeOnly bool) ([]*models.Guild, error) {
+	return []*models.Guild{{ID: 1, Active: true, Config: f.config}}, nil
+}
+
+func newTestDecayService(policy models.DecayConfig) (*DecayService, *fakeDecayRatings, *fakeTrackerSource) {
+	ratings := &fakeDecayRatings{
+		fakeRatingStore: newFakeRatingStore(),
+		playlists:       &fakePlaylistRatings{ratings: make(map[string]*models.PlayerPlaylistRating)},
+		latest:          make(map[int64]*models.PlayerHistoricalMMR),
+	}
+	guilds := &fakeDecayGuilds{fakeGuildConfigStore{config: models.GuildConfig{Decay: policy}}}
+	trackers := &fakeTrackerSource{}
+	users := &fakeStatsUsers{users: []*models.User{{ID: 1, DiscordID: playerID(1)}, {ID: 2, DiscordID: playerID(2)}, {ID: 3, DiscordID: playerID(3)}}}
+
+	service := &DecayService{
+		ratingRepo:  ratings,
+		guildRepo:   guilds,
+		userRepo:    users,
+		trackerRepo: trackers,
+		config:      &config.Config{TrueSkill: config.TrueSkillConfig{InitialMu: 1000, SigmaMax: 8.333}},
+	}
+	return service, ratings, trackers
+}
+
+func TestApplyDecayGrowsSigmaInVarianceAndPullsMuToFloor(t *testing.T) {
+	service, _, _ := newTestDecayService(models.DecayConfig{})
+	policy := models.DecayConfig{SigmaGrowthPerDay: 0.5, MuDecayPerDay: 0.01, MuFloor: 900}
+	rating := TrueSkillRating{Mu: 1200, Sigma: 3}
+
+	once := service.ApplyDecay(rating, 20, policy)
+	if math.Abs(once.Sigma-math.Sqrt(9+20*0.25)) > 0.001 {
+		t.Errorf("expected sigma %.3f, got %.3f", math.Sqrt(9+20*0.25), once.Sigma)
+	}
+	if want := 900 + 300*math.Pow(0.99, 20); math.Abs(once.Mu-want) > 0.001 {
+		t.Errorf("expected mu %.3f, got %.3f", want, once.Mu)
+	}
+
+	twice := service.ApplyDecay(service.ApplyDecay(rating, 10, policy), 10, policy)
+	if math.Abs(twice.Sigma-once.Sigma) > 0.002 || math.Abs(twice.Mu-once.Mu) > 0.002 {
+		t.Errorf("two 10 day runs should match one 20 day run: %+v vs %+v", twice, once)
+	}
+
+	capped := service.ApplyDecay(rating, 1000, policy)
+	if capped.Sigma != 8.333 {
+		t.Errorf("sigma should stop at SigmaMax, got %.3f", capped.Sigma)
+	}
+
+	below := service.ApplyDecay(TrueSkillRating{Mu: 800, Sigma: 3}, 20, policy)
+	if below.Mu != 800 {
+		t.Errorf("mu below the floor should not move, got %.3f", below.Mu)
+	}
+}
+
+func TestRunAllDecaysOnlyInactivePlayersOnce(t *testing.T) {
+	service, ratings, trackers := newTestDecayService(models.DecayConfig{Enabled: true, InactiveDays: 30, SigmaGrowthPerDay: 0.5})
+	now := time.Now()
+	longAgo := now.AddDate(0, 0, -60)
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 1, TrueSkillMu: 1100, TrueSkillSigma: 3, LastUpdated: longAgo}
+	ratings.effective[2] = &models.PlayerEffectiveMMR{UserID: 2, GuildID: 1, TrueSkillMu: 1100, TrueSkillSigma: 3, LastUpdated: longAgo}
+	ratings.effective[3] = &models.PlayerEffectiveMMR{UserID: 3, GuildID: 1, TrueSkillMu: 1100, TrueSkillSigma: 3, LastUpdated: now.AddDate(0, 0, -2)}
+	trackers.trackers = []*models.UserTracker{{DiscordID: playerID(2), LastUpdated: now.AddDate(0, 0, -5)}}
+
+	result, err := service.RunAll(now)
+	if err != nil {
+		t.Fatalf("RunAll failed: %v", err)
+	}
+
+	if len(result.Adjustments) != 1 || result.Adjustments[0].UserID != 1 {
+		t.Fatalf("expected only user 1 to decay, got %+v", result.Adjustments)
+	}
+	decayed := ratings.effective[1]
+	if want := roundRating(math.Sqrt(9 + 30*0.25)); math.Abs(decayed.TrueSkillSigma-want) > 0.002 {
+		t.Errorf("expected 30 days of sigma growth to %.3f, got %.3f", want, decayed.TrueSkillSigma)
+	}
+	if decayed.TrueSkillMu != 1100 {
+		t.Errorf("mu should not move without a mu rate, got %.3f", decayed.TrueSkillMu)
+	}
+	if !decayed.LastUpdated.Equal(longAgo) {
+		t.Errorf("decay must not count as activity, last updated moved to %v", decayed.LastUpdated)
+	}
+	if len(ratings.history) != 1 || ratings.history[0].ChangeReason != models.ChangeReasonInactivityDecay {
+		t.Errorf("expected one inactivity_decay history row, got %+v", ratings.history)
+	}
+
+	again, err := service.RunAll(now)
+	if err != nil {
+		t.Fatalf("second RunAll failed: %v", err)
+	}
+	if len(again.Adjustments) != 0 {
+		t.Errorf("a second run at the same time should not decay again, got %+v", again.Adjustments)
+	}
+}
+
+func TestRunGuildMeasuresInactivityFromTheGivenTime(t *testing.T) {
+	service, ratings, _ := newTestDecayService(models.DecayConfig{Enabled: true, InactiveDays: 30, SigmaGrowthPerDay: 0.5})
+	recently := time.Now().AddDate(0, 0, -2)
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 1, TrueSkillMu: 1100, TrueSkillSigma: 3, LastUpdated: recently}
+
+	result, err := service.RunGuild(1, recently.AddDate(0, 0, 40))
+	if err != nil {
+		t.Fatalf("RunGuild failed: %v", err)
+	}
+	if len(result.Adjustments) != 1 || result.Adjustments[0].DaysDecayed != 10 {
+		t.Fatalf("expected 10 days of decay measured from the run time, got %+v", result.Adjustments)
+	}
+}
+
+func TestRunAllRetriesAFailedDecayOnce(t *testing.T) {
+	service, ratings, _ := newTestDecayService(models.DecayConfig{Enabled: true, InactiveDays: 30, SigmaGrowthPerDay: 0.5})
+	now := time.Now()
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 1, TrueSkillMu: 1100, TrueSkillSigma: 3, LastUpdated: now.AddDate(0, 0, -60)}
+
+	ratings.decayFails = 1
+	failed, err := service.RunAll(now)
+	if err != nil {
+		t.Fatalf("RunAll failed: %v", err)
+	}
+	if len(failed.Errors) != 1 || ratings.effective[1].TrueSkillSigma != 3 || len(ratings.history) != 0 {
+		t.Fatalf("a failed decay should write nothing, got sigma %.3f with %d history rows", ratings.effective[1].TrueSkillSigma, len(ratings.history))
+	}
+
+	retried, err := service.RunAll(now)
+	if err != nil {
+		t.Fatalf("second RunAll failed: %v", err)
+	}
+	if len(retried.Adjustments) != 1 || len(ratings.history) != 1 {
+		t.Fatalf("expected the retry to decay once, got %+v with %d history rows", retried.Adjustments, len(ratings.history))
+	}
+	if want := roundRating(math.Sqrt(9 + 30*0.25)); math.Abs(ratings.effective[1].TrueSkillSigma-want) > 0.002 {
+		t.Errorf("expected 30 days of sigma growth to %.3f, got %.3f", want, ratings.effective[1].TrueSkillSigma)
+	}
+}
+
+func TestRunAllDecaysPlaylistRatingsWithTheCombinedRating(t *testing.T) {
+	service, ratings, _ := newTestDecayService(models.DecayConfig{Enabled: true, InactiveDays: 30, SigmaGrowthPerDay: 0.5})
+	now := time.Now()
+	longAgo := now.AddDate(0, 0, -60)
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 1, TrueSkillMu: 1100, TrueSkillSigma: 3, LastUpdated: longAgo}
+	ratings.playlists.ratings[playlistRatingKey(1, models.RatingPlaylistThrees)] = &models.PlayerPlaylistRating{
+		UserID: 1, GuildID: 1, Playlist: models.RatingPlaylistThrees, TrueSkillMu: 1150, TrueSkillSigma: 4, GamesPlayed: 12, LastUpdated: longAgo,
+	}
+
+	result, err := service.RunAll(now)
+	if err != nil {
+		t.Fatalf("RunAll failed: %v", err)
+	}
+	if len(result.Adjustments) != 1 || len(result.Adjustments[0].Playlists) != 1 {
+		t.Fatalf("expected user 1 and their 3v3 rating to decay, got %+v", result.Adjustments)
+	}
+
+	threes := ratings.playlists.ratings[playlistRatingKey(1, models.RatingPlaylistThrees)]
+	if want := roundRating(math.Sqrt(16 + 30*0.25)); math.Abs(threes.TrueSkillSigma-want) > 0.002 {
+		t.Errorf("expected the 3v3 sigma to grow for the same 30 days to %.3f, got %.3f", want, threes.TrueSkillSigma)
+	}
+	if !threes.LastUpdated.Equal(longAgo) || threes.GamesPlayed != 12 {
+		t.Errorf("decay must not count as playlist activity, got %+v", threes)
+	}
+
+	again, err := service.RunAll(now)
+	if err != nil {
+		t.Fatalf("second RunAll failed: %v", err)
+	}
+	if len(again.Adjustments) != 0 || ratings.playlists.ratings[playlistRatingKey(1, models.RatingPlaylistThrees)].TrueSkillSigma != threes.TrueSkillSigma {
+		t.Errorf("a second run at the same time should not decay the playlist rating again")
+	}
+}
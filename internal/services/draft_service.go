@@ -0,0 +1,541 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/usl"
+)
+
+// Draft errors
+var (
+	ErrInvalidDraft        = errors.New("invalid draft")
+	ErrDraftNotFound       = errors.New("draft not found")
+	ErrDraftNotActive      = errors.New("draft is not running")
+	ErrPlayerNotAvailable  = errors.New("player is not available in this draft")
+	errNoAutoPickCandidate = errors.New("no free agent fits the team on the clock")
+)
+
+// DraftStore interface for persisting drafts and their picks
+type DraftStore interface {
+	CreateDraft(request models.DraftCreateRequest) (*models.Draft, error)
+	FindDraftByID(draftID int64) (*models.Draft, error) // nil when the draft does not exist
+	GetDraftsByGuild(guildID int64) ([]*models.Draft, error)
+	UpdateDraftProgress(draftID int64, status string, currentPick int, pickStartedAt *time.Time) error
+	CreateDraftPick(pick models.DraftPick) (*models.DraftPick, error)
+	GetDraftPicks(draftID int64) ([]models.DraftPick, error)
+	DeleteDraftPick(pickID int64) error
+}
+
+// DraftTeamStore interface for looking up draft teams and who is already rostered
+type DraftTeamStore interface {
+	FindTeamByID(teamID int64) (*models.Team, error)
+	GetSeasonMembers(guildID int64, seasonID *int64) ([]models.TeamMember, error)
+}
+
+// DraftRosterManager interface for placing drafted players on rosters and taking them off again
+type DraftRosterManager interface {
+	SignPlayer(teamID int64, discordID string, changedBy *int64) (*TeamRoster, error)
+	ReleasePlayer(teamID int64, discordID string, changedBy *int64) (*TeamRoster, error)
+}
+
+// DraftTeam is a team taking part in a draft
+type DraftTeam struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// DraftPickView is a pick with the team and player names attached
+type DraftPickView struct {
+	models.DraftPick
+	TeamName   string  `json:"team_name"`
+	PlayerName string  `json:"player_name"`
+	Mu         float64 `json:"mu"`
+}
+
+// DraftSlot is one team's pick in a round of the board
+type DraftSlot struct {
+	PickNumber int            `json:"pick_number"`
+	TeamID     int64          `json:"team_id"`
+	Pick       *DraftPickView `json:"pick,omitempty"`
+	OnTheClock bool           `json:"on_the_clock"`
+}
+
+// DraftRound is a row of the board; slots follow the first-round team order in every round
+type DraftRound struct {
+	Number int         `json:"number"`
+	Slots  []DraftSlot `json:"slots"`
+}
+
+// DraftPoolPlayer is an undrafted free agent
+type DraftPoolPlayer struct {
+	DiscordID string  `json:"discord_id"`
+	Name      string  `json:"name"`
+	Mu        float64 `json:"mu"`
+	Sigma     float64 `json:"sigma"`
+}
+
+// DraftBoard is the live state of a draft for captains following along
+type DraftBoard struct {
+	Draft       *models.Draft     `json:"draft"`
+	Teams       []DraftTeam       `json:"teams"` // first-round order
+	Rounds      []DraftRound      `json:"rounds"`
+	Picks       []DraftPickView   `json:"picks"`
+	OnTheClock  *DraftTeam        `json:"on_the_clock,omitempty"`
+	Round       int               `json:"round"`
+	SecondsLeft int               `json:"seconds_left"` // -1 without a pick timer or while not running
+	Pool        []DraftPoolPlayer `json:"pool"`         // best mu first
+}
+
+// DraftService runs snake drafts of free agents onto team rosters.
+// Service Responsibilities:
+// - Setting up drafts with a first-round order that snakes on even rounds
+// - Listing the free-agent pool of eligible, unrostered players by mu
+// - Signing each pick onto the drafting team's roster under the roster rules
+// - Auto-picking the best available player when a pick timer runs out
+// - Letting admins undo the last pick
+type DraftService struct {
+	draftRepo    DraftStore
+	teamRepo     DraftTeamStore
+	seasonRepo   ActiveSeasonFinder
+	rosters      DraftRosterManager
+	playerSource PlacementPlayerSource
+	resolver     RatingResolver
+	config       *config.Config
+}
+
+// NewDraftService creates a new draft service
+func NewDraftService(
+	draftRepo *repositories.DraftRepository,
+	teamRepo *repositories.TeamRepository,
+	seasonRepo *repositories.SeasonRepository,
+	rosters *RosterService,
+	playerSource *usl.USLRepository,
+	resolver *PlayerRatingResolver,
+	config *config.Config,
+) *DraftService {
+	return &DraftService{
+		draftRepo:    draftRepo,
+		teamRepo:     teamRepo,
+		seasonRepo:   seasonRepo,
+		rosters:      rosters,
+		playerSource: playerSource,
+		resolver:     resolver,
+		config:       config,
+	}
+}
+
+// CreateDraft sets up a pending draft; every team must be in the guild and in the same season
+func (s *DraftService) CreateDraft(request models.DraftCreateRequest) (*models.Draft, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDraft, err)
+	}
+
+	if request.SeasonID == nil {
+		season, err := s.seasonRepo.FindActiveSeason(request.GuildID)
+		if err != nil {
+			return nil, err
+		}
+		if season != nil {
+			request.SeasonID = &season.ID
+		}
+	}
+
+	for _, teamID := range request.TeamOrder {
+		team, err := s.teamRepo.FindTeamByID(teamID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: team %d not found", ErrInvalidDraft, teamID)
+		}
+		if team.GuildID != request.GuildID {
+			return nil, fmt.Errorf("%w: team %s belongs to another guild", ErrInvalidDraft, team.Name)
+		}
+		if !equalInt64Ptr(team.SeasonID, request.SeasonID) {
+			return nil, fmt.Errorf("%w: team %s is not in the draft's season", ErrInvalidDraft, team.Name)
+		}
+	}
+
+	draft, err := s.draftRepo.CreateDraft(request)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("DraftService: Created draft %s (%d) with %d teams and %d rounds", draft.Name, draft.ID, len(draft.TeamOrder), draft.Rounds)
+	return draft, nil
+}
+
+// GetDrafts returns a guild's drafts, newest first
+func (s *DraftService) GetDrafts(guildID int64) ([]*models.Draft, error) {
+	return s.draftRepo.GetDraftsByGuild(guildID)
+}
+
+// StartDraft puts the first pick on the clock
+func (s *DraftService) StartDraft(draftID int64) (*models.Draft, error) {
+	draft, err := s.findDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != models.DraftStatusPending {
+		return nil, fmt.Errorf("%w: draft %s has already started", ErrInvalidDraft, draft.Name)
+	}
+
+	now := time.Now()
+	if err := s.draftRepo.UpdateDraftProgress(draftID, models.DraftStatusActive, 1, &now); err != nil {
+		return nil, err
+	}
+
+	log.Printf("DraftService: Started draft %d", draftID)
+	return s.findDraft(draftID)
+}
+
+// GetBoard returns the draft's picks, the team on the clock and the free-agent pool
+// An expired pick timer is settled first by auto-picking for the team on the clock.
+func (s *DraftService) GetBoard(draftID int64) (*DraftBoard, error) {
+	draft, err := s.findDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.pickTimerExpired(draft) {
+		if err := s.autoPick(draft); err != nil && !errors.Is(err, errNoAutoPickCandidate) {
+			return nil, err
+		}
+		if draft, err = s.findDraft(draftID); err != nil {
+			return nil, err
+		}
+	}
+
+	picks, err := s.draftRepo.GetDraftPicks(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildBoard(draft, picks)
+}
+
+// MakePick signs a player to the team on the clock and moves the draft to the next pick
+func (s *DraftService) MakePick(draftID int64, discordID string, changedBy *int64) (*models.DraftPick, error) {
+	draft, err := s.findDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+	if !draft.IsActive() {
+		return nil, fmt.Errorf("%w: %s is %s", ErrDraftNotActive, draft.Name, draft.Status)
+	}
+
+	picks, err := s.draftRepo.GetDraftPicks(draftID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pick := range picks {
+		if pick.DiscordID == discordID {
+			return nil, fmt.Errorf("%w: %s was taken with pick %d", ErrPlayerNotAvailable, discordID, pick.PickNumber)
+		}
+	}
+
+	return s.pick(draft, discordID, false, changedBy)
+}
+
+// UndoPick takes back the draft's last pick, releasing the player and putting that pick back on the clock
+func (s *DraftService) UndoPick(draftID int64, changedBy *int64) (*models.DraftPick, error) {
+	draft, err := s.findDraft(draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	picks, err := s.draftRepo.GetDraftPicks(draftID)
+	if err != nil {
+		return nil, err
+	}
+	if len(picks) == 0 {
+		return nil, fmt.Errorf("%w: %s has no picks to undo", ErrInvalidDraft, draft.Name)
+	}
+
+	last := picks[len(picks)-1]
+	if _, err := s.rosters.ReleasePlayer(last.TeamID, last.DiscordID, changedBy); err != nil {
+		if !errors.Is(err, ErrInvalidRoster) {
+			return nil, err
+		}
+		// Already released from the roster by hand; the pick is still taken back
+		log.Printf("DraftService: %s was no longer on team %d when undoing pick %d", last.DiscordID, last.TeamID, last.PickNumber)
+	}
+
+	if err := s.draftRepo.DeleteDraftPick(last.ID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.draftRepo.UpdateDraftProgress(draftID, models.DraftStatusActive, last.PickNumber, &now); err != nil {
+		return nil, err
+	}
+
+	log.Printf("DraftService: Undid pick %d (%s) in draft %d", last.PickNumber, last.DiscordID, draftID)
+	return &last, nil
+}
+
+// pick records the pick on the clock and signs the player, taking the pick back if the signing is refused
+// The unique pick number stops two simultaneous picks from both landing.
+func (s *DraftService) pick(draft *models.Draft, discordID string, autoPick bool, changedBy *int64) (*models.DraftPick, error) {
+	round, teamID := draft.PickSlot(draft.CurrentPick)
+
+	pick, err := s.draftRepo.CreateDraftPick(models.DraftPick{
+		DraftID:    draft.ID,
+		PickNumber: draft.CurrentPick,
+		Round:      round,
+		TeamID:     teamID,
+		DiscordID:  discordID,
+		AutoPick:   autoPick,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.rosters.SignPlayer(teamID, discordID, changedBy); err != nil {
+		if deleteErr := s.draftRepo.DeleteDraftPick(pick.ID); deleteErr != nil {
+			log.Printf("DraftService: Failed to take back refused pick %d in draft %d: %v", pick.PickNumber, draft.ID, deleteErr)
+		}
+		return nil, err
+	}
+
+	status, next := models.DraftStatusActive, draft.CurrentPick+1
+	var pickStartedAt *time.Time
+	if next > draft.TotalPicks() {
+		status = models.DraftStatusCompleted
+	} else {
+		now := time.Now()
+		pickStartedAt = &now
+	}
+
+	if err := s.draftRepo.UpdateDraftProgress(draft.ID, status, next, pickStartedAt); err != nil {
+		return nil, err
+	}
+
+	log.Printf("DraftService: Pick %d in draft %d: team %d took %s (auto: %t)", pick.PickNumber, draft.ID, teamID, discordID, autoPick)
+	return pick, nil
+}
+
+// pickTimerExpired checks if the team on the clock has run out of time
+func (s *DraftService) pickTimerExpired(draft *models.Draft) bool {
+	if !draft.IsActive() || draft.PickSeconds <= 0 || draft.PickStartedAt == nil {
+		return false
+	}
+	return time.Since(*draft.PickStartedAt) >= time.Duration(draft.PickSeconds)*time.Second
+}
+
+// autoPick takes the best available free agent the team on the clock can sign
+func (s *DraftService) autoPick(draft *models.Draft) error {
+	picks, err := s.draftRepo.GetDraftPicks(draft.ID)
+	if err != nil {
+		return err
+	}
+
+	pool, _, err := s.pool(draft, picks)
+	if err != nil {
+		return err
+	}
+
+	for _, player := range pool {
+		_, err := s.pick(draft, player.DiscordID, true, nil)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrSalaryCapExceeded) && !errors.Is(err, ErrPlayerIneligible) &&
+			!errors.Is(err, ErrPlayerRostered) && !errors.Is(err, ErrInvalidRoster) {
+			// Another poll of the board may have auto-picked first, tripping the unique pick number or player
+			if landed, landedErr := s.pickLanded(draft); landedErr == nil && landed {
+				log.Printf("DraftService: Pick %d in draft %d was already made by another request", draft.CurrentPick, draft.ID)
+				return nil
+			}
+			return err
+		}
+	}
+
+	log.Printf("DraftService: Pick %d in draft %d expired with no signable free agent", draft.CurrentPick, draft.ID)
+	return errNoAutoPickCandidate
+}
+
+// pickLanded re-reads the draft to check if the pick on the clock has been made since draft was loaded
+func (s *DraftService) pickLanded(draft *models.Draft) (bool, error) {
+	current, err := s.findDraft(draft.ID)
+	if err != nil {
+		return false, err
+	}
+	if current.CurrentPick != draft.CurrentPick || current.Status != draft.Status {
+		return true, nil
+	}
+
+	// The pick is recorded before the draft moves on, so it can exist while the draft is still on it
+	picks, err := s.draftRepo.GetDraftPicks(draft.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, pick := range picks {
+		if pick.PickNumber == draft.CurrentPick {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// findDraft loads a draft, returning ErrDraftNotFound when there is no draft with the ID
+func (s *DraftService) findDraft(draftID int64) (*models.Draft, error) {
+	draft, err := s.draftRepo.FindDraftByID(draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, fmt.Errorf("%w: %d", ErrDraftNotFound, draftID)
+	}
+	return draft, nil
+}
+
+// pool lists eligible players who are neither drafted nor rostered this season, best mu first
+// It also returns the display names of every player, for labelling picks.
+func (s *DraftService) pool(draft *models.Draft, picks []models.DraftPick) ([]DraftPoolPlayer, map[string]string, error) {
+	users, err := s.playerSource.GetAllUsers()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members, err := s.teamRepo.GetSeasonMembers(draft.GuildID, draft.SeasonID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taken := make(map[string]bool, len(members)+len(picks))
+	for _, member := range members {
+		taken[member.DiscordID] = true
+	}
+	for _, pick := range picks {
+		taken[pick.DiscordID] = true
+	}
+
+	names := make(map[string]string, len(users))
+	var available []string
+	for _, user := range users {
+		names[user.DiscordID] = user.DisplayName()
+		if user.IsValidForPlay() && !taken[user.DiscordID] {
+			available = append(available, user.DiscordID)
+		}
+	}
+
+	pool := make([]DraftPoolPlayer, 0, len(available))
+	if len(available) == 0 {
+		return pool, names, nil
+	}
+
+	ratings, err := s.resolver.Resolve(draft.GuildID, available)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rating := range ratings {
+		pool = append(pool, DraftPoolPlayer{
+			DiscordID: rating.DiscordID,
+			Name:      names[rating.DiscordID],
+			Mu:        roundRating(rating.Rating.Mu),
+			Sigma:     roundRating(rating.Rating.Sigma),
+		})
+	}
+
+	sort.SliceStable(pool, func(i, j int) bool {
+		if pool[i].Mu != pool[j].Mu {
+			return pool[i].Mu > pool[j].Mu
+		}
+		return pool[i].Name < pool[j].Name
+	})
+
+	return pool, names, nil
+}
+
+// buildBoard lays the picks out by round and team and works out who is on the clock
+func (s *DraftService) buildBoard(draft *models.Draft, picks []models.DraftPick) (*DraftBoard, error) {
+	pool, names, err := s.pool(draft, picks)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &DraftBoard{
+		Draft:       draft,
+		Teams:       make([]DraftTeam, 0, len(draft.TeamOrder)),
+		Picks:       make([]DraftPickView, 0, len(picks)),
+		SecondsLeft: -1,
+		Pool:        pool,
+	}
+
+	teamNames := make(map[int64]string, len(draft.TeamOrder))
+	for _, teamID := range draft.TeamOrder {
+		team, err := s.teamRepo.FindTeamByID(teamID)
+		if err != nil {
+			return nil, err
+		}
+		teamNames[teamID] = team.Name
+		board.Teams = append(board.Teams, DraftTeam{ID: teamID, Name: team.Name})
+	}
+
+	pickedIDs := make([]string, 0, len(picks))
+	for _, pick := range picks {
+		pickedIDs = append(pickedIDs, pick.DiscordID)
+	}
+	pickedMu := make(map[string]float64, len(picks))
+	if len(pickedIDs) > 0 {
+		ratings, err := s.resolver.Resolve(draft.GuildID, pickedIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, rating := range ratings {
+			pickedMu[rating.DiscordID] = roundRating(rating.Rating.Mu)
+		}
+	}
+
+	byNumber := make(map[int]*DraftPickView, len(picks))
+	for _, pick := range picks {
+		name := names[pick.DiscordID]
+		if name == "" {
+			name = pick.DiscordID
+		}
+		board.Picks = append(board.Picks, DraftPickView{
+			DraftPick:  pick,
+			TeamName:   teamNames[pick.TeamID],
+			PlayerName: name,
+			Mu:         pickedMu[pick.DiscordID],
+		})
+	}
+	for i := range board.Picks {
+		byNumber[board.Picks[i].PickNumber] = &board.Picks[i]
+	}
+
+	teams := len(draft.TeamOrder)
+	for round := 1; round <= draft.Rounds; round++ {
+		row := DraftRound{Number: round, Slots: make([]DraftSlot, 0, teams)}
+		for i, teamID := range draft.TeamOrder {
+			pickNumber := (round-1)*teams + i + 1
+			if round%2 == 0 {
+				pickNumber = round*teams - i
+			}
+			row.Slots = append(row.Slots, DraftSlot{
+				PickNumber: pickNumber,
+				TeamID:     teamID,
+				Pick:       byNumber[pickNumber],
+				OnTheClock: draft.IsActive() && pickNumber == draft.CurrentPick,
+			})
+		}
+		board.Rounds = append(board.Rounds, row)
+	}
+
+	if draft.IsActive() && draft.CurrentPick <= draft.TotalPicks() {
+		round, teamID := draft.PickSlot(draft.CurrentPick)
+		board.Round = round
+		board.OnTheClock = &DraftTeam{ID: teamID, Name: teamNames[teamID]}
+
+		if draft.PickSeconds > 0 && draft.PickStartedAt != nil {
+			remaining := time.Duration(draft.PickSeconds)*time.Second - time.Since(*draft.PickStartedAt)
+			board.SecondsLeft = max(int(remaining.Seconds()), 0)
+		}
+	}
+
+	return board, nil
+}
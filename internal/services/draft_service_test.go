@@ -0,0 +1,304 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/usl"
+)
+
+type fakeDraftStore struct {
+	draft  *models.Draft
+	picks  []models.DraftPick
+	nextID int64
+
+	beforeCreatePick func() // runs once before the next pick is recorded, to stage a concurrent request
+}
+
+func (f *fakeDraftStore) CreateDraft(request models.DraftCreateRequest) (*models.Draft, error) {
+	f.draft = &models.Draft{ID: 1, GuildID: request.GuildID, SeasonID: request.SeasonID, Name: request.Name,
+		Status: models.DraftStatusPending, Rounds: request.Rounds, PickSeconds: request.PickSeconds, TeamOrder: request.TeamOrder, CurrentPick: 1}
+	return f.draft, nil
+}
+
+func (f *fakeDraftStore) FindDraftByID(draftID int64) (*models.Draft, error) {
+	if f.draft == nil || f.draft.ID != draftID {
+		return nil, nil
+	}
+	draft := *f.draft
+	return &draft, nil
+}
+
+func (f *fakeDraftStore) GetDraftsByGuild(guildID int64) ([]*models.Draft, error) {
+	return []*models.Draft{f.draft}, nil
+}
+
+func (f *fakeDraftStore) UpdateDraftProgress(draftID int64, status string, currentPick int, pickStartedAt *time.Time) error {
+	f.draft.Status = status
+	f.draft.CurrentPick = currentPick
+	f.draft.PickStartedAt = pickStartedAt
+	return nil
+}
+
+func (f *fakeDraftStore) CreateDraftPick(pick models.DraftPick) (*models.DraftPick, error) {
+	if before := f.beforeCreatePick; before != nil {
+		f.beforeCreatePick = nil
+		before()
+	}
+	for _, existing := range f.picks {
+		if existing.PickNumber == pick.PickNumber {
+			return nil, errors.New("duplicate pick number")
+		}
+	}
+	f.nextID++
+	pick.ID = f.nextID
+	f.picks = append(f.picks, pick)
+	return &pick, nil
+}
+
+func (f *fakeDraftStore) GetDraftPicks(draftID int64) ([]models.DraftPick, error) {
+	return append([]models.DraftPick(nil), f.picks...), nil
+}
+
+func (f *fakeDraftStore) DeleteDraftPick(pickID int64) error {
+	kept := f.picks[:0]
+	for _, pick := range f.picks {
+		if pick.ID != pickID {
+			kept = append(kept, pick)
+		}
+	}
+	f.picks = kept
+	return nil
+}
+
+type fakeDraftTeamStore struct {
+	teams   map[int64]*models.Team
+	members []models.TeamMember
+}
+
+func (f *fakeDraftTeamStore) FindTeamByID(teamID int64) (*models.Team, error) {
+	team, ok := f.teams[teamID]
+	if !ok {
+		return nil, errors.New("team not found")
+	}
+	return team, nil
+}
+
+func (f *fakeDraftTeamStore) GetSeasonMembers(guildID int64, seasonID *int64) ([]models.TeamMember, error) {
+	return f.members, nil
+}
+
+// fakeDraftRosters signs anyone except the players in refuse, and keeps the signings in the team store
+type fakeDraftRosters struct {
+	teams  *fakeDraftTeamStore
+	refuse map[string]error
+}
+
+func (f *fakeDraftRosters) SignPlayer(teamID int64, discordID string, changedBy *int64) (*TeamRoster, error) {
+	if err := f.refuse[discordID]; err != nil {
+		return nil, err
+	}
+	f.teams.members = append(f.teams.members, models.TeamMember{TeamID: teamID, DiscordID: discordID})
+	return &TeamRoster{}, nil
+}
+
+func (f *fakeDraftRosters) ReleasePlayer(teamID int64, discordID string, changedBy *int64) (*TeamRoster, error) {
+	kept := f.teams.members[:0]
+	for _, member := range f.teams.members {
+		if member.TeamID != teamID || member.DiscordID != discordID {
+			kept = append(kept, member)
+		}
+	}
+	f.teams.members = kept
+	return &TeamRoster{}, nil
+}
+
+// newTestDraftService sets up teams 1..teams in season 7 and rates player N ("pa", "pb", ...)
+// at mu 1000-10N, so the pool is ordered pa, pb, pc, ...
+func newTestDraftService(teams, players int) (*DraftService, *fakeDraftStore, *fakeDraftTeamStore, *fakeDraftRosters) {
+	resolver := &fakeRatingResolver{ratings: make(map[string]TrueSkillRating), userIDs: make(map[string]int64)}
+	source := &fakePlayerSource{}
+	for i := 1; i <= players; i++ {
+		discordID := playerID(i)
+		resolver.ratings[discordID] = TrueSkillRating{Mu: 1000 - float64(10*i), Sigma: 5}
+		source.users = append(source.users, &usl.USLUser{ID: int64(i), DiscordID: discordID, Name: "Player " + discordID, Active: true})
+	}
+
+	teamStore := &fakeDraftTeamStore{teams: make(map[int64]*models.Team)}
+	for i := 1; i <= teams; i++ {
+		teamStore.teams[int64(i)] = &models.Team{ID: int64(i), GuildID: 1, SeasonID: int64Ptr(7), Name: "Team " + playerID(i)}
+	}
+
+	store := &fakeDraftStore{}
+	rosters := &fakeDraftRosters{teams: teamStore, refuse: make(map[string]error)}
+	return &DraftService{
+		draftRepo:    store,
+		teamRepo:     teamStore,
+		seasonRepo:   &fakeSeasonFinder{active: &models.Season{ID: 7, GuildID: 1, Number: 2, Status: models.SeasonStatusActive}},
+		rosters:      rosters,
+		playerSource: source,
+		resolver:     resolver,
+		config:       &config.Config{},
+	}, store, teamStore, rosters
+}
+
+func startTestDraft(t *testing.T, service *DraftService, rounds, pickSeconds int, order []int64) *models.Draft {
+	t.Helper()
+	draft, err := service.CreateDraft(models.DraftCreateRequest{GuildID: 1, Name: "Season 2 Draft", Rounds: rounds, PickSeconds: pickSeconds, TeamOrder: order})
+	if err != nil {
+		t.Fatalf("CreateDraft returned error: %v", err)
+	}
+	if draft, err = service.StartDraft(draft.ID); err != nil {
+		t.Fatalf("StartDraft returned error: %v", err)
+	}
+	return draft
+}
+
+func TestDraftPicksSnakeAndComplete(t *testing.T) {
+	service, store, _, _ := newTestDraftService(3, 8)
+	draft := startTestDraft(t, service, 2, 0, []int64{3, 1, 2})
+
+	wantTeams := []int64{3, 1, 2, 2, 1, 3}
+	for i, discordID := range []string{"pa", "pb", "pc", "pd", "pe", "pf"} {
+		pick, err := service.MakePick(draft.ID, discordID, nil)
+		if err != nil {
+			t.Fatalf("pick %d returned error: %v", i+1, err)
+		}
+		if pick.TeamID != wantTeams[i] || pick.PickNumber != i+1 {
+			t.Errorf("pick %d went to team %d, want team %d", pick.PickNumber, pick.TeamID, wantTeams[i])
+		}
+	}
+
+	if store.draft.Status != models.DraftStatusCompleted || store.draft.PickStartedAt != nil {
+		t.Errorf("expected draft completed with no clock, got %s", store.draft.Status)
+	}
+	if _, err := service.MakePick(draft.ID, "pg", nil); !errors.Is(err, ErrDraftNotActive) {
+		t.Errorf("expected picks after completion to be rejected, got %v", err)
+	}
+
+	board, err := service.GetBoard(draft.ID)
+	if err != nil {
+		t.Fatalf("GetBoard returned error: %v", err)
+	}
+	// Second round runs in reverse, so team 3's column holds picks 1 and 6
+	if slot := board.Rounds[1].Slots[0]; slot.PickNumber != 6 || slot.Pick == nil || slot.Pick.DiscordID != "pf" {
+		t.Errorf("expected team 3's second-round slot to be pick 6 (pf), got %+v", slot)
+	}
+	if len(board.Pool) != 2 || board.Pool[0].DiscordID != "pg" {
+		t.Errorf("expected pg and ph left in the pool, got %+v", board.Pool)
+	}
+}
+
+func TestDraftPoolExcludesRosteredAndIneligiblePlayers(t *testing.T) {
+	service, _, teams, _ := newTestDraftService(2, 5)
+	teams.members = []models.TeamMember{{TeamID: 1, DiscordID: "pa"}}
+	service.playerSource.(*fakePlayerSource).users[1].Banned = true
+
+	draft := startTestDraft(t, service, 1, 0, []int64{1, 2})
+	board, err := service.GetBoard(draft.ID)
+	if err != nil {
+		t.Fatalf("GetBoard returned error: %v", err)
+	}
+
+	if len(board.Pool) != 3 || board.Pool[0].DiscordID != "pc" {
+		t.Errorf("expected pool pc, pd, pe, got %+v", board.Pool)
+	}
+	if board.OnTheClock == nil || board.OnTheClock.ID != 1 || board.Round != 1 {
+		t.Errorf("expected team 1 on the clock in round 1, got %+v", board.OnTheClock)
+	}
+}
+
+func TestDraftRefusedSigningTakesPickBack(t *testing.T) {
+	service, store, _, rosters := newTestDraftService(2, 4)
+	rosters.refuse["pa"] = ErrSalaryCapExceeded
+	draft := startTestDraft(t, service, 1, 0, []int64{1, 2})
+
+	if _, err := service.MakePick(draft.ID, "pa", nil); !errors.Is(err, ErrSalaryCapExceeded) {
+		t.Fatalf("expected the cap to refuse the pick, got %v", err)
+	}
+	if len(store.picks) != 0 || store.draft.CurrentPick != 1 {
+		t.Errorf("expected the refused pick taken back with pick 1 still on the clock, got %d picks", len(store.picks))
+	}
+}
+
+func TestDraftUndoReleasesPlayerAndRewinds(t *testing.T) {
+	service, store, teams, _ := newTestDraftService(2, 4)
+	draft := startTestDraft(t, service, 1, 0, []int64{1, 2})
+
+	service.MakePick(draft.ID, "pa", nil)
+	service.MakePick(draft.ID, "pb", nil)
+	if store.draft.Status != models.DraftStatusCompleted {
+		t.Fatalf("expected draft complete after two picks, got %s", store.draft.Status)
+	}
+
+	undone, err := service.UndoPick(draft.ID, nil)
+	if err != nil {
+		t.Fatalf("UndoPick returned error: %v", err)
+	}
+	if undone.DiscordID != "pb" || store.draft.CurrentPick != 2 || !store.draft.IsActive() {
+		t.Errorf("expected pick 2 (pb) undone and back on the clock, got %+v", store.draft)
+	}
+	if len(teams.members) != 1 || teams.members[0].DiscordID != "pa" {
+		t.Errorf("expected pb released from team 2, got %+v", teams.members)
+	}
+}
+
+func TestDraftExpiredTimerAutoPicksBestSignablePlayer(t *testing.T) {
+	service, store, _, rosters := newTestDraftService(2, 4)
+	rosters.refuse["pa"] = ErrSalaryCapExceeded
+	draft := startTestDraft(t, service, 1, 60, []int64{2, 1})
+
+	board, err := service.GetBoard(draft.ID)
+	if err != nil {
+		t.Fatalf("GetBoard returned error: %v", err)
+	}
+	if len(board.Picks) != 0 || board.SecondsLeft <= 0 {
+		t.Fatalf("expected no auto-pick while the clock runs, got %d picks and %ds left", len(board.Picks), board.SecondsLeft)
+	}
+
+	expired := time.Now().Add(-2 * time.Minute)
+	store.draft.PickStartedAt = &expired
+
+	board, err = service.GetBoard(draft.ID)
+	if err != nil {
+		t.Fatalf("GetBoard returned error: %v", err)
+	}
+	if len(board.Picks) != 1 || board.Picks[0].DiscordID != "pb" || !board.Picks[0].AutoPick || board.Picks[0].TeamID != 2 {
+		t.Errorf("expected team 2 to auto-pick pb past the capped pa, got %+v", board.Picks)
+	}
+	if board.OnTheClock == nil || board.OnTheClock.ID != 1 {
+		t.Errorf("expected team 1 on the clock next, got %+v", board.OnTheClock)
+	}
+}
+
+func TestDraftAutoPickToleratesAPickAlreadyMade(t *testing.T) {
+	service, store, _, _ := newTestDraftService(2, 4)
+	draft := startTestDraft(t, service, 1, 60, []int64{2, 1})
+
+	expired := time.Now().Add(-2 * time.Minute)
+	store.draft.PickStartedAt = &expired
+	// A second poll of the board auto-picks pa for team 2 after this one has loaded the draft
+	store.beforeCreatePick = func() {
+		store.nextID++
+		store.picks = append(store.picks, models.DraftPick{ID: store.nextID, DraftID: draft.ID, PickNumber: 1, Round: 1, TeamID: 2, DiscordID: "pa", AutoPick: true})
+	}
+
+	board, err := service.GetBoard(draft.ID)
+	if err != nil {
+		t.Fatalf("GetBoard returned error for a pick another request already made: %v", err)
+	}
+	if len(board.Picks) != 1 || board.Picks[0].DiscordID != "pa" {
+		t.Errorf("expected only the other request's pick of pa, got %+v", board.Picks)
+	}
+}
+
+func TestDraftNotFound(t *testing.T) {
+	service, _, _, _ := newTestDraftService(2, 4)
+	startTestDraft(t, service, 1, 0, []int64{1, 2})
+
+	if _, err := service.GetBoard(99); !errors.Is(err, ErrDraftNotFound) {
+		t.Errorf("expected ErrDraftNotFound, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"math"
 	"usl-server/internal/config"
+	"usl-server/internal/models"
 )
 
 // Enhanced uncertainty calculation constants
@@ -22,6 +23,13 @@ const (
 	VariancePenaltyDivisor = 10000.0
 	MaxDistributionPenalty = 0.2
 
+	// Skill consistency and peak factors, measured on the normalized skill scale (0-100)
+	// the seeded μ is built from
+	MaxSkillSpread    = 25.0 // games-weighted spread across playlists and seasons that leaves no consistency
+	MaxPeakSkillGap   = 20.0 // recent peaks this far below all-time peaks give the lowest peak factor
+	MinPeakFactor     = 0.5
+	DefaultPeakFactor = 0.75 // no all-time peak to compare recent play against
+
 	// Precision for rounding
 	UncertaintyPrecision = 1000.0
 )
@@ -38,13 +46,15 @@ const (
 //
 // Exact port of JavaScript EnhancedUncertaintyCalculator
 type EnhancedUncertaintyCalculator struct {
-	config *config.Config
+	config              *config.Config
+	percentileConverter *PercentileConverter
 }
 
 // NewEnhancedUncertaintyCalculator creates a new enhanced uncertainty calculator
-func NewEnhancedUncertaintyCalculator(config *config.Config) *EnhancedUncertaintyCalculator {
+func NewEnhancedUncertaintyCalculator(config *config.Config, percentileConverter *PercentileConverter) *EnhancedUncertaintyCalculator {
 	return &EnhancedUncertaintyCalculator{
-		config: config,
+		config:              config,
+		percentileConverter: percentileConverter,
 	}
 }
 
@@ -57,8 +67,15 @@ type TrackerBreakdown struct {
 
 // TrackerPlaylistBreakdown represents playlist data breakdown
 type TrackerPlaylistBreakdown struct {
-	Current  SeasonBreakdown `json:"current"`
-	Previous SeasonBreakdown `json:"previous"`
+	Current     SeasonBreakdown `json:"current"`
+	Previous    SeasonBreakdown `json:"previous"`
+	AllTimePeak int             `json:"allTimePeak"`
+}
+
+// playlistSkills is one playlist's peaks as normalized skill, 0 where the peak is missing
+type playlistSkills struct {
+	current, previous, allTime  float64
+	currentGames, previousGames int
 }
 
 // SeasonBreakdown represents season data breakdown
@@ -67,6 +84,28 @@ type SeasonBreakdown struct {
 	Games int `json:"games"`
 }
 
+// UncertaintyFactors are the certainty factors behind a seeded σ
+// Each is between 0 and 1, higher meaning more certain; Combined is their product.
+type UncertaintyFactors struct {
+	Experience      float64 `json:"experience"`
+	Diversity       float64 `json:"diversity"`
+	Consistency     float64 `json:"consistency"`
+	Recency         float64 `json:"recency"`
+	PeakPerformance float64 `json:"peakPerformance"`
+	DataQuality     float64 `json:"dataQuality"`
+	Combined        float64 `json:"combined"`
+}
+
+// UncertaintyExplanation shows how a seeded σ was reached from the factors
+// Sigma is SigmaMax - Combined*(SigmaMax-SigmaMin), clamped to the range and rounded.
+type UncertaintyExplanation struct {
+	TotalGames int                `json:"totalGames"`
+	Factors    UncertaintyFactors `json:"factors"`
+	SigmaMax   float64            `json:"sigmaMax"`
+	SigmaMin   float64            `json:"sigmaMin"`
+	Sigma      float64            `json:"sigma"`
+}
+
 // CalculateEnhancedUncertainty calculates enhanced TrueSkill sigma value
 // This enhanced calculation considers:
 // - Experience factor (total games played)
@@ -78,45 +117,68 @@ type SeasonBreakdown struct {
 //
 // Exact port of JavaScript calculateEnhancedUncertainty() function
 func (c *EnhancedUncertaintyCalculator) CalculateEnhancedUncertainty(trackerData *TrackerData) (float64, error) {
+	return c.ExplainUncertainty(trackerData).Sigma, nil
+}
+
+// ExplainUncertainty calculates the enhanced sigma and keeps every factor that went into it
+// Peaks are read against the rank distribution of the season the tracker was last updated in.
+func (c *EnhancedUncertaintyCalculator) ExplainUncertainty(trackerData *TrackerData) *UncertaintyExplanation {
+	return c.ExplainUncertaintyForSeason(trackerData, c.percentileConverter.SeasonAt(trackerData.LastUpdated))
+}
+
+// ExplainUncertaintyForSeason calculates the enhanced sigma with a game season's rank distribution
+// Season 0 uses the latest season, as with the percentile conversions.
+func (c *EnhancedUncertaintyCalculator) ExplainUncertaintyForSeason(trackerData *TrackerData, season int) *UncertaintyExplanation {
 	sigmaMax, sigmaMin := c.config.GetTrueSkillSigmaRange()
 
 	breakdown := c.parseTrackerData(trackerData)
 	totalGames := c.calculateTotalGames(breakdown)
-
-	experienceFactor := c.calculateExperienceFactor(totalGames)
-	diversityFactor := c.calculatePlaylistDiversityFactor(breakdown)
-	consistencyFactor := c.calculatePercentileSkillConsistency(breakdown)
-	recencyFactor := c.calculateRecencyFactor(breakdown)
-	peakPerformanceFactor := c.calculatePeakPerformanceFactor(breakdown)
-	dataQualityFactor := c.calculateDataQualityFactor(trackerData)
-
-	combinedFactor := experienceFactor *
-		diversityFactor *
-		consistencyFactor *
-		recencyFactor *
-		peakPerformanceFactor *
-		dataQualityFactor
-
-	enhancedSigma := sigmaMax - (combinedFactor * (sigmaMax - sigmaMin))
+	skills := c.calculatePlaylistSkills(breakdown, season)
+
+	factors := UncertaintyFactors{
+		Experience:      c.calculateExperienceFactor(totalGames),
+		Diversity:       c.calculatePlaylistDiversityFactor(breakdown),
+		Consistency:     c.calculatePercentileSkillConsistency(skills, totalGames),
+		Recency:         c.calculateRecencyFactor(breakdown),
+		PeakPerformance: c.calculatePeakPerformanceFactor(skills),
+		DataQuality:     c.calculateDataQualityFactor(trackerData),
+	}
+	factors.Combined = factors.Experience *
+		factors.Diversity *
+		factors.Consistency *
+		factors.Recency *
+		factors.PeakPerformance *
+		factors.DataQuality
+
+	enhancedSigma := sigmaMax - (factors.Combined * (sigmaMax - sigmaMin))
 	finalSigma := math.Max(sigmaMin, math.Min(sigmaMax, enhancedSigma))
 
-	return math.Round(finalSigma*UncertaintyPrecision) / UncertaintyPrecision, nil
+	return &UncertaintyExplanation{
+		TotalGames: totalGames,
+		Factors:    factors,
+		SigmaMax:   sigmaMax,
+		SigmaMin:   sigmaMin,
+		Sigma:      math.Round(finalSigma*UncertaintyPrecision) / UncertaintyPrecision,
+	}
 }
 
 // parseTrackerData parses tracker data into structured breakdown
 func (c *EnhancedUncertaintyCalculator) parseTrackerData(trackerData *TrackerData) TrackerBreakdown {
 	return TrackerBreakdown{
 		Ones: TrackerPlaylistBreakdown{
-			Current:  SeasonBreakdown{MMR: trackerData.OnesCurrentPeak, Games: trackerData.OnesCurrentGames},
-			Previous: SeasonBreakdown{MMR: trackerData.OnesPreviousPeak, Games: trackerData.OnesPreviousGames},
+			Current:     SeasonBreakdown{MMR: trackerData.OnesCurrentPeak, Games: trackerData.OnesCurrentGames},
+			Previous:    SeasonBreakdown{MMR: trackerData.OnesPreviousPeak, Games: trackerData.OnesPreviousGames},
+			AllTimePeak: trackerData.OnesAllTimePeak,
 		},
 		Twos: TrackerPlaylistBreakdown{
-			Current:  SeasonBreakdown{MMR: trackerData.TwosCurrentPeak, Games: trackerData.TwosCurrentGames},
-			Previous: SeasonBreakdown{MMR: trackerData.TwosPreviousPeak, Games: trackerData.TwosPreviousGames},
+			Current:     SeasonBreakdown{MMR: trackerData.TwosCurrentPeak, Games: trackerData.TwosCurrentGames},
+			Previous:    SeasonBreakdown{MMR: trackerData.TwosPreviousPeak, Games: trackerData.TwosPreviousGames},
+			AllTimePeak: trackerData.TwosAllTimePeak,
 		},
 		Threes: TrackerPlaylistBreakdown{
-			Current:  SeasonBreakdown{MMR: trackerData.ThreesCurrentPeak, Games: trackerData.ThreesCurrentGames},
-			Previous: SeasonBreakdown{MMR: trackerData.ThreesPreviousPeak, Games: trackerData.ThreesPreviousGames},
+			Current:     SeasonBreakdown{MMR: trackerData.ThreesCurrentPeak, Games: trackerData.ThreesCurrentGames},
+			Previous:    SeasonBreakdown{MMR: trackerData.ThreesPreviousPeak, Games: trackerData.ThreesPreviousGames},
+			AllTimePeak: trackerData.ThreesAllTimePeak,
 		},
 	}
 }
@@ -141,7 +203,7 @@ func (c *EnhancedUncertaintyCalculator) calculatePlaylistDiversityFactor(breakdo
 	playlists := []TrackerPlaylistBreakdown{breakdown.Ones, breakdown.Twos, breakdown.Threes}
 	for _, playlist := range playlists {
 		totalGames := playlist.Current.Games + playlist.Previous.Games
-		if totalGames >= MinGamesThreshold {
+		if totalGames >= c.minGames() {
 			activePlaylistCount++
 			gameDistribution = append(gameDistribution, totalGames)
 		}
@@ -176,15 +238,86 @@ func (c *EnhancedUncertaintyCalculator) calculatePlaylistDiversityFactor(breakdo
 	return math.Max(MinUncertaintyFactor, math.Min(MaxUncertaintyFactor, diversityBonus))
 }
 
-// calculatePercentileSkillConsistency calculates skill consistency across playlists
-func (c *EnhancedUncertaintyCalculator) calculatePercentileSkillConsistency(breakdown TrackerBreakdown) float64 {
-	// This is a simplified version - in practice, would need percentile calculations
-	// For now, return a reasonable default based on game activity
-	totalGames := c.calculateTotalGames(breakdown)
+// calculatePlaylistSkills converts every playlist's peaks to normalized skill in one pass
+// The skill scale is the one the seeded μ is built from, so spreads here match spreads in μ.
+func (c *EnhancedUncertaintyCalculator) calculatePlaylistSkills(breakdown TrackerBreakdown, season int) []playlistSkills {
+	skill := func(mmr int, playlist string) float64 {
+		if mmr <= 0 {
+			return 0
+		}
+		return c.percentileConverter.MMRToNormalizedSkillForSeason(float64(mmr), playlist, season)
+	}
+
+	playlists := []struct {
+		key       string
+		breakdown TrackerPlaylistBreakdown
+	}{
+		{models.RankPlaylistSoloDuel, breakdown.Ones},
+		{models.RankPlaylistDoubles, breakdown.Twos},
+		{models.RankPlaylistStandard, breakdown.Threes},
+	}
+
+	skills := make([]playlistSkills, 0, len(playlists))
+	for _, playlist := range playlists {
+		skills = append(skills, playlistSkills{
+			current:       skill(playlist.breakdown.Current.MMR, playlist.key),
+			previous:      skill(playlist.breakdown.Previous.MMR, playlist.key),
+			allTime:       skill(playlist.breakdown.AllTimePeak, playlist.key),
+			currentGames:  playlist.breakdown.Current.Games,
+			previousGames: playlist.breakdown.Previous.Games,
+		})
+	}
+	return skills
+}
+
+// calculatePercentileSkillConsistency calculates skill consistency across playlists and seasons
+// Every playlist season with enough games is one sample of the player's skill; the games-weighted
+// standard deviation of those samples is the spread, and no spread is full consistency.
+func (c *EnhancedUncertaintyCalculator) calculatePercentileSkillConsistency(skills []playlistSkills, totalGames int) float64 {
 	if totalGames < LowActivityGamesThreshold {
 		return DefaultUncertaintyFactor
 	}
-	return 0.8
+
+	minGames := c.minGames()
+	var values, weights []float64
+	for _, playlist := range skills {
+		if playlist.current > 0 && playlist.currentGames >= minGames {
+			values = append(values, playlist.current)
+			weights = append(weights, float64(playlist.currentGames))
+		}
+		if playlist.previous > 0 && playlist.previousGames >= minGames {
+			values = append(values, playlist.previous)
+			weights = append(weights, float64(playlist.previousGames))
+		}
+	}
+
+	// A single sample shows nothing about consistency either way
+	if len(values) < 2 {
+		return DefaultUncertaintyFactor
+	}
+
+	var mean, totalWeight float64
+	for i, value := range values {
+		mean += value * weights[i]
+		totalWeight += weights[i]
+	}
+	mean /= totalWeight
+
+	var variance float64
+	for i, value := range values {
+		variance += weights[i] * math.Pow(value-mean, 2)
+	}
+	spread := math.Sqrt(variance / totalWeight)
+
+	return math.Max(MinUncertaintyFactor, math.Min(MaxUncertaintyFactor, 1-spread/MaxSkillSpread))
+}
+
+// minGames returns the configured games a playlist season needs to count, MinGamesThreshold when unset
+func (c *EnhancedUncertaintyCalculator) minGames() int {
+	if c.config != nil && c.config.MMR.MinGamesThreshold > 0 {
+		return c.config.MMR.MinGamesThreshold
+	}
+	return MinGamesThreshold
 }
 
 // calculateRecencyFactor calculates recency factor based on current vs previous season activity
@@ -202,31 +335,28 @@ func (c *EnhancedUncertaintyCalculator) calculateRecencyFactor(breakdown Tracker
 	return math.Max(0.3, math.Min(1.0, 0.5+recencyRatio*0.5))
 }
 
-// calculatePeakPerformanceFactor calculates peak performance factor
-func (c *EnhancedUncertaintyCalculator) calculatePeakPerformanceFactor(breakdown TrackerBreakdown) float64 {
-	// Simplified peak performance analysis
-	// In practice, would compare current peaks to all-time peaks
-	maxPeak := 0
-	playlists := []TrackerPlaylistBreakdown{breakdown.Ones, breakdown.Twos, breakdown.Threes}
-
-	for _, playlist := range playlists {
-		if playlist.Current.MMR > maxPeak {
-			maxPeak = playlist.Current.MMR
-		}
-		if playlist.Previous.MMR > maxPeak {
-			maxPeak = playlist.Previous.MMR
+// calculatePeakPerformanceFactor compares recent peaks with all-time peaks
+// A player playing at their all-time level is well measured; one far below it is declining,
+// returning from a break or holding back, and their true skill is less certain. The gap is
+// weighted by each playlist's recent games.
+func (c *EnhancedUncertaintyCalculator) calculatePeakPerformanceFactor(skills []playlistSkills) float64 {
+	var weightedGap, totalWeight float64
+	for _, playlist := range skills {
+		recent := math.Max(playlist.current, playlist.previous)
+		games := playlist.currentGames + playlist.previousGames
+		if playlist.allTime <= 0 || recent <= 0 || games == 0 {
+			continue
 		}
+		weightedGap += math.Max(0, playlist.allTime-recent) * float64(games)
+		totalWeight += float64(games)
 	}
 
-	// Players with higher peaks have more certainty in their skill level
-	if maxPeak < 600 {
-		return 0.5 // Lower certainty for lower skilled players
-	} else if maxPeak > 1200 {
-		return 0.9 // Higher certainty for higher skilled players
+	if totalWeight == 0 {
+		return DefaultPeakFactor
 	}
 
-	// Linear interpolation between 600-1200 MMR
-	return 0.5 + (float64(maxPeak-600)/600.0)*0.4
+	gap := math.Min(1, weightedGap/totalWeight/MaxPeakSkillGap)
+	return 1 - gap*(1-MinPeakFactor)
 }
 
 // calculateDataQualityFactor calculates data quality and freshness factor
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"testing"
+	"usl-server/internal/config"
+)
+
+func newTestUncertaintyCalculator() *EnhancedUncertaintyCalculator {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333},
+	}
+	return NewEnhancedUncertaintyCalculator(cfg, NewPercentileConverter(cfg))
+}
+
+func TestSkillConsistencyMeasuresSpreadAcrossPlaylistsAndSeasons(t *testing.T) {
+	calculator := newTestUncertaintyCalculator()
+
+	steady := calculator.ExplainUncertainty(&TrackerData{
+		OnesCurrentPeak: 1000, OnesCurrentGames: 100, OnesPreviousPeak: 990, OnesPreviousGames: 120,
+		TwosCurrentPeak: 1050, TwosCurrentGames: 300, TwosPreviousPeak: 1040, TwosPreviousGames: 400,
+		ThreesCurrentPeak: 1030, ThreesCurrentGames: 200, ThreesPreviousPeak: 1020, ThreesPreviousGames: 250,
+	})
+	erratic := calculator.ExplainUncertainty(&TrackerData{
+		OnesCurrentPeak: 600, OnesCurrentGames: 100, OnesPreviousPeak: 700, OnesPreviousGames: 120,
+		TwosCurrentPeak: 1400, TwosCurrentGames: 300, TwosPreviousPeak: 900, TwosPreviousGames: 400,
+		ThreesCurrentPeak: 1200, ThreesCurrentGames: 200, ThreesPreviousPeak: 800, ThreesPreviousGames: 250,
+	})
+
+	if steady.Factors.Consistency <= 0.8 {
+		t.Errorf("expected a player at one level everywhere to be consistent, got %.3f", steady.Factors.Consistency)
+	}
+	if erratic.Factors.Consistency >= steady.Factors.Consistency || erratic.Factors.Consistency < MinUncertaintyFactor {
+		t.Errorf("expected a spread-out player to be less consistent than %.3f, got %.3f",
+			steady.Factors.Consistency, erratic.Factors.Consistency)
+	}
+	if erratic.Sigma <= steady.Sigma {
+		t.Errorf("expected the erratic player's σ %.3f above the steady player's %.3f", erratic.Sigma, steady.Sigma)
+	}
+
+	oneSample := calculator.ExplainUncertainty(&TrackerData{TwosCurrentPeak: 1050, TwosCurrentGames: 300})
+	if oneSample.Factors.Consistency != DefaultUncertaintyFactor {
+		t.Errorf("expected the default consistency from a single playlist season, got %.3f", oneSample.Factors.Consistency)
+	}
+}
+
+func TestSkillConsistencyUsesTheConfiguredMinimumGames(t *testing.T) {
+	// Two 30-game seasons far apart: counted under the default threshold, dropped under 50
+	player := &TrackerData{
+		OnesCurrentPeak: 600, OnesCurrentGames: 30,
+		TwosCurrentPeak: 1400, TwosCurrentGames: 30,
+	}
+
+	unset := newTestUncertaintyCalculator().ExplainUncertainty(player)
+	if unset.Factors.Consistency == DefaultUncertaintyFactor {
+		t.Fatalf("expected both seasons to count under the default threshold, got %.3f", unset.Factors.Consistency)
+	}
+
+	strict := newTestUncertaintyCalculator()
+	strict.config.MMR.MinGamesThreshold = 50
+	if got := strict.ExplainUncertainty(player).Factors.Consistency; got != DefaultUncertaintyFactor {
+		t.Errorf("expected MMR.MinGamesThreshold to drop both 30-game seasons, got %.3f", got)
+	}
+}
+
+func TestPeakFactorComparesRecentPeaksWithAllTimePeaks(t *testing.T) {
+	calculator := newTestUncertaintyCalculator()
+	player := func(recentPeak, allTimePeak int) *TrackerData {
+		return &TrackerData{
+			TwosCurrentPeak: recentPeak, TwosCurrentGames: 300, TwosPreviousPeak: recentPeak - 20, TwosPreviousGames: 400,
+			TwosAllTimePeak: allTimePeak,
+		}
+	}
+
+	atPeak := calculator.ExplainUncertainty(player(1100, 1100)).Factors.PeakPerformance
+	below := calculator.ExplainUncertainty(player(1100, 1350)).Factors.PeakPerformance
+	farBelow := calculator.ExplainUncertainty(player(700, 1100)).Factors.PeakPerformance
+	unknown := calculator.ExplainUncertainty(player(1100, 0)).Factors.PeakPerformance
+
+	if atPeak != MaxUncertaintyFactor {
+		t.Errorf("expected full certainty for a player at their all-time peak, got %.3f", atPeak)
+	}
+	if below >= atPeak || below <= farBelow {
+		t.Errorf("expected the factor to fall as the all-time peak pulls away: %.3f, %.3f, %.3f", atPeak, below, farBelow)
+	}
+	if farBelow != MinPeakFactor {
+		t.Errorf("expected the lowest peak factor far below the all-time peak, got %.3f", farBelow)
+	}
+	if unknown != DefaultPeakFactor {
+		t.Errorf("expected the default peak factor without an all-time peak, got %.3f", unknown)
+	}
+}
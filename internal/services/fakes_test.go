@@ -0,0 +1,14 @@
+package services
+
+import "usl-server/internal/models"
+
+// Fakes shared by several services' tests; fixtures for one feature stay next to its tests.
+
+// fakeTrackerSource serves a fixed set of trackers to any service that reads them
+type fakeTrackerSource struct {
+	trackers []*models.UserTracker
+}
+
+func (f *fakeTrackerSource) GetAllTrackers(validOnly bool) ([]*models.UserTracker, error) {
+	return f.trackers, nil
+}
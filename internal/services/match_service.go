@@ -0,0 +1,362 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidMatchReport is returned when a match cannot be recorded because of bad input
+var ErrInvalidMatchReport = errors.New("invalid match report")
+
+// maxRecordMatchAttempts bounds how often a match is rated again after concurrent rating changes
+const maxRecordMatchAttempts = 3
+
+// MatchStore interface for persisting matches
+// RecordRatedMatch writes the match with its ratings and history in one transaction.
+type MatchStore interface {
+	RecordRatedMatch(record models.RatedMatchRecord) (*models.Match, error)
+	GetMatchesByGuild(guildID int64, limit int) ([]*models.Match, error)
+}
+
+// PlayerRatingSource interface for reading the per-guild ratings a match starts from
+type PlayerRatingSource interface {
+	FindEffectiveMMR(userID, guildID int64) (*models.PlayerEffectiveMMR, error)
+}
+
+// PlaylistRatingTracks interface for the per-playlist ratings a match also updates
+type PlaylistRatingTracks interface {
+	LoadRatings(guildID int64, playlist string, combined []*models.PlayerEffectiveMMR) ([]*models.PlayerPlaylistRating, error)
+}
+
+// UserDirectory interface for resolving Discord IDs to users
+type UserDirectory interface {
+	FindUserByDiscordID(discordID string) (*models.User, error)
+}
+
+// MatchService records match results and applies rating updates to every player involved.
+// Service Responsibilities:
+// - Resolving Discord IDs to users
+// - Rating the match with the guild's engine (TrueSkill unless the guild picks Glicko-2 or Elo)
+// - Updating the players' rating in the match's playlist (1v1, 2v2 or 3v3) alongside the combined one
+// - Persisting the match, its rosters, player_effective_mmr, player_historical_mmr and playlist ratings in one transaction
+type MatchService struct {
+	matchRepo  MatchStore
+	ratingRepo PlayerRatingSource
+	userRepo   UserDirectory
+	engines    RatingEngineSource
+	playlists  PlaylistRatingTracks // nil leaves playlist ratings untouched
+	rater      *TrueSkillRater      // used when no engine source is set
+	config     *config.Config
+}
+
+// MatchReport is a match result identified by Discord IDs, as submitted by the bot or admins
+type MatchReport struct {
+	GuildID             int64      `json:"guild_id"`
+	TeamA               []string   `json:"team_a"`
+	TeamB               []string   `json:"team_b"`
+	TeamAScore          int        `json:"team_a_score"`
+	TeamBScore          int        `json:"team_b_score"`
+	PlayedAt            *time.Time `json:"played_at,omitempty"`
+	ReportedByDiscordID string     `json:"reported_by,omitempty"`
+	Playlist            string     `json:"playlist,omitempty"` // ones, 2v2, doubles...; empty picks it by team size
+
+	// Optional per-player stat lines, by Discord ID
+	Stats map[string]models.MatchPlayerStats `json:"stats,omitempty"`
+}
+
+// PlayerRatingChange describes how a single player's rating moved after a match
+type PlayerRatingChange struct {
+	UserID      int64   `json:"user_id"`
+	Team        int     `json:"team"`
+	MuBefore    float64 `json:"mu_before"`
+	MuAfter     float64 `json:"mu_after"`
+	SigmaBefore float64 `json:"sigma_before"`
+	SigmaAfter  float64 `json:"sigma_after"`
+}
+
+// MatchResult represents a recorded match and the rating changes it produced
+// PlaylistChanges are the moves in the match's playlist rating, empty for formats without one.
+type MatchResult struct {
+	Match           *models.Match        `json:"match"`
+	Changes         []PlayerRatingChange `json:"changes"`
+	PlaylistChanges []PlayerRatingChange `json:"playlist_changes,omitempty"`
+}
+
+// NewMatchService creates a new match service
+func NewMatchService(
+	matchRepo *repositories.MatchRepository,
+	ratingRepo *repositories.PlayerMMRRepository,
+	userRepo *repositories.UserRepository,
+	engines *RatingEngineSelector,
+	playlists *PlaylistRatingService,
+	config *config.Config,
+) *MatchService {
+	return &MatchService{
+		matchRepo:  matchRepo,
+		ratingRepo: ratingRepo,
+		userRepo:   userRepo,
+		engines:    engines,
+		playlists:  playlists,
+		rater:      NewTrueSkillRater(config),
+		config:     config,
+	}
+}
+
+// RecordMatchReport resolves the Discord IDs in a report and records the match
+func (s *MatchService) RecordMatchReport(report MatchReport) (*MatchResult, error) {
+	teamA, err := s.resolveUserIDs(report.TeamA)
+	if err != nil {
+		return nil, err
+	}
+	teamB, err := s.resolveUserIDs(report.TeamB)
+	if err != nil {
+		return nil, err
+	}
+
+	request := models.MatchCreateRequest{
+		GuildID:      report.GuildID,
+		TeamAUserIDs: teamA,
+		TeamBUserIDs: teamB,
+		TeamAScore:   report.TeamAScore,
+		TeamBScore:   report.TeamBScore,
+		PlayedAt:     report.PlayedAt,
+		Playlist:     report.Playlist,
+	}
+
+	if len(report.Stats) > 0 {
+		request.PlayerStats = make(map[int64]models.MatchPlayerStats, len(report.Stats))
+		for discordID, stats := range report.Stats {
+			userIDs, err := s.resolveUserIDs([]string{discordID})
+			if err != nil {
+				return nil, err
+			}
+			request.PlayerStats[userIDs[0]] = stats
+		}
+	}
+
+	if report.ReportedByDiscordID != "" {
+		reporter, err := s.userRepo.FindUserByDiscordID(report.ReportedByDiscordID)
+		if err == nil && reporter != nil {
+			reporterID := int64(reporter.ID)
+			request.ReportedByUserID = &reporterID
+		}
+	}
+
+	return s.RecordMatch(request)
+}
+
+// RecordMatch rates a match with the guild's engine and stores it with every rating change
+// The match and its ratings are written together: on error nothing was recorded and the
+// match can be submitted again. When another match changes a player's rating in between,
+// the match is rated again from the new ratings.
+func (s *MatchService) RecordMatch(request models.MatchCreateRequest) (*MatchResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMatchReport, err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := s.rateAndRecordMatch(request)
+		if errors.Is(err, repositories.ErrRatingChanged) && attempt < maxRecordMatchAttempts {
+			log.Printf("MatchService: Ratings changed while rating a match in guild %d, rating it again (attempt %d): %v",
+				request.GuildID, attempt, err)
+			continue
+		}
+		return result, err
+	}
+}
+
+// rateAndRecordMatch loads the players' ratings, rates the match and records it
+func (s *MatchService) rateAndRecordMatch(request models.MatchCreateRequest) (*MatchResult, error) {
+	ratingsA, err := s.loadRatings(request.TeamAUserIDs, request.GuildID)
+	if err != nil {
+		return nil, err
+	}
+	ratingsB, err := s.loadRatings(request.TeamBUserIDs, request.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var playlistA, playlistB []*models.PlayerPlaylistRating
+	if playlist := request.RatingPlaylist(); playlist != "" && s.playlists != nil {
+		if playlistA, err = s.playlists.LoadRatings(request.GuildID, playlist, ratingsA); err != nil {
+			return nil, err
+		}
+		if playlistB, err = s.playlists.LoadRatings(request.GuildID, playlist, ratingsB); err != nil {
+			return nil, err
+		}
+	}
+
+	engine := s.ratingEngine(request.GuildID)
+	outcome := matchOutcome(&models.Match{TeamAScore: request.TeamAScore, TeamBScore: request.TeamBScore})
+	now := time.Now()
+	record := models.RatedMatchRecord{Match: request}
+	result := &MatchResult{Changes: make([]PlayerRatingChange, 0, len(ratingsA)+len(ratingsB))}
+
+	newA, newB := engine.RateMatch(toTrueSkillRatings(ratingsA), toTrueSkillRatings(ratingsB), outcome)
+	rate := func(current *models.PlayerEffectiveMMR, updated TrueSkillRating, team int) {
+		change, saved, history := s.newRatingChange(current, updated, team, request.ReportedByUserID, now)
+		result.Changes = append(result.Changes, change)
+		record.Ratings = append(record.Ratings, saved)
+		record.History = append(record.History, history)
+	}
+	for i, current := range ratingsA {
+		rate(current, newA[i], models.MatchTeamA)
+	}
+	for i, current := range ratingsB {
+		rate(current, newB[i], models.MatchTeamB)
+	}
+
+	// The combined rating keeps the audit history, so playlist moves are only reported in the result
+	if len(playlistA) > 0 {
+		newA, newB := engine.RateMatch(playlistTrueSkillRatings(playlistA), playlistTrueSkillRatings(playlistB), outcome)
+		ratePlaylist := func(current *models.PlayerPlaylistRating, updated TrueSkillRating, team int) {
+			change, saved := newPlaylistRatingChange(current, updated, team, now)
+			result.PlaylistChanges = append(result.PlaylistChanges, change)
+			record.PlaylistRatings = append(record.PlaylistRatings, saved)
+		}
+		for i, current := range playlistA {
+			ratePlaylist(current, newA[i], models.MatchTeamA)
+		}
+		for i, current := range playlistB {
+			ratePlaylist(current, newB[i], models.MatchTeamB)
+		}
+	}
+
+	match, err := s.matchRepo.RecordRatedMatch(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record match: %w", err)
+	}
+	result.Match = match
+
+	log.Printf("MatchService: Recorded match %d (%d-%d) with %d %s rating updates",
+		match.ID, match.TeamAScore, match.TeamBScore, len(result.Changes), engine.Name())
+
+	return result, nil
+}
+
+// GetRecentMatches returns the most recent matches in a guild
+func (s *MatchService) GetRecentMatches(guildID int64, limit int) ([]*models.Match, error) {
+	return s.matchRepo.GetMatchesByGuild(guildID, limit)
+}
+
+// ratingEngine returns the engine that rates the guild's matches
+func (s *MatchService) ratingEngine(guildID int64) RatingEngine {
+	if s.engines != nil {
+		return s.engines.ForGuild(guildID)
+	}
+	return &TrueSkillEngine{rater: s.rater}
+}
+
+// newRatingChange builds a player's rating change, the effective rating to save and its audit row
+// The audit row's match_id is filled in when the match is stored.
+func (s *MatchService) newRatingChange(current *models.PlayerEffectiveMMR, updated TrueSkillRating, team int, changedBy *int64, now time.Time) (PlayerRatingChange, *models.PlayerEffectiveMMR, models.PlayerHistoricalMMRCreateRequest) {
+	change := PlayerRatingChange{
+		UserID:      current.UserID,
+		Team:        team,
+		MuBefore:    current.TrueSkillMu,
+		MuAfter:     roundRating(updated.Mu),
+		SigmaBefore: current.TrueSkillSigma,
+		SigmaAfter:  roundRating(updated.Sigma),
+	}
+
+	saved := *current
+	saved.TrueSkillMu = change.MuAfter
+	saved.TrueSkillSigma = change.SigmaAfter
+	saved.GamesPlayed = current.GamesPlayed + 1
+	saved.LastUpdated = now
+
+	mmrBefore := current.MMR
+	muBefore := change.MuBefore
+	sigmaBefore := change.SigmaBefore
+
+	return change, &saved, models.PlayerHistoricalMMRCreateRequest{
+		UserID:               current.UserID,
+		GuildID:              current.GuildID,
+		MMRBefore:            &mmrBefore,
+		MMRAfter:             saved.MMR,
+		TrueSkillMuBefore:    &muBefore,
+		TrueSkillMuAfter:     change.MuAfter,
+		TrueSkillSigmaBefore: &sigmaBefore,
+		TrueSkillSigmaAfter:  change.SigmaAfter,
+		ChangeReason:         models.ChangeReasonMatchResult,
+		ChangedByUserID:      changedBy,
+	}
+}
+
+// loadRatings returns the current rating for each user, falling back to config defaults for new players
+func (s *MatchService) loadRatings(userIDs []int64, guildID int64) ([]*models.PlayerEffectiveMMR, error) {
+	ratings := make([]*models.PlayerEffectiveMMR, 0, len(userIDs))
+	for _, userID := range userIDs {
+		effective, err := s.ratingRepo.FindEffectiveMMR(userID, guildID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rating for user %d: %w", userID, err)
+		}
+
+		if effective == nil {
+			mu, sigma := s.config.GetTrueSkillDefaults()
+			effective = &models.PlayerEffectiveMMR{
+				UserID:         userID,
+				GuildID:        guildID,
+				TrueSkillMu:    mu,
+				TrueSkillSigma: sigma,
+			}
+		}
+
+		ratings = append(ratings, effective)
+	}
+	return ratings, nil
+}
+
+// resolveUserIDs maps Discord IDs to internal user IDs
+func (s *MatchService) resolveUserIDs(discordIDs []string) ([]int64, error) {
+	userIDs := make([]int64, 0, len(discordIDs))
+	for _, discordID := range discordIDs {
+		user, err := s.userRepo.FindUserByDiscordID(discordID)
+		if err != nil || user == nil {
+			return nil, fmt.Errorf("%w: user with Discord ID %s not found", ErrInvalidMatchReport, discordID)
+		}
+		userIDs = append(userIDs, int64(user.ID))
+	}
+	return userIDs, nil
+}
+
+// matchOutcome converts match scores into a TrueSkill outcome
+func matchOutcome(match *models.Match) MatchOutcome {
+	switch match.Winner() {
+	case models.MatchTeamA:
+		return MatchOutcomeTeamAWins
+	case models.MatchTeamB:
+		return MatchOutcomeTeamBWins
+	default:
+		return MatchOutcomeDraw
+	}
+}
+
+// toTrueSkillRatings extracts mu/sigma pairs from stored ratings
+func toTrueSkillRatings(ratings []*models.PlayerEffectiveMMR) []TrueSkillRating {
+	result := make([]TrueSkillRating, len(ratings))
+	for i, rating := range ratings {
+		result[i] = TrueSkillRating{Mu: rating.TrueSkillMu, Sigma: rating.TrueSkillSigma}
+	}
+	return result
+}
+
+// playlistTrueSkillRatings extracts mu/sigma pairs from stored playlist ratings
+func playlistTrueSkillRatings(ratings []*models.PlayerPlaylistRating) []TrueSkillRating {
+	result := make([]TrueSkillRating, len(ratings))
+	for i, rating := range ratings {
+		result[i] = TrueSkillRating{Mu: rating.TrueSkillMu, Sigma: rating.TrueSkillSigma}
+	}
+	return result
+}
+
+// roundRating rounds to the 3 decimal places stored by the database
+func roundRating(value float64) float64 {
+	return math.Round(value*UncertaintyPrecision) / UncertaintyPrecision
+}
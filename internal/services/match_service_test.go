@@ -0,0 +1,302 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// fakeMatchStore writes a match's ratings, history and playlist ratings into the rating fakes
+// all at once like record_rated_match does, or nothing at all when fail is set or a player's
+// stored rating no longer matches the history row's before values
+type fakeMatchStore struct {
+	created   []models.MatchCreateRequest
+	ratings   *fakeRatingStore
+	playlists *fakePlaylistRatings
+	fail      bool
+	attempts  int
+
+	beforeRecord func() // runs once before the next write, to stage a concurrent match
+}
+
+func (f *fakeMatchStore) RecordRatedMatch(record models.RatedMatchRecord) (*models.Match, error) {
+	f.attempts++
+	if before := f.beforeRecord; before != nil {
+		f.beforeRecord = nil
+		before()
+	}
+	if f.fail {
+		return nil, errors.New("transaction rolled back")
+	}
+	for _, entry := range record.History {
+		stored, ok := f.ratings.effective[entry.UserID]
+		if ok && (stored.TrueSkillMu != *entry.TrueSkillMuBefore || stored.TrueSkillSigma != *entry.TrueSkillSigmaBefore) {
+			return nil, fmt.Errorf("%w: user %d", repositories.ErrRatingChanged, entry.UserID)
+		}
+	}
+
+	request := record.Match
+	f.created = append(f.created, request)
+	match := &models.Match{ID: int64(len(f.created)), GuildID: request.GuildID, TeamAScore: request.TeamAScore, TeamBScore: request.TeamBScore}
+	for _, userID := range request.TeamAUserIDs {
+		match.Players = append(match.Players, models.MatchPlayer{MatchID: match.ID, UserID: userID, Team: models.MatchTeamA})
+	}
+	for _, userID := range request.TeamBUserIDs {
+		match.Players = append(match.Players, models.MatchPlayer{MatchID: match.ID, UserID: userID, Team: models.MatchTeamB})
+	}
+
+	for _, rating := range record.Ratings {
+		f.ratings.UpsertEffectiveMMR(rating)
+	}
+	for _, entry := range record.History {
+		entry.MatchID = &match.ID
+		f.ratings.CreateHistoricalMMR(entry)
+	}
+	for _, rating := range record.PlaylistRatings {
+		f.playlists.UpsertPlaylistRating(rating)
+	}
+	return match, nil
+}
+
+func (f *fakeMatchStore) GetMatchesByGuild(guildID int64, limit int) ([]*models.Match, error) {
+	return nil, nil
+}
+
+type fakeRatingStore struct {
+	effective map[int64]*models.PlayerEffectiveMMR
+	history   []models.PlayerHistoricalMMRCreateRequest
+}
+
+func newFakeRatingStore() *fakeRatingStore {
+	return &fakeRatingStore{effective: make(map[int64]*models.PlayerEffectiveMMR)}
+}
+
+func (f *fakeRatingStore) FindEffectiveMMR(userID, guildID int64) (*models.PlayerEffectiveMMR, error) {
+	if effective, ok := f.effective[userID]; ok {
+		copied := *effective
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeRatingStore) UpsertEffectiveMMR(effective *models.PlayerEffectiveMMR) error {
+	copied := *effective
+	f.effective[effective.UserID] = &copied
+	return nil
+}
+
+func (f *fakeRatingStore) CreateHistoricalMMR(request models.PlayerHistoricalMMRCreateRequest) error {
+	f.history = append(f.history, request)
+	return nil
+}
+
+type fakeUserDirectory struct {
+	users map[string]*models.User
+}
+
+func (f *fakeUserDirectory) FindUserByDiscordID(discordID string) (*models.User, error) {
+	if user, ok := f.users[discordID]; ok {
+		return user, nil
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func newTestMatchService() (*MatchService, *fakeRatingStore) {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333},
+	}
+	ratings := newFakeRatingStore()
+	users := &fakeUserDirectory{users: map[string]*models.User{
+		"100000000000000001": {ID: 1, DiscordID: "100000000000000001"},
+		"100000000000000002": {ID: 2, DiscordID: "100000000000000002"},
+		"100000000000000003": {ID: 3, DiscordID: "100000000000000003"},
+		"100000000000000004": {ID: 4, DiscordID: "100000000000000004"},
+	}}
+
+	return &MatchService{
+		matchRepo:  &fakeMatchStore{ratings: ratings},
+		ratingRepo: ratings,
+		userRepo:   users,
+		rater:      NewTrueSkillRater(cfg),
+		config:     cfg,
+	}, ratings
+}
+
+func TestRecordMatchReportUpdatesRatingsAndHistory(t *testing.T) {
+	service, ratings := newTestMatchService()
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 7, MMR: 1200, TrueSkillMu: 1100, TrueSkillSigma: 5, GamesPlayed: 3}
+
+	result, err := service.RecordMatchReport(MatchReport{
+		GuildID:    7,
+		TeamA:      []string{"100000000000000001", "100000000000000002"},
+		TeamB:      []string{"100000000000000003", "100000000000000004"},
+		TeamAScore: 3,
+		TeamBScore: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Changes) != 4 {
+		t.Fatalf("expected 4 changes, got %d", len(result.Changes))
+	}
+	if len(ratings.history) != 4 {
+		t.Fatalf("expected one history row per player, got %d", len(ratings.history))
+	}
+
+	for _, row := range ratings.history {
+		if row.ChangeReason != models.ChangeReasonMatchResult {
+			t.Errorf("expected reason %s, got %s", models.ChangeReasonMatchResult, row.ChangeReason)
+		}
+		if row.MatchID == nil || *row.MatchID != result.Match.ID {
+			t.Errorf("history row for user %d should link to match %d", row.UserID, result.Match.ID)
+		}
+	}
+
+	for _, change := range result.Changes {
+		won := change.Team == models.MatchTeamA
+		if won && change.MuAfter <= change.MuBefore {
+			t.Errorf("winner %d should gain mu: %.3f -> %.3f", change.UserID, change.MuBefore, change.MuAfter)
+		}
+		if !won && change.MuAfter >= change.MuBefore {
+			t.Errorf("loser %d should lose mu: %.3f -> %.3f", change.UserID, change.MuBefore, change.MuAfter)
+		}
+	}
+
+	seeded := ratings.effective[1]
+	if seeded.GamesPlayed != 4 || seeded.MMR != 1200 {
+		t.Errorf("existing rating should keep MMR and count the game, got games=%d mmr=%d", seeded.GamesPlayed, seeded.MMR)
+	}
+
+	newPlayer := ratings.effective[3]
+	if newPlayer == nil || newPlayer.GamesPlayed != 1 || newPlayer.TrueSkillMu >= 1000 {
+		t.Errorf("unrated loser should start from defaults and drop below 1000, got %+v", newPlayer)
+	}
+}
+
+func TestRecordMatchReportRejectsUnknownPlayers(t *testing.T) {
+	service, ratings := newTestMatchService()
+
+	_, err := service.RecordMatchReport(MatchReport{
+		GuildID: 7,
+		TeamA:   []string{"100000000000000001"},
+		TeamB:   []string{"999999999999999999"},
+	})
+	if !errors.Is(err, ErrInvalidMatchReport) {
+		t.Fatalf("expected ErrInvalidMatchReport, got %v", err)
+	}
+	if len(ratings.history) != 0 {
+		t.Errorf("no ratings should be written for a rejected match")
+	}
+}
+
+func TestRecordMatchRejectsDuplicatePlayers(t *testing.T) {
+	service, _ := newTestMatchService()
+
+	_, err := service.RecordMatch(models.MatchCreateRequest{
+		GuildID:      7,
+		TeamAUserIDs: []int64{1, 2},
+		TeamBUserIDs: []int64{2, 3},
+	})
+	if !errors.Is(err, ErrInvalidMatchReport) {
+		t.Fatalf("expected ErrInvalidMatchReport for a player on both teams, got %v", err)
+	}
+}
+
+func TestRecordMatchWritesNothingWhenTheMatchCannotBeStored(t *testing.T) {
+	service, ratings := newTestMatchService()
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 7, TrueSkillMu: 1100, TrueSkillSigma: 5}
+	store := service.matchRepo.(*fakeMatchStore)
+	store.fail = true
+
+	report := MatchReport{
+		GuildID:    7,
+		TeamA:      []string{"100000000000000001"},
+		TeamB:      []string{"100000000000000003"},
+		TeamAScore: 3,
+		TeamBScore: 1,
+	}
+	if result, err := service.RecordMatchReport(report); err == nil {
+		t.Fatalf("expected the failed write to be returned, got %+v", result)
+	}
+	if ratings.effective[1].TrueSkillMu != 1100 || ratings.effective[3] != nil || len(ratings.history) != 0 {
+		t.Fatalf("a failed match must not leave ratings or history behind")
+	}
+
+	// Nothing was stored, so the same report can simply be sent again
+	store.fail = false
+	result, err := service.RecordMatchReport(report)
+	if err != nil {
+		t.Fatalf("unexpected error on retry: %v", err)
+	}
+	if len(store.created) != 1 || len(ratings.history) != 2 || ratings.effective[3] == nil {
+		t.Errorf("expected the retry to record one match with both ratings, got %d matches and %d history rows", len(store.created), len(ratings.history))
+	}
+	if ratings.effective[1].TrueSkillMu != result.Changes[0].MuAfter {
+		t.Errorf("expected the winner's rating saved from the retry, got %+v", ratings.effective[1])
+	}
+}
+
+func TestRecordMatchRatesAgainWhenAConcurrentMatchChangedARating(t *testing.T) {
+	service, ratings := newTestMatchService()
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 7, TrueSkillMu: 1100, TrueSkillSigma: 5}
+	store := service.matchRepo.(*fakeMatchStore)
+	// Another match for player 1 lands after this one loaded the ratings
+	store.beforeRecord = func() {
+		ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 7, TrueSkillMu: 1150, TrueSkillSigma: 4.8, GamesPlayed: 1}
+	}
+
+	result, err := service.RecordMatchReport(MatchReport{
+		GuildID:    7,
+		TeamA:      []string{"100000000000000001"},
+		TeamB:      []string{"100000000000000003"},
+		TeamAScore: 3,
+		TeamBScore: 1,
+	})
+	if err != nil {
+		t.Fatalf("expected the match rated again and recorded, got %v", err)
+	}
+	if store.attempts != 2 || len(store.created) != 1 {
+		t.Fatalf("expected one rejected attempt then one recorded match, got %d attempts and %d matches", store.attempts, len(store.created))
+	}
+	if change := result.Changes[0]; change.MuBefore != 1150 || change.SigmaBefore != 4.8 {
+		t.Errorf("expected player 1 rated from the concurrent match's 1150/4.8, got %.3f/%.3f", change.MuBefore, change.SigmaBefore)
+	}
+	if ratings.effective[1].GamesPlayed != 2 {
+		t.Errorf("expected both matches counted for player 1, got %d games", ratings.effective[1].GamesPlayed)
+	}
+}
+
+func TestRecordMatchGivesUpAfterRepeatedRatingChanges(t *testing.T) {
+	service, ratings := newTestMatchService()
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 7, TrueSkillMu: 1100, TrueSkillSigma: 5}
+	store := service.matchRepo.(*fakeMatchStore)
+	service.matchRepo = &alwaysChangedMatchStore{fakeMatchStore: store}
+
+	_, err := service.RecordMatchReport(MatchReport{
+		GuildID:    7,
+		TeamA:      []string{"100000000000000001"},
+		TeamB:      []string{"100000000000000003"},
+		TeamAScore: 3,
+		TeamBScore: 1,
+	})
+	if !errors.Is(err, repositories.ErrRatingChanged) {
+		t.Fatalf("expected ErrRatingChanged after the last attempt, got %v", err)
+	}
+	if store.attempts != maxRecordMatchAttempts || len(ratings.history) != 0 {
+		t.Errorf("expected %d attempts and nothing written, got %d attempts and %d history rows", maxRecordMatchAttempts, store.attempts, len(ratings.history))
+	}
+}
+
+// alwaysChangedMatchStore moves player 1's rating before every write, as if matches kept landing
+type alwaysChangedMatchStore struct {
+	*fakeMatchStore
+}
+
+func (f *alwaysChangedMatchStore) RecordRatedMatch(record models.RatedMatchRecord) (*models.Match, error) {
+	f.ratings.effective[1].TrueSkillMu++
+	return f.fakeMatchStore.RecordRatedMatch(record)
+}
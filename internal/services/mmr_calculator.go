@@ -8,6 +8,19 @@ import (
 	"usl-server/internal/models"
 )
 
+// Season pooling strategies: how a playlist's current and previous season peaks become one MMR
+const (
+	SeasonPoolingGames  = "games"  // weighted by games played in each season
+	SeasonPoolingSeason = "season" // fixed current and previous season weights from config
+	SeasonPoolingHybrid = "hybrid" // games played, with previous-season games decayed by their age
+)
+
+// Hybrid pooling defaults
+const (
+	DefaultSeasonHalfLifeDays = 45.0 // previous-season games count half after this many days
+	NominalSeasonDays         = 90.0 // age of the previous season when the tracker date is unknown
+)
+
 // PlaylistData represents MMR and games for current and previous seasons
 type PlaylistData struct {
 	Current  PlaylistSeason `json:"current"`
@@ -26,6 +39,14 @@ type PlayerMMRData struct {
 	Ones   PlaylistData `json:"ones"`
 	Twos   PlaylistData `json:"twos"`
 	Threes PlaylistData `json:"threes"`
+
+	// GameSeason selects the rank distribution the peaks are read against; 0 uses the latest
+	GameSeason int `json:"gameSeason,omitempty"`
+	// TrackedAt is when the peaks were read; hybrid pooling ages the previous season from it
+	TrackedAt time.Time `json:"trackedAt,omitempty"`
+	// Tracker aggregation and the accounts behind each playlist, passed through to the breakdown
+	TrackerAggregation string              `json:"trackerAggregation,omitempty"`
+	Sources            map[string][]string `json:"sources,omitempty"`
 }
 
 // Type aliases for TrueSkill service compatibility
@@ -36,8 +57,10 @@ type PercentileSkillResult = SkillCalculationResult
 // PlaylistBreakdown represents the calculation breakdown for each playlist
 type PlaylistBreakdown struct {
 	EffectiveMMR    float64  `json:"effectiveMMR"`
+	Percentile      *float64 `json:"percentile"`      // rank distribution percentile, null when not counted
 	NormalizedSkill *float64 `json:"normalizedSkill"` // Pointer to allow null
 	Games           int      `json:"games"`
+	Sources         []string `json:"sources,omitempty"` // tracker URLs this playlist was taken from
 }
 
 // SkillCalculationResult matches the JavaScript return structure exactly
@@ -53,10 +76,15 @@ type SkillCalculationResult struct {
 }
 
 // AggregationInfo provides metadata about the calculation method
+// Method names the season pooling, e.g. "percentile-based:hybrid"; seeds from before pooling
+// was selectable record "percentile-based" and were pooled by games.
 type AggregationInfo struct {
-	Method    string `json:"method"`
-	Converter string `json:"converter"`
-	Timestamp string `json:"timestamp"`
+	Method     string `json:"method"`
+	Converter  string `json:"converter"`
+	RankSeason int    `json:"rankSeason,omitempty"` // game season of the rank distribution used
+	// TrackerAggregation is how the player's trackers were combined before seeding
+	TrackerAggregation string `json:"trackerAggregation,omitempty"`
+	Timestamp          string `json:"timestamp"`
 }
 
 // MMRCalculator handles all MMR calculation logic
@@ -79,7 +107,11 @@ func NewMMRCalculator(cfg *config.Config, percentileConverter *PercentileConvert
 func (m *MMRCalculator) CalculatePercentileBasedSkill(playerData PlayerMMRData) SkillCalculationResult {
 
 	// Validate input data
-	if err := m.validatePlayerData(playerData); err != nil {
+	pooling, err := m.seasonPooling()
+	if err == nil {
+		err = m.validatePlayerData(playerData)
+	}
+	if err != nil {
 		return SkillCalculationResult{
 			NormalizedSkill: 50.0,   // Default to median
 			TrueskillMu:     1000.0, // Default μ for 50th percentile (0-2000 range)
@@ -96,20 +128,20 @@ func (m *MMRCalculator) CalculatePercentileBasedSkill(playerData PlayerMMRData)
 	}
 
 	playlistEffectiveMMRs := make(map[string]float64)
+	playlistPercentiles := make(map[string]*float64)
 	playlistNormalizedSkills := make(map[string]*float64)
 
-	minGames := 10 // config.RELIABILITY_THRESHOLDS.MIN_GAMES_INCLUSION
+	minGames := m.config.MMR.MinGamesThreshold
+	if minGames <= 0 {
+		minGames = MinGamesThreshold
+	}
+	rankSeason := m.percentileConverter.SeasonFor(playerData.GameSeason)
+	previousSeasonAge := m.previousSeasonAge(playerData.TrackedAt)
 
-	// Calculate effective MMR for each playlist (games-weighted pooling)
+	// Calculate effective MMR for each playlist with the configured season pooling
 	for playlistName, data := range playlists {
 		totalGames := data.Current.Games + data.Previous.Games
-		var effectiveMMR float64
-
-		if totalGames > 0 {
-			effectiveMMR = float64(data.Current.MMR*data.Current.Games+data.Previous.MMR*data.Previous.Games) / float64(totalGames)
-		} else {
-			effectiveMMR = 0
-		}
+		effectiveMMR := m.poolSeasons(data, pooling, previousSeasonAge)
 
 		playlistEffectiveMMRs[playlistName] = effectiveMMR
 
@@ -121,9 +153,12 @@ func (m *MMRCalculator) CalculatePercentileBasedSkill(playerData PlayerMMRData)
 				"threes": "standard",
 			}
 
-			normalizedSkill := m.percentileConverter.MMRToNormalizedSkill(effectiveMMR, playlistMapping[playlistName])
+			percentile := m.percentileConverter.MMRToPercentileForSeason(effectiveMMR, playlistMapping[playlistName], rankSeason)
+			normalizedSkill := m.percentileConverter.PercentileToNormalizedSkill(percentile)
+			playlistPercentiles[playlistName] = &percentile
 			playlistNormalizedSkills[playlistName] = &normalizedSkill
 		} else {
+			playlistPercentiles[playlistName] = nil
 			playlistNormalizedSkills[playlistName] = nil
 		}
 	}
@@ -149,18 +184,24 @@ func (m *MMRCalculator) CalculatePercentileBasedSkill(playerData PlayerMMRData)
 	breakdown := map[string]PlaylistBreakdown{
 		"ones": {
 			EffectiveMMR:    playlistEffectiveMMRs["ones"],
+			Percentile:      playlistPercentiles["ones"],
 			NormalizedSkill: playlistNormalizedSkills["ones"],
 			Games:           playerData.Ones.Current.Games + playerData.Ones.Previous.Games,
+			Sources:         playerData.Sources["ones"],
 		},
 		"twos": {
 			EffectiveMMR:    playlistEffectiveMMRs["twos"],
+			Percentile:      playlistPercentiles["twos"],
 			NormalizedSkill: playlistNormalizedSkills["twos"],
 			Games:           playerData.Twos.Current.Games + playerData.Twos.Previous.Games,
+			Sources:         playerData.Sources["twos"],
 		},
 		"threes": {
 			EffectiveMMR:    playlistEffectiveMMRs["threes"],
+			Percentile:      playlistPercentiles["threes"],
 			NormalizedSkill: playlistNormalizedSkills["threes"],
 			Games:           playerData.Threes.Current.Games + playerData.Threes.Previous.Games,
+			Sources:         playerData.Sources["threes"],
 		},
 	}
 
@@ -171,9 +212,11 @@ func (m *MMRCalculator) CalculatePercentileBasedSkill(playerData PlayerMMRData)
 		Breakdown:       breakdown,
 		Weights:         weights,
 		AggregationInfo: AggregationInfo{
-			Method:    "percentile-based",
-			Converter: "PercentileConverter",
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Method:             "percentile-based:" + pooling,
+			Converter:          "PercentileConverter",
+			RankSeason:         rankSeason,
+			TrackerAggregation: playerData.TrackerAggregation,
+			Timestamp:          time.Now().UTC().Format(time.RFC3339),
 		},
 	}
 }
@@ -219,11 +262,64 @@ func (m *MMRCalculator) CalculatePercentileSkillFromTracker(tracker models.UserT
 			Current:  PlaylistSeason{MMR: tracker.ThreesCurrentSeasonPeak, Games: tracker.ThreesCurrentSeasonGames},
 			Previous: PlaylistSeason{MMR: tracker.ThreesPreviousSeasonPeak, Games: tracker.ThreesPreviousSeasonGames},
 		},
+		GameSeason: m.percentileConverter.SeasonAt(tracker.LastUpdated),
+		TrackedAt:  tracker.LastUpdated,
 	}
 
 	return m.CalculatePercentileBasedSkill(playerData)
 }
 
+// seasonPooling returns the configured season pooling strategy, games when none is set
+func (m *MMRCalculator) seasonPooling() (string, error) {
+	switch pooling := m.config.MMR.SeasonPooling; pooling {
+	case "":
+		return SeasonPoolingGames, nil
+	case SeasonPoolingGames, SeasonPoolingSeason, SeasonPoolingHybrid:
+		return pooling, nil
+	default:
+		return "", fmt.Errorf("unknown season pooling %q: expected %s, %s or %s",
+			pooling, SeasonPoolingGames, SeasonPoolingSeason, SeasonPoolingHybrid)
+	}
+}
+
+// poolSeasons combines a playlist's current and previous season peaks into one effective MMR
+// Games weights each season by its games played; season uses the configured season weights
+// for each season with games; hybrid weights by games played, halving a previous-season game's
+// weight every SeasonHalfLifeDays since that season ended, so the previous peak counts almost
+// fully early in a season and fades as the current season goes on.
+func (m *MMRCalculator) poolSeasons(data PlaylistData, pooling string, previousSeasonAge float64) float64 {
+	currentWeight := float64(data.Current.Games)
+	previousWeight := float64(data.Previous.Games)
+
+	switch pooling {
+	case SeasonPoolingSeason:
+		currentWeight = math.Min(currentWeight, 1) * m.config.MMR.CurrentSeasonWeight
+		previousWeight = math.Min(previousWeight, 1) * m.config.MMR.PreviousSeasonWeight
+	case SeasonPoolingHybrid:
+		halfLife := m.config.MMR.SeasonHalfLifeDays
+		if halfLife <= 0 {
+			halfLife = DefaultSeasonHalfLifeDays
+		}
+		previousWeight *= math.Pow(0.5, previousSeasonAge/halfLife)
+	}
+
+	totalWeight := currentWeight + previousWeight
+	if totalWeight <= 0 {
+		return 0
+	}
+	return (float64(data.Current.MMR)*currentWeight + float64(data.Previous.MMR)*previousWeight) / totalWeight
+}
+
+// previousSeasonAge returns how many days before trackedAt the previous season ended, which is
+// when the tracker's current season began; NominalSeasonDays when that season is not known
+func (m *MMRCalculator) previousSeasonAge(trackedAt time.Time) float64 {
+	start, ok := m.percentileConverter.SeasonStartedAt(trackedAt)
+	if !ok {
+		return NominalSeasonDays
+	}
+	return trackedAt.Sub(start).Hours() / 24
+}
+
 // validatePlayerData ensures the input data is valid
 func (m *MMRCalculator) validatePlayerData(playerData PlayerMMRData) error {
 	// Basic validation - could be expanded based on JavaScript validation logic
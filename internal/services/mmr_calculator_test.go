@@ -0,0 +1,123 @@
+package services
+
+import (
+	"testing"
+	"time"
+	"usl-server/internal/config"
+)
+
+func TestSeasonPoolingStrategies(t *testing.T) {
+	newCalculator := func(pooling string, minGames int) *MMRCalculator {
+		cfg := &config.Config{
+			MMR: config.MMRConfig{
+				OnesWeight:           1.0,
+				TwosWeight:           1.5,
+				ThreesWeight:         1.2,
+				MinGamesThreshold:    minGames,
+				CurrentSeasonWeight:  0.7,
+				PreviousSeasonWeight: 0.3,
+				SeasonPooling:        pooling,
+			},
+		}
+		return NewMMRCalculator(cfg, NewPercentileConverter(cfg))
+	}
+	// Few games at a higher peak this season, many at a lower peak last season
+	playerData := PlayerMMRData{
+		Twos: PlaylistData{
+			Current:  PlaylistSeason{MMR: 1200, Games: 20},
+			Previous: PlaylistSeason{MMR: 1000, Games: 80},
+		},
+		Ones: PlaylistData{Current: PlaylistSeason{MMR: 900, Games: 12}},
+	}
+
+	tests := []struct {
+		pooling string
+		twos    float64
+		method  string
+	}{
+		{"", 1040, "percentile-based:games"},
+		{SeasonPoolingGames, 1040, "percentile-based:games"},
+		{SeasonPoolingSeason, 1140, "percentile-based:season"},
+		{SeasonPoolingHybrid, 1100, "percentile-based:hybrid"}, // no tracker date: last season ended NominalSeasonDays ago
+	}
+	for _, tt := range tests {
+		result := newCalculator(tt.pooling, 10).CalculatePercentileBasedSkill(playerData)
+		if result.Error != "" {
+			t.Fatalf("%q: unexpected error %s", tt.pooling, result.Error)
+		}
+		if got := result.Breakdown["twos"].EffectiveMMR; abs(got-tt.twos) > 0.01 {
+			t.Errorf("%q: expected 2v2 pooled to %.2f, got %.2f", tt.pooling, tt.twos, got)
+		}
+		if abs(result.Breakdown["ones"].EffectiveMMR-900) > 0.01 {
+			t.Errorf("%q: expected a single season to pool to its own peak, got %.2f", tt.pooling, result.Breakdown["ones"].EffectiveMMR)
+		}
+		if result.AggregationInfo.Method != tt.method {
+			t.Errorf("%q: expected method %s, got %s", tt.pooling, tt.method, result.AggregationInfo.Method)
+		}
+	}
+
+	strict := newCalculator(SeasonPoolingGames, 50).CalculatePercentileBasedSkill(playerData)
+	if strict.Breakdown["ones"].NormalizedSkill != nil || strict.Breakdown["twos"].NormalizedSkill == nil {
+		t.Errorf("expected MinGamesThreshold to drop the 12-game playlist only, got %+v", strict.Breakdown)
+	}
+
+	unknown := newCalculator("median", 10).CalculatePercentileBasedSkill(playerData)
+	if unknown.Error == "" || unknown.Fallback != "default_values" {
+		t.Errorf("expected an unknown pooling to fall back to default values, got %+v", unknown)
+	}
+}
+
+func TestConfigAcceptsEverySeasonPooling(t *testing.T) {
+	for _, pooling := range []string{SeasonPoolingGames, SeasonPoolingSeason, SeasonPoolingHybrid} {
+		if err := (config.MMRConfig{SeasonPooling: pooling, TrackerAggregation: TrackerAggregationMax}).Validate(); err != nil {
+			t.Errorf("config should accept season pooling %q: %v", pooling, err)
+		}
+	}
+}
+
+func TestHybridPoolingDecaysThePreviousSeasonWithAge(t *testing.T) {
+	newCalculator := func(halfLife float64) *MMRCalculator {
+		cfg := &config.Config{
+			MMR: config.MMRConfig{
+				TwosWeight:         1.0,
+				MinGamesThreshold:  10,
+				SeasonPooling:      SeasonPoolingHybrid,
+				SeasonHalfLifeDays: halfLife,
+			},
+		}
+		return NewMMRCalculator(cfg, NewPercentileConverter(cfg))
+	}
+	// The built-in season 14 table starts on 2024-03-07
+	seasonStart := time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC)
+	twos := PlaylistData{
+		Current:  PlaylistSeason{MMR: 1200, Games: 20},
+		Previous: PlaylistSeason{MMR: 1000, Games: 80},
+	}
+
+	tests := []struct {
+		name      string
+		halfLife  float64
+		trackedAt time.Time
+		twos      float64
+	}{
+		{"read as the season starts, last season counts fully", 45, seasonStart, 1040},
+		{"one half-life in, last season's games count half", 45, seasonStart.AddDate(0, 0, 45), 1066.67},
+		{"two half-lives in, they count a quarter", 45, seasonStart.AddDate(0, 0, 90), 1100},
+		{"a shorter half-life fades them faster", 15, seasonStart.AddDate(0, 0, 45), 1133.33},
+		{"unset half-life uses the default", 0, seasonStart.AddDate(0, 0, 45), 1066.67},
+		{"unknown tracker date ages last season a nominal season", 45, time.Time{}, 1100},
+		{"tracker older than every table ages last season a nominal season", 45, seasonStart.AddDate(0, 0, -10), 1100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newCalculator(tt.halfLife).CalculatePercentileBasedSkill(PlayerMMRData{Twos: twos, TrackedAt: tt.trackedAt})
+			if got := result.Breakdown["twos"].EffectiveMMR; abs(got-tt.twos) > 0.01 {
+				t.Errorf("expected 2v2 pooled to %.2f, got %.2f", tt.twos, got)
+			}
+		})
+	}
+
+	if err := (config.MMRConfig{SeasonPooling: SeasonPoolingHybrid, TrackerAggregation: TrackerAggregationMax, SeasonHalfLifeDays: -1}).Validate(); err == nil {
+		t.Error("config should reject a negative season half-life")
+	}
+}
@@ -1,11 +1,24 @@
 package services
 
 import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
 	"math"
 	"sort"
+	"sync"
+	"time"
 	"usl-server/internal/config"
+	"usl-server/internal/models"
 )
 
+// builtInRankDistributions holds the versioned rank distributions shipped with the server
+// Newer seasons are uploaded by admins and stored in the rank_distributions table.
+//
+//go:embed rank_distributions/*.json
+var builtInRankDistributions embed.FS
+
 // RankRange represents MMR range for a rank
 type RankRange struct {
 	Rank   string
@@ -13,80 +26,180 @@ type RankRange struct {
 	MaxMMR float64
 }
 
+// RankDivisions is how many divisions every rank below the top one is split into
+const RankDivisions = 4
+
+// rankDivisionNumerals labels divisions the way the game does
+var rankDivisionNumerals = []string{"I", "II", "III", "IV"}
+
+// RankLookup is the rank and division an MMR falls in for one playlist and game season
+// Divisions split a rank's MMR range into equal parts; the game's own division bounds
+// overlap slightly, so a player near a boundary may see the neighbouring division in game.
+type RankLookup struct {
+	Playlist   string  `json:"playlist"` // soloDuel, doubles or standard
+	MMR        float64 `json:"mmr"`
+	Rank       string  `json:"rank"`     // e.g. "Champion 2"
+	Division   int     `json:"division"` // 1-4, or 0 for the top rank, which has none
+	Name       string  `json:"name"`     // rank and division, e.g. "Champion 2 Div III"
+	Percentile float64 `json:"percentile"`
+	GameSeason int     `json:"game_season"`
+	MinMMR     float64 `json:"min_mmr"`
+	MaxMMR     float64 `json:"max_mmr"`
+}
+
 // RankInfo contains cached rank information for O(1) lookup
 type RankInfo struct {
 	CumulativeBelow float64
 	RankPercent     float64
 }
 
+// rankTable is one game season's distribution prepared for lookups
+type rankTable struct {
+	distribution *models.RankDistribution
+	sortedRanges map[string][]RankRange
+	rankInfo     map[string]map[string]RankInfo
+}
+
 // PercentileConverter handles MMR to percentile conversions
-// Exact port of JavaScript PercentileConverter with performance optimizations
+// Exact port of JavaScript PercentileConverter with performance optimizations.
+// Rank distributions are versioned by game season; conversions use the requested season's
+// table, or the latest season when none is given.
 type PercentileConverter struct {
-	config                 *config.Config
-	sortedRangesByPlaylist map[string][]RankRange
-	rankOrderCache         map[string]map[string]RankInfo
+	config *config.Config
+
+	mu     sync.RWMutex
+	tables map[int]*rankTable
 }
 
 func NewPercentileConverter(cfg *config.Config) *PercentileConverter {
-	return &PercentileConverter{
-		config:                 cfg,
-		sortedRangesByPlaylist: make(map[string][]RankRange),
-		rankOrderCache:         make(map[string]map[string]RankInfo),
+	converter := &PercentileConverter{
+		config: cfg,
+		tables: make(map[int]*rankTable),
+	}
+	if err := converter.LoadDistributions(loadBuiltInRankDistributions()); err != nil {
+		panic(fmt.Sprintf("invalid built-in rank distribution: %v", err))
+	}
+	return converter
+}
+
+// LoadDistributions adds or replaces the tables of the given game seasons
+func (p *PercentileConverter) LoadDistributions(distributions []*models.RankDistribution) error {
+	tables := make([]*rankTable, 0, len(distributions))
+	for _, distribution := range distributions {
+		if err := distribution.Validate(); err != nil {
+			return fmt.Errorf("season %d: %w", distribution.GameSeason, err)
+		}
+		tables = append(tables, newRankTable(distribution))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, table := range tables {
+		p.tables[table.distribution.GameSeason] = table
+	}
+	return nil
+}
+
+// Distributions returns every loaded distribution, oldest season first
+func (p *PercentileConverter) Distributions() []*models.RankDistribution {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	distributions := make([]*models.RankDistribution, 0, len(p.tables))
+	for _, table := range p.tables {
+		distributions = append(distributions, table.distribution)
+	}
+	sort.Slice(distributions, func(i, j int) bool {
+		return distributions[i].GameSeason < distributions[j].GameSeason
+	})
+	return distributions
+}
+
+// SeasonAt returns the game season whose distribution applies to data from the given time:
+// the latest season started by then, the earliest season for older data, or the latest
+// season when the time is unknown
+func (p *PercentileConverter) SeasonAt(at time.Time) int {
+	distributions := p.Distributions()
+	if len(distributions) == 0 {
+		return 0
+	}
+	if at.IsZero() {
+		return distributions[len(distributions)-1].GameSeason
+	}
+
+	season := distributions[0].GameSeason
+	for _, distribution := range distributions {
+		if distribution.StartsAt.After(at) {
+			break
+		}
+		season = distribution.GameSeason
 	}
+	return season
 }
 
-// MMRToPercentile converts MMR to percentile for a given playlist
-// Exact port of JavaScript mmrToPercentile() with binary search optimization
+// SeasonStartedAt returns when the latest season started by the given time began; false
+// when the time is unknown or older than every loaded season
+func (p *PercentileConverter) SeasonStartedAt(at time.Time) (time.Time, bool) {
+	if at.IsZero() {
+		return time.Time{}, false
+	}
+
+	var start time.Time
+	for _, distribution := range p.Distributions() {
+		if distribution.StartsAt.After(at) {
+			break
+		}
+		start = distribution.StartsAt
+	}
+	return start, !start.IsZero()
+}
+
+// SeasonFor returns the game season whose table a conversion for season uses
+func (p *PercentileConverter) SeasonFor(season int) int {
+	if table := p.table(season); table != nil {
+		return table.distribution.GameSeason
+	}
+	return 0
+}
+
+// MMRToPercentile converts MMR to percentile for a given playlist using the latest season
 func (p *PercentileConverter) MMRToPercentile(mmr float64, playlist string) float64 {
+	return p.MMRToPercentileForSeason(mmr, playlist, 0)
+}
+
+// MMRToPercentileForSeason converts MMR to percentile with a game season's distribution
+// Exact port of JavaScript mmrToPercentile() with binary search optimization.
+// Season 0 uses the latest season; a season without a table uses the closest earlier one.
+func (p *PercentileConverter) MMRToPercentileForSeason(mmr float64, playlist string, season int) float64 {
 
 	// Validate inputs
 	if mmr < 0 {
 		mmr = 0
 	}
 
-	// Use hardcoded rank distributions matching JavaScript config
-	rankDistributions := p.getRankDistributions()
-	mmrRanges := p.getMMRRanges()
-
-	if _, exists := rankDistributions[playlist]; !exists {
-		playlist = "doubles" // Default fallback
+	table := p.table(season)
+	if table == nil {
+		return 50.0 // Default to median
 	}
 
-	distribution := rankDistributions[playlist]
-	ranges := mmrRanges[playlist]
-
-	if _, exists := p.sortedRangesByPlaylist[playlist]; !exists {
-		var sortedRanges []RankRange
-		for rank, mmrRange := range ranges {
-			sortedRanges = append(sortedRanges, RankRange{
-				Rank:   rank,
-				MinMMR: mmrRange[0],
-				MaxMMR: mmrRange[1],
-			})
-		}
-		sort.Slice(sortedRanges, func(i, j int) bool {
-			return sortedRanges[i].MinMMR < sortedRanges[j].MinMMR
-		})
-		p.sortedRangesByPlaylist[playlist] = sortedRanges
+	if _, exists := table.sortedRanges[playlist]; !exists {
+		playlist = models.RankPlaylistDoubles // Default fallback
 	}
+	sortedRanges := table.sortedRanges[playlist]
 
 	// Binary search to find rank
-	targetRank := p.binarySearchRank(mmr, p.sortedRangesByPlaylist[playlist])
+	rankRange, found := p.binarySearchRank(mmr, sortedRanges)
 
 	// Handle edge cases
-	if targetRank == "" {
-		if mmr < p.getLowestMMR(ranges) {
+	if !found {
+		if mmr < sortedRanges[0].MinMMR {
 			return 0.00001 // Below lowest rank
 		} else {
 			return 99.99999 // Above highest rank
 		}
 	}
 
-	if _, exists := p.rankOrderCache[playlist]; !exists {
-		p.rankOrderCache[playlist] = p.buildRankOrderCache(playlist, distribution)
-	}
-
-	rankInfo, exists := p.rankOrderCache[playlist][targetRank]
+	rankInfo, exists := table.rankInfo[playlist][rankRange.Rank]
 	if !exists {
 		return 50.0 // Default to median
 	}
@@ -95,10 +208,9 @@ func (p *PercentileConverter) MMRToPercentile(mmr float64, playlist string) floa
 	cumulativePercent := rankInfo.CumulativeBelow
 
 	// Interpolate within the rank based on MMR position
-	rankRange := ranges[targetRank]
-	rankSpan := rankRange[1] - rankRange[0]
+	rankSpan := rankRange.MaxMMR - rankRange.MinMMR
 	if rankSpan > 0 {
-		positionWithinRank := (mmr - rankRange[0]) / rankSpan
+		positionWithinRank := (mmr - rankRange.MinMMR) / rankSpan
 		positionWithinRank = math.Max(0, math.Min(1, positionWithinRank))
 		cumulativePercent += positionWithinRank * rankInfo.RankPercent
 	}
@@ -106,10 +218,57 @@ func (p *PercentileConverter) MMRToPercentile(mmr float64, playlist string) floa
 	return math.Max(0.00001, math.Min(99.99999, cumulativePercent))
 }
 
+// LookupRank returns the rank and division mmr falls in with a game season's distribution
+// Playlist is a distribution key. MMR below the lowest rank counts as the lowest rank, above
+// the top rank as the top rank, and in a gap between ranks as the rank below the gap.
+// ok is false when no distribution covers the playlist.
+func (p *PercentileConverter) LookupRank(mmr float64, playlist string, season int) (RankLookup, bool) {
+	table := p.table(season)
+	if table == nil || len(table.sortedRanges[playlist]) == 0 {
+		return RankLookup{}, false
+	}
+	sortedRanges := table.sortedRanges[playlist]
+
+	// Bands are whole numbers, so round before searching
+	rounded := math.Round(mmr)
+	rankRange, found := p.binarySearchRank(rounded, sortedRanges)
+	if !found {
+		rankRange = sortedRanges[0]
+		for _, r := range sortedRanges {
+			if r.MinMMR > rounded {
+				break
+			}
+			rankRange = r
+		}
+	}
+
+	lookup := RankLookup{
+		Playlist:   playlist,
+		MMR:        mmr,
+		Rank:       rankRange.Rank,
+		Name:       rankRange.Rank,
+		Percentile: p.MMRToPercentileForSeason(mmr, playlist, table.distribution.GameSeason),
+		GameSeason: table.distribution.GameSeason,
+		MinMMR:     rankRange.MinMMR,
+		MaxMMR:     rankRange.MaxMMR,
+	}
+	if rankRange != sortedRanges[len(sortedRanges)-1] {
+		position := (rounded - rankRange.MinMMR) / (rankRange.MaxMMR - rankRange.MinMMR + 1)
+		lookup.Division = int(math.Max(1, math.Min(RankDivisions, math.Floor(position*RankDivisions)+1)))
+		lookup.Name = fmt.Sprintf("%s Div %s", rankRange.Rank, rankDivisionNumerals[lookup.Division-1])
+	}
+	return lookup, true
+}
+
 // MMRToNormalizedSkill converts MMR to normalized skill (0-100 scale)
 // Exact port of JavaScript mmrToNormalizedSkill()
 func (p *PercentileConverter) MMRToNormalizedSkill(mmr float64, playlist string) float64 {
-	percentile := p.MMRToPercentile(mmr, playlist)
+	return p.MMRToNormalizedSkillForSeason(mmr, playlist, 0)
+}
+
+// MMRToNormalizedSkillForSeason converts MMR to normalized skill with a game season's distribution
+func (p *PercentileConverter) MMRToNormalizedSkillForSeason(mmr float64, playlist string, season int) float64 {
+	percentile := p.MMRToPercentileForSeason(mmr, playlist, season)
 	return p.PercentileToNormalizedSkill(percentile)
 }
 
@@ -181,7 +340,32 @@ func (p *PercentileConverter) AggregatePlaylistSkills(playlistSkills map[string]
 
 // Helper functions
 
-func (p *PercentileConverter) binarySearchRank(mmr float64, sortedRanges []RankRange) string {
+// table returns the season's table, the closest earlier season's, or the latest for season 0
+func (p *PercentileConverter) table(season int) *rankTable {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if table, exists := p.tables[season]; exists {
+		return table
+	}
+
+	var best, earliest *rankTable
+	for number, table := range p.tables {
+		if earliest == nil || number < earliest.distribution.GameSeason {
+			earliest = table
+		}
+		if (season == 0 || number < season) && (best == nil || number > best.distribution.GameSeason) {
+			best = table
+		}
+	}
+	if best == nil {
+		return earliest
+	}
+	return best
+}
+
+// binarySearchRank finds the range containing mmr
+func (p *PercentileConverter) binarySearchRank(mmr float64, sortedRanges []RankRange) (RankRange, bool) {
 	left, right := 0, len(sortedRanges)-1
 
 	for left <= right {
@@ -189,7 +373,7 @@ func (p *PercentileConverter) binarySearchRank(mmr float64, sortedRanges []RankR
 		r := sortedRanges[mid]
 
 		if mmr >= r.MinMMR && mmr <= r.MaxMMR {
-			return r.Rank
+			return r, true
 		} else if mmr < r.MinMMR {
 			right = mid - 1
 		} else {
@@ -197,120 +381,67 @@ func (p *PercentileConverter) binarySearchRank(mmr float64, sortedRanges []RankR
 		}
 	}
 
-	return "" // Not found
+	return RankRange{}, false // Not found
 }
 
-func (p *PercentileConverter) getLowestMMR(ranges map[string][2]float64) float64 {
-	lowest := math.Inf(1)
-	for _, mmrRange := range ranges {
-		if mmrRange[0] < lowest {
-			lowest = mmrRange[0]
+// newRankTable sorts each playlist's ranges and caches the share of players below each rank
+func newRankTable(distribution *models.RankDistribution) *rankTable {
+	table := &rankTable{
+		distribution: distribution,
+		sortedRanges: make(map[string][]RankRange, len(distribution.Playlists)),
+		rankInfo:     make(map[string]map[string]RankInfo, len(distribution.Playlists)),
+	}
+
+	for playlist, bands := range distribution.Playlists {
+		sortedRanges := make([]RankRange, 0, len(bands))
+		for _, band := range bands {
+			sortedRanges = append(sortedRanges, RankRange{Rank: band.Rank, MinMMR: band.MinMMR, MaxMMR: band.MaxMMR})
 		}
+		sort.Slice(sortedRanges, func(i, j int) bool {
+			return sortedRanges[i].MinMMR < sortedRanges[j].MinMMR
+		})
+		table.sortedRanges[playlist] = sortedRanges
+		table.rankInfo[playlist] = buildRankOrderCache(bands)
 	}
-	return lowest
+
+	return table
 }
 
-func (p *PercentileConverter) buildRankOrderCache(playlist string, distribution map[string]float64) map[string]RankInfo {
-	cache := make(map[string]RankInfo)
+// buildRankOrderCache accumulates rank percentages from the lowest rank up
+func buildRankOrderCache(bands []models.RankBand) map[string]RankInfo {
+	cache := make(map[string]RankInfo, len(bands))
 
-	// EXACT JavaScript rank order matching PercentileConfig.js
 	var cumulative float64
-
-	// Rank order matching JavaScript _buildRankOrderCache()
-	rankOrder := []string{
-		"Bronze 1", "Bronze 2", "Bronze 3",
-		"Silver 1", "Silver 2", "Silver 3",
-		"Gold 1", "Gold 2", "Gold 3",
-		"Platinum 1", "Platinum 2", "Platinum 3",
-		"Diamond 1", "Diamond 2", "Diamond 3",
-		"Champion 1", "Champion 2", "Champion 3",
-		"Grand Champion 1", "Grand Champion 2", "Grand Champion 3",
-		"Supersonic Legend",
-	}
-
-	for _, rank := range rankOrder {
-		if percent, exists := distribution[rank]; exists {
-			cache[rank] = RankInfo{
-				CumulativeBelow: cumulative,
-				RankPercent:     percent,
-			}
-			cumulative += percent
+	for _, band := range bands {
+		cache[band.Rank] = RankInfo{
+			CumulativeBelow: cumulative,
+			RankPercent:     band.Percent,
 		}
+		cumulative += band.Percent
 	}
 
 	return cache
 }
 
-// Hardcoded configuration data (matches JavaScript PercentileConfig)
-
-func (p *PercentileConverter) getRankDistributions() map[string]map[string]float64 {
-	// EXACT JavaScript rank distributions from PercentileConfig.js (Season 14, 2024)
-	return map[string]map[string]float64{
-		"soloDuel": {
-			"Bronze 1": 0.063, "Bronze 2": 0.296, "Bronze 3": 0.952,
-			"Silver 1": 2.248, "Silver 2": 4.383, "Silver 3": 7.353,
-			"Gold 1": 11.090, "Gold 2": 14.354, "Gold 3": 16.356,
-			"Platinum 1": 16.361, "Platinum 2": 11.923, "Platinum 3": 7.116,
-			"Diamond 1": 3.828, "Diamond 2": 1.864, "Diamond 3": 0.921,
-			"Champion 1": 0.473, "Champion 2": 0.217, "Champion 3": 0.103,
-			"Grand Champion 1": 0.053, "Grand Champion 2": 0.024, "Grand Champion 3": 0.011,
-			"Supersonic Legend": 0.013,
-		},
-		"doubles": {
-			"Bronze 1": 0.292, "Bronze 2": 0.713, "Bronze 3": 1.485,
-			"Silver 1": 2.741, "Silver 2": 4.411, "Silver 3": 6.346,
-			"Gold 1": 8.427, "Gold 2": 9.790, "Gold 3": 10.237,
-			"Platinum 1": 10.422, "Platinum 2": 9.093, "Platinum 3": 7.552,
-			"Diamond 1": 8.364, "Diamond 2": 6.109, "Diamond 3": 4.451,
-			"Champion 1": 4.663, "Champion 2": 2.397, "Champion 3": 1.272,
-			"Grand Champion 1": 0.809, "Grand Champion 2": 0.293, "Grand Champion 3": 0.087,
-			"Supersonic Legend": 0.045,
-		},
-		"standard": {
-			"Bronze 1": 0.112, "Bronze 2": 0.347, "Bronze 3": 0.956,
-			"Silver 1": 2.316, "Silver 2": 4.882, "Silver 3": 8.466,
-			"Gold 1": 12.146, "Gold 2": 13.673, "Gold 3": 12.832,
-			"Platinum 1": 11.137, "Platinum 2": 8.700, "Platinum 3": 6.701,
-			"Diamond 1": 6.651, "Diamond 2": 4.291, "Diamond 3": 2.742,
-			"Champion 1": 2.339, "Champion 2": 0.989, "Champion 3": 0.431,
-			"Grand Champion 1": 0.205, "Grand Champion 2": 0.064, "Grand Champion 3": 0.017,
-			"Supersonic Legend": 0.003,
-		},
+// loadBuiltInRankDistributions parses every distribution file embedded in the binary
+func loadBuiltInRankDistributions() []*models.RankDistribution {
+	files, err := fs.Glob(builtInRankDistributions, "rank_distributions/*.json")
+	if err != nil {
+		panic(fmt.Sprintf("cannot list built-in rank distributions: %v", err))
 	}
-}
 
-func (p *PercentileConverter) getMMRRanges() map[string]map[string][2]float64 {
-	// EXACT JavaScript MMR ranges from PercentileConfig.js
-	return map[string]map[string][2]float64{
-		"soloDuel": {
-			"Bronze 1": {0, 152}, "Bronze 2": {153, 214}, "Bronze 3": {215, 274},
-			"Silver 1": {275, 334}, "Silver 2": {335, 394}, "Silver 3": {395, 454},
-			"Gold 1": {455, 514}, "Gold 2": {515, 574}, "Gold 3": {575, 634},
-			"Platinum 1": {635, 694}, "Platinum 2": {695, 754}, "Platinum 3": {755, 814},
-			"Diamond 1": {815, 874}, "Diamond 2": {875, 934}, "Diamond 3": {935, 994},
-			"Champion 1": {995, 1054}, "Champion 2": {1055, 1114}, "Champion 3": {1115, 1174},
-			"Grand Champion 1": {1175, 1234}, "Grand Champion 2": {1235, 1294}, "Grand Champion 3": {1295, 1354},
-			"Supersonic Legend": {1355, 2000},
-		},
-		"doubles": {
-			"Bronze 1": {0, 152}, "Bronze 2": {153, 214}, "Bronze 3": {215, 274},
-			"Silver 1": {275, 334}, "Silver 2": {335, 394}, "Silver 3": {395, 454},
-			"Gold 1": {455, 514}, "Gold 2": {515, 574}, "Gold 3": {575, 634},
-			"Platinum 1": {635, 694}, "Platinum 2": {695, 754}, "Platinum 3": {755, 814},
-			"Diamond 1": {815, 874}, "Diamond 2": {875, 934}, "Diamond 3": {935, 994},
-			"Champion 1": {995, 1074}, "Champion 2": {1075, 1174}, "Champion 3": {1175, 1274},
-			"Grand Champion 1": {1275, 1374}, "Grand Champion 2": {1375, 1474}, "Grand Champion 3": {1475, 1574},
-			"Supersonic Legend": {1575, 2300},
-		},
-		"standard": {
-			"Bronze 1": {0, 152}, "Bronze 2": {153, 214}, "Bronze 3": {215, 274},
-			"Silver 1": {275, 334}, "Silver 2": {335, 394}, "Silver 3": {395, 454},
-			"Gold 1": {455, 514}, "Gold 2": {515, 574}, "Gold 3": {575, 634},
-			"Platinum 1": {635, 694}, "Platinum 2": {695, 754}, "Platinum 3": {755, 834},
-			"Diamond 1": {835, 914}, "Diamond 2": {915, 994}, "Diamond 3": {995, 1074},
-			"Champion 1": {1075, 1174}, "Champion 2": {1175, 1274}, "Champion 3": {1275, 1374},
-			"Grand Champion 1": {1375, 1474}, "Grand Champion 2": {1475, 1574}, "Grand Champion 3": {1575, 1674},
-			"Supersonic Legend": {1675, 2300},
-		},
+	distributions := make([]*models.RankDistribution, 0, len(files))
+	for _, file := range files {
+		data, err := builtInRankDistributions.ReadFile(file)
+		if err != nil {
+			panic(fmt.Sprintf("cannot read %s: %v", file, err))
+		}
+
+		var distribution models.RankDistribution
+		if err := json.Unmarshal(data, &distribution); err != nil {
+			panic(fmt.Sprintf("cannot parse %s: %v", file, err))
+		}
+		distributions = append(distributions, &distribution)
 	}
+	return distributions
 }
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/usl"
+)
+
+// Placement errors
+var (
+	ErrInvalidPlacement = errors.New("invalid placement")
+)
+
+// Placement move directions shown in the preview
+const (
+	PlacementMoveUp      = "up"
+	PlacementMoveDown    = "down"
+	PlacementMoveNew     = "new"     // eligible now, not placed last time
+	PlacementMoveDropped = "dropped" // placed last time, no longer active or now banned
+)
+
+// PlacementStore interface for persisting per-season tier placements
+type PlacementStore interface {
+	ReplacePlacements(seasonID int64, placements []models.TierPlacement) error
+	GetLatestPlacements(guildID int64) ([]models.TierPlacement, error)
+	FindLatestPlacement(guildID int64, discordID string) (*models.TierPlacement, error)
+}
+
+// PlacementPlayerSource interface for listing the players who may be placed
+type PlacementPlayerSource interface {
+	GetAllUsers() ([]*usl.USLUser, error)
+}
+
+// GuildConfigStore interface for reading and saving a guild's configuration
+type GuildConfigStore interface {
+	GetConfig(guildID int64) (*models.GuildConfig, error)
+	UpdateConfig(guildID int64, config *models.GuildConfig) error
+}
+
+// ActiveSeasonFinder interface for looking up the running season in a guild
+type ActiveSeasonFinder interface {
+	FindActiveSeason(guildID int64) (*models.Season, error)
+}
+
+// PlacementMove is a player whose tier changes between the last placement and this one
+type PlacementMove struct {
+	DiscordID    string `json:"discord_id"`
+	PlayerName   string `json:"player_name"`
+	FromTier     int    `json:"from_tier,omitempty"` // 0 when the player was not placed
+	FromTierName string `json:"from_tier_name,omitempty"`
+	ToTier       int    `json:"to_tier,omitempty"` // 0 when the player dropped out
+	ToTierName   string `json:"to_tier_name,omitempty"`
+	Direction    string `json:"direction"`
+}
+
+// PlacementTierSummary is the size and skill range of one tier in a placement
+type PlacementTierSummary struct {
+	Number   int     `json:"number"`
+	Name     string  `json:"name"`
+	Label    string  `json:"label"`
+	Players  int     `json:"players"`
+	MinSkill float64 `json:"min_skill"`
+	MaxSkill float64 `json:"max_skill"`
+}
+
+// PlacementPreview is a computed placement with the movers against the previous one
+type PlacementPreview struct {
+	Season     *models.Season         `json:"season"`
+	Config     models.PlacementConfig `json:"config"`
+	Tiers      []PlacementTierSummary `json:"tiers"`
+	Placements []models.TierPlacement `json:"placements"`
+	Moves      []PlacementMove        `json:"moves"`
+	Unchanged  int                    `json:"unchanged"`
+	Committed  bool                   `json:"committed"`
+}
+
+// placementCandidate is an eligible player with the rating used to place them
+type placementCandidate struct {
+	DiscordID string
+	Name      string
+	Rating    TrueSkillRating
+	Skill     float64
+}
+
+// PlacementService assigns players to tiers each season.
+// Service Responsibilities:
+// - Selecting active, non-banned players and resolving their current ratings
+// - Placing them by mu - k*sigma cutoffs or by fixed tier sizes
+// - Previewing movers against the last stored placement before committing
+// - Storing placements per season and serving tier labels to the leaderboard and bot
+type PlacementService struct {
+	placementRepo PlacementStore
+	seasonRepo    ActiveSeasonFinder
+	guildRepo     GuildConfigStore
+	playerSource  PlacementPlayerSource
+	resolver      RatingResolver
+	config        *config.Config
+}
+
+// NewPlacementService creates a new placement service
+func NewPlacementService(
+	placementRepo *repositories.PlacementRepository,
+	seasonRepo *repositories.SeasonRepository,
+	guildRepo *repositories.GuildRepository,
+	playerSource *usl.USLRepository,
+	resolver *PlayerRatingResolver,
+	config *config.Config,
+) *PlacementService {
+	return &PlacementService{
+		placementRepo: placementRepo,
+		seasonRepo:    seasonRepo,
+		guildRepo:     guildRepo,
+		playerSource:  playerSource,
+		resolver:      resolver,
+		config:        config,
+	}
+}
+
+// GetPlacementConfig returns the guild's saved tiers with defaults applied
+func (s *PlacementService) GetPlacementConfig(guildID int64) (models.PlacementConfig, error) {
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return models.PlacementConfig{}, err
+	}
+
+	placement := guildConfig.Placement
+	if err := placement.Validate(); err != nil {
+		return placement, fmt.Errorf("%w: %v", ErrInvalidPlacement, err)
+	}
+	return placement, nil
+}
+
+// GetCurrentPlacements returns the guild's most recent placements ordered by rank
+func (s *PlacementService) GetCurrentPlacements(guildID int64) ([]models.TierPlacement, error) {
+	return s.placementRepo.GetLatestPlacements(guildID)
+}
+
+// FindPlacement returns a player's most recent placement, or nil when never placed
+func (s *PlacementService) FindPlacement(guildID int64, discordID string) (*models.TierPlacement, error) {
+	return s.placementRepo.FindLatestPlacement(guildID, discordID)
+}
+
+// Preview places every eligible player without storing anything
+func (s *PlacementService) Preview(guildID int64, placement models.PlacementConfig) (*PlacementPreview, error) {
+	if err := placement.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPlacement, err)
+	}
+	if !placement.IsConfigured() {
+		return nil, fmt.Errorf("%w: no tiers configured", ErrInvalidPlacement)
+	}
+
+	season, err := s.seasonRepo.FindActiveSeason(guildID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, ErrNoActiveSeason
+	}
+
+	candidates, err := s.loadCandidates(guildID, placement.SigmaMultiplier)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.placementRepo.GetLatestPlacements(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	placements := placeInTiers(guildID, season.ID, placement, candidates)
+	moves, unchanged := diffPlacements(previous, placements)
+
+	return &PlacementPreview{
+		Season:     season,
+		Config:     placement,
+		Tiers:      summarizeTiers(placement, placements),
+		Placements: placements,
+		Moves:      moves,
+		Unchanged:  unchanged,
+	}, nil
+}
+
+// Commit places every eligible player, saves the tier rules to the guild config and stores
+// the placements for the active season, replacing any earlier placement of that season
+func (s *PlacementService) Commit(guildID int64, placement models.PlacementConfig) (*PlacementPreview, error) {
+	preview, err := s.Preview(guildID, placement)
+	if err != nil {
+		return nil, err
+	}
+
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+	guildConfig.Placement = preview.Config
+	if err := s.guildRepo.UpdateConfig(guildID, guildConfig); err != nil {
+		return nil, err
+	}
+
+	if err := s.placementRepo.ReplacePlacements(preview.Season.ID, preview.Placements); err != nil {
+		return nil, err
+	}
+	preview.Committed = true
+
+	log.Printf("PlacementService: Placed %d players in %d tiers for season %d in guild %d (%d moves)",
+		len(preview.Placements), len(preview.Tiers), preview.Season.Number, guildID, len(preview.Moves))
+
+	return preview, nil
+}
+
+// loadCandidates returns every player valid for play with their resolved rating
+func (s *PlacementService) loadCandidates(guildID int64, sigmaMultiplier float64) ([]placementCandidate, error) {
+	users, err := s.playerSource.GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	var eligible []*usl.USLUser
+	var discordIDs []string
+	for _, user := range users {
+		if user.IsValidForPlay() {
+			eligible = append(eligible, user)
+			discordIDs = append(discordIDs, user.DiscordID)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil, fmt.Errorf("%w: no active players to place", ErrInvalidPlacement)
+	}
+
+	ratings, err := s.resolver.Resolve(guildID, discordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]placementCandidate, 0, len(eligible))
+	for i, user := range eligible {
+		rating := ratings[i].Rating
+		candidates = append(candidates, placementCandidate{
+			DiscordID: user.DiscordID,
+			Name:      user.DisplayName(),
+			Rating:    rating,
+			Skill:     rating.Mu - sigmaMultiplier*rating.Sigma,
+		})
+	}
+	return candidates, nil
+}
+
+// placeInTiers ranks candidates by skill estimate and assigns tiers best first
+// With cutoffs a player drops past every tier whose minimum they miss; with fixed sizes
+// each tier fills in turn. The last tier always takes whoever is left.
+func placeInTiers(guildID, seasonID int64, placement models.PlacementConfig, candidates []placementCandidate) []models.TierPlacement {
+	ranked := append([]placementCandidate{}, candidates...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Skill != ranked[j].Skill {
+			return ranked[i].Skill > ranked[j].Skill
+		}
+		if ranked[i].Rating.Mu != ranked[j].Rating.Mu {
+			return ranked[i].Rating.Mu > ranked[j].Rating.Mu
+		}
+		return ranked[i].DiscordID < ranked[j].DiscordID
+	})
+
+	last := len(placement.Tiers) - 1
+	tier, filled := 0, 0
+	placements := make([]models.TierPlacement, 0, len(ranked))
+	for i, candidate := range ranked {
+		switch placement.Method {
+		case models.PlacementMethodFixedSizes:
+			for tier < last && filled >= placement.Tiers[tier].Size {
+				tier++
+				filled = 0
+			}
+		default:
+			for tier < last && candidate.Skill < placement.Tiers[tier].MinSkill {
+				tier++
+			}
+		}
+		filled++
+
+		placements = append(placements, models.TierPlacement{
+			GuildID:        guildID,
+			SeasonID:       seasonID,
+			DiscordID:      candidate.DiscordID,
+			PlayerName:     candidate.Name,
+			TierNumber:     tier + 1,
+			TierName:       placement.Tiers[tier].Name,
+			TierLabel:      models.FormatTierLabel(tier+1, placement.Tiers[tier].Name),
+			Rank:           i + 1,
+			TrueSkillMu:    roundRating(candidate.Rating.Mu),
+			TrueSkillSigma: roundRating(candidate.Rating.Sigma),
+			SkillEstimate:  roundRating(candidate.Skill),
+		})
+	}
+	return placements
+}
+
+// diffPlacements lists players whose tier changed, in new rank order followed by drop-outs
+// Tier numbers are compared, so renaming a tier does not count as a move.
+func diffPlacements(previous, current []models.TierPlacement) ([]PlacementMove, int) {
+	before := make(map[string]models.TierPlacement, len(previous))
+	for _, placement := range previous {
+		before[placement.DiscordID] = placement
+	}
+
+	moves := make([]PlacementMove, 0)
+	unchanged := 0
+	seen := make(map[string]bool, len(current))
+	for _, placement := range current {
+		seen[placement.DiscordID] = true
+		move := PlacementMove{
+			DiscordID:  placement.DiscordID,
+			PlayerName: placement.PlayerName,
+			ToTier:     placement.TierNumber,
+			ToTierName: placement.TierName,
+		}
+
+		old, found := before[placement.DiscordID]
+		switch {
+		case !found:
+			move.Direction = PlacementMoveNew
+		case old.TierNumber == placement.TierNumber:
+			unchanged++
+			continue
+		case placement.TierNumber < old.TierNumber:
+			move.Direction = PlacementMoveUp
+		default:
+			move.Direction = PlacementMoveDown
+		}
+		if found {
+			move.FromTier, move.FromTierName = old.TierNumber, old.TierName
+		}
+		moves = append(moves, move)
+	}
+
+	for _, placement := range previous {
+		if seen[placement.DiscordID] {
+			continue
+		}
+		moves = append(moves, PlacementMove{
+			DiscordID:    placement.DiscordID,
+			PlayerName:   placement.PlayerName,
+			FromTier:     placement.TierNumber,
+			FromTierName: placement.TierName,
+			Direction:    PlacementMoveDropped,
+		})
+	}
+
+	return moves, unchanged
+}
+
+// summarizeTiers counts each tier's players and skill range; empty tiers are kept
+func summarizeTiers(placement models.PlacementConfig, placements []models.TierPlacement) []PlacementTierSummary {
+	tiers := make([]PlacementTierSummary, len(placement.Tiers))
+	for i, tier := range placement.Tiers {
+		tiers[i] = PlacementTierSummary{Number: i + 1, Name: tier.Name, Label: models.FormatTierLabel(i+1, tier.Name)}
+	}
+
+	for _, p := range placements {
+		summary := &tiers[p.TierNumber-1]
+		if summary.Players == 0 || p.SkillEstimate > summary.MaxSkill {
+			summary.MaxSkill = p.SkillEstimate
+		}
+		if summary.Players == 0 || p.SkillEstimate < summary.MinSkill {
+			summary.MinSkill = p.SkillEstimate
+		}
+		summary.Players++
+	}
+	return tiers
+}
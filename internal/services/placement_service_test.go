@@ -0,0 +1,230 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/usl"
+)
+
+type fakePlacementStore struct {
+	saved    map[int64][]models.TierPlacement
+	latest   []models.TierPlacement
+	replaced int64
+}
+
+func (f *fakePlacementStore) ReplacePlacements(seasonID int64, placements []models.TierPlacement) error {
+	f.saved[seasonID] = placements
+	f.latest = placements
+	f.replaced = seasonID
+	return nil
+}
+
+func (f *fakePlacementStore) GetLatestPlacements(guildID int64) ([]models.TierPlacement, error) {
+	return f.latest, nil
+}
+
+func (f *fakePlacementStore) FindLatestPlacement(guildID int64, discordID string) (*models.TierPlacement, error) {
+	for _, placement := range f.latest {
+		if placement.DiscordID == discordID {
+			return &placement, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeSeasonFinder struct {
+	active *models.Season
+}
+
+func (f *fakeSeasonFinder) FindActiveSeason(guildID int64) (*models.Season, error) {
+	return f.active, nil
+}
+
+type fakeGuildConfigStore struct {
+	config models.GuildConfig
+}
+
+func (f *fakeGuildConfigStore) GetConfig(guildID int64) (*models.GuildConfig, error) {
+	config := f.config
+	return &config, nil
+}
+
+func (f *fakeGuildConfigStore) UpdateConfig(guildID int64, config *models.GuildConfig) error {
+	f.config = *config
+	return nil
+}
+
+type fakePlayerSource struct {
+	users []*usl.USLUser
+}
+
+func (f *fakePlayerSource) GetAllUsers() ([]*usl.USLUser, error) {
+	return f.users, nil
+}
+
+// newTestPlacementService rates player N ("pa", "pb", ...) at mu 1000-100N with sigma 10,
+// so skill estimates at k=3 are 870, 770, 670, ...
+func newTestPlacementService(players int) (*PlacementService, *fakePlacementStore, *fakePlayerSource) {
+	resolver := &fakeRatingResolver{ratings: make(map[string]TrueSkillRating), userIDs: make(map[string]int64)}
+	source := &fakePlayerSource{}
+	for i := 1; i <= players; i++ {
+		discordID := playerID(i)
+		resolver.ratings[discordID] = TrueSkillRating{Mu: 1000 - float64(100*i), Sigma: 10}
+		source.users = append(source.users, &usl.USLUser{ID: int64(i), DiscordID: discordID, Name: "Player " + discordID, Active: true})
+	}
+
+	store := &fakePlacementStore{saved: make(map[int64][]models.TierPlacement)}
+	return &PlacementService{
+		placementRepo: store,
+		seasonRepo:    &fakeSeasonFinder{active: &models.Season{ID: 7, GuildID: 1, Number: 2, Status: models.SeasonStatusActive}},
+		guildRepo:     &fakeGuildConfigStore{},
+		playerSource:  source,
+		resolver:      resolver,
+		config:        &config.Config{},
+	}, store, source
+}
+
+func tierNumbers(placements []models.TierPlacement) map[string]int {
+	tiers := make(map[string]int, len(placements))
+	for _, placement := range placements {
+		tiers[placement.DiscordID] = placement.TierNumber
+	}
+	return tiers
+}
+
+func TestPlacementBySkillCutoffs(t *testing.T) {
+	service, _, _ := newTestPlacementService(6)
+	placement := models.PlacementConfig{
+		Method: models.PlacementMethodSkillCutoffs,
+		Tiers: []models.TierConfig{
+			{Name: "Premier", MinSkill: 750},
+			{Name: "Elite", MinSkill: 500},
+			{Name: "Open"},
+		},
+	}
+
+	preview, err := service.Preview(1, placement)
+	if err != nil {
+		t.Fatalf("Preview returned error: %v", err)
+	}
+
+	// skills: pa 870, pb 770, pc 670, pd 570, pe 470, pf 370
+	want := map[string]int{"pa": 1, "pb": 1, "pc": 2, "pd": 2, "pe": 3, "pf": 3}
+	got := tierNumbers(preview.Placements)
+	for discordID, tier := range want {
+		if got[discordID] != tier {
+			t.Errorf("%s placed in tier %d, want %d", discordID, got[discordID], tier)
+		}
+	}
+
+	if preview.Placements[2].TierLabel != "Tier 2 – Elite" {
+		t.Errorf("expected label %q, got %q", "Tier 2 – Elite", preview.Placements[2].TierLabel)
+	}
+	if preview.Tiers[0].Players != 2 || preview.Tiers[0].MinSkill != 770 || preview.Tiers[0].MaxSkill != 870 {
+		t.Errorf("unexpected tier 1 summary: %+v", preview.Tiers[0])
+	}
+}
+
+func TestPlacementByFixedSizesSkipsIneligiblePlayers(t *testing.T) {
+	service, _, source := newTestPlacementService(6)
+	source.users[0].Banned = true
+	source.users[3].Active = false
+
+	placement := models.PlacementConfig{
+		Method: models.PlacementMethodFixedSizes,
+		Tiers: []models.TierConfig{
+			{Name: "Premier", Size: 2},
+			{Name: "Open"},
+		},
+	}
+
+	preview, err := service.Preview(1, placement)
+	if err != nil {
+		t.Fatalf("Preview returned error: %v", err)
+	}
+
+	want := map[string]int{"pb": 1, "pc": 1, "pe": 2, "pf": 2}
+	got := tierNumbers(preview.Placements)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d placements, got %v", len(want), got)
+	}
+	for discordID, tier := range want {
+		if got[discordID] != tier {
+			t.Errorf("%s placed in tier %d, want %d", discordID, got[discordID], tier)
+		}
+	}
+	if preview.Placements[0].Rank != 1 || preview.Placements[0].DiscordID != "pb" {
+		t.Errorf("expected pb ranked first, got %+v", preview.Placements[0])
+	}
+}
+
+func TestPlacementPreviewListsMoversAndCommitStoresSeason(t *testing.T) {
+	service, store, _ := newTestPlacementService(4)
+	store.latest = []models.TierPlacement{
+		{DiscordID: "pa", TierNumber: 1, TierName: "Premier"},
+		{DiscordID: "pb", TierNumber: 2, TierName: "Open"},
+		{DiscordID: "pc", TierNumber: 1, TierName: "Premier"},
+		{DiscordID: "gone", TierNumber: 2, TierName: "Open"},
+	}
+
+	placement := models.PlacementConfig{
+		Method: models.PlacementMethodFixedSizes,
+		Tiers:  []models.TierConfig{{Name: "Premier", Size: 2}, {Name: "Open"}},
+	}
+
+	preview, err := service.Preview(1, placement)
+	if err != nil {
+		t.Fatalf("Preview returned error: %v", err)
+	}
+	if store.replaced != 0 {
+		t.Fatalf("Preview must not store placements")
+	}
+
+	directions := make(map[string]string)
+	for _, move := range preview.Moves {
+		directions[move.DiscordID] = move.Direction
+	}
+	want := map[string]string{"pb": PlacementMoveUp, "pc": PlacementMoveDown, "pd": PlacementMoveNew, "gone": PlacementMoveDropped}
+	for discordID, direction := range want {
+		if directions[discordID] != direction {
+			t.Errorf("%s: expected move %q, got %q", discordID, direction, directions[discordID])
+		}
+	}
+	if preview.Unchanged != 1 {
+		t.Errorf("expected 1 unchanged player, got %d", preview.Unchanged)
+	}
+
+	committed, err := service.Commit(1, placement)
+	if err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+	if !committed.Committed || store.replaced != 7 || len(store.saved[7]) != 4 {
+		t.Errorf("expected 4 placements stored for season 7, got %d in season %d", len(store.saved[7]), store.replaced)
+	}
+
+	saved, _ := service.GetPlacementConfig(1)
+	if len(saved.Tiers) != 2 || saved.SigmaMultiplier != models.DefaultPlacementSigmaMultiplier {
+		t.Errorf("expected tier rules saved with defaults, got %+v", saved)
+	}
+}
+
+func TestPlacementRequiresTiersAndActiveSeason(t *testing.T) {
+	service, _, _ := newTestPlacementService(2)
+
+	if _, err := service.Preview(1, models.PlacementConfig{}); !errors.Is(err, ErrInvalidPlacement) {
+		t.Errorf("expected a placement without tiers to be rejected, got %v", err)
+	}
+
+	unordered := models.PlacementConfig{Tiers: []models.TierConfig{{Name: "A", MinSkill: 100}, {Name: "B", MinSkill: 200}, {Name: "C"}}}
+	if _, err := service.Preview(1, unordered); !errors.Is(err, ErrInvalidPlacement) {
+		t.Errorf("expected cutoffs that do not decrease to be rejected, got %v", err)
+	}
+
+	service.seasonRepo = &fakeSeasonFinder{}
+	placement := models.PlacementConfig{Tiers: []models.TierConfig{{Name: "Open"}}}
+	if _, err := service.Preview(1, placement); !errors.Is(err, ErrNoActiveSeason) {
+		t.Errorf("expected placement without an active season to fail, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// Rating sources reported by PlayerRatingResolver
+const (
+	RatingSourceGuild   = "guild"   // player_effective_mmr, kept current by match results
+	RatingSourceSeed    = "seed"    // tracker-seeded rating from the USL tables
+	RatingSourceDefault = "default" // config defaults for players with no rating anywhere
+
+	RatingSourcePlaylist     = "playlist"      // player_playlist_ratings, kept current by matches of that mode
+	RatingSourcePlaylistSeed = "playlist_seed" // seeded from the matching tracker playlist
+)
+
+// EffectiveRatingReader interface for reading current per-guild ratings
+type EffectiveRatingReader interface {
+	FindEffectiveMMR(userID, guildID int64) (*models.PlayerEffectiveMMR, error)
+}
+
+// SeedRatingSource interface for tracker-seeded ratings of players without a guild rating
+type SeedRatingSource interface {
+	GetSeedRating(discordID string) (mu, sigma float64, found bool)
+}
+
+// PlaylistRatingSource interface for per-playlist ratings and their tracker seeds
+type PlaylistRatingSource interface {
+	Rating(guildID int64, user *models.User, playlist string) (TrueSkillRating, string, bool, error)
+}
+
+// ResolvedRating is a player's rating together with where it came from
+type ResolvedRating struct {
+	DiscordID string          `json:"discord_id"`
+	UserID    int64           `json:"user_id,omitempty"`
+	Rating    TrueSkillRating `json:"rating"`
+	Source    string          `json:"source"`
+}
+
+// PlayerRatingResolver looks up the best available rating for Discord IDs in a guild.
+// Precedence: player_effective_mmr, then the seed source, then config defaults.
+// For a playlist, the player's rating in that playlist and its tracker seed come first.
+type PlayerRatingResolver struct {
+	userRepo   UserDirectory
+	ratingRepo EffectiveRatingReader
+	seedSource SeedRatingSource
+	playlists  PlaylistRatingSource
+	config     *config.Config
+}
+
+// NewPlayerRatingResolver creates a new rating resolver; seedSource may be nil
+func NewPlayerRatingResolver(
+	userRepo *repositories.UserRepository,
+	ratingRepo *repositories.PlayerMMRRepository,
+	seedSource SeedRatingSource,
+	playlists *PlaylistRatingService,
+	config *config.Config,
+) *PlayerRatingResolver {
+	return &PlayerRatingResolver{
+		userRepo:   userRepo,
+		ratingRepo: ratingRepo,
+		seedSource: seedSource,
+		playlists:  playlists,
+		config:     config,
+	}
+}
+
+// Resolve returns one rating per Discord ID, in the order given
+func (r *PlayerRatingResolver) Resolve(guildID int64, discordIDs []string) ([]ResolvedRating, error) {
+	resolved := make([]ResolvedRating, 0, len(discordIDs))
+	for _, discordID := range discordIDs {
+		rating, err := r.resolveOne(guildID, discordID)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, rating)
+	}
+	return resolved, nil
+}
+
+// ResolvePlaylist returns one rating per Discord ID in a playlist, in the order given
+// Players with no rating or tracker data in the playlist get their combined rating;
+// an empty playlist resolves combined ratings for everyone.
+func (r *PlayerRatingResolver) ResolvePlaylist(guildID int64, discordIDs []string, playlist string) ([]ResolvedRating, error) {
+	if playlist == "" || r.playlists == nil {
+		return r.Resolve(guildID, discordIDs)
+	}
+
+	resolved := make([]ResolvedRating, 0, len(discordIDs))
+	for _, discordID := range discordIDs {
+		user, err := r.userRepo.FindUserByDiscordID(discordID)
+		if err == nil && user != nil {
+			rating, source, found, err := r.playlists.Rating(guildID, user, playlist)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				resolved = append(resolved, ResolvedRating{DiscordID: discordID, UserID: int64(user.ID), Rating: rating, Source: source})
+				continue
+			}
+		}
+
+		rating, err := r.resolveOne(guildID, discordID)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, rating)
+	}
+	return resolved, nil
+}
+
+// resolveOne applies the lookup precedence for a single player
+func (r *PlayerRatingResolver) resolveOne(guildID int64, discordID string) (ResolvedRating, error) {
+	resolved := ResolvedRating{DiscordID: discordID}
+
+	user, err := r.userRepo.FindUserByDiscordID(discordID)
+	if err == nil && user != nil {
+		resolved.UserID = int64(user.ID)
+
+		effective, err := r.ratingRepo.FindEffectiveMMR(resolved.UserID, guildID)
+		if err != nil {
+			return resolved, fmt.Errorf("failed to load rating for %s: %w", discordID, err)
+		}
+		if effective != nil {
+			resolved.Rating = TrueSkillRating{Mu: effective.TrueSkillMu, Sigma: effective.TrueSkillSigma}
+			resolved.Source = RatingSourceGuild
+			return resolved, nil
+		}
+	}
+
+	if r.seedSource != nil {
+		if mu, sigma, found := r.seedSource.GetSeedRating(discordID); found {
+			resolved.Rating = TrueSkillRating{Mu: mu, Sigma: sigma}
+			resolved.Source = RatingSourceSeed
+			return resolved, nil
+		}
+	}
+
+	mu, sigma := r.config.GetTrueSkillDefaults()
+	resolved.Rating = TrueSkillRating{Mu: mu, Sigma: sigma}
+	resolved.Source = RatingSourceDefault
+	return resolved, nil
+}
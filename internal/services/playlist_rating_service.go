@@ -0,0 +1,305 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidPlaylist is returned for a playlist without a rating track
+var ErrInvalidPlaylist = errors.New("invalid playlist")
+
+// Rating leaderboard limits
+const (
+	DefaultRatingLeaderboardLimit = 50
+	MaxRatingLeaderboardLimit     = 500
+)
+
+// PlaylistRatingStore interface for reading per-playlist ratings
+// Combined ratings are read for the leaderboard across every playlist.
+type PlaylistRatingStore interface {
+	FindPlaylistRating(userID, guildID int64, playlist string) (*models.PlayerPlaylistRating, error)
+	GetGuildPlaylistRatings(guildID int64, playlist string) ([]*models.PlayerPlaylistRating, error)
+	GetGuildEffectiveMMRs(guildID int64) ([]*models.PlayerEffectiveMMR, error)
+}
+
+// PlaylistUserSource interface for the players behind playlist ratings
+type PlaylistUserSource interface {
+	FindUserByID(userID int64) (*models.User, error)
+	FindUsersByIDs(userIDs []int64) ([]*models.User, error)
+}
+
+// RatingLeaderboardEntry is one row of a guild rating leaderboard
+type RatingLeaderboardEntry struct {
+	Rank          int     `json:"rank"`
+	UserID        int64   `json:"user_id"`
+	DiscordID     string  `json:"discord_id,omitempty"`
+	Name          string  `json:"name,omitempty"`
+	Mu            float64 `json:"mu"`
+	Sigma         float64 `json:"sigma"`
+	SkillEstimate float64 `json:"skill_estimate"` // mu - 3σ
+	GamesPlayed   int     `json:"games_played"`
+}
+
+// PlaylistRatingAdjustment describes how one player's playlist rating moved in a soft reset or decay
+type PlaylistRatingAdjustment struct {
+	UserID      int64   `json:"user_id"`
+	Playlist    string  `json:"playlist"`
+	MuBefore    float64 `json:"mu_before"`
+	MuAfter     float64 `json:"mu_after"`
+	SigmaBefore float64 `json:"sigma_before"`
+	SigmaAfter  float64 `json:"sigma_after"`
+}
+
+// PlaylistRatingService keeps a separate rating per game mode next to the combined rating.
+// Service Responsibilities:
+// - Seeding a playlist's rating from the matching tracker playlist with the guild's engine
+// - Falling back to the combined rating when the tracker has too few games in the playlist
+// - Loading the playlist ratings a match updates; MatchService stores them with the match
+// - Serving per-playlist leaderboards, and the combined leaderboard when no playlist is given
+type PlaylistRatingService struct {
+	ratingRepo                PlaylistRatingStore
+	userRepo                  PlaylistUserSource
+	trackerRepo               RankTrackerSource
+	engines                   RatingEngineSource
+	dataTransformationService *DataTransformationService
+	config                    *config.Config
+}
+
+// NewPlaylistRatingService creates a new playlist rating service
+func NewPlaylistRatingService(
+	ratingRepo *repositories.PlayerMMRRepository,
+	userRepo *repositories.UserRepository,
+	trackerRepo *repositories.TrackerRepository,
+	engines *RatingEngineSelector,
+	dataTransformationService *DataTransformationService,
+	config *config.Config,
+) *PlaylistRatingService {
+	return &PlaylistRatingService{
+		ratingRepo:                ratingRepo,
+		userRepo:                  userRepo,
+		trackerRepo:               trackerRepo,
+		engines:                   engines,
+		dataTransformationService: dataTransformationService,
+		config:                    config,
+	}
+}
+
+// ParsePlaylist returns the rating playlist for ones, 2v2, doubles and the like
+func ParsePlaylist(playlist string) (string, error) {
+	key, ok := models.RatingPlaylistFor(playlist)
+	if !ok {
+		return "", fmt.Errorf("%w: %q, expected ones, twos or threes", ErrInvalidPlaylist, playlist)
+	}
+	return key, nil
+}
+
+// LoadRatings returns each player's rating in a playlist, in the order of their combined ratings
+// Players without one yet are seeded from their trackers, or start from their combined rating.
+func (s *PlaylistRatingService) LoadRatings(guildID int64, playlist string, combined []*models.PlayerEffectiveMMR) ([]*models.PlayerPlaylistRating, error) {
+	ratings := make([]*models.PlayerPlaylistRating, 0, len(combined))
+	for _, effective := range combined {
+		rating, err := s.ratingRepo.FindPlaylistRating(effective.UserID, guildID, playlist)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s rating for user %d: %w", playlist, effective.UserID, err)
+		}
+
+		if rating == nil {
+			seed := TrueSkillRating{Mu: effective.TrueSkillMu, Sigma: effective.TrueSkillSigma}
+			if user, err := s.userRepo.FindUserByID(effective.UserID); err != nil || user == nil {
+				log.Printf("PlaylistRatingService: No user %d to seed %s from, using the combined rating: %v", effective.UserID, playlist, err)
+			} else if trackerSeed, found := s.seedRating(guildID, user.DiscordID, playlist); found {
+				seed = trackerSeed
+			}
+
+			rating = &models.PlayerPlaylistRating{
+				UserID:         effective.UserID,
+				GuildID:        guildID,
+				Playlist:       playlist,
+				TrueSkillMu:    roundRating(seed.Mu),
+				TrueSkillSigma: roundRating(seed.Sigma),
+			}
+		}
+
+		ratings = append(ratings, rating)
+	}
+	return ratings, nil
+}
+
+// Rating returns a player's rating in a playlist and where it came from: the stored rating,
+// else a seed from the matching tracker playlist. found is false when the player has neither.
+func (s *PlaylistRatingService) Rating(guildID int64, user *models.User, playlist string) (TrueSkillRating, string, bool, error) {
+	stored, err := s.ratingRepo.FindPlaylistRating(int64(user.ID), guildID, playlist)
+	if err != nil {
+		return TrueSkillRating{}, "", false, fmt.Errorf("failed to load %s rating for %s: %w", playlist, user.DiscordID, err)
+	}
+	if stored != nil {
+		return TrueSkillRating{Mu: stored.TrueSkillMu, Sigma: stored.TrueSkillSigma}, RatingSourcePlaylist, true, nil
+	}
+
+	if seed, found := s.seedRating(guildID, user.DiscordID, playlist); found {
+		return seed, RatingSourcePlaylistSeed, true, nil
+	}
+	return TrueSkillRating{}, "", false, nil
+}
+
+// Leaderboard ranks a guild's players by mu in one playlist, or by combined rating for an empty playlist
+func (s *PlaylistRatingService) Leaderboard(guildID int64, playlist string, limit int) ([]RatingLeaderboardEntry, error) {
+	if limit <= 0 {
+		limit = DefaultRatingLeaderboardLimit
+	}
+	if limit > MaxRatingLeaderboardLimit {
+		limit = MaxRatingLeaderboardLimit
+	}
+
+	var entries []RatingLeaderboardEntry
+	if playlist == "" {
+		ratings, err := s.ratingRepo.GetGuildEffectiveMMRs(guildID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rating := range ratings {
+			entries = append(entries, RatingLeaderboardEntry{
+				UserID: rating.UserID, Mu: rating.TrueSkillMu, Sigma: rating.TrueSkillSigma,
+				SkillEstimate: roundRating(rating.GetSkillEstimate()), GamesPlayed: rating.GamesPlayed,
+			})
+		}
+	} else {
+		ratings, err := s.ratingRepo.GetGuildPlaylistRatings(guildID, playlist)
+		if err != nil {
+			return nil, err
+		}
+		for _, rating := range ratings {
+			entries = append(entries, RatingLeaderboardEntry{
+				UserID: rating.UserID, Mu: rating.TrueSkillMu, Sigma: rating.TrueSkillSigma,
+				SkillEstimate: roundRating(rating.GetSkillEstimate()), GamesPlayed: rating.GamesPlayed,
+			})
+		}
+	}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	if entries == nil {
+		return []RatingLeaderboardEntry{}, nil
+	}
+
+	// Names are optional on the leaderboard, so a lookup failure is logged and skipped
+	userIDs := make([]int64, len(entries))
+	for i := range entries {
+		userIDs[i] = entries[i].UserID
+	}
+	users, err := s.userRepo.FindUsersByIDs(userIDs)
+	if err != nil {
+		log.Printf("PlaylistRatingService: Failed to load users for the guild %d leaderboard: %v", guildID, err)
+	}
+	byID := make(map[int64]*models.User, len(users))
+	for _, user := range users {
+		byID[int64(user.ID)] = user
+	}
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if user, ok := byID[entries[i].UserID]; ok {
+			entries[i].DiscordID = user.DiscordID
+			entries[i].Name = user.DisplayText()
+		}
+	}
+	return entries, nil
+}
+
+// seedRating seeds a playlist rating from the player's trackers with the guild's engine
+// found is false without trackers or when the playlist has too few games to be counted.
+func (s *PlaylistRatingService) seedRating(guildID int64, discordID, playlist string) (TrueSkillRating, bool) {
+	trackers, err := s.trackerRepo.GetTrackersByDiscordID(discordID, true)
+	if err != nil {
+		log.Printf("PlaylistRatingService: Failed to load trackers for %s: %v", discordID, err)
+		return TrueSkillRating{}, false
+	}
+	if len(trackers) == 0 {
+		return TrueSkillRating{}, false
+	}
+
+	trackerData, err := s.dataTransformationService.AggregateTrackers(trackers, s.config.MMR.TrackerAggregation)
+	if err != nil {
+		log.Printf("PlaylistRatingService: Failed to aggregate trackers for %s: %v", discordID, err)
+		return TrueSkillRating{}, false
+	}
+
+	seed, err := s.engines.ForGuild(guildID).Seed(trackerData.onlyPlaylist(playlist))
+	if err != nil {
+		log.Printf("PlaylistRatingService: Failed to seed %s for %s: %v", playlist, discordID, err)
+		return TrueSkillRating{}, false
+	}
+	if seed.SkillResult == nil || seed.SkillResult.Breakdown[playlist].NormalizedSkill == nil {
+		return TrueSkillRating{}, false
+	}
+
+	return TrueSkillRating{Mu: seed.Mu, Sigma: seed.Sigma}, true
+}
+
+// loadGuildPlaylistRatings returns every playlist rating in a guild grouped by user ID
+func loadGuildPlaylistRatings(source GuildPlaylistRatingSource, guildID int64) (map[int64][]*models.PlayerPlaylistRating, error) {
+	byUser := make(map[int64][]*models.PlayerPlaylistRating)
+	for _, playlist := range models.RatingPlaylists {
+		ratings, err := source.GetGuildPlaylistRatings(guildID, playlist)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s ratings: %w", playlist, err)
+		}
+		for _, rating := range ratings {
+			byUser[rating.UserID] = append(byUser[rating.UserID], rating)
+		}
+	}
+	return byUser, nil
+}
+
+// adjustPlaylistRatings applies adjust to each playlist rating, returning the moves worth writing
+// and the updated copies to save. LastUpdated is kept so the change does not count as activity.
+func adjustPlaylistRatings(ratings []*models.PlayerPlaylistRating, adjust func(TrueSkillRating) TrueSkillRating, minChange float64) ([]PlaylistRatingAdjustment, []*models.PlayerPlaylistRating) {
+	var adjustments []PlaylistRatingAdjustment
+	var updated []*models.PlayerPlaylistRating
+	for _, current := range ratings {
+		moved := adjust(TrueSkillRating{Mu: current.TrueSkillMu, Sigma: current.TrueSkillSigma})
+		if math.Abs(moved.Mu-current.TrueSkillMu) < minChange && math.Abs(moved.Sigma-current.TrueSkillSigma) < minChange {
+			continue
+		}
+
+		adjustments = append(adjustments, PlaylistRatingAdjustment{
+			UserID:      current.UserID,
+			Playlist:    current.Playlist,
+			MuBefore:    current.TrueSkillMu,
+			MuAfter:     moved.Mu,
+			SigmaBefore: current.TrueSkillSigma,
+			SigmaAfter:  moved.Sigma,
+		})
+		saved := *current
+		saved.TrueSkillMu = moved.Mu
+		saved.TrueSkillSigma = moved.Sigma
+		updated = append(updated, &saved)
+	}
+	return adjustments, updated
+}
+
+// newPlaylistRatingChange builds a playlist rating's change and its updated copy after a match
+func newPlaylistRatingChange(current *models.PlayerPlaylistRating, updated TrueSkillRating, team int, now time.Time) (PlayerRatingChange, *models.PlayerPlaylistRating) {
+	change := PlayerRatingChange{
+		UserID:      current.UserID,
+		Team:        team,
+		MuBefore:    current.TrueSkillMu,
+		MuAfter:     roundRating(updated.Mu),
+		SigmaBefore: current.TrueSkillSigma,
+		SigmaAfter:  roundRating(updated.Sigma),
+	}
+
+	saved := *current
+	saved.TrueSkillMu = change.MuAfter
+	saved.TrueSkillSigma = change.SigmaAfter
+	saved.GamesPlayed = current.GamesPlayed + 1
+	saved.LastUpdated = now
+	return change, &saved
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakePlaylistRatings struct {
+	ratings  map[string]*models.PlayerPlaylistRating // by "user/playlist"
+	combined []*models.PlayerEffectiveMMR
+}
+
+func playlistRatingKey(userID int64, playlist string) string {
+	return fmt.Sprintf("%d/%s", userID, playlist)
+}
+
+func (f *fakePlaylistRatings) FindPlaylistRating(userID, guildID int64, playlist string) (*models.PlayerPlaylistRating, error) {
+	if rating, ok := f.ratings[playlistRatingKey(userID, playlist)]; ok {
+		copied := *rating
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (f *fakePlaylistRatings) GetGuildPlaylistRatings(guildID int64, playlist string) ([]*models.PlayerPlaylistRating, error) {
+	var ratings []*models.PlayerPlaylistRating
+	for _, rating := range f.ratings {
+		if rating.Playlist == playlist {
+			ratings = append(ratings, rating)
+		}
+	}
+	sortPlaylistRatings(ratings)
+	return ratings, nil
+}
+
+func (f *fakePlaylistRatings) UpsertPlaylistRating(rating *models.PlayerPlaylistRating) error {
+	copied := *rating
+	f.ratings[playlistRatingKey(rating.UserID, rating.Playlist)] = &copied
+	return nil
+}
+
+func (f *fakePlaylistRatings) GetGuildEffectiveMMRs(guildID int64) ([]*models.PlayerEffectiveMMR, error) {
+	return f.combined, nil
+}
+
+// sortPlaylistRatings orders ratings by mu, highest first, as the repository does
+func sortPlaylistRatings(ratings []*models.PlayerPlaylistRating) {
+	for i := 1; i < len(ratings); i++ {
+		for j := i; j > 0 && ratings[j].TrueSkillMu > ratings[j-1].TrueSkillMu; j-- {
+			ratings[j], ratings[j-1] = ratings[j-1], ratings[j]
+		}
+	}
+}
+
+type fakePlaylistUsers struct {
+	users   []*models.User
+	lookups [][]int64
+}
+
+func (f *fakePlaylistUsers) FindUserByID(userID int64) (*models.User, error) {
+	for _, user := range f.users {
+		if int64(user.ID) == userID {
+			return user, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func (f *fakePlaylistUsers) FindUsersByIDs(userIDs []int64) ([]*models.User, error) {
+	f.lookups = append(f.lookups, userIDs)
+	var users []*models.User
+	for _, userID := range userIDs {
+		if user, err := f.FindUserByID(userID); err == nil {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// newTestPlaylistRatingService builds a service where player 1 is a strong 1v1 player with a
+// weak 2v2 tracker, player 2 has too few 1v1 games to seed from and the others have no trackers
+func newTestPlaylistRatingService() (*PlaylistRatingService, *fakePlaylistRatings) {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333},
+		MMR:       config.MMRConfig{OnesWeight: 1.0, TwosWeight: 1.5, ThreesWeight: 1.2, MinGamesThreshold: 10, TrackerAggregation: TrackerAggregationMax},
+	}
+	converter := NewPercentileConverter(cfg)
+	seeder := &trackerSeeder{mmrCalculator: NewMMRCalculator(cfg, converter), uncertaintyCalculator: NewEnhancedUncertaintyCalculator(cfg, converter)}
+	now := time.Now()
+
+	users := make([]*models.User, 0, 4)
+	for i := 1; i <= 4; i++ {
+		users = append(users, &models.User{ID: i, DiscordID: fmt.Sprintf("10000000000000000%d", i), Name: fmt.Sprintf("Player %d", i)})
+	}
+
+	ratings := &fakePlaylistRatings{ratings: make(map[string]*models.PlayerPlaylistRating)}
+	return &PlaylistRatingService{
+		ratingRepo: ratings,
+		userRepo:   &fakePlaylistUsers{users: users},
+		trackerRepo: &fakeRankTrackers{trackers: []*models.UserTracker{
+			{DiscordID: users[0].DiscordID, URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/duelist/overview",
+				OnesCurrentSeasonPeak: 1350, OnesCurrentSeasonGames: 300, TwosCurrentSeasonPeak: 700, TwosCurrentSeasonGames: 300, LastUpdated: now},
+			{DiscordID: users[1].DiscordID, URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/casual/overview",
+				OnesCurrentSeasonPeak: 1500, OnesCurrentSeasonGames: 3, LastUpdated: now},
+		}},
+		engines:                   &RatingEngineSelector{engines: newRatingEngines(seeder, cfg), guildRepo: &fakeGuildConfigStore{}},
+		dataTransformationService: NewDataTransformationService(),
+		config:                    cfg,
+	}, ratings
+}
+
+func TestMatchesUpdateTheRatingOfTheirPlaylist(t *testing.T) {
+	playlists, stored := newTestPlaylistRatingService()
+	service, combined := newTestMatchService()
+	service.playlists = playlists
+	service.matchRepo.(*fakeMatchStore).playlists = stored
+
+	duel, err := service.RecordMatchReport(MatchReport{
+		GuildID: 7, TeamA: []string{"100000000000000001"}, TeamB: []string{"100000000000000002"}, TeamAScore: 2, TeamBScore: 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(duel.Changes) != 2 || len(duel.PlaylistChanges) != 2 {
+		t.Fatalf("expected combined and 1v1 changes for both players, got %+v", duel)
+	}
+
+	duelist := duel.PlaylistChanges[0]
+	if duelist.MuBefore <= 1200 || duelist.MuAfter >= duelist.MuBefore {
+		t.Errorf("expected the 1v1 rating seeded from the strong 1v1 tracker and dropping after a loss, got %+v", duelist)
+	}
+	if casual := duel.PlaylistChanges[1]; casual.MuBefore != 1000 {
+		t.Errorf("expected a player with too few 1v1 games to start from their combined rating, got %+v", casual)
+	}
+	if rating := stored.ratings[playlistRatingKey(1, models.RatingPlaylistOnes)]; rating == nil || rating.GamesPlayed != 1 {
+		t.Errorf("expected the 1v1 rating saved with one game, got %+v", rating)
+	}
+
+	if _, err := service.RecordMatchReport(MatchReport{
+		GuildID: 7, TeamA: []string{"100000000000000001", "100000000000000003"}, TeamB: []string{"100000000000000002", "100000000000000004"},
+		TeamAScore: 3, TeamBScore: 0,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rating := stored.ratings[playlistRatingKey(1, models.RatingPlaylistOnes)]; rating.GamesPlayed != 1 {
+		t.Errorf("expected a 2v2 match to leave the 1v1 rating alone, got %+v", rating)
+	}
+	twos := stored.ratings[playlistRatingKey(1, models.RatingPlaylistTwos)]
+	if twos == nil || twos.TrueSkillMu >= duelist.MuAfter {
+		t.Errorf("expected a separate 2v2 rating seeded from the weaker 2v2 tracker, got %+v", twos)
+	}
+	if combined.effective[1].GamesPlayed != 2 {
+		t.Errorf("expected the combined rating to count both matches, got %+v", combined.effective[1])
+	}
+
+	if _, err := service.RecordMatchReport(MatchReport{
+		GuildID: 7, TeamA: []string{"100000000000000001"}, TeamB: []string{"100000000000000002"}, Playlist: "4v4",
+	}); err == nil {
+		t.Error("expected an unknown playlist to be rejected")
+	}
+}
+
+func TestResolvePlaylistAndLeaderboards(t *testing.T) {
+	playlists, stored := newTestPlaylistRatingService()
+	stored.ratings[playlistRatingKey(3, models.RatingPlaylistOnes)] = &models.PlayerPlaylistRating{
+		UserID: 3, GuildID: 7, Playlist: models.RatingPlaylistOnes, TrueSkillMu: 1400, TrueSkillSigma: 3, GamesPlayed: 20,
+	}
+	stored.combined = []*models.PlayerEffectiveMMR{
+		{UserID: 4, GuildID: 7, TrueSkillMu: 1300, TrueSkillSigma: 4, GamesPlayed: 40},
+		{UserID: 3, GuildID: 7, TrueSkillMu: 900, TrueSkillSigma: 4, GamesPlayed: 30},
+	}
+
+	resolver := &PlayerRatingResolver{
+		userRepo:   &fakeUserDirectory{users: map[string]*models.User{}},
+		ratingRepo: &fakeRatingStore{effective: map[int64]*models.PlayerEffectiveMMR{4: stored.combined[0]}},
+		playlists:  playlists,
+		config:     playlists.config,
+	}
+	for _, user := range playlists.userRepo.(*fakePlaylistUsers).users {
+		resolver.userRepo.(*fakeUserDirectory).users[user.DiscordID] = user
+	}
+
+	resolved, err := resolver.ResolvePlaylist(7, []string{"100000000000000003", "100000000000000001", "100000000000000004"}, models.RatingPlaylistOnes)
+	if err != nil {
+		t.Fatalf("ResolvePlaylist failed: %v", err)
+	}
+	sources := []string{RatingSourcePlaylist, RatingSourcePlaylistSeed, RatingSourceGuild}
+	for i, rating := range resolved {
+		if rating.Source != sources[i] {
+			t.Errorf("%s: expected source %s, got %s", rating.DiscordID, sources[i], rating.Source)
+		}
+	}
+	if resolved[0].Rating.Mu != 1400 || resolved[2].Rating.Mu != 1300 {
+		t.Errorf("expected the stored 1v1 rating and the combined fallback, got %+v", resolved)
+	}
+
+	ones, err := playlists.Leaderboard(7, models.RatingPlaylistOnes, 0)
+	if err != nil || len(ones) != 1 || ones[0].Name != "Player 3" || ones[0].Mu != 1400 || ones[0].SkillEstimate != 1391 {
+		t.Errorf("expected player 3 alone on the 1v1 leaderboard, got %+v (%v)", ones, err)
+	}
+	all, err := playlists.Leaderboard(7, "", 1)
+	if err != nil || len(all) != 1 || all[0].UserID != 4 || all[0].Rank != 1 || all[0].Name != "Player 4" {
+		t.Errorf("expected the combined leaderboard cut to its top player, got %+v (%v)", all, err)
+	}
+	lookups := playlists.userRepo.(*fakePlaylistUsers).lookups
+	if len(lookups) == 0 || !reflect.DeepEqual(lookups[len(lookups)-1], []int64{4}) {
+		t.Errorf("expected the leaderboard to look up only the listed player, got %v", lookups)
+	}
+
+	if _, err := ParsePlaylist("rumble"); err == nil {
+		t.Error("expected rumble to have no rating playlist")
+	}
+	if playlist, err := ParsePlaylist("2v2"); err != nil || playlist != models.RatingPlaylistTwos {
+		t.Errorf("expected 2v2 to parse as twos, got %q (%v)", playlist, err)
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+	"usl-server/internal/models"
+)
+
+// Rank badge layout
+const (
+	rankBadgeWidth     = 320
+	rankBadgeHeight    = 80
+	rankBadgeMaxName   = 24 // runes of the player name shown before it is cut short
+	rankBadgeUnranked  = "#6b7280"
+	rankBadgeFontStack = "Verdana,DejaVu Sans,sans-serif"
+)
+
+// rankFamily is a group of ranks sharing a badge colour and abbreviation
+type rankFamily struct {
+	prefix string
+	short  string
+	color  string
+}
+
+// rankFamilies is checked in order, so Grand Champion comes before Champion
+var rankFamilies = []rankFamily{
+	{"Supersonic Legend", "SSL", "#d8d3ff"},
+	{"Grand Champion", "GC", "#e0463f"},
+	{"Champion", "C", "#a05bd6"},
+	{"Diamond", "D", "#3d8ee0"},
+	{"Platinum", "P", "#5fd0d9"},
+	{"Gold", "G", "#e5b83b"},
+	{"Silver", "S", "#c3cad3"},
+	{"Bronze", "B", "#b0703c"},
+}
+
+// rankBadgePlaylists labels distribution keys on the badge
+var rankBadgePlaylists = map[string]string{
+	models.RankPlaylistSoloDuel: "1v1",
+	models.RankPlaylistDoubles:  "2v2",
+	models.RankPlaylistStandard: "3v3",
+}
+
+// buildRankBadge renders a rank as an SVG badge, or an unranked badge when rank is nil
+func buildRankBadge(name string, rank *RankLookup) []byte {
+	color, short, title, detail := rankBadgeUnranked, "-", "Unranked", "No ranked games"
+	if rank != nil {
+		color, short = rankBadgeStyle(rank.Rank)
+		title = rank.Name
+		detail = fmt.Sprintf("%s · %.0f MMR · top %.1f%%", rankBadgePlaylists[rank.Playlist], rank.MMR, 100-rank.Percentile)
+	}
+	if name == "" {
+		name = title
+		title = ""
+	}
+	if runes := []rune(name); len(runes) > rankBadgeMaxName {
+		name = string(runes[:rankBadgeMaxName-1]) + "…"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%s">`,
+		rankBadgeWidth, rankBadgeHeight, rankBadgeWidth, rankBadgeHeight, html.EscapeString(strings.TrimSpace(name+" "+title)))
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" rx="8" fill="#1f2937"/>`, rankBadgeWidth, rankBadgeHeight)
+	fmt.Fprintf(&b, `<path d="M40 10 L64 20 L64 44 Q64 62 40 70 Q16 62 16 44 L16 20 Z" fill="%s"/>`, color)
+	fmt.Fprintf(&b, `<text x="40" y="47" text-anchor="middle" font-family="%s" font-size="15" font-weight="bold" fill="#111827">%s</text>`,
+		rankBadgeFontStack, html.EscapeString(short))
+	fmt.Fprintf(&b, `<text x="80" y="28" font-family="%s" font-size="16" font-weight="bold" fill="#f9fafb">%s</text>`,
+		rankBadgeFontStack, html.EscapeString(name))
+	if title != "" {
+		fmt.Fprintf(&b, `<text x="80" y="48" font-family="%s" font-size="13" fill="%s">%s</text>`,
+			rankBadgeFontStack, color, html.EscapeString(title))
+	}
+	fmt.Fprintf(&b, `<text x="80" y="66" font-family="%s" font-size="11" fill="#9ca3af">%s</text>`,
+		rankBadgeFontStack, html.EscapeString(detail))
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+// rankBadgeStyle returns a rank's badge colour and abbreviation, e.g. "GC2" for Grand Champion 2
+// Ranks outside the known families, from custom distributions, use their initials.
+func rankBadgeStyle(rank string) (string, string) {
+	for _, family := range rankFamilies {
+		if strings.HasPrefix(rank, family.prefix) {
+			return family.color, family.short + strings.TrimSpace(strings.TrimPrefix(rank, family.prefix))
+		}
+	}
+
+	var short strings.Builder
+	for _, word := range strings.Fields(rank) {
+		first := []rune(word)[0]
+		if unicode.IsDigit(first) {
+			short.WriteString(word)
+		} else {
+			short.WriteRune(unicode.ToUpper(first))
+		}
+	}
+	return rankBadgeUnranked, short.String()
+}
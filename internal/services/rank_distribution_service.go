@@ -0,0 +1,129 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// Rank distribution errors
+var (
+	ErrInvalidRankDistribution  = errors.New("invalid rank distribution")
+	ErrRankDistributionNotFound = errors.New("rank distribution not found")
+)
+
+// RankDistributionStore interface for uploaded rank distributions
+type RankDistributionStore interface {
+	GetDistributions() ([]*models.RankDistribution, error)
+	UpsertDistribution(distribution *models.RankDistribution) (*models.RankDistribution, error)
+}
+
+// RankDistributionSummary describes a loaded distribution without its rank bands
+type RankDistributionSummary struct {
+	GameSeason int                `json:"game_season"`
+	Name       string             `json:"name"`
+	StartsAt   string             `json:"starts_at"`
+	Ranks      map[string]int     `json:"ranks"`        // ranks per playlist
+	TopRankMMR map[string]float64 `json:"top_rank_mmr"` // MMR where each playlist's highest rank starts
+	Uploaded   bool               `json:"uploaded"`     // false for a season built into the server
+}
+
+// RankDistributionService manages the rank distributions tracker MMR is read against.
+// Service Responsibilities:
+// - Loading uploaded distributions into the percentile converter at startup
+// - Validating and storing a new game season's distribution, then using it right away
+// - Listing the seasons the converter knows about
+type RankDistributionService struct {
+	distributionRepo RankDistributionStore
+	converter        *PercentileConverter
+	config           *config.Config
+}
+
+func NewRankDistributionService(distributionRepo *repositories.RankDistributionRepository, converter *PercentileConverter, config *config.Config) *RankDistributionService {
+	return &RankDistributionService{
+		distributionRepo: distributionRepo,
+		converter:        converter,
+		config:           config,
+	}
+}
+
+// Reload loads every uploaded distribution into the converter
+// Built-in seasons stay in place when loading fails, so seeding keeps working.
+func (s *RankDistributionService) Reload() error {
+	distributions, err := s.distributionRepo.GetDistributions()
+	if err != nil {
+		return err
+	}
+	if err := s.converter.LoadDistributions(distributions); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRankDistribution, err)
+	}
+
+	log.Printf("RankDistributionService: Loaded %d uploaded rank distributions", len(distributions))
+	return nil
+}
+
+// Upload stores a game season's distribution and switches the converter to it
+// Uploading a season again replaces it; ratings already seeded are not recalculated.
+func (s *RankDistributionService) Upload(distribution *models.RankDistribution) (*models.RankDistribution, error) {
+	if err := distribution.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRankDistribution, err)
+	}
+
+	saved, err := s.distributionRepo.UpsertDistribution(distribution)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.converter.LoadDistributions([]*models.RankDistribution{saved}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRankDistribution, err)
+	}
+
+	log.Printf("RankDistributionService: Season %d distribution uploaded, starting %s", saved.GameSeason, saved.StartsAt.Format("2006-01-02"))
+	return saved, nil
+}
+
+// ParseDistribution reads an uploaded distribution file
+func (s *RankDistributionService) ParseDistribution(data []byte) (*models.RankDistribution, error) {
+	var distribution models.RankDistribution
+	if err := json.Unmarshal(data, &distribution); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRankDistribution, err)
+	}
+	return &distribution, nil
+}
+
+// GetDistribution returns the distribution loaded for a game season
+func (s *RankDistributionService) GetDistribution(gameSeason int) (*models.RankDistribution, error) {
+	for _, distribution := range s.converter.Distributions() {
+		if distribution.GameSeason == gameSeason {
+			return distribution, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: season %d", ErrRankDistributionNotFound, gameSeason)
+}
+
+// ListDistributions summarizes every loaded season, oldest first
+func (s *RankDistributionService) ListDistributions() []RankDistributionSummary {
+	distributions := s.converter.Distributions()
+	summaries := make([]RankDistributionSummary, 0, len(distributions))
+	for _, distribution := range distributions {
+		summary := RankDistributionSummary{
+			GameSeason: distribution.GameSeason,
+			Name:       distribution.Name,
+			StartsAt:   distribution.StartsAt.Format("2006-01-02"),
+			Ranks:      make(map[string]int, len(distribution.Playlists)),
+			TopRankMMR: make(map[string]float64, len(distribution.Playlists)),
+			Uploaded:   distribution.ID > 0,
+		}
+		for playlist, bands := range distribution.Playlists {
+			summary.Ranks[playlist] = len(bands)
+			if len(bands) > 0 {
+				summary.TopRankMMR[playlist] = bands[len(bands)-1].MinMMR
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
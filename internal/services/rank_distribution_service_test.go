@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakeRankDistributions struct {
+	saved []*models.RankDistribution
+}
+
+func (f *fakeRankDistributions) GetDistributions() ([]*models.RankDistribution, error) {
+	return f.saved, nil
+}
+
+func (f *fakeRankDistributions) UpsertDistribution(distribution *models.RankDistribution) (*models.RankDistribution, error) {
+	saved := *distribution
+	saved.ID = int64(len(f.saved) + 1)
+	f.saved = append(f.saved, &saved)
+	return &saved, nil
+}
+
+// shiftedDistribution copies a season's bands with every MMR range moved up
+func shiftedDistribution(base *models.RankDistribution, gameSeason int, startsAt time.Time, shift float64) *models.RankDistribution {
+	playlists := make(map[string][]models.RankBand, len(base.Playlists))
+	for playlist, bands := range base.Playlists {
+		shifted := make([]models.RankBand, len(bands))
+		for i, band := range bands {
+			band.MinMMR += shift
+			band.MaxMMR += shift
+			shifted[i] = band
+		}
+		playlists[playlist] = shifted
+	}
+	return &models.RankDistribution{GameSeason: gameSeason, StartsAt: startsAt, Playlists: playlists}
+}
+
+func TestUploadedSeasonIsUsedForTrackersUpdatedAfterItStarts(t *testing.T) {
+	converter := NewPercentileConverter(&config.Config{})
+	service := &RankDistributionService{distributionRepo: &fakeRankDistributions{}, converter: converter, config: &config.Config{}}
+
+	builtIn, err := service.GetDistribution(14)
+	if err != nil {
+		t.Fatalf("built-in season 14 should be loaded: %v", err)
+	}
+	before := converter.MMRToPercentile(1200, models.RankPlaylistDoubles)
+
+	seasonStart := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	saved, err := service.Upload(shiftedDistribution(builtIn, 15, seasonStart, 100))
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	if saved.Name != "Season 15" {
+		t.Errorf("expected a default name, got %q", saved.Name)
+	}
+
+	if got := converter.SeasonAt(seasonStart.AddDate(0, 1, 0)); got != 15 {
+		t.Errorf("tracker updated after the season started should use season 15, got %d", got)
+	}
+	if got := converter.SeasonAt(seasonStart.AddDate(0, -1, 0)); got != 14 {
+		t.Errorf("tracker updated before the season started should use season 14, got %d", got)
+	}
+	if got := converter.MMRToPercentileForSeason(1200, models.RankPlaylistDoubles, 14); got != before {
+		t.Errorf("season 14 percentiles should not change, got %.3f want %.3f", got, before)
+	}
+	if got := converter.MMRToPercentileForSeason(1200, models.RankPlaylistDoubles, 15); got >= before {
+		t.Errorf("the same MMR should rank lower against inflated season 15, got %.3f vs %.3f", got, before)
+	}
+
+	summaries := service.ListDistributions()
+	if len(summaries) != 2 || summaries[0].GameSeason != 14 || !summaries[1].Uploaded {
+		t.Errorf("expected built-in 14 then uploaded 15, got %+v", summaries)
+	}
+}
+
+func TestUploadRejectsOverlappingBands(t *testing.T) {
+	converter := NewPercentileConverter(&config.Config{})
+	store := &fakeRankDistributions{}
+	service := &RankDistributionService{distributionRepo: store, converter: converter, config: &config.Config{}}
+
+	builtIn, _ := service.GetDistribution(14)
+	bad := shiftedDistribution(builtIn, 15, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), 0)
+	bad.Playlists[models.RankPlaylistStandard][1].MinMMR = bad.Playlists[models.RankPlaylistStandard][0].MaxMMR
+
+	if _, err := service.Upload(bad); !errors.Is(err, ErrInvalidRankDistribution) {
+		t.Fatalf("expected ErrInvalidRankDistribution, got %v", err)
+	}
+	if len(store.saved) != 0 || len(converter.Distributions()) != 1 {
+		t.Errorf("a rejected upload must not be stored or loaded")
+	}
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// Rank service errors
+var (
+	ErrInvalidRankLookup = errors.New("invalid rank lookup")
+	ErrRankNotFound      = errors.New("player has no trackers")
+)
+
+// RankTrackerSource interface for reading a player's trackers
+type RankTrackerSource interface {
+	GetTrackersByDiscordID(discordID string, validOnly bool) ([]*models.UserTracker, error)
+}
+
+// RankService names the rank behind tracker MMR and renders rank badges.
+// Service Responsibilities:
+// - Looking up the rank and division of any MMR in any playlist and game season
+// - Annotating trackers and players with their rank name in each playlist
+// - Rendering a player's best rank as an SVG badge for Discord embeds and profile pages
+type RankService struct {
+	converter                 *PercentileConverter
+	trackerRepo               RankTrackerSource
+	userRepo                  UserDirectory
+	dataTransformationService *DataTransformationService
+}
+
+func NewRankService(
+	converter *PercentileConverter,
+	trackerRepo *repositories.TrackerRepository,
+	userRepo *repositories.UserRepository,
+	dataTransformationService *DataTransformationService,
+) *RankService {
+	return &RankService{
+		converter:                 converter,
+		trackerRepo:               trackerRepo,
+		userRepo:                  userRepo,
+		dataTransformationService: dataTransformationService,
+	}
+}
+
+// Lookup returns the rank mmr falls in for a playlist, or for every playlist when none is given
+// Playlist may be a tracker key (ones), a match format (1v1) or a distribution key (soloDuel).
+// Season 0 uses the latest game season.
+func (s *RankService) Lookup(mmr float64, playlist string, season int) ([]RankLookup, error) {
+	if mmr < 0 {
+		return nil, fmt.Errorf("%w: mmr must not be negative", ErrInvalidRankLookup)
+	}
+	if season < 0 {
+		return nil, fmt.Errorf("%w: game season must not be negative", ErrInvalidRankLookup)
+	}
+
+	playlists := models.RankPlaylists
+	if playlist != "" {
+		key, ok := models.RankPlaylistFor(playlist)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown playlist %q", ErrInvalidRankLookup, playlist)
+		}
+		playlists = []string{key}
+	}
+
+	lookups := make([]RankLookup, 0, len(playlists))
+	for _, key := range playlists {
+		lookup, ok := s.converter.LookupRank(mmr, key, season)
+		if !ok {
+			return nil, fmt.Errorf("%w: no rank distribution covers %s", ErrInvalidRankLookup, key)
+		}
+		lookups = append(lookups, lookup)
+	}
+	return lookups, nil
+}
+
+// Ranks names the best rank in each playlist across the given trackers
+// Returns nil when none of them has ranked data.
+func (s *RankService) Ranks(trackers ...*TrackerData) *models.PlaylistRanks {
+	best := s.bestRanks(trackers)
+	if len(best) == 0 {
+		return nil
+	}
+
+	ranks := &models.PlaylistRanks{}
+	for playlist, lookup := range best {
+		switch playlist {
+		case "ones":
+			ranks.Ones = lookup.Name
+		case "twos":
+			ranks.Twos = lookup.Name
+		case "threes":
+			ranks.Threes = lookup.Name
+		}
+	}
+	return ranks
+}
+
+// AnnotateTrackers sets each tracker's rank name in every playlist
+func (s *RankService) AnnotateTrackers(trackers []*models.UserTracker) {
+	for _, tracker := range trackers {
+		data, err := s.dataTransformationService.PrepareTrackerDataForCalculation(tracker)
+		if err != nil {
+			continue
+		}
+		tracker.Ranks = s.Ranks(data)
+	}
+}
+
+// Badge renders a player's rank as an SVG badge
+// Without a playlist the badge shows the player's highest rank by percentile. Players whose
+// trackers have no ranked data get an unranked badge; players without trackers are an error.
+func (s *RankService) Badge(discordID, playlist string) ([]byte, error) {
+	key := ""
+	if playlist != "" {
+		var ok bool
+		if key, ok = models.RankPlaylistFor(playlist); !ok {
+			return nil, fmt.Errorf("%w: unknown playlist %q", ErrInvalidRankLookup, playlist)
+		}
+	}
+
+	trackers, err := s.trackerRepo.GetTrackersByDiscordID(discordID, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(trackers) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrRankNotFound, discordID)
+	}
+
+	data := make([]*TrackerData, 0, len(trackers))
+	for _, tracker := range trackers {
+		trackerData, err := s.dataTransformationService.PrepareTrackerDataForCalculation(tracker)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, trackerData)
+	}
+
+	var best *RankLookup
+	for _, lookup := range s.bestRanks(data) {
+		if key != "" && lookup.Playlist != key {
+			continue
+		}
+		if best == nil || lookup.Percentile > best.Percentile {
+			best = &lookup
+		}
+	}
+
+	name := ""
+	if user, err := s.userRepo.FindUserByDiscordID(discordID); err == nil && user != nil {
+		name = user.Name
+	} else {
+		log.Printf("RankService: No user found for badge %s, leaving the name off: %v", discordID, err)
+	}
+
+	return buildRankBadge(name, best), nil
+}
+
+// bestRanks returns the highest-MMR rank in each playlist across the trackers, keyed ones, twos and threes
+func (s *RankService) bestRanks(trackers []*TrackerData) map[string]RankLookup {
+	best := make(map[string]RankLookup)
+	for _, tracker := range trackers {
+		if tracker == nil {
+			continue
+		}
+		season := s.converter.SeasonAt(tracker.LastUpdated)
+		for _, playlist := range trackerPlaylists {
+			lookup, ok := s.trackerRank(tracker.playlist(playlist), playlist, season)
+			if !ok {
+				continue
+			}
+			if current, seen := best[playlist]; !seen || lookup.MMR > current.MMR {
+				best[playlist] = lookup
+			}
+		}
+	}
+	return best
+}
+
+// trackerRank looks up the rank a tracker shows in a playlist: its current-season peak, or
+// the previous season's peak against the season before when the current season has none
+func (s *RankService) trackerRank(data trackerPlaylist, playlist string, season int) (RankLookup, bool) {
+	key, ok := models.RankPlaylistFor(playlist)
+	if !ok {
+		return RankLookup{}, false
+	}
+
+	mmr := data.CurrentPeak
+	if mmr <= 0 {
+		mmr = data.PreviousPeak
+		if season > 0 {
+			season--
+		}
+	}
+	if mmr <= 0 {
+		return RankLookup{}, false
+	}
+	return s.converter.LookupRank(float64(mmr), key, season)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakeRankTrackers struct {
+	trackers []*models.UserTracker
+}
+
+func (f *fakeRankTrackers) GetTrackersByDiscordID(discordID string, validOnly bool) ([]*models.UserTracker, error) {
+	var trackers []*models.UserTracker
+	for _, tracker := range f.trackers {
+		if tracker.DiscordID == discordID {
+			trackers = append(trackers, tracker)
+		}
+	}
+	return trackers, nil
+}
+
+func newTestRankService(trackers []*models.UserTracker) *RankService {
+	return &RankService{
+		converter:   NewPercentileConverter(&config.Config{}),
+		trackerRepo: &fakeRankTrackers{trackers: trackers},
+		userRepo: &fakeUserDirectory{users: map[string]*models.User{
+			playerID(1): {ID: 1, DiscordID: playerID(1), Name: "Striker <3"},
+		}},
+		dataTransformationService: NewDataTransformationService(),
+	}
+}
+
+func TestLookupRankSplitsRanksIntoDivisions(t *testing.T) {
+	converter := NewPercentileConverter(&config.Config{})
+
+	tests := []struct {
+		mmr      float64
+		rank     string
+		division int
+		name     string
+	}{
+		{995, "Champion 1", 1, "Champion 1 Div I"},
+		{1100, "Champion 2", 2, "Champion 2 Div II"},
+		{1174.4, "Champion 2", 4, "Champion 2 Div IV"},
+		{1600, "Supersonic Legend", 0, "Supersonic Legend"},
+		{5000, "Supersonic Legend", 0, "Supersonic Legend"},
+	}
+	for _, tt := range tests {
+		lookup, ok := converter.LookupRank(tt.mmr, models.RankPlaylistDoubles, 0)
+		if !ok {
+			t.Fatalf("expected a rank for %.1f", tt.mmr)
+		}
+		if lookup.Rank != tt.rank || lookup.Division != tt.division || lookup.Name != tt.name {
+			t.Errorf("%.1f: expected %s, got %+v", tt.mmr, tt.name, lookup)
+		}
+		if lookup.GameSeason == 0 || lookup.Percentile <= 0 {
+			t.Errorf("%.1f: expected the season and percentile, got %+v", tt.mmr, lookup)
+		}
+	}
+
+	if _, ok := converter.LookupRank(1000, "rumble", 0); ok {
+		t.Error("expected no rank for a playlist without a distribution")
+	}
+}
+
+func TestRankServiceLookupAcceptsPlaylistAliases(t *testing.T) {
+	service := newTestRankService(nil)
+
+	lookups, err := service.Lookup(1100, "2v2", 0)
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if len(lookups) != 1 || lookups[0].Playlist != models.RankPlaylistDoubles || lookups[0].Name != "Champion 2 Div II" {
+		t.Errorf("expected 2v2 to look up doubles, got %+v", lookups)
+	}
+
+	all, err := service.Lookup(1100, "", 0)
+	if err != nil || len(all) != len(models.RankPlaylists) {
+		t.Errorf("expected a rank for every playlist, got %+v (%v)", all, err)
+	}
+
+	for _, playlist := range []string{"rumble", "4v4"} {
+		if _, err := service.Lookup(1100, playlist, 0); !errors.Is(err, ErrInvalidRankLookup) {
+			t.Errorf("%s: expected ErrInvalidRankLookup, got %v", playlist, err)
+		}
+	}
+	if _, err := service.Lookup(-1, "ones", 0); !errors.Is(err, ErrInvalidRankLookup) {
+		t.Errorf("expected negative MMR to be rejected, got %v", err)
+	}
+}
+
+func TestRankServiceRanksAndBadge(t *testing.T) {
+	service := newTestRankService([]*models.UserTracker{
+		{ID: 1, DiscordID: playerID(1), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/striker/overview",
+			OnesPreviousSeasonPeak: 1060, TwosCurrentSeasonPeak: 1100, TwosCurrentSeasonGames: 200},
+		{ID: 2, DiscordID: playerID(1), URL: "https://rocketleague.tracker.network/rocket-league/profile/steam/alt/overview",
+			TwosCurrentSeasonPeak: 900, TwosCurrentSeasonGames: 100},
+		{ID: 3, DiscordID: playerID(2), URL: "https://rocketleague.tracker.network/rocket-league/profile/psn/casual/overview"},
+	})
+
+	trackers, _ := service.trackerRepo.GetTrackersByDiscordID(playerID(1), true)
+	service.AnnotateTrackers(trackers)
+	if ranks := trackers[1].Ranks; ranks == nil || ranks.Twos != "Diamond 2 Div II" || ranks.Ones != "" {
+		t.Errorf("expected the alt to show its own 2v2 rank, got %+v", ranks)
+	}
+
+	data := make([]*TrackerData, 0, len(trackers))
+	for _, tracker := range trackers {
+		trackerData, _ := service.dataTransformationService.PrepareTrackerDataForCalculation(tracker)
+		data = append(data, trackerData)
+	}
+	ranks := service.Ranks(data...)
+	if ranks == nil || ranks.Ones != "Champion 2 Div I" || ranks.Twos != "Champion 2 Div II" || ranks.Threes != "" {
+		t.Errorf("expected the best rank per playlist, falling back to last season's peak, got %+v", ranks)
+	}
+
+	badge, err := service.Badge(playerID(1), "twos")
+	if err != nil {
+		t.Fatalf("Badge failed: %v", err)
+	}
+	svg := string(badge)
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, "Champion 2 Div II") || !strings.Contains(svg, "Striker &lt;3") {
+		t.Errorf("expected an SVG naming the escaped player and their 2v2 rank, got %s", svg)
+	}
+
+	unranked, err := service.Badge(playerID(2), "")
+	if err != nil || !strings.Contains(string(unranked), "Unranked") {
+		t.Errorf("expected an unranked badge for trackers without ranked data, got %s (%v)", unranked, err)
+	}
+
+	if _, err := service.Badge(playerID(3), ""); !errors.Is(err, ErrRankNotFound) {
+		t.Errorf("expected ErrRankNotFound for a player without trackers, got %v", err)
+	}
+	if _, err := service.Badge(playerID(1), "rumble"); !errors.Is(err, ErrInvalidRankLookup) {
+		t.Errorf("expected ErrInvalidRankLookup for an unknown playlist, got %v", err)
+	}
+}
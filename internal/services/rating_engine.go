@@ -0,0 +1,348 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidRatingEngine is returned when a guild picks an engine that does not exist
+var ErrInvalidRatingEngine = errors.New("invalid rating engine")
+
+// RatingEngine seeds ratings from tracker data and updates them from match results.
+// Every engine works on the stored mu (0-2000 display scale) and sigma (TrueSkill units),
+// so a guild can switch engines, or compare them on the same match history, without a migration.
+type RatingEngine interface {
+	// Name returns the engine's key, one of the models.RatingEngine constants
+	Name() string
+	// Seed calculates a starting rating from a player's tracker data
+	Seed(trackerData *TrackerData) (*TrueSkillCalculation, error)
+	// RateMatch returns both teams' ratings after a match, in the order they were passed in
+	RateMatch(teamA, teamB []TrueSkillRating, outcome MatchOutcome) ([]TrueSkillRating, []TrueSkillRating)
+	// WinProbability returns the probability that teamA beats teamB
+	WinProbability(teamA, teamB []TrueSkillRating) float64
+	// Explain shows how the engine would rate a match, step by step
+	Explain(teamA, teamB []TrueSkillRating, outcome MatchOutcome) *RatingExplanation
+}
+
+// RatingEngineSource interface for finding the engine that rates a guild's matches
+type RatingEngineSource interface {
+	ForGuild(guildID int64) RatingEngine
+}
+
+// RatingStep is one player's rating before and after a rated match
+type RatingStep struct {
+	Before  TrueSkillRating `json:"before"`
+	After   TrueSkillRating `json:"after"`
+	MuDelta float64         `json:"mu_delta"`
+}
+
+// RatingExplanation describes how an engine arrives at a match update
+type RatingExplanation struct {
+	Engine              string             `json:"engine"`
+	Outcome             string             `json:"outcome"`
+	TeamAWinProbability float64            `json:"team_a_win_probability"`
+	Parameters          map[string]float64 `json:"parameters"`
+	Steps               []string           `json:"steps"`
+	TeamA               []RatingStep       `json:"team_a"`
+	TeamB               []RatingStep       `json:"team_b"`
+}
+
+// String returns the outcome as shown in rating explanations
+func (o MatchOutcome) String() string {
+	switch o {
+	case MatchOutcomeTeamAWins:
+		return "team_a_wins"
+	case MatchOutcomeTeamBWins:
+		return "team_b_wins"
+	default:
+		return "draw"
+	}
+}
+
+// ParseMatchOutcome reads an outcome in the form returned by MatchOutcome.String
+func ParseMatchOutcome(value string) (MatchOutcome, error) {
+	switch value {
+	case "team_a_wins":
+		return MatchOutcomeTeamAWins, nil
+	case "team_b_wins":
+		return MatchOutcomeTeamBWins, nil
+	case "draw":
+		return MatchOutcomeDraw, nil
+	default:
+		return MatchOutcomeDraw, fmt.Errorf("unknown outcome %q: expected team_a_wins, team_b_wins or draw", value)
+	}
+}
+
+// RatingEngineSelector holds every rating engine and picks each guild's from its config.
+// Service Responsibilities:
+// - Building the TrueSkill, Glicko-2 and Elo engines from the application config
+// - Resolving and saving a guild's rating.engine setting, falling back to TrueSkill
+type RatingEngineSelector struct {
+	engines   map[string]RatingEngine
+	guildRepo GuildConfigStore
+}
+
+// NewRatingEngineSelector creates the engines; all of them seed from tracker data the same way
+func NewRatingEngineSelector(
+	guildRepo *repositories.GuildRepository,
+	mmrCalculator *MMRCalculator,
+	uncertaintyCalculator *EnhancedUncertaintyCalculator,
+	config *config.Config,
+) *RatingEngineSelector {
+	seeder := &trackerSeeder{mmrCalculator: mmrCalculator, uncertaintyCalculator: uncertaintyCalculator}
+	return &RatingEngineSelector{
+		engines:   newRatingEngines(seeder, config),
+		guildRepo: guildRepo,
+	}
+}
+
+// newRatingEngines builds every engine around one shared seeder, keyed by name
+func newRatingEngines(seeder *trackerSeeder, config *config.Config) map[string]RatingEngine {
+	engines := make(map[string]RatingEngine)
+	for _, engine := range []RatingEngine{
+		&TrueSkillEngine{trackerSeeder: seeder, rater: NewTrueSkillRater(config)},
+		NewGlicko2Engine(seeder, config),
+		NewEloEngine(seeder, config),
+	} {
+		engines[engine.Name()] = engine
+	}
+	return engines
+}
+
+// NewTrueSkillEngine creates the default engine from the percentile seed and the TrueSkill rater
+func NewTrueSkillEngine(mmrCalculator *MMRCalculator, uncertaintyCalculator *EnhancedUncertaintyCalculator, config *config.Config) *TrueSkillEngine {
+	return &TrueSkillEngine{
+		trackerSeeder: &trackerSeeder{mmrCalculator: mmrCalculator, uncertaintyCalculator: uncertaintyCalculator},
+		rater:         NewTrueSkillRater(config),
+	}
+}
+
+// Engine returns an engine by name
+func (s *RatingEngineSelector) Engine(name string) (RatingEngine, error) {
+	engine, ok := s.engines[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown rating engine: %s", name)
+	}
+	return engine, nil
+}
+
+// Default returns the TrueSkill engine
+func (s *RatingEngineSelector) Default() RatingEngine {
+	return s.engines[models.RatingEngineTrueSkill]
+}
+
+// Names returns the engine names in alphabetical order
+func (s *RatingEngineSelector) Names() []string {
+	names := make([]string, 0, len(s.engines))
+	for name := range s.engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForGuild returns the engine set in the guild's config
+// An unreadable config falls back to TrueSkill so matches can still be rated.
+func (s *RatingEngineSelector) ForGuild(guildID int64) RatingEngine {
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		log.Printf("RatingEngineSelector: Failed to load config for guild %d, using TrueSkill: %v", guildID, err)
+		return s.Default()
+	}
+
+	rating := guildConfig.Rating
+	if err := rating.Validate(); err != nil {
+		log.Printf("RatingEngineSelector: Guild %d has %v, using TrueSkill", guildID, err)
+		return s.Default()
+	}
+
+	engine, err := s.Engine(rating.Engine)
+	if err != nil {
+		return s.Default()
+	}
+	return engine
+}
+
+// GetGuildEngine returns the name of the engine set in the guild's config, with the default applied
+func (s *RatingEngineSelector) GetGuildEngine(guildID int64) (string, error) {
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return "", err
+	}
+
+	rating := guildConfig.Rating
+	if err := rating.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRatingEngine, err)
+	}
+	return rating.Engine, nil
+}
+
+// SetGuildEngine saves the engine that rates the guild's future matches
+// Ratings already stored are kept; only later updates use the new engine.
+func (s *RatingEngineSelector) SetGuildEngine(guildID int64, name string) error {
+	rating := models.RatingConfig{Engine: name}
+	if err := rating.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRatingEngine, err)
+	}
+
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	guildConfig.Rating = rating
+	if err := s.guildRepo.UpdateConfig(guildID, guildConfig); err != nil {
+		return err
+	}
+
+	log.Printf("RatingEngineSelector: Guild %d now rates matches with %s", guildID, name)
+	return nil
+}
+
+// trackerSeeder calculates a starting mu from percentile-weighted tracker peaks and sigma from
+// the enhanced uncertainty factors. It is shared by every engine so seeds match across them.
+type trackerSeeder struct {
+	mmrCalculator         *MMRCalculator
+	uncertaintyCalculator *EnhancedUncertaintyCalculator
+	rankSeason            int // game season whose rank table every tracker is read against; 0 picks by tracker date
+}
+
+// Seed calculates mu and sigma from tracker data
+func (s *trackerSeeder) Seed(trackerData *TrackerData) (*TrueSkillCalculation, error) {
+	if s == nil || s.mmrCalculator == nil || s.uncertaintyCalculator == nil {
+		return nil, fmt.Errorf("rating engine has no tracker seed calculators")
+	}
+
+	// Calculate percentile-based TrueSkill seeding using structured object format
+	playerData := PlayerData{
+		Ones: PlaylistData{
+			Current:  PlaylistSeasonData{MMR: trackerData.OnesCurrentPeak, Games: trackerData.OnesCurrentGames},
+			Previous: PlaylistSeasonData{MMR: trackerData.OnesPreviousPeak, Games: trackerData.OnesPreviousGames},
+		},
+		Twos: PlaylistData{
+			Current:  PlaylistSeasonData{MMR: trackerData.TwosCurrentPeak, Games: trackerData.TwosCurrentGames},
+			Previous: PlaylistSeasonData{MMR: trackerData.TwosPreviousPeak, Games: trackerData.TwosPreviousGames},
+		},
+		Threes: PlaylistData{
+			Current:  PlaylistSeasonData{MMR: trackerData.ThreesCurrentPeak, Games: trackerData.ThreesCurrentGames},
+			Previous: PlaylistSeasonData{MMR: trackerData.ThreesPreviousPeak, Games: trackerData.ThreesPreviousGames},
+		},
+		GameSeason:         s.gameSeason(trackerData),
+		TrackedAt:          trackerData.LastUpdated,
+		TrackerAggregation: trackerData.Aggregation,
+		Sources:            trackerData.Sources,
+	}
+
+	skillResult := s.mmrCalculator.CalculatePercentileBasedSkill(playerData)
+
+	uncertainty := s.uncertaintyCalculator.ExplainUncertaintyForSeason(trackerData, playerData.GameSeason)
+
+	return &TrueSkillCalculation{
+		Mu:          skillResult.TrueskillMu,
+		Sigma:       uncertainty.Sigma,
+		SkillResult: &skillResult,
+		Uncertainty: uncertainty,
+		LastUpdated: time.Now(),
+	}, nil
+}
+
+// gameSeason returns the rank table season for tracker data
+func (s *trackerSeeder) gameSeason(trackerData *TrackerData) int {
+	if s.rankSeason > 0 {
+		return s.rankSeason
+	}
+	return s.mmrCalculator.percentileConverter.SeasonAt(trackerData.LastUpdated)
+}
+
+// TrueSkillEngine rates matches with the Bayesian TrueSkill update; it is the default engine
+type TrueSkillEngine struct {
+	*trackerSeeder
+	rater *TrueSkillRater
+}
+
+// Name returns the engine's key
+func (e *TrueSkillEngine) Name() string {
+	return models.RatingEngineTrueSkill
+}
+
+// RateMatch applies the TrueSkill update to both teams
+func (e *TrueSkillEngine) RateMatch(teamA, teamB []TrueSkillRating, outcome MatchOutcome) ([]TrueSkillRating, []TrueSkillRating) {
+	return e.rater.RateMatch(teamA, teamB, outcome)
+}
+
+// WinProbability returns the TrueSkill probability that teamA wins outright
+func (e *TrueSkillEngine) WinProbability(teamA, teamB []TrueSkillRating) float64 {
+	return e.rater.WinProbability(teamA, teamB)
+}
+
+// Explain shows the TrueSkill parameters and update for a match
+func (e *TrueSkillEngine) Explain(teamA, teamB []TrueSkillRating, outcome MatchOutcome) *RatingExplanation {
+	parameters := map[string]float64{
+		"beta":             e.rater.beta,
+		"tau":              e.rater.tau,
+		"draw_probability": e.rater.drawProbability,
+		"mu_scale":         e.rater.muScale,
+		"match_quality":    roundProbability(e.rater.MatchQuality(teamA, teamB)),
+	}
+	steps := []string{
+		fmt.Sprintf("Each player's σ is inflated by τ=%.4f so ratings keep moving", e.rater.tau),
+		fmt.Sprintf("Team performances are compared as the sum of μ/%.0f with β=%.4f of noise per player", e.rater.muScale, e.rater.beta),
+		"The winners' μ rises and the losers' μ falls in proportion to each player's share of the total variance",
+		"The more surprising the result, the larger the move; every player's σ shrinks after the match",
+	}
+	return explainMatch(e, parameters, steps, teamA, teamB, outcome)
+}
+
+// explainMatch fills in the outcome, win probability and per-player steps common to every engine
+func explainMatch(engine RatingEngine, parameters map[string]float64, steps []string, teamA, teamB []TrueSkillRating, outcome MatchOutcome) *RatingExplanation {
+	newA, newB := engine.RateMatch(teamA, teamB, outcome)
+
+	ratingSteps := func(before, after []TrueSkillRating) []RatingStep {
+		result := make([]RatingStep, len(before))
+		for i := range before {
+			rounded := TrueSkillRating{Mu: roundRating(after[i].Mu), Sigma: roundRating(after[i].Sigma)}
+			result[i] = RatingStep{Before: before[i], After: rounded, MuDelta: roundRating(rounded.Mu - before[i].Mu)}
+		}
+		return result
+	}
+
+	return &RatingExplanation{
+		Engine:              engine.Name(),
+		Outcome:             outcome.String(),
+		TeamAWinProbability: roundProbability(engine.WinProbability(teamA, teamB)),
+		Parameters:          parameters,
+		Steps:               steps,
+		TeamA:               ratingSteps(teamA, newA),
+		TeamB:               ratingSteps(teamB, newB),
+	}
+}
+
+// averageMu returns a team's mean mu
+func averageMu(team []TrueSkillRating) float64 {
+	if len(team) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, player := range team {
+		total += player.Mu
+	}
+	return total / float64(len(team))
+}
+
+// outcomeScore returns team A's actual score: 1 for a win, 0.5 for a draw and 0 for a loss
+func outcomeScore(outcome MatchOutcome) float64 {
+	switch outcome {
+	case MatchOutcomeTeamAWins:
+		return 1
+	case MatchOutcomeTeamBWins:
+		return 0
+	default:
+		return 0.5
+	}
+}
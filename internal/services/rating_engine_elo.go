@@ -0,0 +1,76 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+// Elo parameters
+const (
+	DefaultEloKFactor = 32.0
+	EloScale          = 400.0 // a rating gap of EloScale makes the stronger side 10x more likely to win
+)
+
+// EloEngine rates matches with plain Elo on team-average mu.
+// Every player on a team moves by the same K*(actual - expected); Elo has no uncertainty,
+// so sigma is left as it was seeded.
+type EloEngine struct {
+	*trackerSeeder
+	kFactor float64
+}
+
+// NewEloEngine creates an Elo engine from the rating configuration
+func NewEloEngine(seeder *trackerSeeder, cfg *config.Config) *EloEngine {
+	engine := &EloEngine{trackerSeeder: seeder, kFactor: DefaultEloKFactor}
+	if cfg != nil && cfg.Rating.EloKFactor > 0 {
+		engine.kFactor = cfg.Rating.EloKFactor
+	}
+	return engine
+}
+
+// Name returns the engine's key
+func (e *EloEngine) Name() string {
+	return models.RatingEngineElo
+}
+
+// RateMatch moves each team's players by the same Elo delta
+func (e *EloEngine) RateMatch(teamA, teamB []TrueSkillRating, outcome MatchOutcome) ([]TrueSkillRating, []TrueSkillRating) {
+	delta := e.kFactor * (outcomeScore(outcome) - e.WinProbability(teamA, teamB))
+	return shiftMu(teamA, delta), shiftMu(teamB, -delta)
+}
+
+// WinProbability returns the Elo expected score of teamA
+func (e *EloEngine) WinProbability(teamA, teamB []TrueSkillRating) float64 {
+	return 1 / (1 + math.Pow(10, (averageMu(teamB)-averageMu(teamA))/EloScale))
+}
+
+// Explain shows the Elo expected score and delta for a match
+func (e *EloEngine) Explain(teamA, teamB []TrueSkillRating, outcome MatchOutcome) *RatingExplanation {
+	expected := e.WinProbability(teamA, teamB)
+	parameters := map[string]float64{
+		"k_factor":     e.kFactor,
+		"scale":        EloScale,
+		"team_a_mu":    roundRating(averageMu(teamA)),
+		"team_b_mu":    roundRating(averageMu(teamB)),
+		"actual_score": outcomeScore(outcome),
+	}
+	steps := []string{
+		fmt.Sprintf("Team A averages μ=%.1f and team B μ=%.1f", averageMu(teamA), averageMu(teamB)),
+		fmt.Sprintf("Team A's expected score is 1 / (1 + 10^((B - A) / %.0f)) = %.3f", EloScale, expected),
+		fmt.Sprintf("Team A scored %.1f, so each team A player moves by K × (actual - expected) = %.1f × (%.1f - %.3f) = %+.1f",
+			outcomeScore(outcome), e.kFactor, outcomeScore(outcome), expected, e.kFactor*(outcomeScore(outcome)-expected)),
+		"Team B players move by the same amount in the other direction; σ is unchanged",
+	}
+	return explainMatch(e, parameters, steps, teamA, teamB, outcome)
+}
+
+// shiftMu returns the team with every player's mu moved by delta
+func shiftMu(team []TrueSkillRating, delta float64) []TrueSkillRating {
+	updated := make([]TrueSkillRating, len(team))
+	for i, player := range team {
+		updated[i] = TrueSkillRating{Mu: player.Mu + delta, Sigma: player.Sigma}
+	}
+	return updated
+}
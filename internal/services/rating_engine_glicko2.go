@@ -0,0 +1,189 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+// Glicko-2 parameters
+const (
+	Glicko2Scale             = 173.7178 // converts ratings and deviations to the Glicko-2 scale
+	DefaultGlicko2Volatility = 0.06
+	DefaultGlicko2Tau        = 0.5
+	glicko2Convergence       = 0.000001
+)
+
+// Glicko2Engine rates matches with Glicko-2, treating the opposing team as one composite player.
+// A player's expected score comes from their team's average rating against the opponents'
+// average, and how far they move from their own rating deviation. The deviation is stored as
+// sigma (RD = sigma * mu scale); volatility is not stored, so every update starts from the
+// configured volatility.
+type Glicko2Engine struct {
+	*trackerSeeder
+	volatility float64
+	tau        float64
+	muScale    float64
+	maxSigma   float64
+}
+
+// NewGlicko2Engine creates a Glicko-2 engine from the rating configuration
+func NewGlicko2Engine(seeder *trackerSeeder, cfg *config.Config) *Glicko2Engine {
+	engine := &Glicko2Engine{
+		trackerSeeder: seeder,
+		volatility:    DefaultGlicko2Volatility,
+		tau:           DefaultGlicko2Tau,
+		muScale:       DefaultTrueSkillMuScale,
+	}
+	if cfg == nil {
+		return engine
+	}
+
+	if cfg.Rating.Glicko2Volatility > 0 {
+		engine.volatility = cfg.Rating.Glicko2Volatility
+	}
+	if cfg.Rating.Glicko2Tau > 0 {
+		engine.tau = cfg.Rating.Glicko2Tau
+	}
+	if cfg.TrueSkill.MuScale > 0 {
+		engine.muScale = cfg.TrueSkill.MuScale
+	}
+	engine.maxSigma = cfg.TrueSkill.SigmaMax
+	return engine
+}
+
+// Name returns the engine's key
+func (e *Glicko2Engine) Name() string {
+	return models.RatingEngineGlicko2
+}
+
+// RateMatch applies a one-game Glicko-2 rating period to every player
+func (e *Glicko2Engine) RateMatch(teamA, teamB []TrueSkillRating, outcome MatchOutcome) ([]TrueSkillRating, []TrueSkillRating) {
+	score := outcomeScore(outcome)
+	return e.rateTeam(teamA, teamB, score), e.rateTeam(teamB, teamA, 1-score)
+}
+
+// WinProbability returns the Glicko-2 expected score of teamA, using both teams' deviations
+func (e *Glicko2Engine) WinProbability(teamA, teamB []TrueSkillRating) float64 {
+	phi := math.Sqrt(e.compositePhi(teamA)*e.compositePhi(teamA) + e.compositePhi(teamB)*e.compositePhi(teamB))
+	return glicko2Expected(e.compositeMu(teamA), e.compositeMu(teamB), phi)
+}
+
+// Explain shows the Glicko-2 quantities for a match
+// team_a_update_expected is the score the update measures team A against, which uses only
+// team B's deviation; the win probability in the same explanation combines both deviations.
+func (e *Glicko2Engine) Explain(teamA, teamB []TrueSkillRating, outcome MatchOutcome) *RatingExplanation {
+	opponentPhi := e.compositePhi(teamB)
+	expected := glicko2Expected(e.compositeMu(teamA), e.compositeMu(teamB), opponentPhi)
+	parameters := map[string]float64{
+		"volatility":             e.volatility,
+		"tau":                    e.tau,
+		"scale":                  Glicko2Scale,
+		"mu_scale":               e.muScale,
+		"team_b_rd":              roundRating(opponentPhi * Glicko2Scale),
+		"team_a_update_expected": roundProbability(expected),
+	}
+	steps := []string{
+		fmt.Sprintf("Ratings and deviations are divided by %.4f; each player's RD is σ × %.0f", Glicko2Scale, e.muScale),
+		fmt.Sprintf("Team B plays as one opponent with its average rating and RD %.1f, so team A's update expects a score of %.3f", opponentPhi*Glicko2Scale, expected),
+		"Each player's estimated variance v and improvement Δ follow from that expectation and the opponent's RD",
+		fmt.Sprintf("Volatility starts at %.3f and is solved for with τ=%.2f, widening RD before the update", e.volatility, e.tau),
+		"The new RD shrinks with the information from the game, and μ moves by RD'² × g × (score - expected)",
+	}
+	return explainMatch(e, parameters, steps, teamA, teamB, outcome)
+}
+
+// rateTeam updates each player on team against the composite opponent
+func (e *Glicko2Engine) rateTeam(team, opponents []TrueSkillRating, score float64) []TrueSkillRating {
+	teamMu := e.compositeMu(team)
+	opponentMu := e.compositeMu(opponents)
+	opponentPhi := e.compositePhi(opponents)
+
+	g := glicko2G(opponentPhi)
+	expected := glicko2Expected(teamMu, opponentMu, opponentPhi)
+	v := 1 / (g * g * expected * (1 - expected))
+	delta := v * g * (score - expected)
+
+	updated := make([]TrueSkillRating, len(team))
+	for i, player := range team {
+		phi := player.Sigma * e.muScale / Glicko2Scale
+		volatility := e.newVolatility(phi, v, delta)
+		phiStar := math.Sqrt(phi*phi + volatility*volatility)
+		newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+
+		sigma := newPhi * Glicko2Scale / e.muScale
+		if e.maxSigma > 0 && sigma > e.maxSigma {
+			sigma = e.maxSigma
+		}
+		updated[i] = TrueSkillRating{
+			Mu:    player.Mu + newPhi*newPhi*g*(score-expected)*Glicko2Scale,
+			Sigma: sigma,
+		}
+	}
+	return updated
+}
+
+// newVolatility solves for the post-game volatility with the Illinois algorithm (Glickman, step 5)
+func (e *Glicko2Engine) newVolatility(phi, v, delta float64) float64 {
+	a := math.Log(e.volatility * e.volatility)
+	tauSquared := e.tau * e.tau
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		denominator := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*denominator*denominator) - (x-a)/tauSquared
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*e.tau) < 0 {
+			k++
+		}
+		B = a - k*e.tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glicko2Convergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
+
+// compositeMu returns a team's average rating on the Glicko-2 scale
+func (e *Glicko2Engine) compositeMu(team []TrueSkillRating) float64 {
+	return averageMu(team) / Glicko2Scale
+}
+
+// compositePhi returns the root-mean-square deviation of a team on the Glicko-2 scale
+func (e *Glicko2Engine) compositePhi(team []TrueSkillRating) float64 {
+	if len(team) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, player := range team {
+		phi := player.Sigma * e.muScale / Glicko2Scale
+		total += phi * phi
+	}
+	return math.Sqrt(total / float64(len(team)))
+}
+
+// glicko2G dampens the expected score by the opponent's deviation
+func glicko2G(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// glicko2Expected is the expected score of mu against an opponent at opponentMu with deviation phi
+func glicko2Expected(mu, opponentMu, phi float64) float64 {
+	return 1 / (1 + math.Exp(-glicko2G(phi)*(mu-opponentMu)))
+}
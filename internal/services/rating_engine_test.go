@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+func TestEloEngineMovesTeamsEquallyAndKeepsSigma(t *testing.T) {
+	engine := NewEloEngine(&trackerSeeder{}, &config.Config{Rating: config.RatingConfig{EloKFactor: 32}})
+	teamA := []TrueSkillRating{{Mu: 1200, Sigma: 80}, {Mu: 1000, Sigma: 120}}
+	teamB := []TrueSkillRating{{Mu: 1100, Sigma: 90}, {Mu: 1100, Sigma: 90}}
+
+	newA, newB := engine.RateMatch(teamA, teamB, MatchOutcomeTeamAWins)
+
+	// Equal averages make the expected score 0.5, so the winners gain K/2
+	for i := range teamA {
+		if math.Abs(newA[i].Mu-teamA[i].Mu-16) > 0.001 {
+			t.Errorf("team A player %d: expected +16, got %+.3f", i, newA[i].Mu-teamA[i].Mu)
+		}
+		if math.Abs(newB[i].Mu-teamB[i].Mu+16) > 0.001 {
+			t.Errorf("team B player %d: expected -16, got %+.3f", i, newB[i].Mu-teamB[i].Mu)
+		}
+		if newA[i].Sigma != teamA[i].Sigma || newB[i].Sigma != teamB[i].Sigma {
+			t.Errorf("player %d: Elo should not change sigma", i)
+		}
+	}
+
+	favourite := []TrueSkillRating{{Mu: 1500, Sigma: 80}}
+	underdog := []TrueSkillRating{{Mu: 1100, Sigma: 80}}
+	if p := engine.WinProbability(favourite, underdog); math.Abs(p-10.0/11) > 0.0001 {
+		t.Errorf("a %.0f point gap should give 10:1 odds, got %.4f", EloScale, p)
+	}
+}
+
+func TestGlicko2EngineUpdatesTowardResultAndShrinksDeviation(t *testing.T) {
+	engine := NewGlicko2Engine(&trackerSeeder{}, &config.Config{
+		TrueSkill: config.TrueSkillConfig{MuScale: 1, SigmaMax: 350},
+	})
+	teamA := []TrueSkillRating{{Mu: 1500, Sigma: 200}, {Mu: 1500, Sigma: 50}}
+	teamB := []TrueSkillRating{{Mu: 1400, Sigma: 30}, {Mu: 1400, Sigma: 30}}
+
+	newA, newB := engine.RateMatch(teamA, teamB, MatchOutcomeTeamBWins)
+
+	for i := range teamA {
+		if newA[i].Mu >= teamA[i].Mu {
+			t.Errorf("team A player %d should lose mu: %.3f -> %.3f", i, teamA[i].Mu, newA[i].Mu)
+		}
+		if newB[i].Mu <= teamB[i].Mu {
+			t.Errorf("team B player %d should gain mu: %.3f -> %.3f", i, teamB[i].Mu, newB[i].Mu)
+		}
+	}
+	if newA[0].Sigma >= teamA[0].Sigma {
+		t.Errorf("an uncertain player's RD should shrink after a game: %.3f -> %.3f", teamA[0].Sigma, newA[0].Sigma)
+	}
+	if loss0, loss1 := teamA[0].Mu-newA[0].Mu, teamA[1].Mu-newA[1].Mu; loss0 <= loss1 {
+		t.Errorf("the higher-RD player should move further: %.3f vs %.3f", loss0, loss1)
+	}
+
+	pA := engine.WinProbability(teamA, teamB)
+	pB := engine.WinProbability(teamB, teamA)
+	if pA <= 0.5 || math.Abs(pA+pB-1) > 0.0001 {
+		t.Errorf("expected the higher-rated team favoured and probabilities summing to 1, got %.4f and %.4f", pA, pB)
+	}
+
+	explanation := engine.Explain(teamA, teamB, MatchOutcomeTeamBWins)
+	if explanation.Engine != models.RatingEngineGlicko2 || explanation.Outcome != "team_b_wins" || len(explanation.TeamA) != 2 {
+		t.Errorf("unexpected explanation: %+v", explanation)
+	}
+}
+
+func TestRatingEngineSelectorUsesGuildSetting(t *testing.T) {
+	guilds := &fakeGuildConfigStore{}
+	selector := &RatingEngineSelector{engines: newRatingEngines(&trackerSeeder{}, &config.Config{}), guildRepo: guilds}
+
+	if engine := selector.ForGuild(1); engine.Name() != models.RatingEngineTrueSkill {
+		t.Errorf("a guild without a setting should use trueskill, got %s", engine.Name())
+	}
+
+	if err := selector.SetGuildEngine(1, models.RatingEngineElo); err != nil {
+		t.Fatalf("SetGuildEngine failed: %v", err)
+	}
+	if engine := selector.ForGuild(1); engine.Name() != models.RatingEngineElo {
+		t.Errorf("expected elo after the setting changed, got %s", engine.Name())
+	}
+
+	if err := selector.SetGuildEngine(1, "whr"); !errors.Is(err, ErrInvalidRatingEngine) {
+		t.Errorf("expected ErrInvalidRatingEngine for an unknown engine, got %v", err)
+	}
+	if name, _ := selector.GetGuildEngine(1); name != models.RatingEngineElo {
+		t.Errorf("a rejected change should keep elo, got %s", name)
+	}
+
+	guilds.config.Rating.Engine = "whr"
+	if engine := selector.ForGuild(1); engine.Name() != models.RatingEngineTrueSkill {
+		t.Errorf("an invalid stored setting should fall back to trueskill, got %s", engine.Name())
+	}
+}
@@ -0,0 +1,476 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidSimulation is returned when simulation overrides cannot be applied
+var ErrInvalidSimulation = errors.New("invalid simulation")
+
+// DefaultSimulationLimit is how many rank moves and mu changes a simulation lists
+const DefaultSimulationLimit = 25
+
+// simulationRankingTier ranks players when the guild has no placement tiers
+const simulationRankingTier = "All players"
+
+// SimulationUserSource interface for listing the players whose ratings are recalculated
+type SimulationUserSource interface {
+	GetAllUsers(activeOnly bool) ([]*models.User, error)
+}
+
+// SimulationRatingSource interface for the stored guild ratings shown next to a simulation
+type SimulationRatingSource interface {
+	GetGuildEffectiveMMRs(guildID int64) ([]*models.PlayerEffectiveMMR, error)
+}
+
+// SimulationTrackerSource interface for listing the trackers seeds are calculated from
+type SimulationTrackerSource interface {
+	GetAllTrackers(validOnly bool) ([]*models.UserTracker, error)
+}
+
+// SimulationOverrides are the config values a simulation replaces; unset fields keep the
+// running config
+type SimulationOverrides struct {
+	OnesWeight         *float64 `json:"ones_weight,omitempty"`
+	TwosWeight         *float64 `json:"twos_weight,omitempty"`
+	ThreesWeight       *float64 `json:"threes_weight,omitempty"`
+	SigmaMin           *float64 `json:"sigma_min,omitempty"`
+	SigmaMax           *float64 `json:"sigma_max,omitempty"`
+	RankSeason         int      `json:"rank_season,omitempty"` // read every tracker against this game season's ranks
+	TrackerAggregation string   `json:"tracker_aggregation,omitempty"`
+	SeasonPooling      string   `json:"season_pooling,omitempty"`
+	SeasonHalfLifeDays *float64 `json:"season_half_life_days,omitempty"`
+}
+
+// SimulationSettings are the seeding values a recalculation runs with
+type SimulationSettings struct {
+	OnesWeight         float64 `json:"ones_weight"`
+	TwosWeight         float64 `json:"twos_weight"`
+	ThreesWeight       float64 `json:"threes_weight"`
+	SigmaMin           float64 `json:"sigma_min"`
+	SigmaMax           float64 `json:"sigma_max"`
+	RankSeason         int     `json:"rank_season"` // 0 picks the season by tracker date
+	TrackerAggregation string  `json:"tracker_aggregation"`
+	SeasonPooling      string  `json:"season_pooling"`
+	SeasonHalfLifeDays float64 `json:"season_half_life_days"`
+}
+
+// SimulatedPlayer compares a player's seed under the running config with the simulated one
+// Ranks and tiers follow the guild's placement skill estimate; RankChange is positive
+// when the player moves up. StoredMu and StoredSigma are the guild rating, which includes
+// every match result, and are context only: they take no part in the diff.
+type SimulatedPlayer struct {
+	DiscordID      string  `json:"discord_id"`
+	Name           string  `json:"name"`
+	StoredMu       float64 `json:"stored_mu"`
+	StoredSigma    float64 `json:"stored_sigma"`
+	CurrentMu      float64 `json:"current_mu"`
+	CurrentSigma   float64 `json:"current_sigma"`
+	SimulatedMu    float64 `json:"simulated_mu"`
+	SimulatedSigma float64 `json:"simulated_sigma"`
+	MuChange       float64 `json:"mu_change"`
+	CurrentRank    int     `json:"current_rank"`
+	SimulatedRank  int     `json:"simulated_rank"`
+	RankChange     int     `json:"rank_change"`
+	Direction      string  `json:"direction,omitempty"` // up or down when the rank changes
+	CurrentTier    string  `json:"current_tier,omitempty"`
+	SimulatedTier  string  `json:"simulated_tier,omitempty"`
+}
+
+// SimulationResult is the difference a recalculation with overridden config would make
+type SimulationResult struct {
+	GuildID          int64                  `json:"guild_id"`
+	Current          SimulationSettings     `json:"current"`
+	Simulated        SimulationSettings     `json:"simulated"`
+	Players          int                    `json:"players"`
+	Recalculated     int                    `json:"recalculated"` // players seeded from trackers
+	Failed           int                    `json:"failed"`       // players whose trackers could not be seeded
+	RankMoves        []SimulatedPlayer      `json:"rank_moves"`
+	LargestMuChanges []SimulatedPlayer      `json:"largest_mu_changes"`
+	TiersConfigured  bool                   `json:"tiers_configured"`
+	Tiers            []PlacementTierSummary `json:"tiers"`
+	TierMoves        []PlacementMove        `json:"tier_moves"`
+	Unchanged        int                    `json:"unchanged"`
+}
+
+// RatingSimulator recalculates every rating in memory with overridden config.
+// Service Responsibilities:
+// - Applying weight, sigma bound, rank table and aggregation overrides to a copy of the config
+// - Seeding every player from their trackers twice, with the running and the overridden config
+// - Comparing the two seeds: rank moves, mu changes and tier moves, with stored ratings as context
+type RatingSimulator struct {
+	userRepo                  SimulationUserSource
+	ratingRepo                SimulationRatingSource
+	trackerRepo               SimulationTrackerSource
+	guildRepo                 GuildConfigStore
+	converter                 *PercentileConverter
+	dataTransformationService *DataTransformationService
+	config                    *config.Config
+}
+
+func NewRatingSimulator(
+	userRepo *repositories.UserRepository,
+	ratingRepo *repositories.PlayerMMRRepository,
+	trackerRepo *repositories.TrackerRepository,
+	guildRepo *repositories.GuildRepository,
+	converter *PercentileConverter,
+	dataTransformationService *DataTransformationService,
+	config *config.Config,
+) *RatingSimulator {
+	return &RatingSimulator{
+		userRepo:                  userRepo,
+		ratingRepo:                ratingRepo,
+		trackerRepo:               trackerRepo,
+		guildRepo:                 guildRepo,
+		converter:                 converter,
+		dataTransformationService: dataTransformationService,
+		config:                    config,
+	}
+}
+
+// CurrentSettings returns the seeding values of the running config
+func (s *RatingSimulator) CurrentSettings() SimulationSettings {
+	return simulationSettings(s.config, 0)
+}
+
+// RankSeasons returns the game seasons a simulation can read trackers against, oldest first
+func (s *RatingSimulator) RankSeasons() []int {
+	distributions := s.converter.Distributions()
+	seasons := make([]int, 0, len(distributions))
+	for _, distribution := range distributions {
+		seasons = append(seasons, distribution.GameSeason)
+	}
+	return seasons
+}
+
+// Run seeds every active player with the running config and with the overrides and compares
+// the two, so the diff shows only what the overrides change and not match history. Players
+// without trackers, or whose trackers cannot be seeded, keep their stored guild rating (or the
+// config defaults) on both sides. Nothing is saved; limit caps the rank move and mu change lists.
+func (s *RatingSimulator) Run(guildID int64, overrides SimulationOverrides, limit int) (*SimulationResult, error) {
+	if limit <= 0 {
+		limit = DefaultSimulationLimit
+	}
+
+	simulated, err := applySimulationOverrides(s.config, s.converter, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return nil, err
+	}
+	placement := guildConfig.Placement
+	if err := placement.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPlacement, err)
+	}
+	ranking := placement
+	if !placement.IsConfigured() {
+		ranking.Tiers = []models.TierConfig{{Name: simulationRankingTier}}
+	}
+
+	users, err := s.userRepo.GetAllUsers(true)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := s.ratingRepo.GetGuildEffectiveMMRs(guildID)
+	if err != nil {
+		return nil, err
+	}
+	ratingsByUser := make(map[int64]*models.PlayerEffectiveMMR, len(ratings))
+	for _, rating := range ratings {
+		ratingsByUser[rating.UserID] = rating
+	}
+	trackers, err := s.trackerRepo.GetAllTrackers(true)
+	if err != nil {
+		return nil, err
+	}
+	trackersByPlayer := make(map[string][]*models.UserTracker)
+	for _, tracker := range trackers {
+		trackersByPlayer[tracker.DiscordID] = append(trackersByPlayer[tracker.DiscordID], tracker)
+	}
+
+	runningSeeder := &trackerSeeder{
+		mmrCalculator:         NewMMRCalculator(s.config, s.converter),
+		uncertaintyCalculator: NewEnhancedUncertaintyCalculator(s.config, s.converter),
+	}
+	simulatedSeeder := &trackerSeeder{
+		mmrCalculator:         NewMMRCalculator(simulated, s.converter),
+		uncertaintyCalculator: NewEnhancedUncertaintyCalculator(simulated, s.converter),
+		rankSeason:            overrides.RankSeason,
+	}
+
+	result := &SimulationResult{
+		GuildID:         guildID,
+		Current:         s.CurrentSettings(),
+		Simulated:       simulationSettings(simulated, overrides.RankSeason),
+		TiersConfigured: placement.IsConfigured(),
+	}
+
+	storedRatings := make(map[string]TrueSkillRating)
+	var current, recalculated []placementCandidate
+	for _, user := range users {
+		if !user.IsValidForPlay() {
+			continue
+		}
+		result.Players++
+
+		var stored TrueSkillRating
+		if rating, ok := ratingsByUser[int64(user.ID)]; ok {
+			stored = TrueSkillRating{Mu: rating.TrueSkillMu, Sigma: rating.TrueSkillSigma}
+		} else {
+			stored.Mu, stored.Sigma = s.config.GetTrueSkillDefaults()
+		}
+		storedRatings[user.DiscordID] = stored
+		running, seeded := stored, stored
+
+		if playerTrackers := trackersByPlayer[user.DiscordID]; len(playerTrackers) > 0 {
+			runningSeed, runningErr := seedFromTrackers(s.dataTransformationService, runningSeeder, playerTrackers, s.config.MMR.TrackerAggregation)
+			simulatedSeed, simulatedErr := seedFromTrackers(s.dataTransformationService, simulatedSeeder, playerTrackers, simulated.MMR.TrackerAggregation)
+			if err := errors.Join(runningErr, simulatedErr); err != nil {
+				log.Printf("RatingSimulator: Keeping stored rating for %s: %v", user.DiscordID, err)
+				result.Failed++
+			} else {
+				running, seeded = runningSeed, simulatedSeed
+				result.Recalculated++
+			}
+		}
+
+		current = append(current, simulationCandidate(user, running, placement.SigmaMultiplier))
+		recalculated = append(recalculated, simulationCandidate(user, seeded, placement.SigmaMultiplier))
+	}
+
+	before := placeInTiers(guildID, 0, ranking, current)
+	after := placeInTiers(guildID, 0, ranking, recalculated)
+	players := compareSimulatedPlayers(before, after, placement.IsConfigured())
+	for i := range players {
+		stored := storedRatings[players[i].DiscordID]
+		players[i].StoredMu, players[i].StoredSigma = stored.Mu, stored.Sigma
+	}
+	result.RankMoves = largestRankMoves(players, limit)
+	result.LargestMuChanges = largestMuChanges(players, limit)
+
+	if placement.IsConfigured() {
+		result.Tiers = summarizeTiers(placement, after)
+		result.TierMoves, result.Unchanged = diffPlacements(before, after)
+	} else {
+		result.Tiers = []PlacementTierSummary{}
+		result.TierMoves = []PlacementMove{}
+	}
+
+	log.Printf("RatingSimulator: Simulated %d players in guild %d (%d recalculated, %d rank moves, %d tier moves)",
+		result.Players, guildID, result.Recalculated, len(result.RankMoves), len(result.TierMoves))
+
+	return result, nil
+}
+
+// applySimulationOverrides returns a copy of base with the overrides applied
+// A rank season override must be one the converter has a distribution for.
+func applySimulationOverrides(base *config.Config, converter *PercentileConverter, overrides SimulationOverrides) (*config.Config, error) {
+	simulated := *base
+	if overrides.OnesWeight != nil {
+		simulated.MMR.OnesWeight = *overrides.OnesWeight
+	}
+	if overrides.TwosWeight != nil {
+		simulated.MMR.TwosWeight = *overrides.TwosWeight
+	}
+	if overrides.ThreesWeight != nil {
+		simulated.MMR.ThreesWeight = *overrides.ThreesWeight
+	}
+	if overrides.SigmaMin != nil {
+		simulated.TrueSkill.SigmaMin = *overrides.SigmaMin
+	}
+	if overrides.SigmaMax != nil {
+		simulated.TrueSkill.SigmaMax = *overrides.SigmaMax
+	}
+	if overrides.TrackerAggregation != "" {
+		simulated.MMR.TrackerAggregation = overrides.TrackerAggregation
+	}
+	if overrides.SeasonPooling != "" {
+		simulated.MMR.SeasonPooling = overrides.SeasonPooling
+	}
+	if overrides.SeasonHalfLifeDays != nil {
+		simulated.MMR.SeasonHalfLifeDays = *overrides.SeasonHalfLifeDays
+	}
+
+	mmr := simulated.MMR
+	if mmr.OnesWeight < 0 || mmr.TwosWeight < 0 || mmr.ThreesWeight < 0 {
+		return nil, fmt.Errorf("%w: playlist weights must not be negative", ErrInvalidSimulation)
+	}
+	if mmr.OnesWeight+mmr.TwosWeight+mmr.ThreesWeight <= 0 {
+		return nil, fmt.Errorf("%w: at least one playlist weight must be positive", ErrInvalidSimulation)
+	}
+
+	sigmaMax, sigmaMin := simulated.GetTrueSkillSigmaRange()
+	if sigmaMin <= 0 || sigmaMin > sigmaMax {
+		return nil, fmt.Errorf("%w: sigma bounds must satisfy 0 < min <= max, got %.3f-%.3f", ErrInvalidSimulation, sigmaMin, sigmaMax)
+	}
+
+	switch mmr.TrackerAggregation {
+	case "", TrackerAggregationMax, TrackerAggregationPool, TrackerAggregationPrimary:
+	default:
+		return nil, fmt.Errorf("%w: unknown tracker aggregation %q", ErrInvalidSimulation, mmr.TrackerAggregation)
+	}
+
+	switch mmr.SeasonPooling {
+	case "", SeasonPoolingGames, SeasonPoolingSeason, SeasonPoolingHybrid:
+	default:
+		return nil, fmt.Errorf("%w: unknown season pooling %q", ErrInvalidSimulation, mmr.SeasonPooling)
+	}
+	if mmr.SeasonHalfLifeDays < 0 {
+		return nil, fmt.Errorf("%w: season half-life must not be negative", ErrInvalidSimulation)
+	}
+
+	if overrides.RankSeason != 0 && !hasRankSeason(converter, overrides.RankSeason) {
+		return nil, fmt.Errorf("%w: no rank distribution for game season %d", ErrInvalidSimulation, overrides.RankSeason)
+	}
+
+	return &simulated, nil
+}
+
+// hasRankSeason checks if the converter has a distribution for a game season
+func hasRankSeason(converter *PercentileConverter, season int) bool {
+	for _, distribution := range converter.Distributions() {
+		if distribution.GameSeason == season {
+			return true
+		}
+	}
+	return false
+}
+
+// seedFromTrackers aggregates a player's trackers and seeds a rating from them
+func seedFromTrackers(dataTransformationService *DataTransformationService, seeder *trackerSeeder, trackers []*models.UserTracker, aggregation string) (TrueSkillRating, error) {
+	trackerData, err := dataTransformationService.AggregateTrackers(trackers, aggregation)
+	if err != nil {
+		return TrueSkillRating{}, err
+	}
+	calculation, err := seeder.Seed(trackerData)
+	if err != nil {
+		return TrueSkillRating{}, err
+	}
+	return TrueSkillRating{Mu: calculation.Mu, Sigma: calculation.Sigma}, nil
+}
+
+// simulationSettings reads the seeding values out of a config
+func simulationSettings(cfg *config.Config, rankSeason int) SimulationSettings {
+	sigmaMax, sigmaMin := cfg.GetTrueSkillSigmaRange()
+	aggregation := cfg.MMR.TrackerAggregation
+	if aggregation == "" {
+		aggregation = TrackerAggregationMax
+	}
+	pooling := cfg.MMR.SeasonPooling
+	if pooling == "" {
+		pooling = SeasonPoolingGames
+	}
+	halfLife := cfg.MMR.SeasonHalfLifeDays
+	if halfLife <= 0 {
+		halfLife = DefaultSeasonHalfLifeDays
+	}
+	return SimulationSettings{
+		OnesWeight:         cfg.MMR.OnesWeight,
+		TwosWeight:         cfg.MMR.TwosWeight,
+		ThreesWeight:       cfg.MMR.ThreesWeight,
+		SigmaMin:           sigmaMin,
+		SigmaMax:           sigmaMax,
+		RankSeason:         rankSeason,
+		TrackerAggregation: aggregation,
+		SeasonPooling:      pooling,
+		SeasonHalfLifeDays: halfLife,
+	}
+}
+
+// simulationCandidate wraps a player's rating for ranking
+func simulationCandidate(user *models.User, rating TrueSkillRating, sigmaMultiplier float64) placementCandidate {
+	return placementCandidate{
+		DiscordID: user.DiscordID,
+		Name:      user.Name,
+		Rating:    rating,
+		Skill:     rating.Mu - sigmaMultiplier*rating.Sigma,
+	}
+}
+
+// compareSimulatedPlayers pairs each player's running config and simulated placement, in simulated rank order
+func compareSimulatedPlayers(before, after []models.TierPlacement, withTiers bool) []SimulatedPlayer {
+	current := make(map[string]models.TierPlacement, len(before))
+	for _, placement := range before {
+		current[placement.DiscordID] = placement
+	}
+
+	players := make([]SimulatedPlayer, 0, len(after))
+	for _, simulated := range after {
+		stored := current[simulated.DiscordID]
+		player := SimulatedPlayer{
+			DiscordID:      simulated.DiscordID,
+			Name:           simulated.PlayerName,
+			CurrentMu:      stored.TrueSkillMu,
+			CurrentSigma:   stored.TrueSkillSigma,
+			SimulatedMu:    simulated.TrueSkillMu,
+			SimulatedSigma: simulated.TrueSkillSigma,
+			MuChange:       roundRating(simulated.TrueSkillMu - stored.TrueSkillMu),
+			CurrentRank:    stored.Rank,
+			SimulatedRank:  simulated.Rank,
+			RankChange:     stored.Rank - simulated.Rank,
+		}
+		switch {
+		case player.RankChange > 0:
+			player.Direction = PlacementMoveUp
+		case player.RankChange < 0:
+			player.Direction = PlacementMoveDown
+		}
+		if withTiers {
+			player.CurrentTier, player.SimulatedTier = stored.TierLabel, simulated.TierLabel
+		}
+		players = append(players, player)
+	}
+	return players
+}
+
+// largestRankMoves returns up to limit players whose rank changed, biggest move first
+func largestRankMoves(players []SimulatedPlayer, limit int) []SimulatedPlayer {
+	moved := make([]SimulatedPlayer, 0)
+	for _, player := range players {
+		if player.RankChange != 0 {
+			moved = append(moved, player)
+		}
+	}
+	sort.SliceStable(moved, func(i, j int) bool {
+		return absInt(moved[i].RankChange) > absInt(moved[j].RankChange)
+	})
+	if len(moved) > limit {
+		moved = moved[:limit]
+	}
+	return moved
+}
+
+// largestMuChanges returns up to limit players whose mu changed, biggest change first
+func largestMuChanges(players []SimulatedPlayer, limit int) []SimulatedPlayer {
+	changed := make([]SimulatedPlayer, 0)
+	for _, player := range players {
+		if player.MuChange != 0 {
+			changed = append(changed, player)
+		}
+	}
+	sort.SliceStable(changed, func(i, j int) bool {
+		return math.Abs(changed[i].MuChange) > math.Abs(changed[j].MuChange)
+	})
+	if len(changed) > limit {
+		changed = changed[:limit]
+	}
+	return changed
+}
+
+// absInt returns the absolute value of an int
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
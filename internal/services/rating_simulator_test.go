@@ -0,0 +1,156 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+// newTestRatingSimulator has a strong player, a threes specialist who is weak in 2v2 and an
+// untracked player. Stored ratings deliberately disagree with the trackers, as they would after
+// a season of matches.
+func newTestRatingSimulator() *RatingSimulator {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 3, SigmaMin: 2.5, SigmaMax: 8.333},
+		MMR:       config.MMRConfig{OnesWeight: 1.0, TwosWeight: 1.5, ThreesWeight: 1.2, TrackerAggregation: TrackerAggregationMax},
+	}
+	now := time.Now()
+	ratings := newFakeRatingStore()
+	for userID, mu := range map[int64]float64{1: 500, 2: 1500, 4: 1200} {
+		ratings.effective[userID] = &models.PlayerEffectiveMMR{UserID: userID, GuildID: 1, TrueSkillMu: mu, TrueSkillSigma: 3}
+	}
+
+	return &RatingSimulator{
+		userRepo: &fakeStatsUsers{users: []*models.User{
+			{ID: 1, DiscordID: playerID(1), Name: "Strong", Active: true},
+			{ID: 2, DiscordID: playerID(2), Name: "Threes Specialist", Active: true},
+			{ID: 3, DiscordID: playerID(3), Name: "Untracked", Active: true},
+			{ID: 4, DiscordID: playerID(4), Name: "Banned", Active: true, Banned: true},
+		}},
+		ratingRepo: ratings,
+		trackerRepo: &fakeTrackerSource{trackers: []*models.UserTracker{
+			{DiscordID: playerID(1), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/strong/overview", TwosCurrentSeasonPeak: 1450, TwosCurrentSeasonGames: 400, ThreesCurrentSeasonPeak: 1400, ThreesCurrentSeasonGames: 300, LastUpdated: now},
+			{DiscordID: playerID(2), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/specialist/overview", TwosCurrentSeasonPeak: 600, TwosCurrentSeasonGames: 400, ThreesCurrentSeasonPeak: 750, ThreesCurrentSeasonGames: 300, LastUpdated: now},
+		}},
+		guildRepo: &fakeGuildConfigStore{config: models.GuildConfig{Placement: models.PlacementConfig{
+			Method:          models.PlacementMethodSkillCutoffs,
+			SigmaMultiplier: 3,
+			Tiers:           []models.TierConfig{{Name: "Premier", MinSkill: 950}, {Name: "Open"}},
+		}}},
+		converter:                 NewPercentileConverter(cfg),
+		dataTransformationService: NewDataTransformationService(),
+		config:                    cfg,
+	}
+}
+
+func TestRatingSimulatorWithoutOverridesChangesNothing(t *testing.T) {
+	simulator := newTestRatingSimulator()
+
+	result, err := simulator.Run(1, SimulationOverrides{}, 0)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Stored ratings include match history, so they must not show up as moves
+	if len(result.RankMoves) != 0 || len(result.LargestMuChanges) != 0 || len(result.TierMoves) != 0 {
+		t.Errorf("expected no moves without overrides, got %+v, %+v and %+v", result.RankMoves, result.LargestMuChanges, result.TierMoves)
+	}
+	if result.Recalculated != 2 || result.Unchanged != 3 {
+		t.Errorf("expected 2 players recalculated and 3 unchanged, got %d and %d", result.Recalculated, result.Unchanged)
+	}
+}
+
+func TestRatingSimulatorReseedsWithOverridesAndDiffsRanksAndTiers(t *testing.T) {
+	simulator := newTestRatingSimulator()
+	twos := 0.0
+
+	result, err := simulator.Run(1, SimulationOverrides{TwosWeight: &twos}, 0)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if simulator.config.MMR.TwosWeight != 1.5 {
+		t.Errorf("the running config must not change, twos weight is now %.2f", simulator.config.MMR.TwosWeight)
+	}
+	if result.Simulated.TwosWeight != 0 || result.Current.TwosWeight != 1.5 {
+		t.Errorf("expected settings to show 1.5 -> 0, got %+v -> %+v", result.Current, result.Simulated)
+	}
+	if result.Players != 3 || result.Recalculated != 2 || result.Failed != 0 {
+		t.Errorf("expected 3 players with 2 recalculated, got %d/%d/%d", result.Players, result.Recalculated, result.Failed)
+	}
+
+	// Both sides of the diff must match a real seed under their config
+	overridden := *simulator.config
+	overridden.MMR.TwosWeight = 0
+	runningEngine := NewTrueSkillEngine(NewMMRCalculator(simulator.config, simulator.converter), NewEnhancedUncertaintyCalculator(simulator.config, simulator.converter), simulator.config)
+	simulatedEngine := NewTrueSkillEngine(NewMMRCalculator(&overridden, simulator.converter), NewEnhancedUncertaintyCalculator(&overridden, simulator.converter), &overridden)
+	byID := make(map[string]SimulatedPlayer)
+	for _, player := range result.LargestMuChanges {
+		byID[player.DiscordID] = player
+	}
+	trackers, _ := simulator.trackerRepo.GetAllTrackers(true)
+	for _, id := range []string{playerID(1), playerID(2)} {
+		var own []*models.UserTracker
+		for _, tracker := range trackers {
+			if tracker.DiscordID == id {
+				own = append(own, tracker)
+			}
+		}
+		data, _ := simulator.dataTransformationService.AggregateTrackers(own, TrackerAggregationMax)
+		running, err := runningEngine.Seed(data)
+		if err != nil {
+			t.Fatalf("Seed failed: %v", err)
+		}
+		simulated, err := simulatedEngine.Seed(data)
+		if err != nil {
+			t.Fatalf("Seed failed: %v", err)
+		}
+		if got := byID[id].CurrentMu; math.Abs(got-roundRating(running.Mu)) > 0.001 {
+			t.Errorf("player %s: expected running config mu %.3f, got %.3f", id, roundRating(running.Mu), got)
+		}
+		if got := byID[id].SimulatedMu; math.Abs(got-roundRating(simulated.Mu)) > 0.001 {
+			t.Errorf("player %s: expected simulated mu %.3f, got %.3f", id, roundRating(simulated.Mu), got)
+		}
+	}
+	if _, ok := byID[playerID(3)]; ok {
+		t.Errorf("a player without trackers keeps the same rating on both sides and should not be listed as changed")
+	}
+
+	specialist := byID[playerID(2)]
+	if specialist.StoredMu != 1500 || specialist.StoredSigma != 3 {
+		t.Errorf("expected the stored guild rating as context, got %.1f/%.3f", specialist.StoredMu, specialist.StoredSigma)
+	}
+	if len(result.RankMoves) != 2 || result.RankMoves[0].DiscordID != playerID(2) || result.RankMoves[0].RankChange != 1 || result.RankMoves[1].RankChange != -1 {
+		t.Errorf("expected the threes specialist to pass the untracked player, got %+v", result.RankMoves)
+	}
+	if specialist.Direction != PlacementMoveUp || specialist.CurrentTier != models.FormatTierLabel(2, "Open") || specialist.SimulatedTier != models.FormatTierLabel(1, "Premier") {
+		t.Errorf("expected the threes specialist to move up into Premier, got %+v", specialist)
+	}
+
+	if !result.TiersConfigured || len(result.TierMoves) != 1 || result.Unchanged != 2 {
+		t.Errorf("expected one tier move and two unchanged players, got %+v (%d unchanged)", result.TierMoves, result.Unchanged)
+	}
+	if len(result.Tiers) != 2 || result.Tiers[0].Players != 3 || result.Tiers[1].Players != 0 {
+		t.Errorf("expected every player in Premier, got %+v", result.Tiers)
+	}
+}
+
+func TestRatingSimulatorRejectsInvalidOverrides(t *testing.T) {
+	simulator := newTestRatingSimulator()
+	negative, high, low := -1.0, 9.0, 1.0
+
+	for name, overrides := range map[string]SimulationOverrides{
+		"negative weight":    {OnesWeight: &negative},
+		"sigma min over max": {SigmaMin: &high, SigmaMax: &low},
+		"unknown season":     {RankSeason: 99},
+		"unknown strategy":   {TrackerAggregation: "average"},
+		"unknown pooling":    {SeasonPooling: "median"},
+	} {
+		if _, err := simulator.Run(1, overrides, 0); !errors.Is(err, ErrInvalidSimulation) {
+			t.Errorf("%s: expected ErrInvalidSimulation, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+var (
+	ErrRecalculationNotFound = errors.New("recalculation not found")
+	ErrInvalidRecalculation  = errors.New("invalid recalculation")
+)
+
+// minRecalculationChange is the smallest mu or sigma movement a recalculation stores as a change
+const minRecalculationChange = 0.001
+
+// RecalculationJobStore interface for persisting recalculations and their changesets
+type RecalculationJobStore interface {
+	CreateJob(job *models.RecalculationJob) (*models.RecalculationJob, error)
+	FindJob(jobID int64) (*models.RecalculationJob, error)
+	GetJobs(guildID int64, limit int) ([]*models.RecalculationJob, error)
+	UpdateJob(job *models.RecalculationJob) error
+}
+
+// RecalculationRatingStore interface for reading and writing a guild's ratings with history
+// linked to the recalculation that wrote them
+// RecordRecalculationChange writes the rating, or removes it when nil, together with the history row
+type RecalculationRatingStore interface {
+	GuildRatingStore
+	FindEffectiveMMR(userID, guildID int64) (*models.PlayerEffectiveMMR, error)
+	RecordRecalculationChange(effective *models.PlayerEffectiveMMR, history models.PlayerHistoricalMMRCreateRequest) error
+	GetHistoryByRecalculationJob(jobID int64) ([]*models.PlayerHistoricalMMR, error)
+}
+
+// RecalculationUserSource interface for the players a recalculation covers
+type RecalculationUserSource interface {
+	GetAllUsers(activeOnly bool) ([]*models.User, error)
+}
+
+// RecalculationTrackerSource interface for the tracker data ratings are seeded from
+type RecalculationTrackerSource interface {
+	GetAllTrackers(validOnly bool) ([]*models.UserTracker, error)
+}
+
+// RecalculationService recalculates a guild's ratings from tracker data as reviewable jobs.
+// Service Responsibilities:
+// - Seeding every active player with the guild's engine and storing the differences as a pending changeset
+// - Applying a reviewed changeset, writing one recalculation history row per player linked by job ID
+// - Reverting an applied job from its history rows
+// - Leaving alone any rating that moved between the preview and the apply, or the apply and the revert
+type RecalculationService struct {
+	jobRepo                   RecalculationJobStore
+	ratingRepo                RecalculationRatingStore
+	userRepo                  RecalculationUserSource
+	trackerRepo               RecalculationTrackerSource
+	engines                   RatingEngineSource
+	dataTransformationService *DataTransformationService
+	config                    *config.Config
+}
+
+func NewRecalculationService(
+	jobRepo *repositories.RecalculationJobRepository,
+	ratingRepo *repositories.PlayerMMRRepository,
+	userRepo *repositories.UserRepository,
+	trackerRepo *repositories.TrackerRepository,
+	engines RatingEngineSource,
+	dataTransformationService *DataTransformationService,
+	config *config.Config,
+) *RecalculationService {
+	return &RecalculationService{
+		jobRepo:                   jobRepo,
+		ratingRepo:                ratingRepo,
+		userRepo:                  userRepo,
+		trackerRepo:               trackerRepo,
+		engines:                   engines,
+		dataTransformationService: dataTransformationService,
+		config:                    config,
+	}
+}
+
+// CreateJob recalculates every active player's rating in a guild and stores the changes as a pending job
+// Nothing is written to player ratings until the job is applied.
+func (s *RecalculationService) CreateJob(guildID int64, createdBy *int64) (*models.RecalculationJob, error) {
+	users, err := s.userRepo.GetAllUsers(true)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := s.ratingRepo.GetGuildEffectiveMMRs(guildID)
+	if err != nil {
+		return nil, err
+	}
+	ratingsByUser := make(map[int64]*models.PlayerEffectiveMMR, len(ratings))
+	for _, rating := range ratings {
+		ratingsByUser[rating.UserID] = rating
+	}
+	trackers, err := s.trackerRepo.GetAllTrackers(true)
+	if err != nil {
+		return nil, err
+	}
+	trackersByPlayer := make(map[string][]*models.UserTracker)
+	for _, tracker := range trackers {
+		trackersByPlayer[tracker.DiscordID] = append(trackersByPlayer[tracker.DiscordID], tracker)
+	}
+
+	engine := s.engines.ForGuild(guildID)
+	job := &models.RecalculationJob{
+		GuildID:         guildID,
+		Status:          models.RecalculationStatusPending,
+		Changes:         []models.RecalculationChange{},
+		Errors:          []string{},
+		CreatedByUserID: createdBy,
+	}
+
+	for _, user := range users {
+		if !user.IsValidForPlay() {
+			continue
+		}
+		job.Players++
+
+		playerTrackers := trackersByPlayer[user.DiscordID]
+		if len(playerTrackers) == 0 {
+			job.Unchanged++
+			continue
+		}
+
+		trackerData, err := s.dataTransformationService.AggregateTrackers(playerTrackers, s.config.MMR.TrackerAggregation)
+		if err != nil {
+			job.Errors = append(job.Errors, fmt.Sprintf("%s: %v", user.DiscordID, err))
+			continue
+		}
+		seed, err := engine.Seed(trackerData)
+		if err != nil {
+			job.Errors = append(job.Errors, fmt.Sprintf("%s: %v", user.DiscordID, err))
+			continue
+		}
+
+		change := models.RecalculationChange{
+			UserID:     int64(user.ID),
+			DiscordID:  user.DiscordID,
+			Name:       user.Name,
+			MuAfter:    roundRating(seed.Mu),
+			SigmaAfter: roundRating(seed.Sigma),
+		}
+		if current, ok := ratingsByUser[int64(user.ID)]; ok {
+			if ratingMatches(current, change.MuAfter, change.SigmaAfter) {
+				job.Unchanged++
+				continue
+			}
+			muBefore, sigmaBefore := current.TrueSkillMu, current.TrueSkillSigma
+			change.MuBefore, change.SigmaBefore = &muBefore, &sigmaBefore
+		}
+		job.Changes = append(job.Changes, change)
+	}
+
+	initialMu, _ := s.config.GetTrueSkillDefaults()
+	sort.SliceStable(job.Changes, func(i, j int) bool {
+		return math.Abs(job.Changes[i].MuChange(initialMu)) > math.Abs(job.Changes[j].MuChange(initialMu))
+	})
+
+	created, err := s.jobRepo.CreateJob(job)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("RecalculationService: Created recalculation %d for guild %d (%d players, %d changes, %d errors)",
+		created.ID, guildID, created.Players, len(created.Changes), len(created.Errors))
+
+	return created, nil
+}
+
+// GetJob returns a recalculation with its changeset
+func (s *RecalculationService) GetJob(jobID int64) (*models.RecalculationJob, error) {
+	job, err := s.jobRepo.FindJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("%w: %d", ErrRecalculationNotFound, jobID)
+	}
+	return job, nil
+}
+
+// GetJobs returns a guild's recent recalculations, newest first
+func (s *RecalculationService) GetJobs(guildID int64, limit int) ([]*models.RecalculationJob, error) {
+	return s.jobRepo.GetJobs(guildID, limit)
+}
+
+// ApplyJob writes a pending job's changes to player ratings
+// A player whose rating moved since the job was created is skipped so the newer rating is not lost.
+func (s *RecalculationService) ApplyJob(jobID int64, appliedBy *int64) (*models.RecalculationJob, error) {
+	job, err := s.jobInStatus(jobID, models.RecalculationStatusPending, "apply")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range job.Changes {
+		change := &job.Changes[i]
+
+		current, err := s.ratingRepo.FindEffectiveMMR(change.UserID, job.GuildID)
+		if err != nil {
+			return nil, s.saveProgress(job, fmt.Errorf("failed to load rating for user %d: %w", change.UserID, err))
+		}
+		if !ratingUnchangedSince(current, change.MuBefore, change.SigmaBefore) {
+			log.Printf("RecalculationService: Skipping user %d in recalculation %d, rating changed since the preview", change.UserID, job.ID)
+			continue
+		}
+
+		if err := s.writeRating(job, change.UserID, current, &change.MuAfter, &change.SigmaAfter, models.ChangeReasonRecalculation, appliedBy); err != nil {
+			return nil, s.saveProgress(job, err)
+		}
+		change.Applied = true
+	}
+
+	now := time.Now()
+	job.Status = models.RecalculationStatusApplied
+	job.AppliedByUserID = appliedBy
+	job.AppliedAt = &now
+	if err := s.jobRepo.UpdateJob(job); err != nil {
+		return nil, err
+	}
+
+	log.Printf("RecalculationService: Applied recalculation %d (%d of %d changes)", job.ID, job.AppliedCount(), len(job.Changes))
+	return job, nil
+}
+
+// RevertJob restores the ratings an applied job replaced, reading them from its history rows
+// A player whose rating moved since the job was applied is skipped so the newer rating is not lost.
+func (s *RecalculationService) RevertJob(jobID int64, revertedBy *int64) (*models.RecalculationJob, error) {
+	job, err := s.jobInStatus(jobID, models.RecalculationStatusApplied, "revert")
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.ratingRepo.GetHistoryByRecalculationJob(job.ID)
+	if err != nil {
+		return nil, err
+	}
+	changeIndex := make(map[int64]int, len(job.Changes))
+	for i, change := range job.Changes {
+		changeIndex[change.UserID] = i
+	}
+
+	for _, entry := range history {
+		if entry.ChangeReason != models.ChangeReasonRecalculation {
+			continue
+		}
+
+		current, err := s.ratingRepo.FindEffectiveMMR(entry.UserID, job.GuildID)
+		if err != nil {
+			return nil, s.saveProgress(job, fmt.Errorf("failed to load rating for user %d: %w", entry.UserID, err))
+		}
+		muAfter, sigmaAfter := entry.TrueSkillMuAfter, entry.TrueSkillSigmaAfter
+		if !ratingUnchangedSince(current, &muAfter, &sigmaAfter) {
+			log.Printf("RecalculationService: Skipping user %d in recalculation %d, rating changed since the apply", entry.UserID, job.ID)
+			continue
+		}
+
+		if err := s.writeRating(job, entry.UserID, current, entry.TrueSkillMuBefore, entry.TrueSkillSigmaBefore, models.ChangeReasonRecalculationRevert, revertedBy); err != nil {
+			return nil, s.saveProgress(job, err)
+		}
+		if i, ok := changeIndex[entry.UserID]; ok {
+			job.Changes[i].Reverted = true
+		}
+	}
+
+	now := time.Now()
+	job.Status = models.RecalculationStatusReverted
+	job.RevertedByUserID = revertedBy
+	job.RevertedAt = &now
+	if err := s.jobRepo.UpdateJob(job); err != nil {
+		return nil, err
+	}
+
+	log.Printf("RecalculationService: Reverted recalculation %d (%d of %d changes)", job.ID, job.RevertedCount(), job.AppliedCount())
+	return job, nil
+}
+
+// DiscardJob drops a pending job without touching any rating
+func (s *RecalculationService) DiscardJob(jobID int64) (*models.RecalculationJob, error) {
+	job, err := s.jobInStatus(jobID, models.RecalculationStatusPending, "discard")
+	if err != nil {
+		return nil, err
+	}
+
+	job.Status = models.RecalculationStatusDiscarded
+	if err := s.jobRepo.UpdateJob(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// jobInStatus loads a job and checks it can take the requested action
+func (s *RecalculationService) jobInStatus(jobID int64, status, action string) (*models.RecalculationJob, error) {
+	job, err := s.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != status {
+		return nil, fmt.Errorf("%w: cannot %s recalculation %d, it is %s", ErrInvalidRecalculation, action, job.ID, job.Status)
+	}
+	return job, nil
+}
+
+// saveProgress stores which changes were written before a failure so a partial apply or revert
+// is still visible on the job, then returns the original error
+func (s *RecalculationService) saveProgress(job *models.RecalculationJob, cause error) error {
+	if err := s.jobRepo.UpdateJob(job); err != nil {
+		log.Printf("RecalculationService: Failed to save progress of recalculation %d: %v", job.ID, err)
+	}
+	return cause
+}
+
+// writeRating sets a player's rating and records the change against the job in one transaction,
+// so every rating a job moved has the history row it is reverted from
+// A nil mu removes the rating, returning the player to the defaults they had before the job.
+func (s *RecalculationService) writeRating(job *models.RecalculationJob, userID int64, current *models.PlayerEffectiveMMR, mu, sigma *float64, reason string, changedBy *int64) error {
+	defaultMu, defaultSigma := s.config.GetTrueSkillDefaults()
+
+	request := models.PlayerHistoricalMMRCreateRequest{
+		UserID:              userID,
+		GuildID:             job.GuildID,
+		TrueSkillMuAfter:    defaultMu,
+		TrueSkillSigmaAfter: defaultSigma,
+		ChangeReason:        reason,
+		RecalculationJobID:  &job.ID,
+		ChangedByUserID:     changedBy,
+	}
+	if current != nil {
+		mmrBefore := current.MMR
+		muBefore, sigmaBefore := current.TrueSkillMu, current.TrueSkillSigma
+		request.MMRBefore = &mmrBefore
+		request.MMRAfter = current.MMR
+		request.TrueSkillMuBefore = &muBefore
+		request.TrueSkillSigmaBefore = &sigmaBefore
+	}
+
+	if mu == nil || sigma == nil {
+		return s.ratingRepo.RecordRecalculationChange(nil, request)
+	}
+
+	updated := models.PlayerEffectiveMMR{UserID: userID, GuildID: job.GuildID}
+	if current != nil {
+		updated = *current
+	}
+	updated.TrueSkillMu = *mu
+	updated.TrueSkillSigma = *sigma
+
+	request.TrueSkillMuAfter = *mu
+	request.TrueSkillSigmaAfter = *sigma
+	return s.ratingRepo.RecordRecalculationChange(&updated, request)
+}
+
+// ratingUnchangedSince checks a player's current rating still equals the one a job recorded
+// Nil mu and sigma mean the player had no rating, so there must still be none.
+func ratingUnchangedSince(current *models.PlayerEffectiveMMR, mu, sigma *float64) bool {
+	if mu == nil || sigma == nil {
+		return current == nil
+	}
+	return current != nil && ratingMatches(current, *mu, *sigma)
+}
+
+// ratingMatches checks a stored rating is within rounding of mu and sigma
+func ratingMatches(current *models.PlayerEffectiveMMR, mu, sigma float64) bool {
+	return math.Abs(current.TrueSkillMu-mu) < minRecalculationChange && math.Abs(current.TrueSkillSigma-sigma) < minRecalculationChange
+}
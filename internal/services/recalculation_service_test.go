@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakeRecalculationJobs struct {
+	jobs map[int64]*models.RecalculationJob
+}
+
+func (f *fakeRecalculationJobs) CreateJob(job *models.RecalculationJob) (*models.RecalculationJob, error) {
+	created := copyRecalculationJob(job)
+	created.ID = int64(len(f.jobs) + 1)
+	f.jobs[created.ID] = created
+	return copyRecalculationJob(created), nil
+}
+
+func (f *fakeRecalculationJobs) FindJob(jobID int64) (*models.RecalculationJob, error) {
+	if job, ok := f.jobs[jobID]; ok {
+		return copyRecalculationJob(job), nil
+	}
+	return nil, nil
+}
+
+func (f *fakeRecalculationJobs) GetJobs(guildID int64, limit int) ([]*models.RecalculationJob, error) {
+	jobs := make([]*models.RecalculationJob, 0, len(f.jobs))
+	for _, job := range f.jobs {
+		jobs = append(jobs, copyRecalculationJob(job))
+	}
+	return jobs, nil
+}
+
+func (f *fakeRecalculationJobs) UpdateJob(job *models.RecalculationJob) error {
+	f.jobs[job.ID] = copyRecalculationJob(job)
+	return nil
+}
+
+func copyRecalculationJob(job *models.RecalculationJob) *models.RecalculationJob {
+	copied := *job
+	copied.Changes = append([]models.RecalculationChange(nil), job.Changes...)
+	return &copied
+}
+
+// fakeRecalculationRatings writes a rating and its history row together like
+// record_recalculation_change, or nothing at all when recordFails is set
+type fakeRecalculationRatings struct {
+	*fakeRatingStore
+	recordFails int
+}
+
+func (f *fakeRecalculationRatings) RecordRecalculationChange(effective *models.PlayerEffectiveMMR, history models.PlayerHistoricalMMRCreateRequest) error {
+	if f.recordFails > 0 {
+		f.recordFails--
+		return errors.New("connection reset")
+	}
+	if effective == nil {
+		delete(f.effective, history.UserID)
+	} else {
+		f.UpsertEffectiveMMR(effective)
+	}
+	return f.CreateHistoricalMMR(history)
+}
+
+// GetHistoryByRecalculationJob lets the shared rating fake back recalculations
+func (f *fakeRatingStore) GetHistoryByRecalculationJob(jobID int64) ([]*models.PlayerHistoricalMMR, error) {
+	var history []*models.PlayerHistoricalMMR
+	for _, request := range f.history {
+		if request.RecalculationJobID == nil || *request.RecalculationJobID != jobID {
+			continue
+		}
+		history = append(history, &models.PlayerHistoricalMMR{
+			UserID:               request.UserID,
+			GuildID:              request.GuildID,
+			TrueSkillMuBefore:    request.TrueSkillMuBefore,
+			TrueSkillMuAfter:     request.TrueSkillMuAfter,
+			TrueSkillSigmaBefore: request.TrueSkillSigmaBefore,
+			TrueSkillSigmaAfter:  request.TrueSkillSigmaAfter,
+			ChangeReason:         request.ChangeReason,
+			RecalculationJobID:   request.RecalculationJobID,
+		})
+	}
+	return history, nil
+}
+
+func newTestRecalculationService() (*RecalculationService, *fakeRecalculationRatings) {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333},
+		MMR:       config.MMRConfig{OnesWeight: 1.0, TwosWeight: 1.5, ThreesWeight: 1.2, TrackerAggregation: TrackerAggregationMax},
+	}
+	seeder := &trackerSeeder{mmrCalculator: NewMMRCalculator(cfg, NewPercentileConverter(cfg)), uncertaintyCalculator: NewEnhancedUncertaintyCalculator(cfg, NewPercentileConverter(cfg))}
+	now := time.Now()
+
+	ratings := &fakeRecalculationRatings{fakeRatingStore: newFakeRatingStore()}
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 1, MMR: 1100, TrueSkillMu: 500, TrueSkillSigma: 3, GamesPlayed: 12}
+	ratings.effective[3] = &models.PlayerEffectiveMMR{UserID: 3, GuildID: 1, TrueSkillMu: 1200, TrueSkillSigma: 4}
+
+	return &RecalculationService{
+		jobRepo:    &fakeRecalculationJobs{jobs: make(map[int64]*models.RecalculationJob)},
+		ratingRepo: ratings,
+		userRepo: &fakeStatsUsers{users: []*models.User{
+			{ID: 1, DiscordID: playerID(1), Name: "Rated", Active: true},
+			{ID: 2, DiscordID: playerID(2), Name: "Unrated", Active: true},
+			{ID: 3, DiscordID: playerID(3), Name: "Untracked", Active: true},
+		}},
+		trackerRepo: &fakeTrackerSource{trackers: []*models.UserTracker{
+			{DiscordID: playerID(1), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/rated/overview", TwosCurrentSeasonPeak: 1450, TwosCurrentSeasonGames: 400, LastUpdated: now},
+			{DiscordID: playerID(2), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/unrated/overview", ThreesCurrentSeasonPeak: 900, ThreesCurrentSeasonGames: 300, LastUpdated: now},
+		}},
+		engines:                   &RatingEngineSelector{engines: newRatingEngines(seeder, cfg), guildRepo: &fakeGuildConfigStore{}},
+		dataTransformationService: NewDataTransformationService(),
+		config:                    cfg,
+	}, ratings
+}
+
+func TestRecalculationJobPreviewsAppliesAndReverts(t *testing.T) {
+	service, ratings := newTestRecalculationService()
+	createdBy := int64(9)
+
+	job, err := service.CreateJob(1, &createdBy)
+	if err != nil {
+		t.Fatalf("CreateJob failed: %v", err)
+	}
+	if job.Status != models.RecalculationStatusPending || job.Players != 3 || job.Unchanged != 1 || len(job.Changes) != 2 {
+		t.Fatalf("expected a pending job with 2 changes and 1 unchanged player, got %+v", job)
+	}
+	if ratings.effective[1].TrueSkillMu != 500 || ratings.effective[2] != nil || len(ratings.history) != 0 {
+		t.Fatalf("creating a job must not write ratings or history")
+	}
+
+	changes := make(map[int64]models.RecalculationChange)
+	for _, change := range job.Changes {
+		changes[change.UserID] = change
+	}
+	if before := changes[1].MuBefore; before == nil || *before != 500 {
+		t.Errorf("expected the rated player's change to start from 500, got %v", before)
+	}
+	if changes[2].MuBefore != nil {
+		t.Errorf("a player without a guild rating should have no before values")
+	}
+
+	applied, err := service.ApplyJob(job.ID, &createdBy)
+	if err != nil {
+		t.Fatalf("ApplyJob failed: %v", err)
+	}
+	if applied.Status != models.RecalculationStatusApplied || applied.AppliedCount() != 2 || applied.AppliedAt == nil {
+		t.Errorf("expected both changes applied, got %+v", applied)
+	}
+	for userID, change := range changes {
+		if rating := ratings.effective[userID]; rating == nil || rating.TrueSkillMu != change.MuAfter || rating.TrueSkillSigma != change.SigmaAfter {
+			t.Errorf("user %d: expected rating %.3f/%.3f, got %+v", userID, change.MuAfter, change.SigmaAfter, rating)
+		}
+	}
+	if ratings.effective[1].GamesPlayed != 12 || ratings.effective[1].MMR != 1100 {
+		t.Errorf("applying should only replace mu and sigma, got %+v", ratings.effective[1])
+	}
+	if len(ratings.history) != 2 {
+		t.Fatalf("expected one history row per applied change, got %d", len(ratings.history))
+	}
+	for _, row := range ratings.history {
+		if row.ChangeReason != models.ChangeReasonRecalculation || row.RecalculationJobID == nil || *row.RecalculationJobID != job.ID {
+			t.Errorf("expected recalculation history linked to job %d, got %+v", job.ID, row)
+		}
+	}
+
+	reverted, err := service.RevertJob(job.ID, &createdBy)
+	if err != nil {
+		t.Fatalf("RevertJob failed: %v", err)
+	}
+	if reverted.Status != models.RecalculationStatusReverted || reverted.RevertedCount() != 2 {
+		t.Errorf("expected both changes reverted, got %+v", reverted)
+	}
+	if rating := ratings.effective[1]; rating == nil || rating.TrueSkillMu != 500 || rating.TrueSkillSigma != 3 {
+		t.Errorf("expected the rated player back at 500/3, got %+v", rating)
+	}
+	if ratings.effective[2] != nil {
+		t.Errorf("expected the previously unrated player's rating removed, got %+v", ratings.effective[2])
+	}
+	if last := ratings.history[len(ratings.history)-1]; last.ChangeReason != models.ChangeReasonRecalculationRevert {
+		t.Errorf("expected revert history rows, got %s", last.ChangeReason)
+	}
+}
+
+func TestRecalculationJobLeavesRatingsThatMovedAlone(t *testing.T) {
+	service, ratings := newTestRecalculationService()
+
+	job, err := service.CreateJob(1, nil)
+	if err != nil {
+		t.Fatalf("CreateJob failed: %v", err)
+	}
+
+	// A match after the preview moves the rated player
+	ratings.effective[1].TrueSkillMu = 520
+	applied, err := service.ApplyJob(job.ID, nil)
+	if err != nil {
+		t.Fatalf("ApplyJob failed: %v", err)
+	}
+	if applied.AppliedCount() != 1 || ratings.effective[1].TrueSkillMu != 520 {
+		t.Errorf("expected the moved rating skipped, got %d applied and mu %.1f", applied.AppliedCount(), ratings.effective[1].TrueSkillMu)
+	}
+
+	// A match after the apply moves the newly rated player
+	ratings.effective[2].TrueSkillMu += 25
+	moved := ratings.effective[2].TrueSkillMu
+	reverted, err := service.RevertJob(job.ID, nil)
+	if err != nil {
+		t.Fatalf("RevertJob failed: %v", err)
+	}
+	if reverted.RevertedCount() != 0 || ratings.effective[2] == nil || ratings.effective[2].TrueSkillMu != moved {
+		t.Errorf("expected the moved rating kept, got %d reverted and %+v", reverted.RevertedCount(), ratings.effective[2])
+	}
+
+	if _, err := service.ApplyJob(job.ID, nil); !errors.Is(err, ErrInvalidRecalculation) {
+		t.Errorf("expected ErrInvalidRecalculation applying a reverted job, got %v", err)
+	}
+	if _, err := service.DiscardJob(job.ID); !errors.Is(err, ErrInvalidRecalculation) {
+		t.Errorf("expected ErrInvalidRecalculation discarding a reverted job, got %v", err)
+	}
+	if _, err := service.GetJob(99); !errors.Is(err, ErrRecalculationNotFound) {
+		t.Errorf("expected ErrRecalculationNotFound, got %v", err)
+	}
+}
+
+func TestRecalculationJobRetriesAFailedWriteAndStaysRevertible(t *testing.T) {
+	service, ratings := newTestRecalculationService()
+
+	job, err := service.CreateJob(1, nil)
+	if err != nil {
+		t.Fatalf("CreateJob failed: %v", err)
+	}
+
+	ratings.recordFails = 1
+	if _, err := service.ApplyJob(job.ID, nil); err == nil {
+		t.Fatal("expected the failed write to be reported")
+	}
+	first := job.Changes[0]
+	if !ratingUnchangedSince(ratings.effective[first.UserID], first.MuBefore, first.SigmaBefore) || len(ratings.history) != 0 {
+		t.Fatalf("a failed write should leave user %d's rating and history untouched, got %+v and %d rows", first.UserID, ratings.effective[first.UserID], len(ratings.history))
+	}
+
+	applied, err := service.ApplyJob(job.ID, nil)
+	if err != nil {
+		t.Fatalf("retrying ApplyJob failed: %v", err)
+	}
+	if applied.AppliedCount() != 2 || len(ratings.history) != 2 {
+		t.Fatalf("expected both changes applied with history, got %d applied and %d rows", applied.AppliedCount(), len(ratings.history))
+	}
+
+	reverted, err := service.RevertJob(job.ID, nil)
+	if err != nil {
+		t.Fatalf("RevertJob failed: %v", err)
+	}
+	if reverted.RevertedCount() != 2 {
+		t.Errorf("expected every applied change to be revertible, got %d reverted", reverted.RevertedCount())
+	}
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidReplayImport is returned when a replay directory cannot be imported from
+var ErrInvalidReplayImport = errors.New("invalid replay import")
+
+// Replay import outcomes
+const (
+	ReplayImported  = "imported"
+	ReplayDuplicate = "duplicate" // already recorded for the guild
+	ReplayFailed    = "failed"
+)
+
+// ReplayMatchStore interface for finding matches already imported from a replay
+type ReplayMatchStore interface {
+	FindMatchByReplayID(guildID int64, replayID string) (*models.Match, error)
+}
+
+// ReplayTrackerSource interface for the trackers that link platform accounts to Discord IDs
+type ReplayTrackerSource interface {
+	GetAllTrackers(validOnly bool) ([]*models.UserTracker, error)
+}
+
+// ReplayMatchRecorder interface for recording an imported match and rating its players
+type ReplayMatchRecorder interface {
+	RecordMatch(request models.MatchCreateRequest) (*MatchResult, error)
+}
+
+// ReplayFile is a replay export read from an upload or from disk
+type ReplayFile struct {
+	Name string
+	Data []byte
+}
+
+// ReplayImportResult is the outcome of importing one replay file
+type ReplayImportResult struct {
+	Source     string               `json:"source"`
+	ReplayID   string               `json:"replay_id,omitempty"`
+	Title      string               `json:"title,omitempty"`
+	Status     string               `json:"status"`
+	MatchID    int64                `json:"match_id,omitempty"`
+	TeamAScore int                  `json:"team_a_score"`        // blue
+	TeamBScore int                  `json:"team_b_score"`        // orange
+	Unmatched  []string             `json:"unmatched,omitempty"` // replay players without a linked tracker
+	Changes    []PlayerRatingChange `json:"changes,omitempty"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// ReplayImportSummary is the outcome of importing a batch of replay files
+type ReplayImportSummary struct {
+	Results    []ReplayImportResult `json:"results"`
+	Imported   int                  `json:"imported"`
+	Duplicates int                  `json:"duplicates"`
+	Failed     int                  `json:"failed"`
+}
+
+// parsedReplay is a replay file that decoded successfully, waiting to be recorded
+type parsedReplay struct {
+	source string
+	replay models.BallchasingReplay
+}
+
+// ReplayImportService records matches from ballchasing-format replay exports.
+// Service Responsibilities:
+// - Decoding uploaded or on-disk replay JSON
+// - Mapping replay platform accounts to users through their linked tracker URLs
+// - Recording each replay as a match with per-player stat lines, in the order they were played
+// - Skipping replays that were already imported for the guild
+type ReplayImportService struct {
+	matchRepo   ReplayMatchStore
+	trackerRepo ReplayTrackerSource
+	userRepo    UserDirectory
+	recorder    ReplayMatchRecorder
+	config      *config.Config
+}
+
+// NewReplayImportService creates a new replay import service
+func NewReplayImportService(
+	matchRepo *repositories.MatchRepository,
+	trackerRepo *repositories.TrackerRepository,
+	userRepo *repositories.UserRepository,
+	matchService *MatchService,
+	config *config.Config,
+) *ReplayImportService {
+	return &ReplayImportService{
+		matchRepo:   matchRepo,
+		trackerRepo: trackerRepo,
+		userRepo:    userRepo,
+		recorder:    matchService,
+		config:      config,
+	}
+}
+
+// ImportDirectory imports every .json replay in a directory under the configured import directory
+// subdir is relative to the import directory; "" imports the import directory itself.
+func (s *ReplayImportService) ImportDirectory(guildID int64, subdir string, reportedBy *int64) (*ReplayImportSummary, error) {
+	dir, err := s.importPath(subdir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read %s: %v", ErrInvalidReplayImport, dir, err)
+	}
+
+	var files []ReplayFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read replay %s: %w", entry.Name(), err)
+		}
+		files = append(files, ReplayFile{Name: entry.Name(), Data: data})
+	}
+
+	log.Printf("ReplayImportService: Importing %d replay files from %s for guild %d", len(files), dir, guildID)
+	return s.ImportReplays(guildID, files, reportedBy)
+}
+
+// ImportReplays records each replay as a match, oldest first so ratings update in play order
+// A replay that cannot be decoded, has players without a linked tracker or is rejected by the
+// match service is reported as failed without stopping the rest of the batch.
+func (s *ReplayImportService) ImportReplays(guildID int64, files []ReplayFile, reportedBy *int64) (*ReplayImportSummary, error) {
+	summary := &ReplayImportSummary{}
+
+	var replays []parsedReplay
+	for _, file := range files {
+		var replay models.BallchasingReplay
+		err := json.Unmarshal(file.Data, &replay)
+		if err == nil {
+			err = replay.Validate()
+		}
+		if err != nil {
+			summary.add(ReplayImportResult{Source: file.Name, Status: ReplayFailed, Error: err.Error()})
+			continue
+		}
+		replays = append(replays, parsedReplay{source: file.Name, replay: replay})
+	}
+
+	if len(replays) == 0 {
+		return summary, nil
+	}
+
+	// Replays without a date go last, in file order
+	sort.SliceStable(replays, func(i, j int) bool {
+		a, b := replays[i].replay.PlayedAt(), replays[j].replay.PlayedAt()
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+
+	accounts, err := s.accountIndex()
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string]int64)
+
+	seen := make(map[string]bool, len(replays))
+	for _, parsed := range replays {
+		if seen[parsed.replay.ID] {
+			summary.add(ReplayImportResult{Source: parsed.source, ReplayID: parsed.replay.ID, Title: parsed.replay.Title, Status: ReplayDuplicate})
+			continue
+		}
+		seen[parsed.replay.ID] = true
+		summary.add(s.importReplay(guildID, parsed, accounts, users, reportedBy))
+	}
+
+	log.Printf("ReplayImportService: Guild %d import finished: %d imported, %d duplicates, %d failed",
+		guildID, summary.Imported, summary.Duplicates, summary.Failed)
+	return summary, nil
+}
+
+// importReplay records one decoded replay
+func (s *ReplayImportService) importReplay(guildID int64, parsed parsedReplay, accounts map[string]string, users map[string]int64, reportedBy *int64) ReplayImportResult {
+	replay := parsed.replay
+	result := ReplayImportResult{
+		Source:     parsed.source,
+		ReplayID:   replay.ID,
+		Title:      replay.Title,
+		Status:     ReplayFailed,
+		TeamAScore: replay.Blue.TotalGoals(),
+		TeamBScore: replay.Orange.TotalGoals(),
+	}
+
+	existing, err := s.matchRepo.FindMatchByReplayID(guildID, replay.ID)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if existing != nil {
+		result.Status = ReplayDuplicate
+		result.MatchID = existing.ID
+		return result
+	}
+
+	request := models.MatchCreateRequest{
+		GuildID:          guildID,
+		TeamAScore:       result.TeamAScore,
+		TeamBScore:       result.TeamBScore,
+		PlayedAt:         replay.PlayedAt(),
+		ReportedByUserID: reportedBy,
+		ReplayID:         &replay.ID,
+		PlayerStats:      make(map[int64]models.MatchPlayerStats),
+	}
+
+	for _, side := range []struct {
+		team    *models.BallchasingTeam
+		userIDs *[]int64
+	}{{&replay.Blue, &request.TeamAUserIDs}, {&replay.Orange, &request.TeamBUserIDs}} {
+		for _, player := range side.team.Players {
+			userID, ok := s.resolvePlayer(player, accounts, users)
+			if !ok {
+				result.Unmatched = append(result.Unmatched, player.Name)
+				continue
+			}
+			*side.userIDs = append(*side.userIDs, userID)
+			request.PlayerStats[userID] = player.MatchStats()
+		}
+	}
+
+	if len(result.Unmatched) > 0 {
+		result.Error = fmt.Sprintf("no linked tracker for %s", strings.Join(result.Unmatched, ", "))
+		return result
+	}
+
+	recorded, err := s.recorder.RecordMatch(request)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = ReplayImported
+	result.MatchID = recorded.Match.ID
+	result.Changes = recorded.Changes
+	return result
+}
+
+// resolvePlayer maps a replay player to a user by platform account ID, then by display name
+// Epic tracker URLs carry the display name rather than the account ID, hence the fallback.
+func (s *ReplayImportService) resolvePlayer(player models.BallchasingPlayer, accounts map[string]string, users map[string]int64) (int64, bool) {
+	platform := models.NormalizePlatform(player.ID.Platform)
+	if platform == "" {
+		return 0, false
+	}
+
+	discordID := accounts[accountKey(platform, player.ID.ID)]
+	if discordID == "" {
+		discordID = accounts[accountKey(platform, player.Name)]
+	}
+	if discordID == "" {
+		return 0, false
+	}
+
+	if userID, ok := users[discordID]; ok {
+		return userID, userID != 0
+	}
+
+	user, err := s.userRepo.FindUserByDiscordID(discordID)
+	if err != nil || user == nil {
+		users[discordID] = 0
+		return 0, false
+	}
+	users[discordID] = int64(user.ID)
+	return int64(user.ID), true
+}
+
+// accountIndex maps every tracker's platform account to its owner's Discord ID
+// An account linked to more than one Discord ID maps to "" so it is never guessed.
+func (s *ReplayImportService) accountIndex() (map[string]string, error) {
+	trackers, err := s.trackerRepo.GetAllTrackers(false)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make(map[string]string, len(trackers))
+	for _, tracker := range trackers {
+		platform, account, ok := tracker.ParsePlatformAccount()
+		if !ok {
+			continue
+		}
+		key := accountKey(platform, account)
+		if existing, found := accounts[key]; found && existing != tracker.DiscordID {
+			accounts[key] = ""
+			continue
+		}
+		accounts[key] = tracker.DiscordID
+	}
+	return accounts, nil
+}
+
+// importPath resolves subdir inside the configured import directory
+// subdir is cleaned as if rooted, so ".." segments cannot climb out of the import directory.
+func (s *ReplayImportService) importPath(subdir string) (string, error) {
+	root := s.config.Replays.ImportDir
+	if root == "" {
+		return "", fmt.Errorf("%w: no replay import directory is configured", ErrInvalidReplayImport)
+	}
+	return filepath.Join(root, filepath.Clean("/"+subdir)), nil
+}
+
+// accountKey builds the lookup key for a platform account
+func accountKey(platform, account string) string {
+	return platform + ":" + strings.ToLower(strings.TrimSpace(account))
+}
+
+// add records a result and counts its outcome
+func (s *ReplayImportSummary) add(result ReplayImportResult) {
+	switch result.Status {
+	case ReplayImported:
+		s.Imported++
+	case ReplayDuplicate:
+		s.Duplicates++
+	default:
+		s.Failed++
+	}
+	s.Results = append(s.Results, result)
+}
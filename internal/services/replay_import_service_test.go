@@ -0,0 +1,172 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakeReplayMatchStore struct {
+	byReplay map[string]*models.Match
+}
+
+func (f *fakeReplayMatchStore) FindMatchByReplayID(guildID int64, replayID string) (*models.Match, error) {
+	return f.byReplay[replayID], nil
+}
+
+// fakeReplayRecorder keeps each recorded request and numbers the matches from 100
+type fakeReplayRecorder struct {
+	requests []models.MatchCreateRequest
+}
+
+func (f *fakeReplayRecorder) RecordMatch(request models.MatchCreateRequest) (*MatchResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMatchReport, err)
+	}
+	f.requests = append(f.requests, request)
+	return &MatchResult{Match: &models.Match{ID: int64(99 + len(f.requests))}}, nil
+}
+
+// replayJSON builds a 1v1 ballchasing export; players are given as "platform|account id|name"
+func replayJSON(id, date string, blueGoals, orangeGoals int, blue, orange string) []byte {
+	player := func(spec string, goals int) string {
+		parts := strings.SplitN(spec, "|", 3)
+		platform, accountID, name := parts[0], parts[1], parts[2]
+		return fmt.Sprintf(`{"name": %q, "id": {"platform": %q, "id": %q}, "stats": {"core": {"goals": %d, "saves": 2, "shots": 4, "assists": 0, "score": 350, "mvp": %t}}}`,
+			name, platform, accountID, goals, goals > 0)
+	}
+	return []byte(fmt.Sprintf(`{"id": %q, "title": "Replay %s", "date": %q,
+		"blue": {"players": [%s], "stats": {"core": {"goals": %d}}},
+		"orange": {"players": [%s], "stats": {"core": {"goals": %d}}}}`,
+		id, id, date, player(blue, blueGoals), blueGoals, player(orange, orangeGoals), orangeGoals))
+}
+
+func newTestReplayImportService() (*ReplayImportService, *fakeReplayMatchStore, *fakeReplayRecorder) {
+	users := &fakeUserDirectory{users: map[string]*models.User{
+		"100000000000000001": {ID: 1, DiscordID: "100000000000000001"},
+		"100000000000000002": {ID: 2, DiscordID: "100000000000000002"},
+		"100000000000000003": {ID: 3, DiscordID: "100000000000000003"},
+	}}
+	trackers := &fakeTrackerSource{trackers: []*models.UserTracker{
+		{DiscordID: "100000000000000001", URL: "https://rocketleague.tracker.network/rocket-league/profile/steam/76561198000000001/overview"},
+		{DiscordID: "100000000000000002", URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/Speedy%20Boi/overview"},
+		{DiscordID: "100000000000000003", URL: "https://ballchasing.com/player/ps4/keeper_3"},
+	}}
+	matches := &fakeReplayMatchStore{byReplay: make(map[string]*models.Match)}
+	recorder := &fakeReplayRecorder{}
+	return &ReplayImportService{
+		matchRepo:   matches,
+		trackerRepo: trackers,
+		userRepo:    users,
+		recorder:    recorder,
+		config:      &config.Config{},
+	}, matches, recorder
+}
+
+func TestImportReplaysMapsTrackersInPlayOrder(t *testing.T) {
+	service, _, recorder := newTestReplayImportService()
+
+	files := []ReplayFile{
+		{Name: "later.json", Data: replayJSON("r2", "2025-09-02T20:00:00+02:00", 1, 3, "steam|76561198000000001|Ace", "ps4|keeper_3|Keeper")},
+		{Name: "earlier.json", Data: replayJSON("r1", "2025-09-01T20:00:00Z", 2, 1, "steam|76561198000000001|Ace", "epic|0af3c9e1|Speedy Boi")},
+	}
+
+	summary, err := service.ImportReplays(1, files, nil)
+	if err != nil {
+		t.Fatalf("ImportReplays returned error: %v", err)
+	}
+	if summary.Imported != 2 || summary.Failed != 0 {
+		t.Fatalf("expected both replays imported, got %+v", summary.Results)
+	}
+
+	if recorder.requests[0].ReplayID == nil || *recorder.requests[0].ReplayID != "r1" {
+		t.Fatalf("expected the earlier replay r1 recorded first, got %+v", recorder.requests[0].ReplayID)
+	}
+	first := recorder.requests[0]
+	if first.TeamAUserIDs[0] != 1 || first.TeamAScore != 2 || first.TeamBScore != 1 {
+		t.Errorf("expected blue (user 1) to win 2-1, got %+v", first)
+	}
+	if stats := first.PlayerStats[1]; stats.Goals != 2 || stats.Saves != 2 || stats.Shots != 4 || !stats.MVP {
+		t.Errorf("expected user 1's stat line carried over, got %+v", stats)
+	}
+	if second := recorder.requests[1]; second.TeamBUserIDs[0] != 3 {
+		t.Errorf("expected the ps4 player linked through the ballchasing tracker, got %+v", second.TeamBUserIDs)
+	}
+}
+
+func TestImportReplaysReportsDuplicatesAndUnlinkedPlayers(t *testing.T) {
+	service, matches, recorder := newTestReplayImportService()
+	matches.byReplay["r1"] = &models.Match{ID: 42}
+
+	files := []ReplayFile{
+		{Name: "old.json", Data: replayJSON("r1", "2025-09-01T20:00:00Z", 2, 1, "steam|76561198000000001|Ace", "ps4|keeper_3|Keeper")},
+		{Name: "stranger.json", Data: replayJSON("r2", "2025-09-02T20:00:00Z", 2, 1, "steam|76561198000000001|Ace", "steam|76561198999999999|Stranger")},
+		{Name: "broken.json", Data: []byte(`{"id": "r3", "blue": `)},
+		{Name: "ok.json", Data: replayJSON("r4", "2025-09-03T20:00:00Z", 0, 1, "steam|76561198000000001|Ace", "ps4|keeper_3|Keeper")},
+		{Name: "ok-copy.json", Data: replayJSON("r4", "2025-09-03T20:00:00Z", 0, 1, "steam|76561198000000001|Ace", "ps4|keeper_3|Keeper")},
+	}
+
+	summary, err := service.ImportReplays(1, files, nil)
+	if err != nil {
+		t.Fatalf("ImportReplays returned error: %v", err)
+	}
+	if summary.Imported != 1 || summary.Duplicates != 2 || summary.Failed != 2 || len(recorder.requests) != 1 {
+		t.Fatalf("expected 1 imported, 2 duplicates and 2 failed, got %+v", summary)
+	}
+
+	for _, result := range summary.Results {
+		switch result.Source {
+		case "old.json":
+			if result.Status != ReplayDuplicate || result.MatchID != 42 {
+				t.Errorf("expected r1 reported as already imported as match 42, got %+v", result)
+			}
+		case "stranger.json":
+			if result.Status != ReplayFailed || len(result.Unmatched) != 1 || result.Unmatched[0] != "Stranger" {
+				t.Errorf("expected r2 to fail on the unlinked player, got %+v", result)
+			}
+		}
+	}
+}
+
+func TestImportDirectoryStaysInImportDir(t *testing.T) {
+	service, _, recorder := newTestReplayImportService()
+	root := filepath.Join(t.TempDir(), "replays")
+	service.config.Replays.ImportDir = root
+
+	if err := os.MkdirAll(filepath.Join(root, "week1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := replayJSON("r1", "2025-09-01T20:00:00Z", 2, 1, "steam|76561198000000001|Ace", "ps4|keeper_3|Keeper")
+	if err := os.WriteFile(filepath.Join(root, "week1", "r1.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "week1", "notes.txt"), []byte("not a replay"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := service.ImportDirectory(1, "week1", nil)
+	if err != nil {
+		t.Fatalf("ImportDirectory returned error: %v", err)
+	}
+	if summary.Imported != 1 || len(summary.Results) != 1 || len(recorder.requests) != 1 {
+		t.Errorf("expected only r1.json imported, got %+v", summary)
+	}
+
+	// A replay next to the import directory must not be reachable by climbing out of it
+	if err := os.WriteFile(filepath.Join(filepath.Dir(root), "outside.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	summary, err = service.ImportDirectory(1, "../..", nil)
+	if err != nil || len(summary.Results) != 0 {
+		t.Errorf("expected \"../..\" to stay in the import directory and find nothing, got %+v, %v", summary, err)
+	}
+
+	if _, err := service.ImportDirectory(1, "week2", nil); !errors.Is(err, ErrInvalidReplayImport) {
+		t.Errorf("expected a missing directory to be refused, got %v", err)
+	}
+}
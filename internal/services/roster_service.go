@@ -0,0 +1,446 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/usl"
+)
+
+// Roster errors
+var (
+	ErrInvalidRoster     = errors.New("invalid roster change")
+	ErrSalaryCapExceeded = errors.New("salary cap exceeded")
+	ErrPlayerIneligible  = errors.New("player is not eligible to play")
+	ErrPlayerRostered    = errors.New("player is already on a team this season")
+)
+
+// rosterHistoryLimit is the number of roster transactions shown with a team
+const rosterHistoryLimit = 50
+
+// RosterStore interface for persisting teams, their rosters and roster history
+type RosterStore interface {
+	TeamStore
+	UpdateTeamCaptain(teamID int64, captainDiscordID *string) error
+	GetTeamMembers(teamID int64) ([]models.TeamMember, error)
+	FindSeasonMember(guildID int64, seasonID *int64, discordID string) (*models.TeamMember, error)
+	AddTeamMember(team *models.Team, discordID string) (*models.TeamMember, error)
+	RemoveTeamMember(teamID int64, discordID string) error
+	CreateRosterTransaction(transaction models.RosterTransaction) error
+	GetRosterTransactions(teamID int64, limit int) ([]models.RosterTransaction, error)
+}
+
+// RosterPlayerSource interface for looking up players and their trackers
+type RosterPlayerSource interface {
+	GetUserByDiscordID(discordID string) (*usl.USLUser, error)
+	GetTrackersByDiscordID(discordID string) ([]*usl.USLUserTracker, error)
+}
+
+// RosterPlayer is a rostered player with their current salary
+type RosterPlayer struct {
+	DiscordID string  `json:"discord_id"`
+	Name      string  `json:"name"`
+	Salary    float64 `json:"salary"`
+	Captain   bool    `json:"captain"`
+	Eligible  bool    `json:"eligible"` // false once the player goes inactive or is banned
+	SignedAt  string  `json:"signed_at"`
+}
+
+// TeamRoster is a team's roster with salaries measured against the guild's cap
+type TeamRoster struct {
+	Team        *models.Team               `json:"team"`
+	Config      models.RosterConfig        `json:"config"`
+	Players     []RosterPlayer             `json:"players"`
+	TotalSalary float64                    `json:"total_salary"`
+	CapRoom     float64                    `json:"cap_room"` // negative when over the cap; 0 without a cap
+	Issues      []string                   `json:"issues"`   // rule breaks caused by rating changes or bans since signing
+	History     []models.RosterTransaction `json:"history"`
+}
+
+// RosterService manages team rosters.
+// Service Responsibilities:
+// - Creating teams for the active season with an optional captain
+// - Signing and releasing players against IsValidForPlay, one team per season, roster size and the salary cap
+// - Pricing players by TrueSkill mu or their best valid tracker MMR
+// - Recording every signing, release and captaincy change in the roster history
+type RosterService struct {
+	teamRepo     RosterStore
+	seasonRepo   ActiveSeasonFinder
+	guildRepo    GuildConfigStore
+	playerSource RosterPlayerSource
+	resolver     RatingResolver
+	config       *config.Config
+}
+
+// NewRosterService creates a new roster service
+func NewRosterService(
+	teamRepo *repositories.TeamRepository,
+	seasonRepo *repositories.SeasonRepository,
+	guildRepo *repositories.GuildRepository,
+	playerSource *usl.USLRepository,
+	resolver *PlayerRatingResolver,
+	config *config.Config,
+) *RosterService {
+	return &RosterService{
+		teamRepo:     teamRepo,
+		seasonRepo:   seasonRepo,
+		guildRepo:    guildRepo,
+		playerSource: playerSource,
+		resolver:     resolver,
+		config:       config,
+	}
+}
+
+// GetRosterConfig returns the guild's roster rules with defaults applied
+func (s *RosterService) GetRosterConfig(guildID int64) (models.RosterConfig, error) {
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return models.RosterConfig{}, err
+	}
+
+	roster := guildConfig.Roster
+	if err := roster.Validate(); err != nil {
+		return roster, fmt.Errorf("%w: %v", ErrInvalidRoster, err)
+	}
+	return roster, nil
+}
+
+// UpdateRosterConfig saves the guild's salary cap basis, cap and roster size limit
+// Existing rosters are not changed; rosters that now break the rules are flagged in GetRoster.
+func (s *RosterService) UpdateRosterConfig(guildID int64, roster models.RosterConfig) error {
+	if err := roster.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRoster, err)
+	}
+
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	guildConfig.Roster = roster
+	return s.guildRepo.UpdateConfig(guildID, guildConfig)
+}
+
+// GetTeams returns every team in a guild
+func (s *RosterService) GetTeams(guildID int64) ([]*models.Team, error) {
+	return s.teamRepo.GetTeamsByGuild(guildID)
+}
+
+// CreateTeam creates a team, in the active season unless one is given, and signs its captain
+// The captain is checked like any other signing before the team is created.
+func (s *RosterService) CreateTeam(request models.TeamCreateRequest, changedBy *int64) (*TeamRoster, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRoster, err)
+	}
+
+	if request.SeasonID == nil {
+		season, err := s.seasonRepo.FindActiveSeason(request.GuildID)
+		if err != nil {
+			return nil, err
+		}
+		if season != nil {
+			request.SeasonID = &season.ID
+		}
+	}
+
+	captain := request.CaptainDiscordID
+	request.CaptainDiscordID = nil
+
+	if captain != nil {
+		roster, err := s.GetRosterConfig(request.GuildID)
+		if err != nil {
+			return nil, err
+		}
+		provisional := &models.Team{GuildID: request.GuildID, SeasonID: request.SeasonID, Name: request.Name}
+		if _, err := s.checkSigning(provisional, roster, nil, *captain); err != nil {
+			return nil, err
+		}
+	}
+
+	team, err := s.teamRepo.CreateTeam(request)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("RosterService: Created team %s (%d) in guild %d", team.Name, team.ID, team.GuildID)
+
+	if captain == nil {
+		return s.GetRoster(team.ID)
+	}
+
+	if _, err := s.SignPlayer(team.ID, *captain, changedBy); err != nil {
+		return nil, err
+	}
+	return s.SetCaptain(team.ID, *captain, changedBy)
+}
+
+// GetRoster returns a team's players, salaries, cap room, rule breaks and recent history
+func (s *RosterService) GetRoster(teamID int64) (*TeamRoster, error) {
+	team, err := s.teamRepo.FindTeamByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	roster, err := s.GetRosterConfig(team.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.teamRepo.GetTeamMembers(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.teamRepo.GetRosterTransactions(teamID, rosterHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	discordIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		discordIDs = append(discordIDs, member.DiscordID)
+	}
+
+	salaries, err := s.salaries(team.GuildID, roster.CapBasis, discordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TeamRoster{
+		Team:    team,
+		Config:  roster,
+		Players: make([]RosterPlayer, 0, len(members)),
+		Issues:  []string{},
+		History: history,
+	}
+
+	for _, member := range members {
+		player := RosterPlayer{
+			DiscordID: member.DiscordID,
+			Name:      member.DiscordID,
+			Salary:    salaries[member.DiscordID],
+			Captain:   team.CaptainDiscordID != nil && *team.CaptainDiscordID == member.DiscordID,
+			SignedAt:  member.SignedAt.Format("2006-01-02"),
+		}
+
+		if user, err := s.playerSource.GetUserByDiscordID(member.DiscordID); err == nil && user != nil {
+			player.Name = user.DisplayName()
+			player.Eligible = user.IsValidForPlay()
+		}
+		if !player.Eligible {
+			result.Issues = append(result.Issues, fmt.Sprintf("%s is no longer eligible to play", player.Name))
+		}
+
+		result.TotalSalary += player.Salary
+		result.Players = append(result.Players, player)
+	}
+
+	result.TotalSalary = roundRating(result.TotalSalary)
+	if roster.HasSalaryCap() {
+		result.CapRoom = roundRating(roster.SalaryCap - result.TotalSalary)
+		if result.CapRoom < 0 {
+			result.Issues = append(result.Issues, fmt.Sprintf("roster salary %.1f is over the cap of %.1f", result.TotalSalary, roster.SalaryCap))
+		}
+	}
+	if roster.MaxRosterSize > 0 && len(result.Players) > roster.MaxRosterSize {
+		result.Issues = append(result.Issues, fmt.Sprintf("%d players rostered, the limit is %d", len(result.Players), roster.MaxRosterSize))
+	}
+
+	return result, nil
+}
+
+// SignPlayer adds a player to a team's roster if they are eligible, unsigned this season and fit under the cap
+func (s *RosterService) SignPlayer(teamID int64, discordID string, changedBy *int64) (*TeamRoster, error) {
+	if discordID == "" {
+		return nil, fmt.Errorf("%w: discord_id is required", ErrInvalidRoster)
+	}
+
+	current, err := s.GetRoster(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	salary, err := s.checkSigning(current.Team, current.Config, current, discordID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.teamRepo.AddTeamMember(current.Team, discordID); err != nil {
+		return nil, err
+	}
+
+	s.recordTransaction(current, models.RosterActionSigned, discordID, salary, current.TotalSalary+salary, changedBy)
+	log.Printf("RosterService: Signed %s to team %d (salary %.1f)", discordID, teamID, salary)
+
+	return s.GetRoster(teamID)
+}
+
+// ReleasePlayer removes a player from a team's roster, clearing the captaincy if they held it
+func (s *RosterService) ReleasePlayer(teamID int64, discordID string, changedBy *int64) (*TeamRoster, error) {
+	current, err := s.GetRoster(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	player := current.findPlayer(discordID)
+	if player == nil {
+		return nil, fmt.Errorf("%w: %s is not on %s", ErrInvalidRoster, discordID, current.Team.Name)
+	}
+
+	if err := s.teamRepo.RemoveTeamMember(teamID, discordID); err != nil {
+		return nil, err
+	}
+
+	if player.Captain {
+		if err := s.teamRepo.UpdateTeamCaptain(teamID, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	s.recordTransaction(current, models.RosterActionReleased, discordID, player.Salary, current.TotalSalary-player.Salary, changedBy)
+	log.Printf("RosterService: Released %s from team %d", discordID, teamID)
+
+	return s.GetRoster(teamID)
+}
+
+// SetCaptain names a rostered player as the team's captain
+func (s *RosterService) SetCaptain(teamID int64, discordID string, changedBy *int64) (*TeamRoster, error) {
+	current, err := s.GetRoster(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	player := current.findPlayer(discordID)
+	if player == nil {
+		return nil, fmt.Errorf("%w: the captain must be on the roster", ErrInvalidRoster)
+	}
+	if player.Captain {
+		return current, nil
+	}
+
+	if err := s.teamRepo.UpdateTeamCaptain(teamID, &discordID); err != nil {
+		return nil, err
+	}
+
+	s.recordTransaction(current, models.RosterActionCaptain, discordID, player.Salary, current.TotalSalary, changedBy)
+
+	return s.GetRoster(teamID)
+}
+
+// checkSigning applies the signing rules and returns the player's salary
+// current is nil for a team that has not been created yet.
+func (s *RosterService) checkSigning(team *models.Team, roster models.RosterConfig, current *TeamRoster, discordID string) (float64, error) {
+	user, err := s.playerSource.GetUserByDiscordID(discordID)
+	if err != nil || user == nil {
+		return 0, fmt.Errorf("%w: %s is not a registered player", ErrPlayerIneligible, discordID)
+	}
+	if !user.IsValidForPlay() {
+		return 0, fmt.Errorf("%w: %s is inactive or banned", ErrPlayerIneligible, user.DisplayName())
+	}
+
+	member, err := s.teamRepo.FindSeasonMember(team.GuildID, team.SeasonID, discordID)
+	if err != nil {
+		return 0, err
+	}
+	if member != nil {
+		if member.TeamID == team.ID {
+			return 0, fmt.Errorf("%w: %s is already on %s", ErrInvalidRoster, user.DisplayName(), team.Name)
+		}
+		return 0, fmt.Errorf("%w: %s is signed to team %d", ErrPlayerRostered, user.DisplayName(), member.TeamID)
+	}
+
+	rostered, totalSalary := 0, 0.0
+	if current != nil {
+		rostered, totalSalary = len(current.Players), current.TotalSalary
+	}
+
+	if roster.MaxRosterSize > 0 && rostered >= roster.MaxRosterSize {
+		return 0, fmt.Errorf("%w: %s already has %d players", ErrInvalidRoster, team.Name, rostered)
+	}
+
+	salaries, err := s.salaries(team.GuildID, roster.CapBasis, []string{discordID})
+	if err != nil {
+		return 0, err
+	}
+
+	salary, ok := salaries[discordID]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s has no valid tracker to set a salary from", ErrPlayerIneligible, user.DisplayName())
+	}
+
+	if roster.HasSalaryCap() && totalSalary+salary > roster.SalaryCap {
+		return 0, fmt.Errorf("%w: signing %s (%.1f) takes %s to %.1f, the cap is %.1f",
+			ErrSalaryCapExceeded, user.DisplayName(), salary, team.Name, totalSalary+salary, roster.SalaryCap)
+	}
+
+	return salary, nil
+}
+
+// salaries prices players by the cap basis: their resolved mu, or their best valid tracker MMR
+// Players without a valid tracker are left out under the mmr basis.
+func (s *RosterService) salaries(guildID int64, capBasis string, discordIDs []string) (map[string]float64, error) {
+	salaries := make(map[string]float64, len(discordIDs))
+	if len(discordIDs) == 0 {
+		return salaries, nil
+	}
+
+	if capBasis == models.RosterCapBasisMMR {
+		for _, discordID := range discordIDs {
+			trackers, err := s.playerSource.GetTrackersByDiscordID(discordID)
+			if err != nil {
+				return nil, err
+			}
+			for _, tracker := range trackers {
+				if !tracker.IsValidTracker() {
+					continue
+				}
+				if best, ok := salaries[discordID]; !ok || float64(tracker.MMR) > best {
+					salaries[discordID] = float64(tracker.MMR)
+				}
+			}
+		}
+		return salaries, nil
+	}
+
+	ratings, err := s.resolver.Resolve(guildID, discordIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, rating := range ratings {
+		salaries[rating.DiscordID] = roundRating(rating.Rating.Mu)
+	}
+	return salaries, nil
+}
+
+// recordTransaction appends a roster change to the team's history
+// A failure is logged rather than returned since the roster change itself has been made.
+func (s *RosterService) recordTransaction(current *TeamRoster, action, discordID string, salary, teamSalaryAfter float64, changedBy *int64) {
+	transaction := models.RosterTransaction{
+		TeamID:          current.Team.ID,
+		GuildID:         current.Team.GuildID,
+		SeasonID:        current.Team.SeasonID,
+		DiscordID:       discordID,
+		Action:          action,
+		Salary:          salary,
+		CapBasis:        current.Config.CapBasis,
+		TeamSalaryAfter: roundRating(teamSalaryAfter),
+		ChangedByUserID: changedBy,
+	}
+
+	if err := s.teamRepo.CreateRosterTransaction(transaction); err != nil {
+		log.Printf("RosterService: Failed to record %s of %s on team %d: %v", action, discordID, current.Team.ID, err)
+	}
+}
+
+// findPlayer returns the rostered player with the given Discord ID, or nil
+func (r *TeamRoster) findPlayer(discordID string) *RosterPlayer {
+	for i := range r.Players {
+		if r.Players[i].DiscordID == discordID {
+			return &r.Players[i]
+		}
+	}
+	return nil
+}
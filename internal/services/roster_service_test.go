@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/usl"
+)
+
+type fakeRosterStore struct {
+	*fakeTeamStore
+	members      []models.TeamMember
+	transactions []models.RosterTransaction
+}
+
+func (f *fakeRosterStore) UpdateTeamCaptain(teamID int64, captainDiscordID *string) error {
+	team, err := f.FindTeamByID(teamID)
+	if err != nil {
+		return err
+	}
+	team.CaptainDiscordID = captainDiscordID
+	return nil
+}
+
+func (f *fakeRosterStore) GetTeamMembers(teamID int64) ([]models.TeamMember, error) {
+	var members []models.TeamMember
+	for _, member := range f.members {
+		if member.TeamID == teamID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (f *fakeRosterStore) FindSeasonMember(guildID int64, seasonID *int64, discordID string) (*models.TeamMember, error) {
+	for _, member := range f.members {
+		if member.GuildID == guildID && equalInt64Ptr(member.SeasonID, seasonID) && member.DiscordID == discordID {
+			return &member, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeRosterStore) AddTeamMember(team *models.Team, discordID string) (*models.TeamMember, error) {
+	member := models.TeamMember{TeamID: team.ID, GuildID: team.GuildID, SeasonID: team.SeasonID, DiscordID: discordID}
+	f.members = append(f.members, member)
+	return &member, nil
+}
+
+func (f *fakeRosterStore) RemoveTeamMember(teamID int64, discordID string) error {
+	kept := f.members[:0]
+	for _, member := range f.members {
+		if member.TeamID != teamID || member.DiscordID != discordID {
+			kept = append(kept, member)
+		}
+	}
+	f.members = kept
+	return nil
+}
+
+func (f *fakeRosterStore) CreateRosterTransaction(transaction models.RosterTransaction) error {
+	f.transactions = append(f.transactions, transaction)
+	return nil
+}
+
+func (f *fakeRosterStore) GetRosterTransactions(teamID int64, limit int) ([]models.RosterTransaction, error) {
+	return f.transactions, nil
+}
+
+type fakeRosterPlayerSource struct {
+	users    map[string]*usl.USLUser
+	trackers map[string][]*usl.USLUserTracker
+}
+
+func (f *fakeRosterPlayerSource) GetUserByDiscordID(discordID string) (*usl.USLUser, error) {
+	user, ok := f.users[discordID]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (f *fakeRosterPlayerSource) GetTrackersByDiscordID(discordID string) ([]*usl.USLUserTracker, error) {
+	return f.trackers[discordID], nil
+}
+
+// newTestRosterService rates player N ("pa", "pb", ...) at mu 1000-100N, gives them a valid
+// tracker at MMR 1500-100N and an invalid one at 2000, and caps rosters at the given salary
+func newTestRosterService(players int, roster models.RosterConfig) (*RosterService, *fakeRosterStore, *fakeRosterPlayerSource) {
+	resolver := &fakeRatingResolver{ratings: make(map[string]TrueSkillRating), userIDs: make(map[string]int64)}
+	source := &fakeRosterPlayerSource{users: make(map[string]*usl.USLUser), trackers: make(map[string][]*usl.USLUserTracker)}
+	for i := 1; i <= players; i++ {
+		discordID := playerID(i)
+		resolver.ratings[discordID] = TrueSkillRating{Mu: 1000 - float64(100*i), Sigma: 5}
+		source.users[discordID] = &usl.USLUser{ID: int64(i), DiscordID: discordID, Name: "Player " + discordID, Active: true}
+		source.trackers[discordID] = []*usl.USLUserTracker{
+			{DiscordID: discordID, MMR: 2000, Valid: false},
+			{DiscordID: discordID, MMR: 1500 - 100*i, Valid: true},
+		}
+	}
+
+	store := &fakeRosterStore{fakeTeamStore: &fakeTeamStore{}}
+	return &RosterService{
+		teamRepo:     store,
+		seasonRepo:   &fakeSeasonFinder{active: &models.Season{ID: 7, GuildID: 1, Number: 2, Status: models.SeasonStatusActive}},
+		guildRepo:    &fakeGuildConfigStore{config: models.GuildConfig{Roster: roster}},
+		playerSource: source,
+		resolver:     resolver,
+		config:       &config.Config{},
+	}, store, source
+}
+
+func TestRosterCreateTeamSignsCaptainInActiveSeason(t *testing.T) {
+	service, store, _ := newTestRosterService(3, models.RosterConfig{})
+	captain := "pa"
+
+	roster, err := service.CreateTeam(models.TeamCreateRequest{GuildID: 1, Name: "Rockets", CaptainDiscordID: &captain}, nil)
+	if err != nil {
+		t.Fatalf("CreateTeam returned error: %v", err)
+	}
+
+	if roster.Team.SeasonID == nil || *roster.Team.SeasonID != 7 {
+		t.Errorf("expected team in active season 7, got %v", roster.Team.SeasonID)
+	}
+	if len(roster.Players) != 1 || !roster.Players[0].Captain || roster.Players[0].Salary != 900 {
+		t.Errorf("expected captain pa signed at salary 900, got %+v", roster.Players)
+	}
+	if len(store.transactions) != 2 || store.transactions[0].Action != models.RosterActionSigned || store.transactions[1].Action != models.RosterActionCaptain {
+		t.Errorf("expected signing and captaincy recorded, got %+v", store.transactions)
+	}
+}
+
+func TestRosterSigningEnforcesCapAndEligibility(t *testing.T) {
+	service, store, source := newTestRosterService(5, models.RosterConfig{SalaryCap: 1600, MaxRosterSize: 3})
+	team, _ := store.CreateTeam(models.TeamCreateRequest{GuildID: 1, Name: "Rockets", SeasonID: int64Ptr(7)})
+	other, _ := store.CreateTeam(models.TeamCreateRequest{GuildID: 1, Name: "Comets", SeasonID: int64Ptr(7)})
+
+	// salaries by mu: pa 900, pb 800, pc 700, pd 600, pe 500
+	if _, err := service.SignPlayer(team.ID, "pb", nil); err != nil {
+		t.Fatalf("SignPlayer returned error: %v", err)
+	}
+	if _, err := service.SignPlayer(team.ID, "pa", nil); !errors.Is(err, ErrSalaryCapExceeded) {
+		t.Errorf("expected 800 + 900 to break a 1600 cap, got %v", err)
+	}
+
+	roster, err := service.SignPlayer(team.ID, "pc", nil)
+	if err != nil {
+		t.Fatalf("SignPlayer returned error: %v", err)
+	}
+	if roster.TotalSalary != 1500 || roster.CapRoom != 100 {
+		t.Errorf("expected salary 1500 with 100 cap room, got %.1f and %.1f", roster.TotalSalary, roster.CapRoom)
+	}
+	if last := store.transactions[len(store.transactions)-1]; last.TeamSalaryAfter != 1500 || last.CapBasis != models.RosterCapBasisMu {
+		t.Errorf("expected transaction to record team salary 1500 on mu, got %+v", last)
+	}
+
+	if _, err := service.SignPlayer(other.ID, "pb", nil); !errors.Is(err, ErrPlayerRostered) {
+		t.Errorf("expected a player on another team this season to be rejected, got %v", err)
+	}
+
+	source.users["pd"].Banned = true
+	if _, err := service.SignPlayer(other.ID, "pd", nil); !errors.Is(err, ErrPlayerIneligible) {
+		t.Errorf("expected a banned player to be rejected, got %v", err)
+	}
+	if _, err := service.SignPlayer(other.ID, "nobody", nil); !errors.Is(err, ErrPlayerIneligible) {
+		t.Errorf("expected an unknown player to be rejected, got %v", err)
+	}
+}
+
+func TestRosterReleaseClearsCaptainAndFlagsIssues(t *testing.T) {
+	service, store, source := newTestRosterService(3, models.RosterConfig{})
+	captain := "pa"
+	created, err := service.CreateTeam(models.TeamCreateRequest{GuildID: 1, Name: "Rockets", CaptainDiscordID: &captain}, nil)
+	if err != nil {
+		t.Fatalf("CreateTeam returned error: %v", err)
+	}
+	teamID := created.Team.ID
+	if _, err := service.SignPlayer(teamID, "pb", nil); err != nil {
+		t.Fatalf("SignPlayer returned error: %v", err)
+	}
+
+	source.users["pb"].Active = false
+	roster, err := service.GetRoster(teamID)
+	if err != nil {
+		t.Fatalf("GetRoster returned error: %v", err)
+	}
+	if len(roster.Issues) != 1 || roster.Players[1].Eligible {
+		t.Errorf("expected the inactive player to be flagged, got %v", roster.Issues)
+	}
+
+	roster, err = service.ReleasePlayer(teamID, "pa", nil)
+	if err != nil {
+		t.Fatalf("ReleasePlayer returned error: %v", err)
+	}
+	if roster.Team.CaptainDiscordID != nil || len(roster.Players) != 1 {
+		t.Errorf("expected captain cleared and one player left, got captain %v and %d players", roster.Team.CaptainDiscordID, len(roster.Players))
+	}
+	if last := store.transactions[len(store.transactions)-1]; last.Action != models.RosterActionReleased || last.TeamSalaryAfter != 800 {
+		t.Errorf("expected release recorded with team salary 800, got %+v", last)
+	}
+
+	if _, err := service.ReleasePlayer(teamID, "pa", nil); !errors.Is(err, ErrInvalidRoster) {
+		t.Errorf("expected releasing an unsigned player to fail, got %v", err)
+	}
+	if _, err := service.SetCaptain(teamID, "pc", nil); !errors.Is(err, ErrInvalidRoster) {
+		t.Errorf("expected an unrostered captain to be rejected, got %v", err)
+	}
+}
+
+func TestRosterMMRBasisUsesBestValidTracker(t *testing.T) {
+	service, store, source := newTestRosterService(2, models.RosterConfig{CapBasis: models.RosterCapBasisMMR, SalaryCap: 2500})
+	team, _ := store.CreateTeam(models.TeamCreateRequest{GuildID: 1, Name: "Rockets", SeasonID: int64Ptr(7)})
+
+	roster, err := service.SignPlayer(team.ID, "pa", nil)
+	if err != nil {
+		t.Fatalf("SignPlayer returned error: %v", err)
+	}
+	if roster.Players[0].Salary != 1400 {
+		t.Errorf("expected salary from the valid 1400 tracker, got %.1f", roster.Players[0].Salary)
+	}
+
+	source.trackers["pb"] = nil
+	if _, err := service.SignPlayer(team.ID, "pb", nil); !errors.Is(err, ErrPlayerIneligible) {
+		t.Errorf("expected a player without a valid tracker to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"usl-server/internal/models"
+)
+
+// FixtureDuration is how long a match night fixture is blocked out in calendar exports
+const FixtureDuration = 2 * time.Hour
+
+// iCalTimeFormat is the UTC date-time form used by RFC 5545
+const iCalTimeFormat = "20060102T150405Z"
+
+// TeamCalendar returns a team's fixtures as an iCalendar feed (RFC 5545) for subscribing
+// Byes are left out; every fixture keeps a stable UID so calendar clients update it in place.
+func (s *ScheduleService) TeamCalendar(teamID int64) ([]byte, error) {
+	team, schedule, err := s.GetTeamSchedule(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	divisionName := ""
+	if schedule.Division != nil {
+		divisionName = schedule.Division.Name
+	}
+
+	return buildTeamCalendar(team, divisionName, schedule.Fixtures, time.Now()), nil
+}
+
+// buildTeamCalendar renders fixtures as a VCALENDAR with one VEVENT per match
+func buildTeamCalendar(team *models.Team, divisionName string, fixtures []ScheduleFixture, now time.Time) []byte {
+	var b strings.Builder
+	writeLine := func(line string) {
+		b.WriteString(foldICalLine(line))
+		b.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//USL//League Schedule//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	writeLine("X-WR-CALNAME:" + escapeICalText(team.Name+" fixtures"))
+
+	stamp := now.UTC().Format(iCalTimeFormat)
+	for _, fixture := range fixtures {
+		if fixture.IsBye() {
+			continue
+		}
+
+		description := fmt.Sprintf("Round %d", fixture.Round)
+		if divisionName != "" {
+			description = fmt.Sprintf("%s, round %d", divisionName, fixture.Round)
+		}
+
+		writeLine("BEGIN:VEVENT")
+		writeLine(fmt.Sprintf("UID:scheduled-match-%d@usl-server", fixture.ID))
+		writeLine("DTSTAMP:" + stamp)
+		writeLine("DTSTART:" + fixture.MatchNight.UTC().Format(iCalTimeFormat))
+		writeLine("DTEND:" + fixture.MatchNight.Add(FixtureDuration).UTC().Format(iCalTimeFormat))
+		writeLine("SUMMARY:" + escapeICalText(fixture.HomeTeamName+" vs "+fixture.AwayTeamName))
+		writeLine("DESCRIPTION:" + escapeICalText(description))
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// escapeICalText escapes backslashes, separators and newlines in TEXT values
+func escapeICalText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "").Replace(value)
+}
+
+// foldICalLine splits content lines longer than 75 octets, continuing with a leading space
+func foldICalLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
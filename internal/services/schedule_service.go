@@ -0,0 +1,343 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// Schedule errors
+var (
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	ErrScheduleExists  = errors.New("division already has a schedule")
+	ErrScheduleStarted = errors.New("division schedule already has completed fixtures")
+)
+
+// DivisionStore interface for persisting divisions and their fixtures
+type DivisionStore interface {
+	CreateDivision(request models.DivisionCreateRequest) (*models.Division, error)
+	FindDivisionByID(divisionID int64) (*models.Division, error)
+	GetDivisionsByGuild(guildID int64) ([]*models.Division, error)
+	CreateScheduledMatches(matches []models.ScheduledMatch) ([]models.ScheduledMatch, error)
+	GetScheduledMatches(divisionID int64) ([]models.ScheduledMatch, error)
+	ReplaceScheduledMatches(divisionID int64, matches []models.ScheduledMatch) ([]models.ScheduledMatch, error)
+}
+
+// TeamStore interface for persisting league teams
+type TeamStore interface {
+	CreateTeam(request models.TeamCreateRequest) (*models.Team, error)
+	FindTeamByID(teamID int64) (*models.Team, error)
+	GetTeamsByDivision(divisionID int64) ([]*models.Team, error)
+	GetTeamsByGuild(guildID int64) ([]*models.Team, error)
+}
+
+// ScheduleFixture is a scheduled match with its team names attached
+type ScheduleFixture struct {
+	models.ScheduledMatch
+	HomeTeamName string `json:"home_team_name"`
+	AwayTeamName string `json:"away_team_name,omitempty"`
+}
+
+// DivisionSchedule is a division's teams and fixtures, by round
+type DivisionSchedule struct {
+	Division *models.Division  `json:"division"`
+	Teams    []*models.Team    `json:"teams"`
+	Fixtures []ScheduleFixture `json:"fixtures"`
+}
+
+// roundRobinPairing is one pairing of a generated round; AwayTeamID 0 is a bye
+type roundRobinPairing struct {
+	HomeTeamID int64
+	AwayTeamID int64
+}
+
+// ScheduleService builds and serves league schedules.
+// Service Responsibilities:
+// - Managing divisions and the teams in them
+// - Generating single or double round-robins with home/away alternation and byes
+// - Placing rounds on match nights and persisting them as scheduled matches
+// - Exporting a team's fixtures as an iCal feed
+type ScheduleService struct {
+	divisionRepo DivisionStore
+	teamRepo     TeamStore
+	config       *config.Config
+}
+
+// NewScheduleService creates a new schedule service
+func NewScheduleService(divisionRepo *repositories.DivisionRepository, teamRepo *repositories.TeamRepository, config *config.Config) *ScheduleService {
+	return &ScheduleService{
+		divisionRepo: divisionRepo,
+		teamRepo:     teamRepo,
+		config:       config,
+	}
+}
+
+// CreateDivision creates a new division
+func (s *ScheduleService) CreateDivision(request models.DivisionCreateRequest) (*models.Division, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	return s.divisionRepo.CreateDivision(request)
+}
+
+// GetDivisions returns every division in a guild
+func (s *ScheduleService) GetDivisions(guildID int64) ([]*models.Division, error) {
+	return s.divisionRepo.GetDivisionsByGuild(guildID)
+}
+
+// AddTeam creates a team in a division, inheriting the division's season
+func (s *ScheduleService) AddTeam(request models.TeamCreateRequest) (*models.Team, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+
+	if request.DivisionID != nil {
+		division, err := s.divisionRepo.FindDivisionByID(*request.DivisionID)
+		if err != nil {
+			return nil, err
+		}
+		if division.GuildID != request.GuildID {
+			return nil, fmt.Errorf("%w: division %d belongs to another guild", ErrInvalidSchedule, division.ID)
+		}
+		if request.SeasonID == nil {
+			request.SeasonID = division.SeasonID
+		}
+	}
+
+	return s.teamRepo.CreateTeam(request)
+}
+
+// GetSchedule returns a division's teams and fixtures
+func (s *ScheduleService) GetSchedule(divisionID int64) (*DivisionSchedule, error) {
+	division, err := s.divisionRepo.FindDivisionByID(divisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.GetTeamsByDivision(divisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.divisionRepo.GetScheduledMatches(divisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DivisionSchedule{
+		Division: division,
+		Teams:    teams,
+		Fixtures: buildScheduleFixtures(teams, matches),
+	}, nil
+}
+
+// GetTeamSchedule returns a team and the fixtures of its division that involve it
+func (s *ScheduleService) GetTeamSchedule(teamID int64) (*models.Team, *DivisionSchedule, error) {
+	team, err := s.teamRepo.FindTeamByID(teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if team.DivisionID == nil {
+		return team, &DivisionSchedule{}, nil
+	}
+
+	schedule, err := s.GetSchedule(*team.DivisionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fixtures := make([]ScheduleFixture, 0, len(schedule.Fixtures))
+	for _, fixture := range schedule.Fixtures {
+		if fixture.Involves(teamID) {
+			fixtures = append(fixtures, fixture)
+		}
+	}
+	schedule.Fixtures = fixtures
+
+	return team, schedule, nil
+}
+
+// GenerateSchedule builds a round-robin for the division's teams and stores it
+// Round N is played on the Nth match night in date order; extra nights are left free.
+func (s *ScheduleService) GenerateSchedule(request models.ScheduleGenerateRequest) (*DivisionSchedule, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+
+	division, err := s.divisionRepo.FindDivisionByID(request.DivisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.GetTeamsByDivision(division.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) < 2 {
+		return nil, fmt.Errorf("%w: division %s needs at least 2 teams", ErrInvalidSchedule, division.Name)
+	}
+
+	teamIDs := make([]int64, 0, len(teams))
+	for _, team := range teams {
+		teamIDs = append(teamIDs, team.ID)
+	}
+	rounds := generateRoundRobin(teamIDs, request.Double)
+
+	nights := append(request.MatchNights[:0:0], request.MatchNights...)
+	sort.Slice(nights, func(i, j int) bool { return nights[i].Before(nights[j]) })
+	if len(nights) < len(rounds) {
+		return nil, fmt.Errorf("%w: %d rounds need %d match nights, got %d",
+			ErrInvalidSchedule, len(rounds), len(rounds), len(nights))
+	}
+
+	existing, err := s.divisionRepo.GetScheduledMatches(division.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		if !request.Replace {
+			return nil, ErrScheduleExists
+		}
+		for _, match := range existing {
+			if match.Status != models.ScheduledMatchScheduled {
+				return nil, ErrScheduleStarted
+			}
+		}
+	}
+
+	var matches []models.ScheduledMatch
+	for i, round := range rounds {
+		for _, pairing := range round {
+			match := models.ScheduledMatch{
+				GuildID:    division.GuildID,
+				DivisionID: division.ID,
+				Round:      i + 1,
+				MatchNight: nights[i],
+				HomeTeamID: pairing.HomeTeamID,
+				Status:     models.ScheduledMatchScheduled,
+			}
+			if pairing.AwayTeamID != 0 {
+				match.AwayTeamID = int64Ptr(pairing.AwayTeamID)
+			}
+			matches = append(matches, match)
+		}
+	}
+
+	// A replaced schedule is swapped in one transaction so a failure keeps the old fixtures
+	var created []models.ScheduledMatch
+	if len(existing) > 0 {
+		created, err = s.divisionRepo.ReplaceScheduledMatches(division.ID, matches)
+	} else {
+		created, err = s.divisionRepo.CreateScheduledMatches(matches)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("ScheduleService: Generated %d rounds (%d fixtures) for division %d (%s)",
+		len(rounds), len(created), division.ID, division.Name)
+
+	return &DivisionSchedule{
+		Division: division,
+		Teams:    teams,
+		Fixtures: buildScheduleFixtures(teams, created),
+	}, nil
+}
+
+// generateRoundRobin pairs every team with every other team once per leg using the canonical
+// circle method: in round k the last slot meets slot k and slot k+i meets slot k-i.
+// Venues alternate by construction, leaving n-2 home/away breaks for n teams (the minimum);
+// with an odd field the last slot is a bye. The second leg of a double round-robin mirrors
+// the first with venues swapped.
+func generateRoundRobin(teamIDs []int64, double bool) [][]roundRobinPairing {
+	slots := append([]int64{}, teamIDs...)
+	if len(slots)%2 == 1 {
+		slots = append(slots, 0)
+	}
+	n := len(slots)
+	fixed := slots[n-1]
+	wheel := n - 1
+
+	pair := func(home, away int64) roundRobinPairing {
+		if home == 0 {
+			return roundRobinPairing{HomeTeamID: away}
+		}
+		if away == 0 {
+			return roundRobinPairing{HomeTeamID: home}
+		}
+		return roundRobinPairing{HomeTeamID: home, AwayTeamID: away}
+	}
+
+	rounds := make([][]roundRobinPairing, 0, wheel)
+	for k := 0; k < wheel; k++ {
+		pairings := make([]roundRobinPairing, 0, n/2)
+		var bye *roundRobinPairing
+
+		add := func(p roundRobinPairing) {
+			if p.AwayTeamID == 0 {
+				bye = &p
+				return
+			}
+			pairings = append(pairings, p)
+		}
+
+		if k%2 == 0 {
+			add(pair(slots[k], fixed))
+		} else {
+			add(pair(fixed, slots[k]))
+		}
+		for i := 1; i < n/2; i++ {
+			up := slots[(k+i)%wheel]
+			down := slots[(k-i+wheel)%wheel]
+			if i%2 == 1 {
+				add(pair(up, down))
+			} else {
+				add(pair(down, up))
+			}
+		}
+
+		if bye != nil {
+			pairings = append(pairings, *bye)
+		}
+		rounds = append(rounds, pairings)
+	}
+
+	if double {
+		firstLeg := len(rounds)
+		for i := 0; i < firstLeg; i++ {
+			mirrored := make([]roundRobinPairing, 0, len(rounds[i]))
+			for _, pairing := range rounds[i] {
+				if pairing.AwayTeamID == 0 {
+					mirrored = append(mirrored, pairing)
+					continue
+				}
+				mirrored = append(mirrored, roundRobinPairing{HomeTeamID: pairing.AwayTeamID, AwayTeamID: pairing.HomeTeamID})
+			}
+			rounds = append(rounds, mirrored)
+		}
+	}
+
+	return rounds
+}
+
+// buildScheduleFixtures attaches team names to scheduled matches
+func buildScheduleFixtures(teams []*models.Team, matches []models.ScheduledMatch) []ScheduleFixture {
+	names := make(map[int64]string, len(teams))
+	for _, team := range teams {
+		names[team.ID] = team.Name
+	}
+
+	fixtures := make([]ScheduleFixture, 0, len(matches))
+	for _, match := range matches {
+		fixture := ScheduleFixture{ScheduledMatch: match, HomeTeamName: names[match.HomeTeamID]}
+		if match.AwayTeamID != nil {
+			fixture.AwayTeamName = names[*match.AwayTeamID]
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakeDivisionStore struct {
+	divisions map[int64]*models.Division
+	matches   []models.ScheduledMatch
+	nextID    int64
+
+	// replaceErr fails ReplaceScheduledMatches the way a rolled back transaction does
+	replaceErr error
+}
+
+func (f *fakeDivisionStore) CreateDivision(request models.DivisionCreateRequest) (*models.Division, error) {
+	f.nextID++
+	division := &models.Division{ID: f.nextID, GuildID: request.GuildID, SeasonID: request.SeasonID, Name: request.Name}
+	f.divisions[division.ID] = division
+	return division, nil
+}
+
+func (f *fakeDivisionStore) FindDivisionByID(divisionID int64) (*models.Division, error) {
+	division, ok := f.divisions[divisionID]
+	if !ok {
+		return nil, errors.New("division not found")
+	}
+	return division, nil
+}
+
+func (f *fakeDivisionStore) GetDivisionsByGuild(guildID int64) ([]*models.Division, error) {
+	var divisions []*models.Division
+	for _, division := range f.divisions {
+		if division.GuildID == guildID {
+			divisions = append(divisions, division)
+		}
+	}
+	return divisions, nil
+}
+
+func (f *fakeDivisionStore) CreateScheduledMatches(matches []models.ScheduledMatch) ([]models.ScheduledMatch, error) {
+	created := make([]models.ScheduledMatch, 0, len(matches))
+	for _, match := range matches {
+		f.nextID++
+		match.ID = f.nextID
+		f.matches = append(f.matches, match)
+		created = append(created, match)
+	}
+	return created, nil
+}
+
+func (f *fakeDivisionStore) GetScheduledMatches(divisionID int64) ([]models.ScheduledMatch, error) {
+	var matches []models.ScheduledMatch
+	for _, match := range f.matches {
+		if match.DivisionID == divisionID {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+func (f *fakeDivisionStore) ReplaceScheduledMatches(divisionID int64, matches []models.ScheduledMatch) ([]models.ScheduledMatch, error) {
+	if f.replaceErr != nil {
+		return nil, f.replaceErr
+	}
+	kept := f.matches[:0]
+	for _, match := range f.matches {
+		if match.DivisionID != divisionID {
+			kept = append(kept, match)
+		}
+	}
+	f.matches = kept
+	return f.CreateScheduledMatches(matches)
+}
+
+type fakeTeamStore struct {
+	teams  []*models.Team
+	nextID int64
+}
+
+func (f *fakeTeamStore) CreateTeam(request models.TeamCreateRequest) (*models.Team, error) {
+	f.nextID++
+	team := &models.Team{ID: f.nextID, GuildID: request.GuildID, SeasonID: request.SeasonID, DivisionID: request.DivisionID, Name: request.Name}
+	f.teams = append(f.teams, team)
+	return team, nil
+}
+
+func (f *fakeTeamStore) FindTeamByID(teamID int64) (*models.Team, error) {
+	for _, team := range f.teams {
+		if team.ID == teamID {
+			return team, nil
+		}
+	}
+	return nil, errors.New("team not found")
+}
+
+func (f *fakeTeamStore) GetTeamsByDivision(divisionID int64) ([]*models.Team, error) {
+	var teams []*models.Team
+	for _, team := range f.teams {
+		if team.DivisionID != nil && *team.DivisionID == divisionID {
+			teams = append(teams, team)
+		}
+	}
+	return teams, nil
+}
+
+func (f *fakeTeamStore) GetTeamsByGuild(guildID int64) ([]*models.Team, error) {
+	var teams []*models.Team
+	for _, team := range f.teams {
+		if team.GuildID == guildID {
+			teams = append(teams, team)
+		}
+	}
+	return teams, nil
+}
+
+// newTestScheduleService creates a division with the named teams
+func newTestScheduleService(t *testing.T, teamNames ...string) (*ScheduleService, *fakeDivisionStore, *models.Division) {
+	t.Helper()
+	divisions := &fakeDivisionStore{divisions: make(map[int64]*models.Division), nextID: 100}
+	service := &ScheduleService{divisionRepo: divisions, teamRepo: &fakeTeamStore{}, config: &config.Config{}}
+
+	division, err := service.CreateDivision(models.DivisionCreateRequest{GuildID: 1, Name: "Premier"})
+	if err != nil {
+		t.Fatalf("CreateDivision returned error: %v", err)
+	}
+	for _, name := range teamNames {
+		if _, err := service.AddTeam(models.TeamCreateRequest{GuildID: 1, DivisionID: &division.ID, Name: name}); err != nil {
+			t.Fatalf("AddTeam returned error: %v", err)
+		}
+	}
+	return service, divisions, division
+}
+
+func weeklyNights(count int) []time.Time {
+	first := time.Date(2025, 9, 1, 20, 0, 0, 0, time.UTC)
+	nights := make([]time.Time, 0, count)
+	for i := 0; i < count; i++ {
+		nights = append(nights, first.AddDate(0, 0, 7*i))
+	}
+	return nights
+}
+
+func TestGenerateRoundRobinPairsEveryTeamOnceWithAlternatingVenues(t *testing.T) {
+	for _, teams := range []int{5, 6} {
+		ids := make([]int64, teams)
+		for i := range ids {
+			ids[i] = int64(i + 1)
+		}
+
+		rounds := generateRoundRobin(ids, false)
+
+		met := make(map[[2]int64]bool)
+		homeGames, awayGames, byes, breaks := map[int64]int{}, map[int64]int{}, map[int64]int{}, 0
+		lastVenue := map[int64]int{}
+		for _, round := range rounds {
+			for _, pairing := range round {
+				if pairing.AwayTeamID == 0 {
+					byes[pairing.HomeTeamID]++
+					continue
+				}
+				key := [2]int64{min(pairing.HomeTeamID, pairing.AwayTeamID), max(pairing.HomeTeamID, pairing.AwayTeamID)}
+				if met[key] {
+					t.Errorf("%d teams: %v meet twice", teams, key)
+				}
+				met[key] = true
+				homeGames[pairing.HomeTeamID]++
+				awayGames[pairing.AwayTeamID]++
+				if lastVenue[pairing.HomeTeamID] == 1 {
+					breaks++
+				}
+				if lastVenue[pairing.AwayTeamID] == -1 {
+					breaks++
+				}
+				lastVenue[pairing.HomeTeamID], lastVenue[pairing.AwayTeamID] = 1, -1
+			}
+		}
+
+		if want := teams * (teams - 1) / 2; len(met) != want {
+			t.Errorf("%d teams: expected %d pairings, got %d", teams, want, len(met))
+		}
+		for _, id := range ids {
+			if diff := homeGames[id] - awayGames[id]; diff > 1 || diff < -1 {
+				t.Errorf("%d teams: team %d has %d home and %d away games", teams, id, homeGames[id], awayGames[id])
+			}
+			if teams%2 == 1 && byes[id] != 1 {
+				t.Errorf("%d teams: team %d has %d byes, want 1", teams, id, byes[id])
+			}
+		}
+
+		wantBreaks := 0
+		if teams%2 == 0 {
+			wantBreaks = teams - 2
+		}
+		if breaks != wantBreaks {
+			t.Errorf("%d teams: expected %d home/away breaks, got %d", teams, wantBreaks, breaks)
+		}
+	}
+}
+
+func TestGenerateRoundRobinDoubleMirrorsFirstLeg(t *testing.T) {
+	rounds := generateRoundRobin([]int64{1, 2, 3, 4}, true)
+	if len(rounds) != 6 {
+		t.Fatalf("expected 6 rounds, got %d", len(rounds))
+	}
+	for i := 0; i < 3; i++ {
+		for j, pairing := range rounds[i] {
+			mirrored := rounds[i+3][j]
+			if mirrored.HomeTeamID != pairing.AwayTeamID || mirrored.AwayTeamID != pairing.HomeTeamID {
+				t.Errorf("round %d pairing %d: second leg %+v does not mirror %+v", i+4, j, mirrored, pairing)
+			}
+		}
+	}
+}
+
+func TestGenerateScheduleUsesMatchNightsInOrder(t *testing.T) {
+	service, divisions, division := newTestScheduleService(t, "Aces", "Bolts", "Comets")
+
+	nights := weeklyNights(4)
+	nights[0], nights[2] = nights[2], nights[0]
+	if _, err := service.GenerateSchedule(models.ScheduleGenerateRequest{DivisionID: division.ID, MatchNights: nights[:2]}); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("expected too few match nights to be rejected, got %v", err)
+	}
+
+	schedule, err := service.GenerateSchedule(models.ScheduleGenerateRequest{DivisionID: division.ID, MatchNights: nights})
+	if err != nil {
+		t.Fatalf("GenerateSchedule returned error: %v", err)
+	}
+
+	// 3 teams: 3 rounds of one match and one bye
+	if len(schedule.Fixtures) != 6 {
+		t.Fatalf("expected 6 fixtures, got %d", len(schedule.Fixtures))
+	}
+	for _, fixture := range schedule.Fixtures {
+		if want := weeklyNights(3)[fixture.Round-1]; !fixture.MatchNight.Equal(want) {
+			t.Errorf("round %d on %v, want %v", fixture.Round, fixture.MatchNight, want)
+		}
+	}
+
+	if _, err := service.GenerateSchedule(models.ScheduleGenerateRequest{DivisionID: division.ID, MatchNights: nights}); !errors.Is(err, ErrScheduleExists) {
+		t.Errorf("expected existing schedule to be kept, got %v", err)
+	}
+
+	divisions.matches[0].Status = models.ScheduledMatchCompleted
+	replace := models.ScheduleGenerateRequest{DivisionID: division.ID, MatchNights: nights, Replace: true}
+	if _, err := service.GenerateSchedule(replace); !errors.Is(err, ErrScheduleStarted) {
+		t.Errorf("expected a started schedule to be kept, got %v", err)
+	}
+}
+
+func TestGenerateScheduleReplaceKeepsOldFixturesOnFailure(t *testing.T) {
+	service, divisions, division := newTestScheduleService(t, "Aces", "Bolts", "Comets", "Dukes")
+	if _, err := service.GenerateSchedule(models.ScheduleGenerateRequest{DivisionID: division.ID, MatchNights: weeklyNights(3)}); err != nil {
+		t.Fatalf("GenerateSchedule returned error: %v", err)
+	}
+	original := append([]models.ScheduledMatch(nil), divisions.matches...)
+
+	replace := models.ScheduleGenerateRequest{DivisionID: division.ID, MatchNights: weeklyNights(6), Double: true, Replace: true}
+	divisions.replaceErr = errors.New("insert failed")
+	if _, err := service.GenerateSchedule(replace); err == nil {
+		t.Fatal("expected the failed replace to be reported")
+	}
+	if len(divisions.matches) != len(original) || divisions.matches[0].ID != original[0].ID {
+		t.Fatalf("expected the old %d fixtures to be kept, got %d", len(original), len(divisions.matches))
+	}
+
+	divisions.replaceErr = nil
+	if _, err := service.GenerateSchedule(replace); err != nil {
+		t.Fatalf("GenerateSchedule returned error: %v", err)
+	}
+	// 4 teams double round-robin: 6 rounds of two matches, none of the old fixtures left
+	if len(divisions.matches) != 12 {
+		t.Fatalf("expected 12 fixtures after the replace, got %d", len(divisions.matches))
+	}
+	for _, match := range divisions.matches {
+		if match.ID <= original[len(original)-1].ID {
+			t.Errorf("old fixture %d survived the replace", match.ID)
+		}
+	}
+}
+
+func TestTeamCalendarListsFixturesWithoutByes(t *testing.T) {
+	service, _, division := newTestScheduleService(t, "Aces", "Bolts, Inc", "Comets")
+	if _, err := service.GenerateSchedule(models.ScheduleGenerateRequest{DivisionID: division.ID, MatchNights: weeklyNights(3)}); err != nil {
+		t.Fatalf("GenerateSchedule returned error: %v", err)
+	}
+
+	calendar, err := service.TeamCalendar(1)
+	if err != nil {
+		t.Fatalf("TeamCalendar returned error: %v", err)
+	}
+	ics := string(calendar)
+
+	if got := strings.Count(ics, "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("expected 2 events (two opponents, bye skipped), got %d", got)
+	}
+	if !strings.Contains(ics, `Bolts\, Inc`) {
+		t.Errorf("expected commas in team names to be escaped:\n%s", ics)
+	}
+	if !strings.Contains(ics, "DTSTART:20250901T200000Z\r\n") && !strings.Contains(ics, "DTSTART:20250908T200000Z\r\n") {
+		t.Errorf("expected UTC start times with CRLF line endings:\n%s", ics)
+	}
+}
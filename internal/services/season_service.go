@@ -0,0 +1,368 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// Season lifecycle errors
+var (
+	ErrSeasonAlreadyActive = errors.New("a season is already active")
+	ErrNoActiveSeason      = errors.New("no active season")
+	ErrInvalidSoftReset    = errors.New("invalid soft reset")
+	ErrInvalidGameSeason   = errors.New("invalid game season")
+	ErrTrackersRolled      = errors.New("trackers already rolled")
+)
+
+// SeasonStore interface for persisting seasons and their final standings
+type SeasonStore interface {
+	CreateSeason(request models.SeasonCreateRequest) (*models.Season, error)
+	FindActiveSeason(guildID int64) (*models.Season, error)
+	GetSeasonsByGuild(guildID int64) ([]*models.Season, error)
+	CloseSeason(record models.SeasonCloseRecord) (*models.Season, error)
+	GetLeaderboardSnapshot(seasonID int64) ([]models.SeasonLeaderboardEntry, error)
+}
+
+// GuildRatingStore interface for reading every rating in a guild and writing changes with history
+type GuildRatingStore interface {
+	GetGuildEffectiveMMRs(guildID int64) ([]*models.PlayerEffectiveMMR, error)
+	UpsertEffectiveMMR(effective *models.PlayerEffectiveMMR) error
+	CreateHistoricalMMR(request models.PlayerHistoricalMMRCreateRequest) error
+}
+
+// GuildPlaylistRatingSource interface for reading every per-playlist rating in a guild
+type GuildPlaylistRatingSource interface {
+	GetGuildPlaylistRatings(guildID int64, playlist string) ([]*models.PlayerPlaylistRating, error)
+}
+
+// TrackerRolloverStore interface for rolling every tracker into a game season and reading the latest one
+// RollTrackerSeasons claims the game season and moves the trackers together, or does neither
+type TrackerRolloverStore interface {
+	FindLatestTrackerRollover() (*models.TrackerSeasonRollover, error)
+	RollTrackerSeasons(gameSeason int, rolledByUserID *int64) (*models.TrackerSeasonRollover, error)
+}
+
+// SoftReset is the fraction of the distance each rating moves toward its target
+// MuFactor pulls mu toward TrueSkill.InitialMu, SigmaFactor inflates sigma toward TrueSkill.SigmaMax
+type SoftReset struct {
+	MuFactor    float64 `json:"mu_factor"`
+	SigmaFactor float64 `json:"sigma_factor"`
+}
+
+// CloseSeasonOptions controls what happens when a season is closed
+type CloseSeasonOptions struct {
+	SoftReset       *SoftReset // nil closes the season without touching ratings
+	ChangedByUserID *int64
+}
+
+// SeasonResetChange describes how a single player's rating moved in the soft reset
+type SeasonResetChange struct {
+	UserID      int64   `json:"user_id"`
+	MuBefore    float64 `json:"mu_before"`
+	MuAfter     float64 `json:"mu_after"`
+	SigmaBefore float64 `json:"sigma_before"`
+	SigmaAfter  float64 `json:"sigma_after"`
+}
+
+// SeasonCloseResult represents a closed season and everything that was done to close it
+type SeasonCloseResult struct {
+	Season         *models.Season             `json:"season"`
+	SnapshotSize   int                        `json:"snapshot_size"`
+	Resets         []SeasonResetChange        `json:"resets"`
+	PlaylistResets []PlaylistRatingAdjustment `json:"playlist_resets"`
+}
+
+// TrackerRolloverResult represents a game season rollover and the trackers it moved
+type TrackerRolloverResult struct {
+	Rollover       *models.TrackerSeasonRollover `json:"rollover"`
+	TrackersRolled int                           `json:"trackers_rolled"`
+}
+
+// SeasonService manages league seasons.
+// Service Responsibilities:
+// - Starting numbered seasons, one active season per guild
+// - Snapshotting the final leaderboard when a season closes
+// - Applying the soft reset to player_effective_mmr with season_reset history rows
+// - Resetting player_playlist_ratings alongside the combined rating
+// - Rolling tracker current season columns into the previous season once per game season
+type SeasonService struct {
+	seasonRepo   SeasonStore
+	ratingRepo   GuildRatingStore
+	playlistRepo GuildPlaylistRatingSource
+	rolloverRepo TrackerRolloverStore
+	config       *config.Config
+}
+
+// NewSeasonService creates a new season service
+func NewSeasonService(
+	seasonRepo *repositories.SeasonRepository,
+	ratingRepo *repositories.PlayerMMRRepository,
+	config *config.Config,
+) *SeasonService {
+	return &SeasonService{
+		seasonRepo:   seasonRepo,
+		ratingRepo:   ratingRepo,
+		playlistRepo: ratingRepo,
+		rolloverRepo: seasonRepo,
+		config:       config,
+	}
+}
+
+// DefaultSoftReset returns the soft reset configured for the server
+func (s *SeasonService) DefaultSoftReset() SoftReset {
+	return SoftReset{
+		MuFactor:    s.config.Season.SoftResetMuFactor,
+		SigmaFactor: s.config.Season.SoftResetSigmaFactor,
+	}
+}
+
+// Validate checks that both factors are fractions between 0 and 1
+func (r SoftReset) Validate() error {
+	if r.MuFactor < 0 || r.MuFactor > 1 {
+		return fmt.Errorf("%w: mu factor must be between 0 and 1, got %.3f", ErrInvalidSoftReset, r.MuFactor)
+	}
+	if r.SigmaFactor < 0 || r.SigmaFactor > 1 {
+		return fmt.Errorf("%w: sigma factor must be between 0 and 1, got %.3f", ErrInvalidSoftReset, r.SigmaFactor)
+	}
+	return nil
+}
+
+// ApplySoftReset returns the rating after the soft reset
+// Sigma is only ever inflated, a player already above SigmaMax keeps their sigma.
+func (s *SeasonService) ApplySoftReset(rating TrueSkillRating, reset SoftReset) TrueSkillRating {
+	targetMu := s.config.TrueSkill.InitialMu
+	sigmaMax := s.config.TrueSkill.SigmaMax
+
+	result := TrueSkillRating{
+		Mu:    rating.Mu + reset.MuFactor*(targetMu-rating.Mu),
+		Sigma: rating.Sigma,
+	}
+	if rating.Sigma < sigmaMax {
+		result.Sigma = rating.Sigma + reset.SigmaFactor*(sigmaMax-rating.Sigma)
+	}
+
+	return TrueSkillRating{Mu: roundRating(result.Mu), Sigma: roundRating(result.Sigma)}
+}
+
+// GetSeasons returns every season in a guild, newest first
+func (s *SeasonService) GetSeasons(guildID int64) ([]*models.Season, error) {
+	return s.seasonRepo.GetSeasonsByGuild(guildID)
+}
+
+// GetActiveSeason returns the running season in a guild, or nil when there is none
+func (s *SeasonService) GetActiveSeason(guildID int64) (*models.Season, error) {
+	return s.seasonRepo.FindActiveSeason(guildID)
+}
+
+// GetFinalStandings returns the leaderboard frozen when a season closed
+func (s *SeasonService) GetFinalStandings(seasonID int64) ([]models.SeasonLeaderboardEntry, error) {
+	return s.seasonRepo.GetLeaderboardSnapshot(seasonID)
+}
+
+// StartSeason opens the next numbered season in a guild
+// A blank name defaults to "Season N"
+func (s *SeasonService) StartSeason(guildID int64, name string) (*models.Season, error) {
+	active, err := s.seasonRepo.FindActiveSeason(guildID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("%w: %s must be closed first", ErrSeasonAlreadyActive, active.Name)
+	}
+
+	seasons, err := s.seasonRepo.GetSeasonsByGuild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	number := 1
+	for _, season := range seasons {
+		if season.Number >= number {
+			number = season.Number + 1
+		}
+	}
+	if name == "" {
+		name = fmt.Sprintf("Season %d", number)
+	}
+
+	season, err := s.seasonRepo.CreateSeason(models.SeasonCreateRequest{
+		GuildID: guildID,
+		Number:  number,
+		Name:    name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("SeasonService: Started season %d (%s) in guild %d", season.Number, season.Name, guildID)
+	return season, nil
+}
+
+// CloseSeason snapshots the final leaderboard, closes the active season and optionally
+// applies the soft reset. Trackers are rolled separately, see RollTrackerSeasons.
+// The snapshot, the close and every reset are written in one transaction, so a failure
+// leaves the season open with ratings untouched and the close can simply be retried.
+func (s *SeasonService) CloseSeason(guildID int64, options CloseSeasonOptions) (*SeasonCloseResult, error) {
+	if options.SoftReset != nil {
+		if err := options.SoftReset.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	active, err := s.seasonRepo.FindActiveSeason(guildID)
+	if err != nil {
+		return nil, err
+	}
+	if active == nil {
+		return nil, ErrNoActiveSeason
+	}
+
+	ratings, err := s.ratingRepo.GetGuildEffectiveMMRs(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ratings for snapshot: %w", err)
+	}
+
+	record := models.SeasonCloseRecord{
+		SeasonID: active.ID,
+		Snapshot: buildLeaderboardSnapshot(active.ID, ratings),
+	}
+	resets := make([]SeasonResetChange, 0, len(ratings))
+	playlistResets := []PlaylistRatingAdjustment{}
+	if options.SoftReset != nil {
+		playlistRatings, err := loadGuildPlaylistRatings(s.playlistRepo, guildID)
+		if err != nil {
+			return nil, err
+		}
+
+		record.MuFactor = &options.SoftReset.MuFactor
+		record.SigmaFactor = &options.SoftReset.SigmaFactor
+		resets = s.buildSoftResets(ratings, *options.SoftReset, options.ChangedByUserID, &record)
+		for _, current := range ratings {
+			adjustments, updated := adjustPlaylistRatings(playlistRatings[current.UserID], func(rating TrueSkillRating) TrueSkillRating {
+				return s.ApplySoftReset(rating, *options.SoftReset)
+			}, 0)
+			playlistResets = append(playlistResets, adjustments...)
+			record.PlaylistRatings = append(record.PlaylistRatings, updated...)
+		}
+	}
+
+	closed, err := s.seasonRepo.CloseSeason(record)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("SeasonService: Closed season %d in guild %d: %d standings, %d resets, %d playlist resets",
+		closed.Number, guildID, len(record.Snapshot), len(resets), len(playlistResets))
+
+	return &SeasonCloseResult{
+		Season:         closed,
+		SnapshotSize:   len(record.Snapshot),
+		Resets:         resets,
+		PlaylistResets: playlistResets,
+	}, nil
+}
+
+// GetLatestTrackerRollover returns the newest game season trackers were rolled into, or nil
+func (s *SeasonService) GetLatestTrackerRollover() (*models.TrackerSeasonRollover, error) {
+	return s.rolloverRepo.FindLatestTrackerRollover()
+}
+
+// RollTrackerSeasons moves every tracker's current season into the previous season when
+// Rocket League starts gameSeason. The flip is global, so it is independent of league seasons
+// and each game season is rolled at most once: a second guild or a repeat gets ErrTrackersRolled
+// instead of wiping the real previous season peaks. The claim and the rolled trackers are
+// written in one transaction, so a failed roll leaves the game season free to retry.
+func (s *SeasonService) RollTrackerSeasons(gameSeason int, rolledByUserID *int64) (*TrackerRolloverResult, error) {
+	if gameSeason < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidGameSeason, gameSeason)
+	}
+
+	latest, err := s.rolloverRepo.FindLatestTrackerRollover()
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && gameSeason <= latest.GameSeason {
+		return nil, fmt.Errorf("%w: trackers are already in game season %d", ErrTrackersRolled, latest.GameSeason)
+	}
+
+	rollover, err := s.rolloverRepo.RollTrackerSeasons(gameSeason, rolledByUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("SeasonService: Rolled %d trackers into game season %d", rollover.TrackersRolled, gameSeason)
+
+	return &TrackerRolloverResult{Rollover: rollover, TrackersRolled: rollover.TrackersRolled}, nil
+}
+
+// buildSoftResets moves every rating toward the defaults, adding the reset rating and its
+// season_reset history row to the close record. LastUpdated is kept so a reset does not
+// count as recent activity.
+func (s *SeasonService) buildSoftResets(ratings []*models.PlayerEffectiveMMR, reset SoftReset, changedBy *int64, record *models.SeasonCloseRecord) []SeasonResetChange {
+	changes := make([]SeasonResetChange, 0, len(ratings))
+	for _, current := range ratings {
+		updated := s.ApplySoftReset(TrueSkillRating{Mu: current.TrueSkillMu, Sigma: current.TrueSkillSigma}, reset)
+		change := SeasonResetChange{
+			UserID:      current.UserID,
+			MuBefore:    current.TrueSkillMu,
+			MuAfter:     updated.Mu,
+			SigmaBefore: current.TrueSkillSigma,
+			SigmaAfter:  updated.Sigma,
+		}
+
+		rating := *current
+		rating.TrueSkillMu = change.MuAfter
+		rating.TrueSkillSigma = change.SigmaAfter
+		record.Ratings = append(record.Ratings, &rating)
+
+		mmrBefore := current.MMR
+		muBefore := change.MuBefore
+		sigmaBefore := change.SigmaBefore
+		record.History = append(record.History, models.PlayerHistoricalMMRCreateRequest{
+			UserID:               current.UserID,
+			GuildID:              current.GuildID,
+			MMRBefore:            &mmrBefore,
+			MMRAfter:             rating.MMR,
+			TrueSkillMuBefore:    &muBefore,
+			TrueSkillMuAfter:     change.MuAfter,
+			TrueSkillSigmaBefore: &sigmaBefore,
+			TrueSkillSigmaAfter:  change.SigmaAfter,
+			ChangeReason:         models.ChangeReasonSeasonReset,
+			ChangedByUserID:      changedBy,
+		})
+
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// buildLeaderboardSnapshot ranks ratings by mu, highest first
+func buildLeaderboardSnapshot(seasonID int64, ratings []*models.PlayerEffectiveMMR) []models.SeasonLeaderboardEntry {
+	ranked := make([]*models.PlayerEffectiveMMR, len(ratings))
+	copy(ranked, ratings)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].TrueSkillMu > ranked[j].TrueSkillMu
+	})
+
+	now := time.Now()
+	entries := make([]models.SeasonLeaderboardEntry, 0, len(ranked))
+	for i, rating := range ranked {
+		entries = append(entries, models.SeasonLeaderboardEntry{
+			SeasonID:       seasonID,
+			UserID:         rating.UserID,
+			Rank:           i + 1,
+			MMR:            rating.MMR,
+			TrueSkillMu:    rating.TrueSkillMu,
+			TrueSkillSigma: rating.TrueSkillSigma,
+			GamesPlayed:    rating.GamesPlayed,
+			CreatedAt:      now,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+// GetGuildEffectiveMMRs lets the shared rating fake back the season service too
+func (f *fakeRatingStore) GetGuildEffectiveMMRs(guildID int64) ([]*models.PlayerEffectiveMMR, error) {
+	ratings := make([]*models.PlayerEffectiveMMR, 0, len(f.effective))
+	for _, effective := range f.effective {
+		copied := *effective
+		ratings = append(ratings, &copied)
+	}
+	return ratings, nil
+}
+
+type fakeSeasonStore struct {
+	seasons    []*models.Season
+	snapshots  []models.SeasonLeaderboardEntry
+	ratings    *fakeRatingStore
+	playlists  *fakePlaylistRatings
+	closeFails int
+}
+
+func (f *fakeSeasonStore) CreateSeason(request models.SeasonCreateRequest) (*models.Season, error) {
+	season := &models.Season{ID: int64(len(f.seasons) + 1), GuildID: request.GuildID, Number: request.Number, Name: request.Name, Status: models.SeasonStatusActive}
+	f.seasons = append(f.seasons, season)
+	return season, nil
+}
+
+func (f *fakeSeasonStore) FindActiveSeason(guildID int64) (*models.Season, error) {
+	for _, season := range f.seasons {
+		if season.GuildID == guildID && season.IsActive() {
+			return season, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSeasonStore) GetSeasonsByGuild(guildID int64) ([]*models.Season, error) {
+	return f.seasons, nil
+}
+
+// CloseSeason writes the snapshot, the close and every reset together like close_season,
+// or nothing at all when closeFails is set
+func (f *fakeSeasonStore) CloseSeason(record models.SeasonCloseRecord) (*models.Season, error) {
+	if f.closeFails > 0 {
+		f.closeFails--
+		return nil, errors.New("connection reset")
+	}
+	season := f.seasons[record.SeasonID-1]
+	if !season.IsActive() {
+		return nil, errors.New("season is not active")
+	}
+	season.Status = models.SeasonStatusClosed
+	season.SoftResetMuFactor = record.MuFactor
+	season.SoftResetSigmaFactor = record.SigmaFactor
+	f.snapshots = append(f.snapshots, record.Snapshot...)
+	for _, rating := range record.Ratings {
+		f.ratings.UpsertEffectiveMMR(rating)
+	}
+	f.ratings.history = append(f.ratings.history, record.History...)
+	for _, rating := range record.PlaylistRatings {
+		f.playlists.UpsertPlaylistRating(rating)
+	}
+	return season, nil
+}
+
+func (f *fakeSeasonStore) GetLeaderboardSnapshot(seasonID int64) ([]models.SeasonLeaderboardEntry, error) {
+	return f.snapshots, nil
+}
+
+// fakeRolloverStore claims a game season and rolls trackers together like roll_tracker_seasons,
+// or writes nothing when rollFails is set
+type fakeRolloverStore struct {
+	rollovers []*models.TrackerSeasonRollover
+	trackers  int
+	rolls     int
+	rollFails int
+}
+
+func (f *fakeRolloverStore) FindLatestTrackerRollover() (*models.TrackerSeasonRollover, error) {
+	var latest *models.TrackerSeasonRollover
+	for _, rollover := range f.rollovers {
+		if latest == nil || rollover.GameSeason > latest.GameSeason {
+			latest = rollover
+		}
+	}
+	return latest, nil
+}
+
+func (f *fakeRolloverStore) RollTrackerSeasons(gameSeason int, rolledByUserID *int64) (*models.TrackerSeasonRollover, error) {
+	if f.rollFails > 0 {
+		f.rollFails--
+		return nil, errors.New("connection reset")
+	}
+	for _, rollover := range f.rollovers {
+		if rollover.GameSeason == gameSeason {
+			return nil, errors.New("duplicate key value violates unique constraint")
+		}
+	}
+	f.rolls++
+	rollover := &models.TrackerSeasonRollover{GameSeason: gameSeason, TrackersRolled: f.trackers, RolledByUserID: rolledByUserID}
+	f.rollovers = append(f.rollovers, rollover)
+	return rollover, nil
+}
+
+func newTestSeasonService() (*SeasonService, *fakeSeasonStore, *fakeRatingStore, *fakeRolloverStore) {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333},
+		Season:    config.SeasonConfig{SoftResetMuFactor: 0.25, SoftResetSigmaFactor: 0.5},
+	}
+	ratings := newFakeRatingStore()
+	playlists := &fakePlaylistRatings{ratings: make(map[string]*models.PlayerPlaylistRating)}
+	seasons := &fakeSeasonStore{ratings: ratings, playlists: playlists}
+	rollovers := &fakeRolloverStore{trackers: 3}
+
+	service := &SeasonService{
+		seasonRepo:   seasons,
+		ratingRepo:   ratings,
+		playlistRepo: playlists,
+		rolloverRepo: rollovers,
+		config:       cfg,
+	}
+	return service, seasons, ratings, rollovers
+}
+
+func TestApplySoftReset(t *testing.T) {
+	service, _, _, _ := newTestSeasonService()
+	reset := service.DefaultSoftReset()
+
+	high := service.ApplySoftReset(TrueSkillRating{Mu: 1400, Sigma: 2.5}, reset)
+	if math.Abs(high.Mu-1300) > 0.001 {
+		t.Errorf("mu should move a quarter of the way to 1000, got %.3f", high.Mu)
+	}
+	if math.Abs(high.Sigma-5.4165) > 0.001 {
+		t.Errorf("sigma should move halfway to 8.333, got %.4f", high.Sigma)
+	}
+
+	low := service.ApplySoftReset(TrueSkillRating{Mu: 600, Sigma: 4}, reset)
+	if math.Abs(low.Mu-700) > 0.001 {
+		t.Errorf("low players should be pulled up toward 1000, got %.3f", low.Mu)
+	}
+
+	uncertain := service.ApplySoftReset(TrueSkillRating{Mu: 1000, Sigma: 9}, reset)
+	if uncertain.Sigma != 9 {
+		t.Errorf("sigma above the maximum should not shrink, got %.3f", uncertain.Sigma)
+	}
+
+	if err := (SoftReset{MuFactor: 1.5}).Validate(); !errors.Is(err, ErrInvalidSoftReset) {
+		t.Errorf("expected ErrInvalidSoftReset for a factor above 1, got %v", err)
+	}
+}
+
+func TestStartSeasonNumbersAndSingleActive(t *testing.T) {
+	service, _, _, _ := newTestSeasonService()
+
+	first, err := service.StartSeason(1, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Number != 1 || first.Name != "Season 1" {
+		t.Errorf("expected Season 1, got %d %q", first.Number, first.Name)
+	}
+
+	if _, err := service.StartSeason(1, "Overlap"); !errors.Is(err, ErrSeasonAlreadyActive) {
+		t.Errorf("expected ErrSeasonAlreadyActive, got %v", err)
+	}
+
+	if _, err := service.CloseSeason(1, CloseSeasonOptions{}); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	second, err := service.StartSeason(1, "Summer Split")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Number != 2 || second.Name != "Summer Split" {
+		t.Errorf("expected season 2 named Summer Split, got %d %q", second.Number, second.Name)
+	}
+}
+
+func TestCloseSeasonSnapshotsAndResets(t *testing.T) {
+	service, seasons, ratings, rollovers := newTestSeasonService()
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 1, TrueSkillMu: 1200, TrueSkillSigma: 3, GamesPlayed: 40}
+	ratings.effective[2] = &models.PlayerEffectiveMMR{UserID: 2, GuildID: 1, TrueSkillMu: 1500, TrueSkillSigma: 3, GamesPlayed: 50}
+
+	if _, err := service.CloseSeason(1, CloseSeasonOptions{}); !errors.Is(err, ErrNoActiveSeason) {
+		t.Fatalf("expected ErrNoActiveSeason, got %v", err)
+	}
+
+	if _, err := service.StartSeason(1, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reset := service.DefaultSoftReset()
+	result, err := service.CloseSeason(1, CloseSeasonOptions{SoftReset: &reset})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Season.IsActive() || *result.Season.SoftResetMuFactor != 0.25 {
+		t.Errorf("season should be closed with the applied factors recorded")
+	}
+	if len(seasons.snapshots) != 2 || seasons.snapshots[0].UserID != 2 || seasons.snapshots[0].Rank != 1 {
+		t.Fatalf("snapshot should rank user 2 first, got %+v", seasons.snapshots)
+	}
+	if seasons.snapshots[0].TrueSkillMu != 1500 {
+		t.Errorf("snapshot should keep pre-reset values, got %.3f", seasons.snapshots[0].TrueSkillMu)
+	}
+
+	if len(result.Resets) != 2 || len(ratings.history) != 2 {
+		t.Fatalf("expected 2 resets with history, got %d/%d", len(result.Resets), len(ratings.history))
+	}
+	for _, row := range ratings.history {
+		if row.ChangeReason != models.ChangeReasonSeasonReset {
+			t.Errorf("expected season_reset history, got %s", row.ChangeReason)
+		}
+	}
+	if got := ratings.effective[2].TrueSkillMu; math.Abs(got-1375) > 0.001 {
+		t.Errorf("expected user 2 reset to 1375, got %.3f", got)
+	}
+	if ratings.effective[2].GamesPlayed != 50 {
+		t.Errorf("a reset should not change games played")
+	}
+
+	if rollovers.rolls != 0 {
+		t.Errorf("closing a league season should not roll trackers, got %d rolls", rollovers.rolls)
+	}
+}
+
+func TestCloseSeasonResetsPlaylistRatings(t *testing.T) {
+	service, seasons, ratings, _ := newTestSeasonService()
+	lastPlayed := time.Now().AddDate(0, 0, -10)
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 1, TrueSkillMu: 1200, TrueSkillSigma: 3}
+	seasons.playlists.ratings[playlistRatingKey(1, models.RatingPlaylistTwos)] = &models.PlayerPlaylistRating{
+		UserID: 1, GuildID: 1, Playlist: models.RatingPlaylistTwos, TrueSkillMu: 1400, TrueSkillSigma: 2.5, GamesPlayed: 30, LastUpdated: lastPlayed,
+	}
+
+	if _, err := service.StartSeason(1, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reset := service.DefaultSoftReset()
+	result, err := service.CloseSeason(1, CloseSeasonOptions{SoftReset: &reset})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := service.ApplySoftReset(TrueSkillRating{Mu: 1400, Sigma: 2.5}, reset)
+	twos := seasons.playlists.ratings[playlistRatingKey(1, models.RatingPlaylistTwos)]
+	if twos.TrueSkillMu != want.Mu || twos.TrueSkillSigma != want.Sigma {
+		t.Errorf("expected the 2v2 rating reset to %.3f/%.3f, got %.3f/%.3f", want.Mu, want.Sigma, twos.TrueSkillMu, twos.TrueSkillSigma)
+	}
+	if twos.GamesPlayed != 30 || !twos.LastUpdated.Equal(lastPlayed) {
+		t.Errorf("a reset should keep games played and last updated, got %+v", twos)
+	}
+	if len(result.PlaylistResets) != 1 || result.PlaylistResets[0].Playlist != models.RatingPlaylistTwos || result.PlaylistResets[0].MuBefore != 1400 {
+		t.Errorf("expected the 2v2 reset reported, got %+v", result.PlaylistResets)
+	}
+}
+
+func TestRollTrackerSeasonsOncePerGameSeason(t *testing.T) {
+	service, _, _, rollovers := newTestSeasonService()
+
+	if _, err := service.RollTrackerSeasons(0, nil); !errors.Is(err, ErrInvalidGameSeason) {
+		t.Errorf("expected ErrInvalidGameSeason, got %v", err)
+	}
+
+	result, err := service.RollTrackerSeasons(14, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rollovers.rolls != 1 || result.TrackersRolled != 3 || result.Rollover.TrackersRolled != 3 {
+		t.Errorf("trackers should be rolled once and the count recorded, got %d rolls and %+v", rollovers.rolls, result)
+	}
+
+	// A second guild closing its season, or a retry, must not roll the same game season again
+	for _, gameSeason := range []int{14, 13} {
+		if _, err := service.RollTrackerSeasons(gameSeason, nil); !errors.Is(err, ErrTrackersRolled) {
+			t.Errorf("game season %d: expected ErrTrackersRolled, got %v", gameSeason, err)
+		}
+	}
+	if rollovers.rolls != 1 {
+		t.Errorf("trackers should not roll again, got %d rolls", rollovers.rolls)
+	}
+
+	if _, err := service.RollTrackerSeasons(15, nil); err != nil || rollovers.rolls != 2 {
+		t.Errorf("the next game season should roll, got %d rolls (%v)", rollovers.rolls, err)
+	}
+	if latest, _ := service.GetLatestTrackerRollover(); latest == nil || latest.GameSeason != 15 {
+		t.Errorf("expected game season 15 as the latest rollover, got %+v", latest)
+	}
+}
+
+func TestRollTrackerSeasonsRetriesAfterAFailedRoll(t *testing.T) {
+	service, _, _, rollovers := newTestSeasonService()
+
+	rollovers.rollFails = 1
+	if _, err := service.RollTrackerSeasons(14, nil); err == nil {
+		t.Fatal("expected the failed roll to be reported")
+	}
+	if latest, _ := service.GetLatestTrackerRollover(); latest != nil {
+		t.Fatalf("a failed roll should leave the game season unclaimed, got %+v", latest)
+	}
+
+	result, err := service.RollTrackerSeasons(14, nil)
+	if err != nil {
+		t.Fatalf("retrying the roll should succeed, got %v", err)
+	}
+	if result.TrackersRolled != 3 || rollovers.rolls != 1 {
+		t.Errorf("expected the retry to roll every tracker once, got %+v after %d rolls", result, rollovers.rolls)
+	}
+}
+
+func TestCloseSeasonRetriesAfterAFailedClose(t *testing.T) {
+	service, seasons, ratings, _ := newTestSeasonService()
+	ratings.effective[1] = &models.PlayerEffectiveMMR{UserID: 1, GuildID: 1, TrueSkillMu: 1200, TrueSkillSigma: 3, GamesPlayed: 40}
+
+	if _, err := service.StartSeason(1, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reset := service.DefaultSoftReset()
+	seasons.closeFails = 1
+	if _, err := service.CloseSeason(1, CloseSeasonOptions{SoftReset: &reset}); err == nil {
+		t.Fatal("expected the failed close to be reported")
+	}
+	if ratings.effective[1].TrueSkillMu != 1200 || len(ratings.history) != 0 {
+		t.Fatalf("a failed close should leave ratings untouched, got %.3f with %d history rows", ratings.effective[1].TrueSkillMu, len(ratings.history))
+	}
+
+	result, err := service.CloseSeason(1, CloseSeasonOptions{SoftReset: &reset})
+	if err != nil {
+		t.Fatalf("retrying the close should succeed, got %v", err)
+	}
+	if result.Season.IsActive() || len(seasons.snapshots) != 1 {
+		t.Errorf("expected a closed season with one snapshot row, got %+v and %d rows", result.Season, len(seasons.snapshots))
+	}
+	if got := ratings.effective[1].TrueSkillMu; math.Abs(got-1150) > 0.001 || len(ratings.history) != 1 {
+		t.Errorf("expected the retry to reset user 1 once to 1150, got %.3f with %d history rows", got, len(ratings.history))
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoSeedTrackers is returned when a player has no valid trackers to explain a seed from
+var ErrNoSeedTrackers = errors.New("no valid trackers")
+
+// SeedExplanation lays out every step from a player's trackers to their seeded μ and σ
+// Skill carries each playlist's effective MMR, percentile and normalized skill along with the
+// weights used to combine them; Uncertainty carries each σ factor.
+type SeedExplanation struct {
+	DiscordID   string                  `json:"discordId"`
+	Engine      string                  `json:"engine"`
+	Trackers    int                     `json:"trackers"` // valid trackers combined into Inputs
+	Inputs      *TrackerData            `json:"inputs"`
+	Skill       *PercentileSkillResult  `json:"skill"`
+	Uncertainty *UncertaintyExplanation `json:"uncertainty"`
+	Mu          float64                 `json:"mu"`
+	Sigma       float64                 `json:"sigma"`
+	Current     *StoredRating           `json:"current,omitempty"`
+}
+
+// StoredRating is the μ/σ saved for the player, which moves away from the seed as matches are played
+type StoredRating struct {
+	Mu          float64   `json:"mu"`
+	Sigma       float64   `json:"sigma"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// ExplainUserRating recalculates a user's seed from their current trackers without saving it
+func (s *UserTrueSkillService) ExplainUserRating(discordID string) (*SeedExplanation, error) {
+	trackers, err := s.getUserTrackersForTrueSkill(discordID)
+	if err != nil {
+		return nil, err
+	}
+	if len(trackers) == 0 {
+		return nil, fmt.Errorf("%w for user %s", ErrNoSeedTrackers, discordID)
+	}
+
+	trackerData, err := s.dataTransformationService.AggregateTrackers(trackers, s.config.MMR.TrackerAggregation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare tracker data: %w", err)
+	}
+
+	explanation, err := s.ExplainTrackerData(trackerData)
+	if err != nil {
+		return nil, err
+	}
+	explanation.Trackers = len(trackers)
+
+	if user, err := s.userRepo.FindUserByDiscordID(discordID); err == nil {
+		explanation.Current = &StoredRating{
+			Mu:          user.TrueSkillMu,
+			Sigma:       user.TrueSkillSigma,
+			LastUpdated: user.TrueSkillLastUpdated,
+		}
+	}
+
+	return explanation, nil
+}
+
+// ExplainTrackerData seeds a rating from tracker data and keeps every intermediate value
+func (s *UserTrueSkillService) ExplainTrackerData(trackerData *TrackerData) (*SeedExplanation, error) {
+	if err := s.dataTransformationService.ValidateTrackerData(trackerData); err != nil {
+		return nil, fmt.Errorf("invalid tracker data: %w", err)
+	}
+
+	seed, err := s.calculateTrueSkillValues(trackerData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate TrueSkill: %w", err)
+	}
+
+	return &SeedExplanation{
+		DiscordID:   trackerData.DiscordID,
+		Engine:      s.engine.Name(),
+		Trackers:    1,
+		Inputs:      trackerData,
+		Skill:       seed.SkillResult,
+		Uncertainty: seed.Uncertainty,
+		Mu:          seed.Mu,
+		Sigma:       seed.Sigma,
+	}, nil
+}
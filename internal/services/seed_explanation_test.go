@@ -0,0 +1,61 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+)
+
+func TestExplainTrackerDataMatchesSeed(t *testing.T) {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333},
+		MMR:       config.MMRConfig{OnesWeight: 1.0, TwosWeight: 1.5, ThreesWeight: 1.2},
+	}
+	converter := NewPercentileConverter(cfg)
+	uncertainty := NewEnhancedUncertaintyCalculator(cfg, converter)
+	service := &UserTrueSkillService{
+		engine:                    NewTrueSkillEngine(NewMMRCalculator(cfg, converter), uncertainty, cfg),
+		dataTransformationService: NewDataTransformationService(),
+		config:                    cfg,
+	}
+
+	trackerData := &TrackerData{
+		DiscordID:       playerID(1),
+		TwosCurrentPeak: 1250, TwosCurrentGames: 400, TwosPreviousPeak: 1150, TwosPreviousGames: 200,
+		ThreesCurrentPeak: 1050, ThreesCurrentGames: 5,
+		LastUpdated: time.Now(),
+	}
+
+	explanation, err := service.ExplainTrackerData(trackerData)
+	if err != nil {
+		t.Fatalf("ExplainTrackerData failed: %v", err)
+	}
+
+	seed := service.CalculateTrueSkillFromTrackerData(trackerData)
+	if explanation.Mu != seed.TrueSkillResult.Mu || explanation.Sigma != seed.TrueSkillResult.Sigma {
+		t.Errorf("explanation μ/σ %.3f/%.3f differ from the seed %.3f/%.3f",
+			explanation.Mu, explanation.Sigma, seed.TrueSkillResult.Mu, seed.TrueSkillResult.Sigma)
+	}
+
+	twos := explanation.Skill.Breakdown["twos"]
+	if twos.Percentile == nil || twos.NormalizedSkill == nil {
+		t.Fatalf("twos should be counted with a percentile, got %+v", twos)
+	}
+	if got := converter.PercentileToNormalizedSkill(*twos.Percentile); got != *twos.NormalizedSkill {
+		t.Errorf("normalized skill %.3f does not follow from percentile %.3f", *twos.NormalizedSkill, *twos.Percentile)
+	}
+	if threes := explanation.Skill.Breakdown["threes"]; threes.Percentile != nil {
+		t.Errorf("threes has too few games to count, got percentile %.3f", *threes.Percentile)
+	}
+
+	factors := explanation.Uncertainty.Factors
+	product := factors.Experience * factors.Diversity * factors.Consistency * factors.Recency * factors.PeakPerformance * factors.DataQuality
+	if math.Abs(product-factors.Combined) > 1e-9 {
+		t.Errorf("combined factor %.6f is not the product of the factors %.6f", factors.Combined, product)
+	}
+	want := math.Round((8.333-factors.Combined*(8.333-2.5))*1000) / 1000
+	if explanation.Uncertainty.Sigma != want || explanation.Sigma != want {
+		t.Errorf("expected σ %.3f from the combined factor, got %.3f", want, explanation.Sigma)
+	}
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// Smurf detection signals
+const (
+	SmurfSignalPeakDrop        = "peak_drop"        // current season far below the all-time peak
+	SmurfSignalLowGames        = "low_games"        // a high peak reached in very few games
+	SmurfSignalAccountMismatch = "account_mismatch" // one player's accounts disagree in a playlist
+)
+
+// Smurf flag scoring
+const (
+	// A signal scores SmurfThresholdScore at its threshold and SmurfMaxScore at twice it
+	SmurfThresholdScore = 50.0
+	SmurfMaxScore       = 100.0
+	// Every flag after a player's strongest adds this much to their score
+	SmurfExtraFlagScore = 10.0
+)
+
+// smurfPlaylistNames labels the tracker playlists in flag reasons
+var smurfPlaylistNames = map[string]string{"ones": "1v1", "twos": "2v2", "threes": "3v3"}
+
+// SmurfUserSource interface for naming the players whose trackers are checked
+type SmurfUserSource interface {
+	GetAllUsers(activeOnly bool) ([]*models.User, error)
+}
+
+// SmurfTrackerSource interface for listing the trackers to check
+type SmurfTrackerSource interface {
+	GetAllTrackers(validOnly bool) ([]*models.UserTracker, error)
+}
+
+// SmurfFlag is one suspicious pattern in a player's tracker data
+type SmurfFlag struct {
+	Signal     string   `json:"signal"`
+	Playlist   string   `json:"playlist"` // 1v1, 2v2 or 3v3
+	Score      float64  `json:"score"`
+	Reason     string   `json:"reason"`
+	TrackerIDs []int    `json:"tracker_ids"`
+	URLs       []string `json:"urls"`
+}
+
+// SmurfSuspect is a player with at least one flag, for moderator review
+type SmurfSuspect struct {
+	DiscordID string      `json:"discord_id"`
+	Name      string      `json:"name"`
+	UserID    int         `json:"user_id,omitempty"` // 0 for trackers without a user
+	Trackers  int         `json:"trackers"`
+	Score     float64     `json:"score"`
+	Flags     []SmurfFlag `json:"flags"`
+}
+
+// SmurfReport lists flagged players, highest score first
+type SmurfReport struct {
+	Players    int                    `json:"players"` // players with at least one valid tracker
+	Flagged    int                    `json:"flagged"` // players with any flag, before the score filter
+	MinScore   float64                `json:"min_score"`
+	Thresholds config.IntegrityConfig `json:"thresholds"`
+	Suspects   []SmurfSuspect         `json:"suspects"`
+}
+
+// SmurfDetector flags tracker data that looks like a smurf or sandbagging account.
+// Service Responsibilities:
+// - Comparing each playlist's current-season peak with the all-time peak
+// - Flagging high peaks reached in very few games
+// - Comparing a player's accounts with each other, playlist by playlist
+// - Scoring every flag and explaining it for the moderation review page
+type SmurfDetector struct {
+	trackerRepo               SmurfTrackerSource
+	userRepo                  SmurfUserSource
+	dataTransformationService *DataTransformationService
+	config                    *config.Config
+}
+
+func NewSmurfDetector(
+	trackerRepo *repositories.TrackerRepository,
+	userRepo *repositories.UserRepository,
+	dataTransformationService *DataTransformationService,
+	config *config.Config,
+) *SmurfDetector {
+	return &SmurfDetector{
+		trackerRepo:               trackerRepo,
+		userRepo:                  userRepo,
+		dataTransformationService: dataTransformationService,
+		config:                    config,
+	}
+}
+
+// Detect checks every valid tracker and returns the players scoring at least minScore
+func (d *SmurfDetector) Detect(minScore float64) (*SmurfReport, error) {
+	trackers, err := d.trackerRepo.GetAllTrackers(true)
+	if err != nil {
+		return nil, err
+	}
+	users, err := d.userRepo.GetAllUsers(false)
+	if err != nil {
+		return nil, err
+	}
+	usersByDiscordID := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		usersByDiscordID[user.DiscordID] = user
+	}
+
+	trackersByPlayer := make(map[string][]*models.UserTracker)
+	var players []string
+	for _, tracker := range trackers {
+		if _, seen := trackersByPlayer[tracker.DiscordID]; !seen {
+			players = append(players, tracker.DiscordID)
+		}
+		trackersByPlayer[tracker.DiscordID] = append(trackersByPlayer[tracker.DiscordID], tracker)
+	}
+
+	report := &SmurfReport{
+		Players:    len(players),
+		MinScore:   minScore,
+		Thresholds: d.config.Integrity,
+		Suspects:   []SmurfSuspect{},
+	}
+	for _, discordID := range players {
+		flags := d.CheckTrackers(trackersByPlayer[discordID])
+		if len(flags) == 0 {
+			continue
+		}
+		report.Flagged++
+
+		suspect := SmurfSuspect{
+			DiscordID: discordID,
+			Name:      discordID,
+			Trackers:  len(trackersByPlayer[discordID]),
+			Score:     smurfScore(flags),
+			Flags:     flags,
+		}
+		if user, ok := usersByDiscordID[discordID]; ok {
+			suspect.Name, suspect.UserID = user.Name, user.ID
+		}
+		if suspect.Score >= minScore {
+			report.Suspects = append(report.Suspects, suspect)
+		}
+	}
+
+	sort.SliceStable(report.Suspects, func(i, j int) bool {
+		if report.Suspects[i].Score != report.Suspects[j].Score {
+			return report.Suspects[i].Score > report.Suspects[j].Score
+		}
+		return report.Suspects[i].Name < report.Suspects[j].Name
+	})
+
+	log.Printf("SmurfDetector: Checked %d players, %d flagged, %d at or above score %.0f",
+		report.Players, report.Flagged, len(report.Suspects), minScore)
+
+	return report, nil
+}
+
+// CheckTrackers returns the flags raised by one player's trackers, strongest first
+func (d *SmurfDetector) CheckTrackers(trackers []*models.UserTracker) []SmurfFlag {
+	thresholds := d.config.Integrity
+	var flags []SmurfFlag
+
+	for _, playlist := range trackerPlaylists {
+		name := smurfPlaylistNames[playlist]
+
+		type account struct {
+			tracker *models.UserTracker
+			peak    int // best of the current and previous season
+		}
+		var accounts []account
+
+		for _, tracker := range trackers {
+			data := d.dataTransformationService.trackerPlaylist(tracker, playlist)
+
+			if thresholds.PeakDrop > 0 && data.AllTimePeak > 0 && data.CurrentPeak > 0 {
+				if drop := data.AllTimePeak - data.CurrentPeak; drop >= thresholds.PeakDrop {
+					flags = append(flags, newSmurfFlag(SmurfSignalPeakDrop, name, thresholdScore(drop, thresholds.PeakDrop),
+						fmt.Sprintf("%s current-season peak %d is %d below the all-time peak %d", name, data.CurrentPeak, drop, data.AllTimePeak),
+						tracker))
+				}
+			}
+
+			peak := max(data.CurrentPeak, data.PreviousPeak)
+			games := data.CurrentGames + data.PreviousGames
+			if thresholds.HighPeak > 0 && thresholds.LowGames > 0 && peak >= thresholds.HighPeak && games < thresholds.LowGames {
+				score := SmurfThresholdScore + (SmurfMaxScore-SmurfThresholdScore)*float64(thresholds.LowGames-games)/float64(thresholds.LowGames)
+				flags = append(flags, newSmurfFlag(SmurfSignalLowGames, name, roundScore(score),
+					fmt.Sprintf("%s peak %d reached in %d games over two seasons", name, peak, games),
+					tracker))
+			}
+
+			if peak > 0 {
+				accounts = append(accounts, account{tracker: tracker, peak: peak})
+			}
+		}
+
+		if thresholds.AccountSpread <= 0 || len(accounts) < 2 {
+			continue
+		}
+		sort.SliceStable(accounts, func(i, j int) bool { return accounts[i].peak > accounts[j].peak })
+		highest, lowest := accounts[0], accounts[len(accounts)-1]
+		if spread := highest.peak - lowest.peak; spread >= thresholds.AccountSpread {
+			flags = append(flags, newSmurfFlag(SmurfSignalAccountMismatch, name, thresholdScore(spread, thresholds.AccountSpread),
+				fmt.Sprintf("%s peaks differ by %d across %d accounts: %d on %s, %d on %s",
+					name, spread, len(accounts), highest.peak, trackerAccountName(highest.tracker), lowest.peak, trackerAccountName(lowest.tracker)),
+				highest.tracker, lowest.tracker))
+		}
+	}
+
+	sort.SliceStable(flags, func(i, j int) bool { return flags[i].Score > flags[j].Score })
+	return flags
+}
+
+// newSmurfFlag builds a flag pointing at the trackers it was raised on
+func newSmurfFlag(signal, playlist string, score float64, reason string, trackers ...*models.UserTracker) SmurfFlag {
+	flag := SmurfFlag{Signal: signal, Playlist: playlist, Score: score, Reason: reason}
+	for _, tracker := range trackers {
+		flag.TrackerIDs = append(flag.TrackerIDs, tracker.ID)
+		flag.URLs = append(flag.URLs, tracker.URL)
+	}
+	return flag
+}
+
+// thresholdScore scores a value from SmurfThresholdScore at the threshold to SmurfMaxScore at twice it
+func thresholdScore(value, threshold int) float64 {
+	return roundScore(math.Min(SmurfMaxScore, SmurfThresholdScore*float64(value)/float64(threshold)))
+}
+
+// smurfScore combines a player's flags: the strongest flag plus a little for each other one
+func smurfScore(flags []SmurfFlag) float64 {
+	if len(flags) == 0 {
+		return 0
+	}
+	score := flags[0].Score
+	for _, flag := range flags[1:] {
+		score = math.Max(score, flag.Score)
+	}
+	return roundScore(math.Min(SmurfMaxScore, score+SmurfExtraFlagScore*float64(len(flags)-1)))
+}
+
+// roundScore rounds a score to one decimal place
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
+
+// trackerAccountName shows a tracker as platform/account, falling back to the URL
+func trackerAccountName(tracker *models.UserTracker) string {
+	if platform, account, ok := tracker.ParsePlatformAccount(); ok {
+		return platform + "/" + account
+	}
+	return strings.TrimSpace(tracker.URL)
+}
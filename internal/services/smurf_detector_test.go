@@ -0,0 +1,86 @@
+package services
+
+import (
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+func newTestSmurfDetector(trackers []*models.UserTracker) *SmurfDetector {
+	return &SmurfDetector{
+		trackerRepo: &fakeTrackerSource{trackers: trackers},
+		userRepo: &fakeStatsUsers{users: []*models.User{
+			{ID: 1, DiscordID: playerID(1), Name: "Steady", Active: true},
+			{ID: 2, DiscordID: playerID(2), Name: "Sandbagger", Active: true},
+			{ID: 3, DiscordID: playerID(3), Name: "Fresh", Active: true},
+			{ID: 4, DiscordID: playerID(4), Name: "Alts", Active: true},
+		}},
+		dataTransformationService: NewDataTransformationService(),
+		config: &config.Config{
+			Integrity: config.IntegrityConfig{PeakDrop: 300, HighPeak: 1200, LowGames: 50, AccountSpread: 400},
+		},
+	}
+}
+
+func TestSmurfDetectorFlagsEachSignal(t *testing.T) {
+	detector := newTestSmurfDetector([]*models.UserTracker{
+		{ID: 1, DiscordID: playerID(1), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/steady/overview",
+			TwosCurrentSeasonPeak: 1100, TwosPreviousSeasonPeak: 1150, TwosAllTimePeak: 1250, TwosCurrentSeasonGames: 300, TwosPreviousSeasonGames: 400},
+		{ID: 2, DiscordID: playerID(2), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/sandbag/overview",
+			TwosCurrentSeasonPeak: 900, TwosPreviousSeasonPeak: 950, TwosAllTimePeak: 1500, TwosCurrentSeasonGames: 200, TwosPreviousSeasonGames: 300},
+		{ID: 3, DiscordID: playerID(3), URL: "https://rocketleague.tracker.network/rocket-league/profile/steam/fresh/overview",
+			ThreesCurrentSeasonPeak: 1400, ThreesAllTimePeak: 1400, ThreesCurrentSeasonGames: 20},
+		{ID: 4, DiscordID: playerID(4), URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/main/overview",
+			TwosCurrentSeasonPeak: 1500, TwosAllTimePeak: 1550, TwosCurrentSeasonGames: 400, TwosPreviousSeasonGames: 500},
+		{ID: 5, DiscordID: playerID(4), URL: "https://rocketleague.tracker.network/rocket-league/profile/psn/alt/overview",
+			TwosCurrentSeasonPeak: 800, TwosAllTimePeak: 900, TwosCurrentSeasonGames: 300, TwosPreviousSeasonGames: 200},
+	})
+
+	report, err := detector.Detect(0)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if report.Players != 4 || report.Flagged != 3 || len(report.Suspects) != 3 {
+		t.Fatalf("expected 3 of 4 players flagged, got %d of %d with %d listed", report.Flagged, report.Players, len(report.Suspects))
+	}
+
+	expected := []struct {
+		name   string
+		signal string
+		score  float64
+	}{
+		{"Sandbagger", SmurfSignalPeakDrop, 100},
+		{"Alts", SmurfSignalAccountMismatch, 87.5},
+		{"Fresh", SmurfSignalLowGames, 80},
+	}
+	for i, want := range expected {
+		suspect := report.Suspects[i]
+		if suspect.Name != want.name || len(suspect.Flags) != 1 || suspect.Flags[0].Signal != want.signal || suspect.Score != want.score {
+			t.Errorf("suspect %d: expected %s flagged %s at %.1f, got %s %+v", i, want.name, want.signal, want.score, suspect.Name, suspect)
+		}
+		if suspect.Flags[0].Reason == "" || len(suspect.Flags[0].URLs) == 0 {
+			t.Errorf("%s: expected a reason and the trackers behind the flag, got %+v", want.name, suspect.Flags[0])
+		}
+	}
+	if alts := report.Suspects[1]; alts.Trackers != 2 || len(alts.Flags[0].TrackerIDs) != 2 {
+		t.Errorf("expected the mismatch to point at both accounts, got %+v", alts)
+	}
+
+	filtered, err := detector.Detect(85)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if filtered.Flagged != 3 || len(filtered.Suspects) != 2 {
+		t.Errorf("expected the score filter to leave 2 of 3 flagged players, got %d of %d", len(filtered.Suspects), filtered.Flagged)
+	}
+}
+
+func TestSmurfScoreAddsForExtraFlags(t *testing.T) {
+	flags := []SmurfFlag{{Score: 60}, {Score: 75}, {Score: 50}}
+	if score := smurfScore(flags); score != 95 {
+		t.Errorf("expected the strongest flag plus 10 per other flag, got %.1f", score)
+	}
+	if score := smurfScore(append(flags, SmurfFlag{Score: 90})); score != SmurfMaxScore {
+		t.Errorf("expected the score capped at %.0f, got %.1f", SmurfMaxScore, score)
+	}
+}
@@ -0,0 +1,474 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidResult is returned for league results that cannot be recorded
+var ErrInvalidResult = errors.New("invalid league result")
+
+// LeagueResultStore interface for persisting league series results
+type LeagueResultStore interface {
+	CreateResult(request models.LeagueResultCreateRequest) (*models.LeagueResult, error)
+	GetResultsByDivision(divisionID int64) ([]models.LeagueResult, error)
+}
+
+// StandingsDivisionStore interface for the divisions and fixtures results are recorded against
+type StandingsDivisionStore interface {
+	FindDivisionByID(divisionID int64) (*models.Division, error)
+	GetScheduledMatches(divisionID int64) ([]models.ScheduledMatch, error)
+	UpdateScheduledMatchStatus(matchID int64, status string) error
+}
+
+// StandingsTeamStore interface for a division's teams and their rosters
+type StandingsTeamStore interface {
+	GetTeamsByDivision(divisionID int64) ([]*models.Team, error)
+	GetTeamMembers(teamID int64) ([]models.TeamMember, error)
+}
+
+// StandingsRow is one team's line in the division table
+type StandingsRow struct {
+	Rank         int     `json:"rank"`
+	TeamID       int64   `json:"team_id"`
+	TeamName     string  `json:"team_name"`
+	Played       int     `json:"played"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	GamesWon     int     `json:"games_won"`
+	GamesLost    int     `json:"games_lost"`
+	GameDiff     int     `json:"game_differential"`
+	GoalsFor     int     `json:"goals_for"`
+	GoalsAgainst int     `json:"goals_against"`
+	GoalDiff     int     `json:"goal_differential"`
+	TeamMu       float64 `json:"team_mu"`              // average mu of the current roster
+	SOS          float64 `json:"strength_of_schedule"` // average team mu of the opponents played
+	DecidedBy    string  `json:"decided_by,omitempty"` // the tiebreaker that placed the team among teams level on record
+}
+
+// StandingsResult is a recorded series with its team names and series score
+type StandingsResult struct {
+	models.LeagueResult
+	HomeTeamName string `json:"home_team_name"`
+	AwayTeamName string `json:"away_team_name"`
+	HomeSeries   int    `json:"home_series"`
+	AwaySeries   int    `json:"away_series"`
+}
+
+// DivisionStandings is a division's table, its results and the fixtures still to be played
+type DivisionStandings struct {
+	Division     *models.Division  `json:"division"`
+	Tiebreakers  []string          `json:"tiebreakers"`
+	Rows         []StandingsRow    `json:"rows"`
+	Results      []StandingsResult `json:"results"`
+	OpenFixtures []ScheduleFixture `json:"open_fixtures"`
+}
+
+// StandingsService records league results and builds division standings.
+// Service Responsibilities:
+// - Recording series results between teams of a division and completing their fixtures
+// - Tallying series, game and goal records per team
+// - Rating strength of schedule from the opponents' roster mu
+// - Ordering teams level on record by the guild's configured tiebreakers
+type StandingsService struct {
+	resultRepo   LeagueResultStore
+	divisionRepo StandingsDivisionStore
+	teamRepo     StandingsTeamStore
+	guildRepo    GuildConfigStore
+	resolver     RatingResolver
+	config       *config.Config
+}
+
+// NewStandingsService creates a new standings service
+func NewStandingsService(
+	resultRepo *repositories.LeagueResultRepository,
+	divisionRepo *repositories.DivisionRepository,
+	teamRepo *repositories.TeamRepository,
+	guildRepo *repositories.GuildRepository,
+	resolver *PlayerRatingResolver,
+	config *config.Config,
+) *StandingsService {
+	return &StandingsService{
+		resultRepo:   resultRepo,
+		divisionRepo: divisionRepo,
+		teamRepo:     teamRepo,
+		guildRepo:    guildRepo,
+		resolver:     resolver,
+		config:       config,
+	}
+}
+
+// GetStandingsConfig returns the guild's tiebreak order with defaults applied
+func (s *StandingsService) GetStandingsConfig(guildID int64) (models.StandingsConfig, error) {
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return models.StandingsConfig{}, err
+	}
+	return guildConfig.Standings, nil
+}
+
+// UpdateStandingsConfig saves the guild's tiebreak order
+func (s *StandingsService) UpdateStandingsConfig(guildID int64, standings models.StandingsConfig) error {
+	if err := standings.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResult, err)
+	}
+
+	guildConfig, err := s.guildRepo.GetConfig(guildID)
+	if err != nil {
+		return err
+	}
+
+	guildConfig.Standings = standings
+	return s.guildRepo.UpdateConfig(guildID, guildConfig)
+}
+
+// RecordResult records a series between two teams of a division
+// A result for a fixture must be between the fixture's teams and completes it.
+func (s *StandingsService) RecordResult(request models.LeagueResultCreateRequest) (*models.LeagueResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResult, err)
+	}
+
+	division, err := s.divisionRepo.FindDivisionByID(request.DivisionID)
+	if err != nil {
+		return nil, err
+	}
+	if division.GuildID != request.GuildID {
+		return nil, fmt.Errorf("%w: division %d belongs to another guild", ErrInvalidResult, division.ID)
+	}
+
+	teams, err := s.teamRepo.GetTeamsByDivision(division.ID)
+	if err != nil {
+		return nil, err
+	}
+	inDivision := make(map[int64]bool, len(teams))
+	for _, team := range teams {
+		inDivision[team.ID] = true
+	}
+	if !inDivision[request.HomeTeamID] || !inDivision[request.AwayTeamID] {
+		return nil, fmt.Errorf("%w: both teams must be in division %s", ErrInvalidResult, division.Name)
+	}
+
+	if request.ScheduledMatchID != nil {
+		if err := s.checkFixture(request); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.resultRepo.CreateResult(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.ScheduledMatchID != nil {
+		if err := s.divisionRepo.UpdateScheduledMatchStatus(*request.ScheduledMatchID, models.ScheduledMatchCompleted); err != nil {
+			log.Printf("StandingsService: Result %d recorded but fixture %d not completed: %v", result.ID, *request.ScheduledMatchID, err)
+		}
+	}
+
+	log.Printf("StandingsService: Recorded result %d in division %d (team %d won)", result.ID, division.ID, result.WinnerID())
+	return result, nil
+}
+
+// checkFixture verifies the result's fixture is an open match between the result's teams
+func (s *StandingsService) checkFixture(request models.LeagueResultCreateRequest) error {
+	matches, err := s.divisionRepo.GetScheduledMatches(request.DivisionID)
+	if err != nil {
+		return err
+	}
+
+	for _, match := range matches {
+		if match.ID != *request.ScheduledMatchID {
+			continue
+		}
+		if match.Status == models.ScheduledMatchCompleted {
+			return fmt.Errorf("%w: fixture %d already has a result", ErrInvalidResult, match.ID)
+		}
+		if match.AwayTeamID == nil || match.HomeTeamID != request.HomeTeamID || *match.AwayTeamID != request.AwayTeamID {
+			return fmt.Errorf("%w: fixture %d is not between these home and away teams", ErrInvalidResult, match.ID)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: fixture %d is not in this division", ErrInvalidResult, *request.ScheduledMatchID)
+}
+
+// GetResults returns a division's results in the order they were played
+func (s *StandingsService) GetResults(divisionID int64) ([]models.LeagueResult, error) {
+	return s.resultRepo.GetResultsByDivision(divisionID)
+}
+
+// GetStandings builds the division's table with the guild's tiebreak order
+func (s *StandingsService) GetStandings(divisionID int64) (*DivisionStandings, error) {
+	division, err := s.divisionRepo.FindDivisionByID(divisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	standingsConfig, err := s.GetStandingsConfig(division.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.GetTeamsByDivision(divisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.resultRepo.GetResultsByDivision(divisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.divisionRepo.GetScheduledMatches(divisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamMu, err := s.teamStrengths(division.GuildID, teams)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(teams))
+	for _, team := range teams {
+		names[team.ID] = team.Name
+	}
+
+	standings := &DivisionStandings{
+		Division:    division,
+		Tiebreakers: standingsConfig.Tiebreakers,
+		Rows:        computeStandings(teams, results, teamMu, standingsConfig.Tiebreakers),
+	}
+
+	for _, result := range results {
+		home, away := result.SeriesScore()
+		standings.Results = append(standings.Results, StandingsResult{
+			LeagueResult: result,
+			HomeTeamName: names[result.HomeTeamID],
+			AwayTeamName: names[result.AwayTeamID],
+			HomeSeries:   home,
+			AwaySeries:   away,
+		})
+	}
+
+	for _, fixture := range buildScheduleFixtures(teams, matches) {
+		if fixture.AwayTeamID != nil && fixture.Status != models.ScheduledMatchCompleted {
+			standings.OpenFixtures = append(standings.OpenFixtures, fixture)
+		}
+	}
+
+	return standings, nil
+}
+
+// teamStrengths returns each team's average roster mu; teams without a rated roster are left out
+func (s *StandingsService) teamStrengths(guildID int64, teams []*models.Team) (map[int64]float64, error) {
+	rosters := make(map[int64][]string, len(teams))
+	var discordIDs []string
+	for _, team := range teams {
+		members, err := s.teamRepo.GetTeamMembers(team.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			rosters[team.ID] = append(rosters[team.ID], member.DiscordID)
+			discordIDs = append(discordIDs, member.DiscordID)
+		}
+	}
+
+	teamMu := make(map[int64]float64, len(teams))
+	if len(discordIDs) == 0 {
+		return teamMu, nil
+	}
+
+	ratings, err := s.resolver.Resolve(guildID, discordIDs)
+	if err != nil {
+		return nil, err
+	}
+	mu := make(map[string]float64, len(ratings))
+	for _, rating := range ratings {
+		mu[rating.DiscordID] = rating.Rating.Mu
+	}
+
+	for teamID, members := range rosters {
+		var total float64
+		var rated int
+		for _, discordID := range members {
+			if value, ok := mu[discordID]; ok {
+				total += value
+				rated++
+			}
+		}
+		if rated > 0 {
+			teamMu[teamID] = roundRating(total / float64(rated))
+		}
+	}
+	return teamMu, nil
+}
+
+// computeStandings tallies the division's results and ranks the teams
+// Teams are ordered by series wins, then fewest losses; teams still level go through the
+// tiebreakers in order, with team name as the final fallback. Results involving teams
+// outside the division are ignored.
+func computeStandings(teams []*models.Team, results []models.LeagueResult, teamMu map[int64]float64, tiebreakers []string) []StandingsRow {
+	rows := make(map[int64]*StandingsRow, len(teams))
+	ordered := make([]*StandingsRow, 0, len(teams))
+	for _, team := range teams {
+		row := &StandingsRow{TeamID: team.ID, TeamName: team.Name, TeamMu: teamMu[team.ID]}
+		rows[team.ID] = row
+		ordered = append(ordered, row)
+	}
+
+	opponentMu := make(map[int64][]float64, len(teams))
+	var counted []models.LeagueResult
+	for _, result := range results {
+		home, away := rows[result.HomeTeamID], rows[result.AwayTeamID]
+		if home == nil || away == nil {
+			continue
+		}
+		counted = append(counted, result)
+
+		homeGames, awayGames := result.SeriesScore()
+		homeGoals, awayGoals := result.Goals()
+		tallySeries(home, homeGames, awayGames, homeGoals, awayGoals)
+		tallySeries(away, awayGames, homeGames, awayGoals, homeGoals)
+
+		if mu, ok := teamMu[away.TeamID]; ok {
+			opponentMu[home.TeamID] = append(opponentMu[home.TeamID], mu)
+		}
+		if mu, ok := teamMu[home.TeamID]; ok {
+			opponentMu[away.TeamID] = append(opponentMu[away.TeamID], mu)
+		}
+	}
+
+	for teamID, mus := range opponentMu {
+		var total float64
+		for _, mu := range mus {
+			total += mu
+		}
+		rows[teamID].SOS = roundRating(total / float64(len(mus)))
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Wins != ordered[j].Wins {
+			return ordered[i].Wins > ordered[j].Wins
+		}
+		return ordered[i].Losses < ordered[j].Losses
+	})
+
+	var ranked []*StandingsRow
+	for start := 0; start < len(ordered); {
+		end := start + 1
+		for end < len(ordered) && ordered[end].Wins == ordered[start].Wins && ordered[end].Losses == ordered[start].Losses {
+			end++
+		}
+		ranked = append(ranked, breakTies(ordered[start:end], counted, tiebreakers)...)
+		start = end
+	}
+
+	standings := make([]StandingsRow, 0, len(ranked))
+	for i, row := range ranked {
+		row.Rank = i + 1
+		standings = append(standings, *row)
+	}
+	return standings
+}
+
+// tallySeries adds one series to a team's record
+func tallySeries(row *StandingsRow, gamesWon, gamesLost, goalsFor, goalsAgainst int) {
+	row.Played++
+	if gamesWon > gamesLost {
+		row.Wins++
+	} else {
+		row.Losses++
+	}
+	row.GamesWon += gamesWon
+	row.GamesLost += gamesLost
+	row.GameDiff = row.GamesWon - row.GamesLost
+	row.GoalsFor += goalsFor
+	row.GoalsAgainst += goalsAgainst
+	row.GoalDiff = row.GoalsFor - row.GoalsAgainst
+}
+
+// breakTies orders teams level on record with the first tiebreaker that separates them
+// Teams the tiebreaker leaves level start over from the first tiebreaker, so head-to-head
+// is recomputed among just the teams still tied.
+func breakTies(group []*StandingsRow, results []models.LeagueResult, tiebreakers []string) []*StandingsRow {
+	if len(group) < 2 {
+		return group
+	}
+
+	for _, tiebreaker := range tiebreakers {
+		values := make(map[int64]float64, len(group))
+		for _, row := range group {
+			values[row.TeamID] = tiebreakValue(tiebreaker, row, group, results)
+		}
+
+		sorted := append([]*StandingsRow(nil), group...)
+		sort.SliceStable(sorted, func(a, b int) bool {
+			return values[sorted[a].TeamID] > values[sorted[b].TeamID]
+		})
+		if values[sorted[0].TeamID] == values[sorted[len(sorted)-1].TeamID] {
+			continue
+		}
+
+		var ordered []*StandingsRow
+		for start := 0; start < len(sorted); {
+			end := start + 1
+			for end < len(sorted) && values[sorted[end].TeamID] == values[sorted[start].TeamID] {
+				end++
+			}
+			if end-start == 1 {
+				sorted[start].DecidedBy = tiebreaker
+			}
+			ordered = append(ordered, breakTies(sorted[start:end], results, tiebreakers)...)
+			start = end
+		}
+		return ordered
+	}
+
+	sorted := append([]*StandingsRow(nil), group...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].TeamName < sorted[b].TeamName
+	})
+	for _, row := range sorted {
+		row.DecidedBy = "name"
+	}
+	return sorted
+}
+
+// tiebreakValue returns a team's value for a tiebreaker; higher is better
+func tiebreakValue(tiebreaker string, row *StandingsRow, group []*StandingsRow, results []models.LeagueResult) float64 {
+	switch tiebreaker {
+	case models.TiebreakHeadToHead:
+		tied := make(map[int64]bool, len(group))
+		for _, other := range group {
+			tied[other.TeamID] = true
+		}
+		var wins int
+		for _, result := range results {
+			if !tied[result.HomeTeamID] || !tied[result.AwayTeamID] {
+				continue
+			}
+			if result.WinnerID() == row.TeamID {
+				wins++
+			}
+		}
+		return float64(wins)
+	case models.TiebreakGameDifferential:
+		return float64(row.GameDiff)
+	case models.TiebreakGoalDifferential:
+		return float64(row.GoalDiff)
+	case models.TiebreakGoalsFor:
+		return float64(row.GoalsFor)
+	case models.TiebreakStrengthOfSchedule:
+		return row.SOS
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakeLeagueResultStore struct {
+	results []models.LeagueResult
+}
+
+func (f *fakeLeagueResultStore) CreateResult(request models.LeagueResultCreateRequest) (*models.LeagueResult, error) {
+	result := models.LeagueResult{
+		ID: int64(len(f.results) + 1), GuildID: request.GuildID, DivisionID: request.DivisionID, ScheduledMatchID: request.ScheduledMatchID,
+		HomeTeamID: request.HomeTeamID, AwayTeamID: request.AwayTeamID, Games: request.Games,
+	}
+	f.results = append(f.results, result)
+	return &result, nil
+}
+
+func (f *fakeLeagueResultStore) GetResultsByDivision(divisionID int64) ([]models.LeagueResult, error) {
+	return f.results, nil
+}
+
+// fakeStandingsDivisionStore adds fixture completion to the schedule fake
+type fakeStandingsDivisionStore struct {
+	*fakeDivisionStore
+}
+
+func (f *fakeStandingsDivisionStore) UpdateScheduledMatchStatus(matchID int64, status string) error {
+	for i := range f.matches {
+		if f.matches[i].ID == matchID {
+			f.matches[i].Status = status
+			return nil
+		}
+	}
+	return errors.New("fixture not found")
+}
+
+// series builds a result where the home team wins homeWins games 1-0 and loses awayWins games 0-1
+func series(homeTeamID, awayTeamID int64, homeWins, awayWins int) models.LeagueResult {
+	result := models.LeagueResult{DivisionID: 1, HomeTeamID: homeTeamID, AwayTeamID: awayTeamID}
+	for i := 0; i < homeWins; i++ {
+		result.Games = append(result.Games, models.LeagueGame{HomeGoals: 1})
+	}
+	for i := 0; i < awayWins; i++ {
+		result.Games = append(result.Games, models.LeagueGame{AwayGoals: 1})
+	}
+	return result
+}
+
+// newTestStandingsService puts teams 1..teams in division 1 of guild 1 with an open fixture 1 v 2
+func newTestStandingsService(teams int) (*StandingsService, *fakeLeagueResultStore, *fakeStandingsDivisionStore, *fakeRosterStore) {
+	teamStore := &fakeRosterStore{fakeTeamStore: &fakeTeamStore{}}
+	for i := 1; i <= teams; i++ {
+		teamStore.teams = append(teamStore.teams, &models.Team{ID: int64(i), GuildID: 1, DivisionID: int64Ptr(1), Name: "Team " + playerID(i)})
+	}
+	teamStore.teams = append(teamStore.teams, &models.Team{ID: 99, GuildID: 1, Name: "Unplaced"})
+
+	divisions := &fakeStandingsDivisionStore{fakeDivisionStore: &fakeDivisionStore{
+		divisions: map[int64]*models.Division{1: {ID: 1, GuildID: 1, Name: "Premier"}},
+		matches:   []models.ScheduledMatch{{ID: 10, DivisionID: 1, Round: 1, HomeTeamID: 1, AwayTeamID: int64Ptr(2), Status: models.ScheduledMatchScheduled}},
+	}}
+
+	guildConfig := models.GuildConfig{}
+	guildConfig.Standings.Tiebreakers = append([]string(nil), models.DefaultStandingsTiebreakers...)
+
+	results := &fakeLeagueResultStore{}
+	return &StandingsService{
+		resultRepo:   results,
+		divisionRepo: divisions,
+		teamRepo:     teamStore,
+		guildRepo:    &fakeGuildConfigStore{config: guildConfig},
+		resolver:     &fakeRatingResolver{ratings: make(map[string]TrueSkillRating), userIDs: make(map[string]int64)},
+		config:       &config.Config{},
+	}, results, divisions, teamStore
+}
+
+func TestComputeStandingsAppliesTiebreakersInOrder(t *testing.T) {
+	teams := []*models.Team{{ID: 1, Name: "Aces"}, {ID: 2, Name: "Bolts"}, {ID: 3, Name: "Comets"}, {ID: 4, Name: "Drifters"}}
+	// Aces, Bolts and Comets beat each other in a cycle and all sweep Drifters
+	results := []models.LeagueResult{
+		series(1, 2, 3, 0),
+		series(2, 3, 3, 2),
+		series(3, 1, 3, 1),
+		series(1, 4, 3, 0),
+		series(2, 4, 3, 0),
+		series(3, 4, 3, 0),
+	}
+
+	rows := computeStandings(teams, results, nil, []string{models.TiebreakHeadToHead, models.TiebreakGameDifferential, models.TiebreakGoalDifferential})
+
+	// Head-to-head is level in the cycle; game differential leaves Aces and Comets level at +4,
+	// and their own meeting puts Comets ahead
+	want := []struct {
+		teamID    int64
+		decidedBy string
+	}{{3, models.TiebreakHeadToHead}, {1, models.TiebreakHeadToHead}, {2, models.TiebreakGameDifferential}, {4, ""}}
+
+	for i, row := range rows {
+		if row.TeamID != want[i].teamID || row.DecidedBy != want[i].decidedBy || row.Rank != i+1 {
+			t.Errorf("rank %d: got team %d decided by %q, want team %d decided by %q", i+1, row.TeamID, row.DecidedBy, want[i].teamID, want[i].decidedBy)
+		}
+	}
+	if rows[0].Wins != 2 || rows[0].Losses != 1 || rows[0].GameDiff != 4 || rows[3].Played != 3 {
+		t.Errorf("unexpected records: %+v", rows)
+	}
+
+	// Without tiebreakers the level teams fall back to name order
+	rows = computeStandings(teams, results, nil, nil)
+	if rows[0].TeamID != 1 || rows[1].TeamID != 2 || rows[2].TeamID != 3 || rows[0].DecidedBy != "name" {
+		t.Errorf("expected name order Aces, Bolts, Comets, got %+v", rows)
+	}
+}
+
+func TestRecordResultCompletesFixture(t *testing.T) {
+	service, results, divisions, _ := newTestStandingsService(3)
+
+	request := models.LeagueResultCreateRequest{
+		GuildID: 1, DivisionID: 1, ScheduledMatchID: int64Ptr(10), HomeTeamID: 1, AwayTeamID: 2,
+		Games: []models.LeagueGame{{HomeGoals: 3, AwayGoals: 1}, {HomeGoals: 0, AwayGoals: 2}, {HomeGoals: 4, AwayGoals: 2}},
+	}
+	result, err := service.RecordResult(request)
+	if err != nil {
+		t.Fatalf("RecordResult returned error: %v", err)
+	}
+	if result.WinnerID() != 1 || divisions.matches[0].Status != models.ScheduledMatchCompleted {
+		t.Errorf("expected team 1 to win and fixture 10 completed, got winner %d and status %s", result.WinnerID(), divisions.matches[0].Status)
+	}
+
+	if _, err := service.RecordResult(request); !errors.Is(err, ErrInvalidResult) {
+		t.Errorf("expected a second result for fixture 10 to be rejected, got %v", err)
+	}
+
+	outside := request
+	outside.ScheduledMatchID, outside.AwayTeamID = nil, 99
+	if _, err := service.RecordResult(outside); !errors.Is(err, ErrInvalidResult) {
+		t.Errorf("expected a team outside the division to be rejected, got %v", err)
+	}
+
+	divisions.matches[0].Status = models.ScheduledMatchScheduled
+	swapped := request
+	swapped.HomeTeamID, swapped.AwayTeamID = 2, 1
+	if _, err := service.RecordResult(swapped); !errors.Is(err, ErrInvalidResult) {
+		t.Errorf("expected a result with the fixture's teams swapped to be rejected, got %v", err)
+	}
+	if len(results.results) != 1 {
+		t.Errorf("expected only the first result recorded, got %d", len(results.results))
+	}
+}
+
+func TestStandingsStrengthOfScheduleUsesRosterMu(t *testing.T) {
+	service, results, _, teams := newTestStandingsService(3)
+	resolver := service.resolver.(*fakeRatingResolver)
+	resolver.ratings["pa"] = TrueSkillRating{Mu: 30, Sigma: 5}
+	resolver.ratings["pb"] = TrueSkillRating{Mu: 20, Sigma: 5}
+	resolver.ratings["pc"] = TrueSkillRating{Mu: 10, Sigma: 5}
+	teams.members = []models.TeamMember{{TeamID: 1, DiscordID: "pa"}, {TeamID: 1, DiscordID: "pb"}, {TeamID: 2, DiscordID: "pc"}}
+
+	results.results = []models.LeagueResult{series(1, 3, 3, 1), series(2, 3, 3, 0)}
+
+	standings, err := service.GetStandings(1)
+	if err != nil {
+		t.Fatalf("GetStandings returned error: %v", err)
+	}
+
+	byTeam := make(map[int64]StandingsRow)
+	for _, row := range standings.Rows {
+		byTeam[row.TeamID] = row
+	}
+	if byTeam[1].TeamMu != 25 || byTeam[2].TeamMu != 10 {
+		t.Errorf("expected team mu 25 and 10, got %v and %v", byTeam[1].TeamMu, byTeam[2].TeamMu)
+	}
+	// Team 3 has no rated roster, so it does not count towards its opponents' schedule strength
+	if byTeam[1].SOS != 0 || byTeam[3].SOS != 17.5 {
+		t.Errorf("expected SOS 0 for team 1 and 17.5 for team 3, got %v and %v", byTeam[1].SOS, byTeam[3].SOS)
+	}
+	// Teams 1 and 2 are level at 1-0 and have not met, so team 2's 3-0 sweep puts it ahead
+	if standings.Rows[0].TeamID != 2 || standings.Rows[0].DecidedBy != models.TiebreakGameDifferential {
+		t.Errorf("expected team 2 first on game differential, got %+v", standings.Rows[0])
+	}
+	if len(standings.OpenFixtures) != 1 || standings.OpenFixtures[0].HomeTeamName != "Team pa" {
+		t.Errorf("expected fixture 10 still open, got %+v", standings.OpenFixtures)
+	}
+}
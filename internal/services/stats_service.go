@@ -0,0 +1,442 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+)
+
+// ErrInvalidStatsQuery is returned for an unknown stat, season or stat line
+var ErrInvalidStatsQuery = errors.New("invalid stats query")
+
+// Leaderboard stat keys
+const (
+	StatGoalsPerGame   = "goals_per_game"
+	StatAssistsPerGame = "assists_per_game"
+	StatSavesPerGame   = "saves_per_game"
+	StatShotsPerGame   = "shots_per_game"
+	StatShootingPct    = "shooting_percentage"
+	StatWinRate        = "win_rate"
+	StatGoals          = "goals"
+	StatAssists        = "assists"
+	StatSaves          = "saves"
+	StatShots          = "shots"
+	StatMVPs           = "mvps"
+)
+
+// Leaderboard defaults
+const (
+	DefaultStatLeaderLimit = 10
+	DefaultStatMinGames    = 5 // rate stats only rank players with at least this many games
+)
+
+// statDefinition describes how a leaderboard stat is read and whether it is a rate
+type statDefinition struct {
+	value func(stats *PlayerSeasonStats) float64
+	games func(stats *PlayerSeasonStats) int // games behind the value, checked against the minimum for rates
+	rate  bool
+}
+
+var statDefinitions = map[string]statDefinition{
+	StatGoalsPerGame:   {value: func(s *PlayerSeasonStats) float64 { return s.GoalsPerGame }, games: statGames, rate: true},
+	StatAssistsPerGame: {value: func(s *PlayerSeasonStats) float64 { return s.AssistsPerGame }, games: statGames, rate: true},
+	StatSavesPerGame:   {value: func(s *PlayerSeasonStats) float64 { return s.SavesPerGame }, games: statGames, rate: true},
+	StatShotsPerGame:   {value: func(s *PlayerSeasonStats) float64 { return s.ShotsPerGame }, games: statGames, rate: true},
+	StatShootingPct:    {value: func(s *PlayerSeasonStats) float64 { return s.ShootingPct }, games: statGames, rate: true},
+	StatWinRate:        {value: func(s *PlayerSeasonStats) float64 { return s.WinRate }, games: matchGames, rate: true},
+	StatGoals:          {value: func(s *PlayerSeasonStats) float64 { return float64(s.Goals) }, games: statGames},
+	StatAssists:        {value: func(s *PlayerSeasonStats) float64 { return float64(s.Assists) }, games: statGames},
+	StatSaves:          {value: func(s *PlayerSeasonStats) float64 { return float64(s.Saves) }, games: statGames},
+	StatShots:          {value: func(s *PlayerSeasonStats) float64 { return float64(s.Shots) }, games: statGames},
+	StatMVPs:           {value: func(s *PlayerSeasonStats) float64 { return float64(s.MVPs) }, games: statGames},
+}
+
+func statGames(s *PlayerSeasonStats) int  { return s.StatGames }
+func matchGames(s *PlayerSeasonStats) int { return s.Games }
+
+// StatKeys returns the leaderboard stat keys in alphabetical order
+func StatKeys() []string {
+	keys := make([]string, 0, len(statDefinitions))
+	for key := range statDefinitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// StatsMatchStore interface for reading matches in a period and correcting stat lines
+type StatsMatchStore interface {
+	FindMatchByID(matchID int64) (*models.Match, error)
+	GetMatchesByGuildBetween(guildID int64, from time.Time, to *time.Time) ([]*models.Match, error)
+	UpdateMatchPlayerStats(matchID, userID int64, stats models.MatchPlayerStats) error
+}
+
+// StatsSeasonStore interface for finding the season a stats query covers
+type StatsSeasonStore interface {
+	FindActiveSeason(guildID int64) (*models.Season, error)
+	GetSeasonsByGuild(guildID int64) ([]*models.Season, error)
+}
+
+// StatsUserStore interface for naming the players found in match rosters
+type StatsUserStore interface {
+	GetAllUsers(activeOnly bool) ([]*models.User, error)
+}
+
+// TeammateRecord is how a player has done alongside one teammate
+type TeammateRecord struct {
+	DiscordID string  `json:"discord_id"`
+	Name      string  `json:"name"`
+	Games     int     `json:"games"`
+	Wins      int     `json:"wins"`
+	WinRate   float64 `json:"win_rate"`
+}
+
+// PlayerSeasonStats is a player's aggregated stat lines and results over a season.
+// Per-game values and shooting % only count matches that have a stat line (StatGames);
+// the win rate counts every match played.
+type PlayerSeasonStats struct {
+	UserID         int64            `json:"user_id"`
+	DiscordID      string           `json:"discord_id"`
+	Name           string           `json:"name"`
+	Games          int              `json:"games"`
+	Wins           int              `json:"wins"`
+	Losses         int              `json:"losses"`
+	Draws          int              `json:"draws"`
+	WinRate        float64          `json:"win_rate"`
+	StatGames      int              `json:"stat_games"`
+	Goals          int              `json:"goals"`
+	Assists        int              `json:"assists"`
+	Saves          int              `json:"saves"`
+	Shots          int              `json:"shots"`
+	Score          int              `json:"score"`
+	MVPs           int              `json:"mvps"`
+	GoalsPerGame   float64          `json:"goals_per_game"`
+	AssistsPerGame float64          `json:"assists_per_game"`
+	SavesPerGame   float64          `json:"saves_per_game"`
+	ShotsPerGame   float64          `json:"shots_per_game"`
+	ShootingPct    float64          `json:"shooting_percentage"`
+	Teammates      []TeammateRecord `json:"teammates"`
+}
+
+// PlayerStatsReport is one player's stats for a season, or all time when Season is nil
+type PlayerStatsReport struct {
+	Season *models.Season     `json:"season,omitempty"`
+	Stats  *PlayerSeasonStats `json:"stats"`
+}
+
+// StatLeader is one row of a stat leaderboard
+type StatLeader struct {
+	Rank      int     `json:"rank"`
+	DiscordID string  `json:"discord_id"`
+	Name      string  `json:"name"`
+	Games     int     `json:"games"`
+	Value     float64 `json:"value"`
+}
+
+// StatLeaderboard ranks players by one stat over a season, or all time when Season is nil
+type StatLeaderboard struct {
+	Stat     string         `json:"stat"`
+	Season   *models.Season `json:"season,omitempty"`
+	MinGames int            `json:"min_games"`
+	Leaders  []StatLeader   `json:"leaders"`
+}
+
+// StatsService aggregates per-match player stat lines into season stats.
+// Service Responsibilities:
+// - Correcting stat lines on recorded matches
+// - Totalling goals, assists, saves, shots and MVPs per player over a season
+// - Computing per-game averages, shooting % and win rates, including with each teammate
+// - Ranking players by a stat, with a minimum-games threshold for rate stats
+type StatsService struct {
+	matchRepo  StatsMatchStore
+	seasonRepo StatsSeasonStore
+	userRepo   StatsUserStore
+	config     *config.Config
+}
+
+// NewStatsService creates a new stats service
+func NewStatsService(
+	matchRepo *repositories.MatchRepository,
+	seasonRepo *repositories.SeasonRepository,
+	userRepo *repositories.UserRepository,
+	config *config.Config,
+) *StatsService {
+	return &StatsService{
+		matchRepo:  matchRepo,
+		seasonRepo: seasonRepo,
+		userRepo:   userRepo,
+		config:     config,
+	}
+}
+
+// SetStatLine enters or corrects a player's stat line in a recorded match
+func (s *StatsService) SetStatLine(matchID int64, discordID string, stats models.MatchPlayerStats) error {
+	if err := stats.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidStatsQuery, err)
+	}
+
+	match, err := s.matchRepo.FindMatchByID(matchID)
+	if err != nil || match == nil {
+		return fmt.Errorf("%w: match %d not found", ErrInvalidStatsQuery, matchID)
+	}
+
+	users, err := s.userRepo.GetAllUsers(false)
+	if err != nil {
+		return fmt.Errorf("failed to load users: %w", err)
+	}
+
+	for _, user := range users {
+		if user.DiscordID != discordID {
+			continue
+		}
+		for _, player := range match.Players {
+			if player.UserID == int64(user.ID) {
+				return s.matchRepo.UpdateMatchPlayerStats(matchID, player.UserID, stats)
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %s did not play in match %d", ErrInvalidStatsQuery, discordID, matchID)
+}
+
+// GetPlayerStats returns a player's stats for a season, defaulting to the active season
+// and falling back to all time when the guild has none
+func (s *StatsService) GetPlayerStats(guildID int64, discordID string, seasonID *int64) (*PlayerStatsReport, error) {
+	season, all, err := s.aggregate(guildID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stats := range all {
+		if stats.DiscordID == discordID {
+			return &PlayerStatsReport{Season: season, Stats: stats}, nil
+		}
+	}
+
+	return &PlayerStatsReport{Season: season, Stats: &PlayerSeasonStats{DiscordID: discordID, Teammates: []TeammateRecord{}}}, nil
+}
+
+// GetLeaders ranks players by a stat over a season, defaulting to the active season
+// Rate stats skip players with fewer than minGames games; minGames <= 0 uses the default
+func (s *StatsService) GetLeaders(guildID int64, stat string, seasonID *int64, limit, minGames int) (*StatLeaderboard, error) {
+	stat = strings.ToLower(strings.TrimSpace(stat))
+	definition, ok := statDefinitions[stat]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown stat %q, expected one of %s", ErrInvalidStatsQuery, stat, strings.Join(StatKeys(), ", "))
+	}
+	if limit <= 0 {
+		limit = DefaultStatLeaderLimit
+	}
+	if minGames <= 0 {
+		minGames = DefaultStatMinGames
+	}
+
+	season, all, err := s.aggregate(guildID, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &StatLeaderboard{Stat: stat, Season: season, MinGames: minGames, Leaders: []StatLeader{}}
+	if !definition.rate {
+		board.MinGames = 0
+	}
+
+	ranked := make([]*PlayerSeasonStats, 0, len(all))
+	for _, stats := range all {
+		games := definition.games(stats)
+		if games == 0 || (definition.rate && games < minGames) {
+			continue
+		}
+		ranked = append(ranked, stats)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		vi, vj := definition.value(ranked[i]), definition.value(ranked[j])
+		if vi != vj {
+			return vi > vj
+		}
+		if gi, gj := definition.games(ranked[i]), definition.games(ranked[j]); gi != gj {
+			return gi > gj
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+
+	for i, stats := range ranked {
+		if i >= limit {
+			break
+		}
+		board.Leaders = append(board.Leaders, StatLeader{
+			Rank:      i + 1,
+			DiscordID: stats.DiscordID,
+			Name:      stats.Name,
+			Games:     definition.games(stats),
+			Value:     roundRating(definition.value(stats)),
+		})
+	}
+
+	return board, nil
+}
+
+// aggregate loads the period's matches and builds every player's stats, sorted by name
+func (s *StatsService) aggregate(guildID int64, seasonID *int64) (*models.Season, []*PlayerSeasonStats, error) {
+	season, err := s.resolveSeason(guildID, seasonID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var from time.Time
+	var to *time.Time
+	if season != nil {
+		from, to = season.StartedAt, season.EndedAt
+	}
+
+	matches, err := s.matchRepo.GetMatchesByGuildBetween(guildID, from, to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load matches: %w", err)
+	}
+
+	users, err := s.userRepo.GetAllUsers(false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load users: %w", err)
+	}
+	usersByID := make(map[int64]*models.User, len(users))
+	for _, user := range users {
+		usersByID[int64(user.ID)] = user
+	}
+
+	stats := aggregatePlayerStats(matches, usersByID)
+	log.Printf("StatsService: Aggregated %d matches into stats for %d players in guild %d", len(matches), len(stats), guildID)
+	return season, stats, nil
+}
+
+// resolveSeason finds the requested season, or the active season when none is requested
+// A nil season with no error means all time
+func (s *StatsService) resolveSeason(guildID int64, seasonID *int64) (*models.Season, error) {
+	if seasonID == nil {
+		season, err := s.seasonRepo.FindActiveSeason(guildID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find active season: %w", err)
+		}
+		return season, nil
+	}
+
+	seasons, err := s.seasonRepo.GetSeasonsByGuild(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load seasons: %w", err)
+	}
+	for _, season := range seasons {
+		if season.ID == *seasonID {
+			return season, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: season %d not found", ErrInvalidStatsQuery, *seasonID)
+}
+
+// aggregatePlayerStats totals every player's stat lines, results and teammate records over the matches
+func aggregatePlayerStats(matches []*models.Match, usersByID map[int64]*models.User) []*PlayerSeasonStats {
+	type teammateTally struct{ games, wins int }
+
+	byUser := make(map[int64]*PlayerSeasonStats)
+	teammates := make(map[int64]map[int64]*teammateTally)
+
+	for _, match := range matches {
+		winner := match.Winner()
+		for _, player := range match.Players {
+			stats, ok := byUser[player.UserID]
+			if !ok {
+				stats = &PlayerSeasonStats{UserID: player.UserID, Name: fmt.Sprintf("User %d", player.UserID)}
+				if user := usersByID[player.UserID]; user != nil {
+					stats.DiscordID, stats.Name = user.DiscordID, user.Name
+				}
+				byUser[player.UserID] = stats
+				teammates[player.UserID] = make(map[int64]*teammateTally)
+			}
+
+			won := winner == player.Team
+			stats.Games++
+			switch {
+			case winner == models.MatchDrawWinner:
+				stats.Draws++
+			case won:
+				stats.Wins++
+			default:
+				stats.Losses++
+			}
+
+			if line := player.Stats; line != nil {
+				stats.StatGames++
+				stats.Goals += line.Goals
+				stats.Assists += line.Assists
+				stats.Saves += line.Saves
+				stats.Shots += line.Shots
+				stats.Score += line.Score
+				if line.MVP {
+					stats.MVPs++
+				}
+			}
+
+			for _, other := range match.Players {
+				if other.UserID == player.UserID || other.Team != player.Team {
+					continue
+				}
+				tally, ok := teammates[player.UserID][other.UserID]
+				if !ok {
+					tally = &teammateTally{}
+					teammates[player.UserID][other.UserID] = tally
+				}
+				tally.games++
+				if won {
+					tally.wins++
+				}
+			}
+		}
+	}
+
+	all := make([]*PlayerSeasonStats, 0, len(byUser))
+	for userID, stats := range byUser {
+		stats.WinRate = ratio(stats.Wins, stats.Games)
+		stats.GoalsPerGame = ratio(stats.Goals, stats.StatGames)
+		stats.AssistsPerGame = ratio(stats.Assists, stats.StatGames)
+		stats.SavesPerGame = ratio(stats.Saves, stats.StatGames)
+		stats.ShotsPerGame = ratio(stats.Shots, stats.StatGames)
+		stats.ShootingPct = 100 * ratio(stats.Goals, stats.Shots)
+
+		stats.Teammates = make([]TeammateRecord, 0, len(teammates[userID]))
+		for teammateID, tally := range teammates[userID] {
+			record := TeammateRecord{Name: fmt.Sprintf("User %d", teammateID), Games: tally.games, Wins: tally.wins, WinRate: ratio(tally.wins, tally.games)}
+			if user := usersByID[teammateID]; user != nil {
+				record.DiscordID, record.Name = user.DiscordID, user.Name
+			}
+			stats.Teammates = append(stats.Teammates, record)
+		}
+		sort.Slice(stats.Teammates, func(i, j int) bool {
+			a, b := stats.Teammates[i], stats.Teammates[j]
+			if a.Games != b.Games {
+				return a.Games > b.Games
+			}
+			if a.WinRate != b.WinRate {
+				return a.WinRate > b.WinRate
+			}
+			return a.Name < b.Name
+		})
+
+		all = append(all, stats)
+	}
+
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// ratio divides two counts, returning 0 when there is nothing to divide by
+func ratio(numerator, denominator int) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"usl-server/internal/config"
+	"usl-server/internal/models"
+)
+
+type fakeStatsMatchStore struct {
+	matches []*models.Match
+	updated map[int64]models.MatchPlayerStats // by user ID
+}
+
+func (f *fakeStatsMatchStore) FindMatchByID(matchID int64) (*models.Match, error) {
+	for _, match := range f.matches {
+		if match.ID == matchID {
+			return match, nil
+		}
+	}
+	return nil, errors.New("match not found")
+}
+
+func (f *fakeStatsMatchStore) GetMatchesByGuildBetween(guildID int64, from time.Time, to *time.Time) ([]*models.Match, error) {
+	var matches []*models.Match
+	for _, match := range f.matches {
+		if !match.PlayedAt.Before(from) && (to == nil || match.PlayedAt.Before(*to)) {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+func (f *fakeStatsMatchStore) UpdateMatchPlayerStats(matchID, userID int64, stats models.MatchPlayerStats) error {
+	f.updated[userID] = stats
+	return nil
+}
+
+type fakeStatsSeasons struct {
+	seasons []*models.Season
+}
+
+func (f *fakeStatsSeasons) FindActiveSeason(guildID int64) (*models.Season, error) {
+	for _, season := range f.seasons {
+		if season.Status == models.SeasonStatusActive {
+			return season, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeStatsSeasons) GetSeasonsByGuild(guildID int64) ([]*models.Season, error) {
+	return f.seasons, nil
+}
+
+type fakeStatsUsers struct {
+	users []*models.User
+}
+
+func (f *fakeStatsUsers) GetAllUsers(activeOnly bool) ([]*models.User, error) {
+	return f.users, nil
+}
+
+// statsMatch builds a match played at day on the given teams of user IDs, with a stat line
+// of {goals, shots, saves} for each player listed in lines
+func statsMatch(id int64, day int, teamA, teamB []int64, scoreA, scoreB int, lines map[int64][3]int, mvp int64) *models.Match {
+	match := &models.Match{ID: id, GuildID: 1, TeamAScore: scoreA, TeamBScore: scoreB, PlayedAt: time.Date(2025, 3, day, 20, 0, 0, 0, time.UTC)}
+	add := func(userIDs []int64, team int) {
+		for _, userID := range userIDs {
+			player := models.MatchPlayer{MatchID: id, UserID: userID, Team: team}
+			if line, ok := lines[userID]; ok {
+				player.Stats = &models.MatchPlayerStats{Goals: line[0], Shots: line[1], Saves: line[2], MVP: userID == mvp}
+			}
+			match.Players = append(match.Players, player)
+		}
+	}
+	add(teamA, models.MatchTeamA)
+	add(teamB, models.MatchTeamB)
+	return match
+}
+
+// newTestStatsService has players pa..pd (user IDs 1..4), a closed season 1 in February
+// and an active season 2 from March
+func newTestStatsService(matches ...*models.Match) (*StatsService, *fakeStatsMatchStore) {
+	users := &fakeStatsUsers{}
+	for i := 1; i <= 4; i++ {
+		users.users = append(users.users, &models.User{ID: i, DiscordID: playerID(i), Name: "Player " + playerID(i)})
+	}
+
+	seasonOneEnd := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	seasons := &fakeStatsSeasons{seasons: []*models.Season{
+		{ID: 1, GuildID: 1, Number: 1, Status: models.SeasonStatusClosed, StartedAt: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), EndedAt: &seasonOneEnd},
+		{ID: 2, GuildID: 1, Number: 2, Status: models.SeasonStatusActive, StartedAt: seasonOneEnd},
+	}}
+
+	store := &fakeStatsMatchStore{matches: matches, updated: make(map[int64]models.MatchPlayerStats)}
+	return &StatsService{matchRepo: store, seasonRepo: seasons, userRepo: users, config: &config.Config{}}, store
+}
+
+func TestStatsAggregatesSeasonPerGameAndTeammates(t *testing.T) {
+	service, _ := newTestStatsService(
+		statsMatch(1, 2, []int64{1, 2}, []int64{3, 4}, 3, 1, map[int64][3]int{1: {2, 4, 1}, 2: {1, 2, 3}}, 1),
+		statsMatch(2, 3, []int64{1, 3}, []int64{2, 4}, 0, 2, map[int64][3]int{1: {0, 4, 3}}, 0),
+		// No stat line, so only the result and teammate count
+		statsMatch(3, 4, []int64{1, 2}, []int64{3, 4}, 4, 0, nil, 0),
+		// Played in February, before the active season started
+		statsMatch(4, -13, []int64{1, 2}, []int64{3, 4}, 0, 5, map[int64][3]int{1: {9, 9, 9}}, 0),
+	)
+
+	report, err := service.GetPlayerStats(1, "pa", nil)
+	if err != nil {
+		t.Fatalf("GetPlayerStats returned error: %v", err)
+	}
+	if report.Season == nil || report.Season.ID != 2 {
+		t.Fatalf("expected the active season by default, got %+v", report.Season)
+	}
+
+	stats := report.Stats
+	if stats.Games != 3 || stats.Wins != 2 || stats.Losses != 1 || stats.StatGames != 2 {
+		t.Fatalf("expected 3 games (2-1) with 2 stat lines, got %+v", stats)
+	}
+	if stats.GoalsPerGame != 1 || stats.SavesPerGame != 2 || stats.ShootingPct != 25 || stats.MVPs != 1 {
+		t.Errorf("expected 1 goal and 2 saves per game, 25%% shooting and 1 MVP, got %+v", stats)
+	}
+	if len(stats.Teammates) != 2 || stats.Teammates[0].DiscordID != "pb" || stats.Teammates[0].Games != 2 || stats.Teammates[0].WinRate != 1 {
+		t.Fatalf("expected pb first as a 2-0 teammate, got %+v", stats.Teammates)
+	}
+	if stats.Teammates[1].DiscordID != "pc" || stats.Teammates[1].WinRate != 0 {
+		t.Errorf("expected pc as a 0-1 teammate, got %+v", stats.Teammates[1])
+	}
+
+	seasonOne := int64(1)
+	report, err = service.GetPlayerStats(1, "pa", &seasonOne)
+	if err != nil {
+		t.Fatalf("GetPlayerStats for season 1 returned error: %v", err)
+	}
+	if report.Stats.Games != 1 || report.Stats.Goals != 9 {
+		t.Errorf("expected only the February match in season 1, got %+v", report.Stats)
+	}
+}
+
+func TestStatsLeadersApplyMinimumGamesToRates(t *testing.T) {
+	service, _ := newTestStatsService(
+		statsMatch(1, 2, []int64{1}, []int64{2}, 1, 0, map[int64][3]int{1: {1, 2, 2}, 2: {0, 1, 6}}, 2),
+		statsMatch(2, 3, []int64{1}, []int64{3}, 0, 1, map[int64][3]int{1: {0, 1, 4}, 3: {1, 1, 1}}, 3),
+		statsMatch(3, 4, []int64{1}, []int64{3}, 2, 1, map[int64][3]int{1: {2, 3, 3}, 3: {1, 2, 0}}, 1),
+	)
+
+	board, err := service.GetLeaders(1, "saves_per_game", nil, 0, 2)
+	if err != nil {
+		t.Fatalf("GetLeaders returned error: %v", err)
+	}
+	// pb averages 6 saves but in only one game, so pa (3.0) leads pc (0.5)
+	if len(board.Leaders) != 2 || board.Leaders[0].DiscordID != "pa" || board.Leaders[0].Value != 3 || board.Leaders[1].DiscordID != "pc" {
+		t.Fatalf("expected pa then pc with pb below the minimum, got %+v", board.Leaders)
+	}
+
+	board, err = service.GetLeaders(1, "saves", nil, 1, 2)
+	if err != nil {
+		t.Fatalf("GetLeaders returned error: %v", err)
+	}
+	if board.MinGames != 0 || len(board.Leaders) != 1 || board.Leaders[0].DiscordID != "pa" || board.Leaders[0].Value != 9 {
+		t.Errorf("expected totals to ignore the minimum and pa to lead with 9 saves, got %+v", board)
+	}
+
+	if _, err := service.GetLeaders(1, "demos", nil, 0, 0); !errors.Is(err, ErrInvalidStatsQuery) {
+		t.Errorf("expected ErrInvalidStatsQuery for an unknown stat, got %v", err)
+	}
+}
+
+func TestStatsSetStatLineRequiresPlayerInMatch(t *testing.T) {
+	service, store := newTestStatsService(statsMatch(1, 2, []int64{1}, []int64{2}, 1, 0, nil, 0))
+
+	if err := service.SetStatLine(1, "pb", models.MatchPlayerStats{Saves: 3, Shots: 1}); err != nil {
+		t.Fatalf("SetStatLine returned error: %v", err)
+	}
+	if stats := store.updated[2]; stats.Saves != 3 {
+		t.Errorf("expected pb's stat line saved under user 2, got %+v", store.updated)
+	}
+
+	if err := service.SetStatLine(1, "pc", models.MatchPlayerStats{}); !errors.Is(err, ErrInvalidStatsQuery) {
+		t.Errorf("expected a player outside the match to be rejected, got %v", err)
+	}
+	if err := service.SetStatLine(1, "pa", models.MatchPlayerStats{Goals: -1}); !errors.Is(err, ErrInvalidStatsQuery) {
+		t.Errorf("expected a negative stat line to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"usl-server/internal/config"
+)
+
+// Team balancing limits
+const (
+	// MaxBalancePlayers caps exhaustive search; 12 players into 3 teams of 4 is 5,775 splits
+	MaxBalancePlayers       = 12
+	DefaultBalanceTeamCount = 2
+	DefaultBalanceResults   = 3
+	MaxBalanceResults       = 10
+)
+
+// BalancePlayer is a player to be placed on a team
+type BalancePlayer struct {
+	DiscordID string          `json:"discord_id"`
+	Rating    TrueSkillRating `json:"rating"`
+	Source    string          `json:"source,omitempty"`
+}
+
+// BalanceRequest describes a lobby to split into teams
+type BalanceRequest struct {
+	Players    []BalancePlayer
+	TeamCount  int
+	TeamSize   int
+	Together   [][]string // Each group must end up on the same team
+	Apart      [][]string // No two players in a group may share a team
+	MaxResults int
+}
+
+// BalancedTeam is one team of a proposed split
+type BalancedTeam struct {
+	Players   []BalancePlayer `json:"players"`
+	TotalMu   float64         `json:"total_mu"`
+	AverageMu float64         `json:"average_mu"`
+}
+
+// BalanceSplit is a complete assignment of players to teams with its scores
+// For more than two teams, MatchQuality is the worst pairwise quality and each
+// win probability is the team's average chance against every other team.
+type BalanceSplit struct {
+	Teams            []BalancedTeam `json:"teams"`
+	MatchQuality     float64        `json:"match_quality"`
+	WinProbabilities []float64      `json:"win_probabilities"`
+	MuGap            float64        `json:"mu_gap"`
+}
+
+// TeamBalancer finds the most even team splits for a lobby using TrueSkill match quality
+type TeamBalancer struct {
+	rater *TrueSkillRater
+}
+
+// NewTeamBalancer creates a new team balancer
+func NewTeamBalancer(cfg *config.Config) *TeamBalancer {
+	return &TeamBalancer{
+		rater: NewTrueSkillRater(cfg),
+	}
+}
+
+// balanceSearch holds the state of a single exhaustive search
+type balanceSearch struct {
+	request    BalanceRequest
+	assignment []int
+	teamSizes  []int
+	groupOf    []int   // together-group index per player, -1 when unconstrained
+	apartFrom  [][]int // player indexes each player must not share a team with
+	results    []BalanceSplit
+	maxResults int
+}
+
+// Balance returns the best splits, ordered by match quality
+func (b *TeamBalancer) Balance(request BalanceRequest) ([]BalanceSplit, error) {
+	if request.TeamCount == 0 {
+		request.TeamCount = DefaultBalanceTeamCount
+	}
+	if request.TeamSize == 0 && request.TeamCount > 0 {
+		request.TeamSize = len(request.Players) / request.TeamCount
+	}
+	if request.MaxResults <= 0 {
+		request.MaxResults = DefaultBalanceResults
+	}
+	if request.MaxResults > MaxBalanceResults {
+		request.MaxResults = MaxBalanceResults
+	}
+
+	search, err := newBalanceSearch(request)
+	if err != nil {
+		return nil, err
+	}
+
+	search.assign(0, b)
+
+	if len(search.results) == 0 {
+		return nil, fmt.Errorf("no team split satisfies the together/apart constraints")
+	}
+
+	return search.results, nil
+}
+
+// newBalanceSearch validates the request and indexes its constraints
+func newBalanceSearch(request BalanceRequest) (*balanceSearch, error) {
+	playerCount := len(request.Players)
+	if request.TeamCount < 2 {
+		return nil, fmt.Errorf("at least 2 teams are required")
+	}
+	if request.TeamSize < 1 {
+		return nil, fmt.Errorf("team size must be at least 1")
+	}
+	if playerCount != request.TeamCount*request.TeamSize {
+		return nil, fmt.Errorf("%d players cannot be split into %d teams of %d", playerCount, request.TeamCount, request.TeamSize)
+	}
+	if playerCount > MaxBalancePlayers {
+		return nil, fmt.Errorf("at most %d players can be balanced at once, got %d", MaxBalancePlayers, playerCount)
+	}
+
+	indexOf := make(map[string]int, playerCount)
+	for i, player := range request.Players {
+		if _, exists := indexOf[player.DiscordID]; exists {
+			return nil, fmt.Errorf("player %s is listed more than once", player.DiscordID)
+		}
+		indexOf[player.DiscordID] = i
+	}
+
+	search := &balanceSearch{
+		request:    request,
+		assignment: make([]int, playerCount),
+		teamSizes:  make([]int, request.TeamCount),
+		groupOf:    make([]int, playerCount),
+		apartFrom:  make([][]int, playerCount),
+		maxResults: request.MaxResults,
+	}
+	for i := range search.groupOf {
+		search.groupOf[i] = -1
+	}
+
+	for groupIndex, group := range request.Together {
+		if len(group) > request.TeamSize {
+			return nil, fmt.Errorf("together group %d has %d players but teams only have %d slots", groupIndex+1, len(group), request.TeamSize)
+		}
+		for _, discordID := range group {
+			playerIndex, ok := indexOf[discordID]
+			if !ok {
+				return nil, fmt.Errorf("together constraint references unknown player %s", discordID)
+			}
+			if search.groupOf[playerIndex] != -1 && search.groupOf[playerIndex] != groupIndex {
+				return nil, fmt.Errorf("player %s appears in more than one together group", discordID)
+			}
+			search.groupOf[playerIndex] = groupIndex
+		}
+	}
+
+	for _, group := range request.Apart {
+		indexes := make([]int, 0, len(group))
+		for _, discordID := range group {
+			playerIndex, ok := indexOf[discordID]
+			if !ok {
+				return nil, fmt.Errorf("apart constraint references unknown player %s", discordID)
+			}
+			indexes = append(indexes, playerIndex)
+		}
+		for _, a := range indexes {
+			for _, other := range indexes {
+				if a != other {
+					search.apartFrom[a] = append(search.apartFrom[a], other)
+				}
+			}
+		}
+	}
+
+	return search, nil
+}
+
+// assign places player i on each allowed team and recurses
+func (s *balanceSearch) assign(i int, b *TeamBalancer) {
+	if i == len(s.request.Players) {
+		s.record(b.score(s.request.Players, s.assignment, s.request.TeamCount))
+		return
+	}
+
+	// Symmetry breaking: a player may only open the first empty team, since team order is irrelevant
+	openTeams := 0
+	for _, size := range s.teamSizes {
+		if size > 0 {
+			openTeams++
+		}
+	}
+
+	for team := 0; team < s.request.TeamCount && team <= openTeams; team++ {
+		if s.teamSizes[team] >= s.request.TeamSize || !s.allowed(i, team) {
+			continue
+		}
+
+		s.assignment[i] = team
+		s.teamSizes[team]++
+		s.assign(i+1, b)
+		s.teamSizes[team]--
+	}
+}
+
+// allowed checks together/apart constraints against players already placed
+func (s *balanceSearch) allowed(i, team int) bool {
+	for placed := 0; placed < i; placed++ {
+		sameTeam := s.assignment[placed] == team
+		if s.groupOf[i] != -1 && s.groupOf[placed] == s.groupOf[i] && !sameTeam {
+			return false
+		}
+	}
+	for _, other := range s.apartFrom[i] {
+		if other < i && s.assignment[other] == team {
+			return false
+		}
+	}
+	return true
+}
+
+// record keeps the split if it is among the best seen so far
+func (s *balanceSearch) record(split BalanceSplit) {
+	s.results = append(s.results, split)
+	sort.SliceStable(s.results, func(a, c int) bool {
+		if s.results[a].MatchQuality != s.results[c].MatchQuality {
+			return s.results[a].MatchQuality > s.results[c].MatchQuality
+		}
+		return s.results[a].MuGap < s.results[c].MuGap
+	})
+	if len(s.results) > s.maxResults {
+		s.results = s.results[:s.maxResults]
+	}
+}
+
+// score builds a split from an assignment and computes its quality and win probabilities
+func (b *TeamBalancer) score(players []BalancePlayer, assignment []int, teamCount int) BalanceSplit {
+	teams := make([]BalancedTeam, teamCount)
+	ratings := make([][]TrueSkillRating, teamCount)
+
+	for i, player := range players {
+		team := assignment[i]
+		teams[team].Players = append(teams[team].Players, player)
+		teams[team].TotalMu += player.Rating.Mu
+		ratings[team] = append(ratings[team], player.Rating)
+	}
+
+	minTotal, maxTotal := math.Inf(1), math.Inf(-1)
+	for i := range teams {
+		teams[i].AverageMu = math.Round(teams[i].TotalMu/float64(len(teams[i].Players))*100) / 100
+		minTotal = math.Min(minTotal, teams[i].TotalMu)
+		maxTotal = math.Max(maxTotal, teams[i].TotalMu)
+	}
+
+	quality := 1.0
+	winProbabilities := make([]float64, teamCount)
+	for i := 0; i < teamCount; i++ {
+		for j := 0; j < teamCount; j++ {
+			if i == j {
+				continue
+			}
+			winProbabilities[i] += b.rater.WinProbability(ratings[i], ratings[j]) / float64(teamCount-1)
+			if i < j {
+				quality = math.Min(quality, b.rater.MatchQuality(ratings[i], ratings[j]))
+			}
+		}
+	}
+
+	for i := range winProbabilities {
+		winProbabilities[i] = roundProbability(winProbabilities[i])
+	}
+
+	return BalanceSplit{
+		Teams:            teams,
+		MatchQuality:     roundProbability(quality),
+		WinProbabilities: winProbabilities,
+		MuGap:            math.Round((maxTotal-minTotal)*100) / 100,
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"usl-server/internal/config"
+)
+
+func balancePlayers(mus ...float64) []BalancePlayer {
+	players := make([]BalancePlayer, len(mus))
+	for i, mu := range mus {
+		players[i] = BalancePlayer{
+			DiscordID: string(rune('a' + i)),
+			Rating:    TrueSkillRating{Mu: mu, Sigma: 5},
+		}
+	}
+	return players
+}
+
+func teamOf(split BalanceSplit, discordID string) int {
+	for teamIndex, team := range split.Teams {
+		for _, player := range team.Players {
+			if player.DiscordID == discordID {
+				return teamIndex
+			}
+		}
+	}
+	return -1
+}
+
+func TestBalanceThreeVsThreeFindsEvenSplit(t *testing.T) {
+	balancer := NewTeamBalancer(&config.Config{})
+
+	splits, err := balancer.Balance(BalanceRequest{
+		Players:  balancePlayers(1600, 1500, 1400, 1300, 1200, 1000),
+		TeamSize: 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(splits) != DefaultBalanceResults {
+		t.Fatalf("expected %d splits, got %d", DefaultBalanceResults, len(splits))
+	}
+
+	best := splits[0]
+	if best.MuGap != 0 {
+		t.Errorf("an exactly even split exists (1600+1400+1000 vs 1500+1300+1200), got gap %.2f", best.MuGap)
+	}
+	if math.Abs(best.WinProbabilities[0]-0.5) > 0.0001 {
+		t.Errorf("even split should be a coin flip, got %.4f", best.WinProbabilities[0])
+	}
+	for i := 1; i < len(splits); i++ {
+		if splits[i].MatchQuality > splits[i-1].MatchQuality {
+			t.Errorf("splits should be ordered by quality: %.4f before %.4f", splits[i-1].MatchQuality, splits[i].MatchQuality)
+		}
+	}
+}
+
+func TestBalanceRespectsTogetherAndApart(t *testing.T) {
+	balancer := NewTeamBalancer(&config.Config{})
+
+	splits, err := balancer.Balance(BalanceRequest{
+		Players:    balancePlayers(1600, 1500, 1400, 1300),
+		TeamSize:   2,
+		Together:   [][]string{{"a", "b"}},
+		MaxResults: 5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(splits) != 1 {
+		t.Fatalf("only one 2v2 split keeps a and b together, got %d", len(splits))
+	}
+	if teamOf(splits[0], "a") != teamOf(splits[0], "b") {
+		t.Errorf("a and b should share a team")
+	}
+
+	splits, err = balancer.Balance(BalanceRequest{
+		Players:    balancePlayers(1600, 1500, 1400, 1300),
+		TeamSize:   2,
+		Apart:      [][]string{{"a", "d"}},
+		MaxResults: 5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, split := range splits {
+		if teamOf(split, "a") == teamOf(split, "d") {
+			t.Errorf("a and d should never share a team")
+		}
+	}
+}
+
+func TestBalanceMultipleTeams(t *testing.T) {
+	balancer := NewTeamBalancer(&config.Config{})
+
+	splits, err := balancer.Balance(BalanceRequest{
+		Players:   balancePlayers(1600, 1500, 1400, 1300, 1200, 1100),
+		TeamCount: 3,
+		TeamSize:  2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	best := splits[0]
+	if len(best.Teams) != 3 || len(best.WinProbabilities) != 3 {
+		t.Fatalf("expected 3 teams with win probabilities, got %d/%d", len(best.Teams), len(best.WinProbabilities))
+	}
+	if best.MuGap != 0 {
+		t.Errorf("pairing highest with lowest gives equal totals, got gap %.2f", best.MuGap)
+	}
+}
+
+func TestBalanceValidation(t *testing.T) {
+	balancer := NewTeamBalancer(&config.Config{})
+
+	tests := []struct {
+		name    string
+		request BalanceRequest
+	}{
+		{"uneven lobby", BalanceRequest{Players: balancePlayers(1, 2, 3), TeamSize: 2}},
+		{"too many players", BalanceRequest{Players: balancePlayers(make([]float64, MaxBalancePlayers+2)...), TeamSize: (MaxBalancePlayers + 2) / 2}},
+		{"unknown together player", BalanceRequest{Players: balancePlayers(1, 2), TeamSize: 1, Together: [][]string{{"a", "z"}}}},
+		{"group larger than team", BalanceRequest{Players: balancePlayers(1, 2, 3, 4), TeamSize: 2, Together: [][]string{{"a", "b", "c"}}}},
+		{"contradictory constraints", BalanceRequest{Players: balancePlayers(1, 2, 3, 4), TeamSize: 2, Together: [][]string{{"a", "b"}}, Apart: [][]string{{"a", "b"}}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := balancer.Balance(tc.request); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -17,15 +17,14 @@ func TestUpdateUserTrueSkillFromTrackerData(t *testing.T) {
 	}
 
 	dataTransformationService := NewDataTransformationService()
-	uncertaintyCalculator := NewEnhancedUncertaintyCalculator(cfg)
 	percentileConverter := NewPercentileConverter(cfg)
+	uncertaintyCalculator := NewEnhancedUncertaintyCalculator(cfg, percentileConverter)
 	mmrCalculator := NewMMRCalculator(cfg, percentileConverter)
 
 	service := &UserTrueSkillService{
 		trackerRepo:               nil, // Will cause graceful failure in real calculation
 		userRepo:                  nil, // Will cause graceful failure in real calculation
-		mmrCalculator:             mmrCalculator,
-		uncertaintyCalculator:     uncertaintyCalculator,
+		engine:                    NewTrueSkillEngine(mmrCalculator, uncertaintyCalculator, cfg),
 		dataTransformationService: dataTransformationService,
 		config:                    cfg,
 	}
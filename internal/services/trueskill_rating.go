@@ -0,0 +1,271 @@
+package services
+
+import (
+	"math"
+	"usl-server/internal/config"
+)
+
+// Default match rating parameters, used when the config leaves them unset.
+// Beta and Tau follow the standard TrueSkill ratios (sigma/2 and sigma/100).
+const (
+	DefaultTrueSkillBeta    = 4.1667
+	DefaultTrueSkillTau     = 0.0833
+	DefaultTrueSkillMuScale = 40.0
+)
+
+// MatchOutcome describes the result of a two-team match from team A's perspective
+type MatchOutcome int
+
+const (
+	MatchOutcomeTeamAWins MatchOutcome = iota
+	MatchOutcomeTeamBWins
+	MatchOutcomeDraw
+)
+
+// TrueSkillRating is a single player's rating as stored in the database
+// Mu is on the 0-2000 display scale, Sigma is in TrueSkill units
+type TrueSkillRating struct {
+	Mu    float64 `json:"mu"`
+	Sigma float64 `json:"sigma"`
+}
+
+// TrueSkillRater performs Bayesian TrueSkill updates for two-team matches
+type TrueSkillRater struct {
+	beta            float64
+	tau             float64
+	drawProbability float64
+	muScale         float64
+}
+
+// NewTrueSkillRater creates a rater from the TrueSkill configuration
+func NewTrueSkillRater(cfg *config.Config) *TrueSkillRater {
+	rater := &TrueSkillRater{
+		beta:            DefaultTrueSkillBeta,
+		tau:             DefaultTrueSkillTau,
+		drawProbability: 0,
+		muScale:         DefaultTrueSkillMuScale,
+	}
+
+	if cfg == nil {
+		return rater
+	}
+
+	if cfg.TrueSkill.Beta > 0 {
+		rater.beta = cfg.TrueSkill.Beta
+	}
+	if cfg.TrueSkill.Tau > 0 {
+		rater.tau = cfg.TrueSkill.Tau
+	}
+	if cfg.TrueSkill.DrawProbability > 0 && cfg.TrueSkill.DrawProbability < 1 {
+		rater.drawProbability = cfg.TrueSkill.DrawProbability
+	}
+	if cfg.TrueSkill.MuScale > 0 {
+		rater.muScale = cfg.TrueSkill.MuScale
+	}
+
+	return rater
+}
+
+// RateMatch returns updated ratings for both teams after a match
+// Ratings are returned in the same order as they were passed in
+func (r *TrueSkillRater) RateMatch(teamA, teamB []TrueSkillRating, outcome MatchOutcome) ([]TrueSkillRating, []TrueSkillRating) {
+	// Dynamics: inflate every sigma by tau before the update so ratings can keep moving
+	varianceA := r.dynamicVariances(teamA)
+	varianceB := r.dynamicVariances(teamB)
+
+	totalPlayers := float64(len(teamA) + len(teamB))
+	c := math.Sqrt(totalPlayers*r.beta*r.beta + sumFloats(varianceA) + sumFloats(varianceB))
+
+	// Work from the winner's perspective; a draw is symmetric so either side works
+	winner, loser := teamA, teamB
+	winnerVar, loserVar := varianceA, varianceB
+	if outcome == MatchOutcomeTeamBWins {
+		winner, loser = teamB, teamA
+		winnerVar, loserVar = varianceB, varianceA
+	}
+
+	t := (r.teamMu(winner) - r.teamMu(loser)) / c
+	epsilon := r.drawMargin(totalPlayers) / c
+
+	var v, w float64
+	if outcome == MatchOutcomeDraw {
+		v = vDraw(t, epsilon)
+		w = wDraw(t, epsilon)
+	} else {
+		v = vWin(t, epsilon)
+		w = wWin(t, epsilon)
+	}
+
+	newWinner := r.applyUpdate(winner, winnerVar, c, v, w, 1)
+	newLoser := r.applyUpdate(loser, loserVar, c, v, w, -1)
+
+	if outcome == MatchOutcomeTeamBWins {
+		return newLoser, newWinner
+	}
+	return newWinner, newLoser
+}
+
+// MatchQuality returns the TrueSkill match quality (0-1) for two teams
+// Higher values mean a draw is more likely, i.e. a more even match
+func (r *TrueSkillRater) MatchQuality(teamA, teamB []TrueSkillRating) float64 {
+	totalPlayers := float64(len(teamA) + len(teamB))
+	betaSquared := totalPlayers * r.beta * r.beta
+	cSquared := betaSquared + r.teamVariance(teamA) + r.teamVariance(teamB)
+	muDifference := r.teamMu(teamA) - r.teamMu(teamB)
+
+	return math.Sqrt(betaSquared/cSquared) * math.Exp(-(muDifference*muDifference)/(2*cSquared))
+}
+
+// WinProbability returns the probability that teamA beats teamB outright
+func (r *TrueSkillRater) WinProbability(teamA, teamB []TrueSkillRating) float64 {
+	totalPlayers := float64(len(teamA) + len(teamB))
+	c := math.Sqrt(totalPlayers*r.beta*r.beta + r.teamVariance(teamA) + r.teamVariance(teamB))
+	muDifference := r.teamMu(teamA) - r.teamMu(teamB)
+
+	return gaussianCDF((muDifference - r.drawMargin(totalPlayers)) / c)
+}
+
+// DrawProbability returns the probability that neither team wins outright
+// Always 0 when the configured draw probability is 0, as in Rocket League
+func (r *TrueSkillRater) DrawProbability(teamA, teamB []TrueSkillRating) float64 {
+	draw := 1 - r.WinProbability(teamA, teamB) - r.WinProbability(teamB, teamA)
+	if draw < 0 {
+		return 0
+	}
+	return draw
+}
+
+// applyUpdate moves each player's mean by the team-level update and shrinks their variance
+func (r *TrueSkillRater) applyUpdate(team []TrueSkillRating, variances []float64, c, v, w, direction float64) []TrueSkillRating {
+	updated := make([]TrueSkillRating, len(team))
+	for i, player := range team {
+		variance := variances[i]
+		muDelta := direction * (variance / c) * v
+		newVariance := variance * (1 - (variance/(c*c))*w)
+		if newVariance < 0 {
+			newVariance = 0
+		}
+
+		updated[i] = TrueSkillRating{
+			Mu:    player.Mu + muDelta*r.muScale,
+			Sigma: math.Sqrt(newVariance),
+		}
+	}
+	return updated
+}
+
+// dynamicVariances returns sigma² + tau² for each player
+func (r *TrueSkillRater) dynamicVariances(team []TrueSkillRating) []float64 {
+	variances := make([]float64, len(team))
+	for i, player := range team {
+		variances[i] = player.Sigma*player.Sigma + r.tau*r.tau
+	}
+	return variances
+}
+
+// teamVariance returns the summed sigma² of a team
+func (r *TrueSkillRater) teamVariance(team []TrueSkillRating) float64 {
+	total := 0.0
+	for _, player := range team {
+		total += player.Sigma * player.Sigma
+	}
+	return total
+}
+
+// teamMu returns the summed team mean in TrueSkill units
+func (r *TrueSkillRater) teamMu(team []TrueSkillRating) float64 {
+	total := 0.0
+	for _, player := range team {
+		total += player.Mu / r.muScale
+	}
+	return total
+}
+
+// drawMargin converts the configured draw probability into a performance margin
+func (r *TrueSkillRater) drawMargin(totalPlayers float64) float64 {
+	if r.drawProbability <= 0 {
+		return 0
+	}
+	return gaussianPPF((r.drawProbability+1)/2) * math.Sqrt(totalPlayers) * r.beta
+}
+
+// vWin is the additive mean correction for a win with performance difference t
+func vWin(t, epsilon float64) float64 {
+	x := t - epsilon
+	denominator := gaussianCDF(x)
+	if denominator < 2.222758749e-162 {
+		return -x
+	}
+	return gaussianPDF(x) / denominator
+}
+
+// wWin is the multiplicative variance correction for a win
+func wWin(t, epsilon float64) float64 {
+	x := t - epsilon
+	v := vWin(t, epsilon)
+	w := v * (v + x)
+	if w <= 0 || w >= 1 {
+		if x < 0 {
+			return 1
+		}
+		return 0
+	}
+	return w
+}
+
+// vDraw is the additive mean correction for a draw
+func vDraw(t, epsilon float64) float64 {
+	absT := math.Abs(t)
+	a := epsilon - absT
+	b := -epsilon - absT
+	denominator := gaussianCDF(a) - gaussianCDF(b)
+	numerator := gaussianPDF(b) - gaussianPDF(a)
+
+	var v float64
+	if denominator < 2.222758749e-162 {
+		v = a
+	} else {
+		v = numerator / denominator
+	}
+	if t < 0 {
+		return -v
+	}
+	return v
+}
+
+// wDraw is the multiplicative variance correction for a draw
+func wDraw(t, epsilon float64) float64 {
+	absT := math.Abs(t)
+	a := epsilon - absT
+	b := -epsilon - absT
+	denominator := gaussianCDF(a) - gaussianCDF(b)
+	if denominator < 2.222758749e-162 {
+		return 1
+	}
+	v := vDraw(absT, epsilon)
+	return v*v + (a*gaussianPDF(a)-b*gaussianPDF(b))/denominator
+}
+
+// gaussianPDF is the standard normal probability density function
+func gaussianPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+// gaussianCDF is the standard normal cumulative distribution function
+func gaussianCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// gaussianPPF is the inverse of the standard normal CDF
+func gaussianPPF(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// sumFloats adds up a slice of floats
+func sumFloats(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
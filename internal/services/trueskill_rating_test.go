@@ -0,0 +1,175 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"usl-server/internal/config"
+)
+
+// standardRater returns a rater with the reference TrueSkill parameters (mu 25, sigma 25/3)
+// so results can be compared against published TrueSkill values
+func standardRater() *TrueSkillRater {
+	return NewTrueSkillRater(&config.Config{
+		TrueSkill: config.TrueSkillConfig{
+			Beta:            25.0 / 6,
+			Tau:             25.0 / 300,
+			DrawProbability: 0.10,
+			MuScale:         1.0,
+		},
+	})
+}
+
+func TestRateMatchOneVsOneWin(t *testing.T) {
+	rater := standardRater()
+	player := TrueSkillRating{Mu: 25, Sigma: 25.0 / 3}
+
+	winner, loser := rater.RateMatch([]TrueSkillRating{player}, []TrueSkillRating{player}, MatchOutcomeTeamAWins)
+
+	assertRating(t, "winner", winner[0], 29.396, 7.171)
+	assertRating(t, "loser", loser[0], 20.604, 7.171)
+}
+
+func TestRateMatchOneVsOneDraw(t *testing.T) {
+	rater := standardRater()
+	player := TrueSkillRating{Mu: 25, Sigma: 25.0 / 3}
+
+	teamA, teamB := rater.RateMatch([]TrueSkillRating{player}, []TrueSkillRating{player}, MatchOutcomeDraw)
+
+	assertRating(t, "team A", teamA[0], 25.0, 6.458)
+	assertRating(t, "team B", teamB[0], 25.0, 6.458)
+}
+
+func TestRateMatchDrawPullsUnequalPlayersTogether(t *testing.T) {
+	for _, drawProbability := range []float64{0, 0.10} {
+		rater := NewTrueSkillRater(&config.Config{
+			TrueSkill: config.TrueSkillConfig{Beta: 25.0 / 6, Tau: 25.0 / 300, DrawProbability: drawProbability, MuScale: 1.0},
+		})
+		favourite := TrueSkillRating{Mu: 30, Sigma: 25.0 / 3}
+		underdog := TrueSkillRating{Mu: 20, Sigma: 25.0 / 3}
+
+		teamA, teamB := rater.RateMatch([]TrueSkillRating{favourite}, []TrueSkillRating{underdog}, MatchOutcomeDraw)
+
+		if teamA[0].Mu >= favourite.Mu {
+			t.Errorf("draw probability %.2f: favourite should lose mu after a draw: %.3f -> %.3f", drawProbability, favourite.Mu, teamA[0].Mu)
+		}
+		if teamB[0].Mu <= underdog.Mu {
+			t.Errorf("draw probability %.2f: underdog should gain mu after a draw: %.3f -> %.3f", drawProbability, underdog.Mu, teamB[0].Mu)
+		}
+	}
+}
+
+func TestRateMatchTeamBWinsKeepsOrder(t *testing.T) {
+	rater := standardRater()
+	teamA := []TrueSkillRating{{Mu: 30, Sigma: 5}, {Mu: 20, Sigma: 8}}
+	teamB := []TrueSkillRating{{Mu: 25, Sigma: 6}, {Mu: 25, Sigma: 6}}
+
+	newA, newB := rater.RateMatch(teamA, teamB, MatchOutcomeTeamBWins)
+
+	if len(newA) != 2 || len(newB) != 2 {
+		t.Fatalf("expected 2 ratings per team, got %d and %d", len(newA), len(newB))
+	}
+	for i := range teamA {
+		if newA[i].Mu >= teamA[i].Mu {
+			t.Errorf("team A player %d should lose mu: %.3f -> %.3f", i, teamA[i].Mu, newA[i].Mu)
+		}
+		if newB[i].Mu <= teamB[i].Mu {
+			t.Errorf("team B player %d should gain mu: %.3f -> %.3f", i, teamB[i].Mu, newB[i].Mu)
+		}
+	}
+
+	// The more uncertain player moves further
+	if teamA[1].Mu-newA[1].Mu <= teamA[0].Mu-newA[0].Mu {
+		t.Errorf("higher sigma player should move more: %.3f vs %.3f", teamA[1].Mu-newA[1].Mu, teamA[0].Mu-newA[0].Mu)
+	}
+}
+
+func TestRateMatchUsesMuScale(t *testing.T) {
+	cfg := &config.Config{TrueSkill: config.TrueSkillConfig{Beta: 25.0 / 6, Tau: 25.0 / 300, MuScale: 40}}
+	rater := NewTrueSkillRater(cfg)
+	player := TrueSkillRating{Mu: 1000, Sigma: 8.333}
+
+	winner, loser := rater.RateMatch([]TrueSkillRating{player}, []TrueSkillRating{player}, MatchOutcomeTeamAWins)
+
+	// Sigma is unitless with respect to the mu scale, the mu change is multiplied by it
+	gain := winner[0].Mu - player.Mu
+	if gain < 100 || gain > 250 {
+		t.Errorf("expected a scaled mu gain between 100 and 250, got %.3f", gain)
+	}
+	if math.Abs((player.Mu-loser[0].Mu)-gain) > 0.001 {
+		t.Errorf("equal players should move symmetrically, got +%.3f / -%.3f", gain, player.Mu-loser[0].Mu)
+	}
+	if winner[0].Sigma >= player.Sigma {
+		t.Errorf("sigma should shrink after a match, got %.3f", winner[0].Sigma)
+	}
+}
+
+func TestNewTrueSkillRaterDefaults(t *testing.T) {
+	rater := NewTrueSkillRater(&config.Config{})
+
+	if rater.beta != DefaultTrueSkillBeta || rater.tau != DefaultTrueSkillTau || rater.muScale != DefaultTrueSkillMuScale {
+		t.Errorf("unset config should fall back to defaults, got beta=%.4f tau=%.4f scale=%.1f", rater.beta, rater.tau, rater.muScale)
+	}
+	if rater.drawProbability != 0 {
+		t.Errorf("draw probability should default to 0, got %.3f", rater.drawProbability)
+	}
+}
+
+func assertRating(t *testing.T, label string, rating TrueSkillRating, expectedMu, expectedSigma float64) {
+	t.Helper()
+	if math.Abs(rating.Mu-expectedMu) > 0.001 {
+		t.Errorf("%s mu: expected %.3f, got %.4f", label, expectedMu, rating.Mu)
+	}
+	if math.Abs(rating.Sigma-expectedSigma) > 0.001 {
+		t.Errorf("%s sigma: expected %.3f, got %.4f", label, expectedSigma, rating.Sigma)
+	}
+}
+
+func TestMatchQualityAndWinProbability(t *testing.T) {
+	rater := standardRater()
+	even := []TrueSkillRating{{Mu: 25, Sigma: 25.0 / 3}}
+
+	// Reference value for two fresh players with the standard parameters
+	if quality := rater.MatchQuality(even, even); math.Abs(quality-0.4472) > 0.0001 {
+		t.Errorf("expected quality 0.4472, got %.4f", quality)
+	}
+
+	win := rater.WinProbability(even, even)
+	loss := rater.WinProbability(even, even)
+	draw := rater.DrawProbability(even, even)
+	if math.Abs(win+loss+draw-1) > 1e-9 {
+		t.Errorf("win/loss/draw should sum to 1, got %.4f", win+loss+draw)
+	}
+
+	stronger := []TrueSkillRating{{Mu: 35, Sigma: 3}}
+	if rater.WinProbability(stronger, even) <= 0.5 {
+		t.Errorf("stronger team should be favoured")
+	}
+	if rater.MatchQuality(stronger, even) >= rater.MatchQuality(even, even) {
+		t.Errorf("a mismatch should have lower quality than an even match")
+	}
+}
+
+func TestPredictMatchOutcome(t *testing.T) {
+	service := &UserTrueSkillService{config: &config.Config{}}
+
+	teamA := []TrueSkillRating{{Mu: 1200, Sigma: 4}, {Mu: 1100, Sigma: 4}}
+	teamB := []TrueSkillRating{{Mu: 1000, Sigma: 4}, {Mu: 1000, Sigma: 4}}
+
+	prediction, err := service.PredictMatchOutcome(teamA, teamB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prediction.TeamAWinProbability <= prediction.TeamBWinProbability {
+		t.Errorf("team A should be favoured: %.4f vs %.4f", prediction.TeamAWinProbability, prediction.TeamBWinProbability)
+	}
+	if prediction.DrawProbability != 0 {
+		t.Errorf("draws are disabled by default, got %.4f", prediction.DrawProbability)
+	}
+	if prediction.TeamAMu != 2300 || prediction.TeamBMu != 2000 {
+		t.Errorf("unexpected team totals %.1f / %.1f", prediction.TeamAMu, prediction.TeamBMu)
+	}
+
+	if _, err := service.PredictMatchOutcome(teamA, nil); err == nil {
+		t.Errorf("expected an error for an empty team")
+	}
+}
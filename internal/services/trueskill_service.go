@@ -3,6 +3,7 @@ package services
 import (
 	"fmt"
 	"log"
+	"math"
 	"time"
 	"usl-server/internal/config"
 	"usl-server/internal/models"
@@ -11,34 +12,19 @@ import (
 
 // UserTrueSkillService manages TrueSkill calculations and updates for individual users and batch operations.
 // Service Responsibilities:
-// - Individual user TrueSkill calculation from tracker data
-// - Batch TrueSkill updates for all users
+// - Individual user TrueSkill calculation from tracker data, seeded by the rating engine
+// - Combining alt account trackers with the configured aggregation before seeding
 // - Default TrueSkill assignment for users without trackers
-// - TrueSkill recalculation workflows
+// Batch recalculations of every player run as reviewable jobs in RecalculationService.
 type UserTrueSkillService struct {
 	trackerRepo               *repositories.TrackerRepository
 	userRepo                  *repositories.UserRepository
-	mmrCalculator             *MMRCalculator
-	uncertaintyCalculator     *EnhancedUncertaintyCalculator
+	engine                    RatingEngine
 	dataTransformationService *DataTransformationService
+	rater                     *TrueSkillRater
 	config                    *config.Config
 }
 
-// BatchUpdateResult represents batch processing results
-type BatchUpdateResult struct {
-	ProcessedCount    int                        `json:"processedCount"`
-	TrackerBasedCount int                        `json:"trackerBasedCount"`
-	DefaultCount      int                        `json:"defaultCount"`
-	Errors            []TrueSkillProcessingError `json:"errors"`
-}
-
-// TrueSkillProcessingError represents errors during batch processing
-type TrueSkillProcessingError struct {
-	User      string `json:"user"`
-	DiscordID string `json:"discordId"`
-	Error     string `json:"error"`
-}
-
 // TrueSkillUpdateResult represents individual user update results
 type TrueSkillUpdateResult struct {
 	Success         bool                  `json:"success"`
@@ -47,133 +33,42 @@ type TrueSkillUpdateResult struct {
 	Error           string                `json:"error,omitempty"`
 }
 
+// MatchOutcomePrediction represents the expected outcome of a two-team match
+type MatchOutcomePrediction struct {
+	TeamAWinProbability float64 `json:"teamAWinProbability"`
+	TeamBWinProbability float64 `json:"teamBWinProbability"`
+	DrawProbability     float64 `json:"drawProbability"`
+	MatchQuality        float64 `json:"matchQuality"`
+	TeamAMu             float64 `json:"teamAMu"`
+	TeamBMu             float64 `json:"teamBMu"`
+}
+
 // TrueSkillCalculation represents TrueSkill calculation results
 type TrueSkillCalculation struct {
-	Mu          float64                `json:"mu"`
-	Sigma       float64                `json:"sigma"`
-	SkillResult *PercentileSkillResult `json:"skillResult"`
-	LastUpdated time.Time              `json:"lastUpdated"`
+	Mu          float64                 `json:"mu"`
+	Sigma       float64                 `json:"sigma"`
+	SkillResult *PercentileSkillResult  `json:"skillResult"`
+	Uncertainty *UncertaintyExplanation `json:"uncertainty,omitempty"`
+	LastUpdated time.Time               `json:"lastUpdated"`
 }
 
 func NewUserTrueSkillService(
 	trackerRepo *repositories.TrackerRepository,
 	userRepo *repositories.UserRepository,
-	mmrCalculator *MMRCalculator,
-	uncertaintyCalculator *EnhancedUncertaintyCalculator,
+	engine RatingEngine,
 	dataTransformationService *DataTransformationService,
 	config *config.Config,
 ) *UserTrueSkillService {
 	return &UserTrueSkillService{
 		trackerRepo:               trackerRepo,
 		userRepo:                  userRepo,
-		mmrCalculator:             mmrCalculator,
-		uncertaintyCalculator:     uncertaintyCalculator,
+		engine:                    engine,
 		dataTransformationService: dataTransformationService,
+		rater:                     NewTrueSkillRater(config),
 		config:                    config,
 	}
 }
 
-// UpdateAllUserTrueSkill updates TrueSkill values for all users
-// Exact port of JavaScript updateAllUserTrueSkill() function
-func (s *UserTrueSkillService) UpdateAllUserTrueSkill() (*BatchUpdateResult, error) {
-
-	allUsers, err := s.userRepo.GetAllUsers(false) // Get all users, not just active
-	if err != nil {
-		return nil, fmt.Errorf("failed to get all users: %w", err)
-	}
-
-	if len(allUsers) == 0 {
-		log.Println("UserTrueSkillService: No users found in repository")
-		return &BatchUpdateResult{
-			ProcessedCount:    0,
-			TrackerBasedCount: 0,
-			DefaultCount:      0,
-			Errors:            []TrueSkillProcessingError{},
-		}, nil
-	}
-
-	log.Printf("UserTrueSkillService: Processing %d users for batch TrueSkill update", len(allUsers))
-
-	var trackerBasedCount int
-	var defaultCount int
-	var errors []TrueSkillProcessingError
-	var usersToUpdate []models.User
-
-	// Calculate TrueSkill for all users and build batch update data
-	for _, user := range allUsers {
-		updatedUser := *user // Copy user data
-
-		// Try to get trackers and calculate TrueSkill
-		trackers, err := s.getUserTrackersForTrueSkill(user.DiscordID)
-		if err != nil || len(trackers) == 0 {
-			// No trackers - use existing values or defaults
-			defaultCount++
-			if !user.HasTrueSkillData() {
-				// User has no TrueSkill data, set defaults
-				s.setDefaultTrueSkillValues(&updatedUser)
-			}
-			updatedUser.TrueSkillLastUpdated = time.Now()
-			log.Printf("Keeping existing TrueSkill for %s: μ=%.1f", user.Name, updatedUser.TrueSkillMu)
-		} else {
-			// User has trackers - calculate new TrueSkill values
-			trackerData, err := s.dataTransformationService.PrepareTrackerDataForCalculation(trackers[0])
-			if err != nil {
-				log.Printf("Failed to prepare tracker data for %s: %v", user.Name, err)
-				errors = append(errors, TrueSkillProcessingError{
-					User:      user.Name,
-					DiscordID: user.DiscordID,
-					Error:     fmt.Sprintf("failed to prepare tracker data: %v", err),
-				})
-				// Fall back to existing values
-				defaultCount++
-				updatedUser.TrueSkillLastUpdated = time.Now()
-				continue
-			}
-
-			trueSkillResult, err := s.calculateTrueSkillValues(trackerData)
-			if err != nil {
-				log.Printf("Failed to calculate TrueSkill for %s: %v", user.Name, err)
-				errors = append(errors, TrueSkillProcessingError{
-					User:      user.Name,
-					DiscordID: user.DiscordID,
-					Error:     fmt.Sprintf("failed to calculate TrueSkill: %v", err),
-				})
-				// Fall back to existing values
-				defaultCount++
-				updatedUser.TrueSkillLastUpdated = time.Now()
-				continue
-			}
-
-			trackerBasedCount++
-			updatedUser.TrueSkillMu = trueSkillResult.Mu
-			updatedUser.TrueSkillSigma = trueSkillResult.Sigma
-			updatedUser.TrueSkillLastUpdated = trueSkillResult.LastUpdated
-			log.Printf("Calculated TrueSkill for %s: μ=%.1f", user.Name, trueSkillResult.Mu)
-		}
-
-		usersToUpdate = append(usersToUpdate, updatedUser)
-	}
-
-	// Batch update all users
-	log.Printf("UserTrueSkillService: Batch updating %d users with new TrueSkill values", len(usersToUpdate))
-	successCount, err := s.userRepo.BatchUpdateTrueSkill(usersToUpdate)
-	if err != nil {
-		return nil, fmt.Errorf("batch update failed: %w", err)
-	}
-
-	log.Printf("UserTrueSkillService: Batch update completed successfully, updated %d users", successCount)
-
-	result := &BatchUpdateResult{
-		ProcessedCount:    len(allUsers),
-		TrackerBasedCount: trackerBasedCount,
-		DefaultCount:      defaultCount,
-		Errors:            errors,
-	}
-
-	s.reportBatchUpdateResults(result)
-	return result, nil
-}
-
 // UpdateUserTrueSkillFromTrackerData updates TrueSkill for a single user from provided tracker data
 // This method bypasses the repository layer and accepts TrackerData directly
 func (s *UserTrueSkillService) UpdateUserTrueSkillFromTrackerData(trackerData *TrackerData) *TrueSkillUpdateResult {
@@ -248,6 +143,39 @@ func (s *UserTrueSkillService) CalculateTrueSkillFromTrackerData(trackerData *Tr
 	}
 }
 
+// PredictMatchOutcome calculates win, draw and match quality for two teams without database operations
+// This is a pure calculation method used to show the expected outcome before a series is played
+func (s *UserTrueSkillService) PredictMatchOutcome(teamA, teamB []TrueSkillRating) (*MatchOutcomePrediction, error) {
+	if len(teamA) == 0 || len(teamB) == 0 {
+		return nil, fmt.Errorf("both teams need at least one player")
+	}
+	for _, rating := range append(append([]TrueSkillRating{}, teamA...), teamB...) {
+		if rating.Sigma <= 0 || rating.Mu < 0 {
+			return nil, fmt.Errorf("invalid rating μ=%.3f σ=%.3f", rating.Mu, rating.Sigma)
+		}
+	}
+
+	rater := s.rater
+	if rater == nil {
+		rater = NewTrueSkillRater(s.config)
+	}
+
+	prediction := &MatchOutcomePrediction{
+		TeamAWinProbability: roundProbability(rater.WinProbability(teamA, teamB)),
+		TeamBWinProbability: roundProbability(rater.WinProbability(teamB, teamA)),
+		DrawProbability:     roundProbability(rater.DrawProbability(teamA, teamB)),
+		MatchQuality:        roundProbability(rater.MatchQuality(teamA, teamB)),
+	}
+	for _, rating := range teamA {
+		prediction.TeamAMu += rating.Mu
+	}
+	for _, rating := range teamB {
+		prediction.TeamBMu += rating.Mu
+	}
+
+	return prediction, nil
+}
+
 // UpdateUserTrueSkillFromTrackers updates TrueSkill for a single user from their tracker data
 // Exact port of JavaScript updateUserTrueSkillFromTrackers() function
 func (s *UserTrueSkillService) UpdateUserTrueSkillFromTrackers(discordID string) *TrueSkillUpdateResult {
@@ -259,7 +187,7 @@ func (s *UserTrueSkillService) UpdateUserTrueSkillFromTrackers(discordID string)
 		}
 	}
 
-	trackerData, err := s.dataTransformationService.PrepareTrackerDataForCalculation(trackers[0])
+	trackerData, err := s.dataTransformationService.AggregateTrackers(trackers, s.config.MMR.TrackerAggregation)
 	if err != nil {
 		return &TrueSkillUpdateResult{
 			Success:     false,
@@ -297,13 +225,6 @@ func (s *UserTrueSkillService) UpdateUserTrueSkillFromTrackers(discordID string)
 	}
 }
 
-// RecalculateAllUserTrueSkill recalculates TrueSkill values for all users
-// Exact port of JavaScript recalculateAllUserTrueSkill() function
-func (s *UserTrueSkillService) RecalculateAllUserTrueSkill() (*BatchUpdateResult, error) {
-	log.Println("UserTrueSkillService: Recalculating all user TrueSkill values (delegating to UpdateAllUserTrueSkill)")
-	return s.UpdateAllUserTrueSkill()
-}
-
 // getUserTrackersForTrueSkill gets valid trackers for a user's TrueSkill calculation
 // Exact port of JavaScript _getUserTrackersForTrueSkill() function
 func (s *UserTrueSkillService) getUserTrackersForTrueSkill(discordID string) ([]*models.UserTracker, error) {
@@ -322,37 +243,12 @@ func (s *UserTrueSkillService) getUserTrackersForTrueSkill(discordID string) ([]
 }
 
 // calculateTrueSkillValues calculates TrueSkill values from tracker data
-// Exact port of JavaScript _calculateTrueSkillValues() function
+// Exact port of JavaScript _calculateTrueSkillValues() function; the seed itself comes from the rating engine
 func (s *UserTrueSkillService) calculateTrueSkillValues(trackerData *TrackerData) (*TrueSkillCalculation, error) {
-	// Calculate percentile-based TrueSkill seeding using structured object format
-	playerData := PlayerData{
-		Ones: PlaylistData{
-			Current:  PlaylistSeasonData{MMR: trackerData.OnesCurrentPeak, Games: trackerData.OnesCurrentGames},
-			Previous: PlaylistSeasonData{MMR: trackerData.OnesPreviousPeak, Games: trackerData.OnesPreviousGames},
-		},
-		Twos: PlaylistData{
-			Current:  PlaylistSeasonData{MMR: trackerData.TwosCurrentPeak, Games: trackerData.TwosCurrentGames},
-			Previous: PlaylistSeasonData{MMR: trackerData.TwosPreviousPeak, Games: trackerData.TwosPreviousGames},
-		},
-		Threes: PlaylistData{
-			Current:  PlaylistSeasonData{MMR: trackerData.ThreesCurrentPeak, Games: trackerData.ThreesCurrentGames},
-			Previous: PlaylistSeasonData{MMR: trackerData.ThreesPreviousPeak, Games: trackerData.ThreesPreviousGames},
-		},
-	}
-
-	skillResult := s.mmrCalculator.CalculatePercentileBasedSkill(playerData)
-
-	trueskillSigma, err := s.uncertaintyCalculator.CalculateEnhancedUncertainty(trackerData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to calculate enhanced uncertainty: %w", err)
+	if s.engine == nil {
+		return nil, fmt.Errorf("no rating engine configured")
 	}
-
-	return &TrueSkillCalculation{
-		Mu:          skillResult.TrueskillMu,
-		Sigma:       trueskillSigma,
-		SkillResult: &skillResult,
-		LastUpdated: time.Now(),
-	}, nil
+	return s.engine.Seed(trackerData)
 }
 
 // updateUserWithTrueSkillValues updates a user's TrueSkill values in the database
@@ -379,21 +275,9 @@ func (s *UserTrueSkillService) setDefaultTrueSkillValues(user *models.User) {
 		user.DiscordID, defaultMu, defaultSigma)
 }
 
-// reportBatchUpdateResults reports the results of batch processing
-// Exact port of JavaScript _reportBatchUpdateResults() function
-func (s *UserTrueSkillService) reportBatchUpdateResults(result *BatchUpdateResult) {
-	log.Printf("UserTrueSkillService: Successfully processed %d users (%d given default values)",
-		result.ProcessedCount, result.DefaultCount)
-
-	if len(result.Errors) > 0 {
-		log.Printf("UserTrueSkillService: %d errors occurred during batch processing", len(result.Errors))
-		for _, err := range result.Errors {
-			log.Printf("  - User %s (%s): %s", err.User, err.DiscordID, err.Error)
-		}
-	}
-
-	log.Printf("TrueSkill update complete! Processed %d users. %d calculated from trackers, %d given default values.",
-		result.ProcessedCount, result.TrackerBasedCount, result.DefaultCount)
+// roundProbability rounds a probability to 4 decimal places for display
+func roundProbability(value float64) float64 {
+	return math.Round(value*10000) / 10000
 }
 
 // GetTrueSkillStats returns service statistics
@@ -405,8 +289,7 @@ func (s *UserTrueSkillService) GetTrueSkillStats() map[string]interface{} {
 		"dependencies": map[string]bool{
 			"trackerRepo":               s.trackerRepo != nil,
 			"userRepo":                  s.userRepo != nil,
-			"mmrCalculator":             s.mmrCalculator != nil,
-			"uncertaintyCalculator":     s.uncertaintyCalculator != nil,
+			"ratingEngine":              s.engine != nil,
 			"dataTransformationService": s.dataTransformationService != nil,
 		},
 	}
@@ -31,7 +31,7 @@ func TestTrueSkillCalculationAccuracy(t *testing.T) {
 
 	percentileConverter := NewPercentileConverter(cfg)
 	mmrCalculator := NewMMRCalculator(cfg, percentileConverter)
-	enhancedUncertainty := NewEnhancedUncertaintyCalculator(cfg)
+	enhancedUncertainty := NewEnhancedUncertaintyCalculator(cfg, percentileConverter)
 	_ = NewDataTransformationService() // Unused in this test but available if needed
 
 	// Test cases derived from JavaScript tests
@@ -255,7 +255,7 @@ func TestTrueSkillServiceIntegration(t *testing.T) {
 		},
 	}
 
-	enhancedUncertainty := NewEnhancedUncertaintyCalculator(cfg)
+	enhancedUncertainty := NewEnhancedUncertaintyCalculator(cfg, NewPercentileConverter(cfg))
 
 	// Test calculation bounds
 	trackerData := &TrackerData{
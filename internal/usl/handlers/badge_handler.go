@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"usl-server/internal/services"
+)
+
+// BadgeHandler serves public rank badges for Discord embeds and profile pages
+type BadgeHandler struct {
+	rankService *services.RankService
+}
+
+func NewBadgeHandler(rankService *services.RankService) *BadgeHandler {
+	return &BadgeHandler{
+		rankService: rankService,
+	}
+}
+
+// RankBadge handles GET /badges/{discord_id}.svg?playlist=
+// Shows the player's highest rank, or their rank in the given playlist (ones, 2v2, doubles...)
+func (h *BadgeHandler) RankBadge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/badges/")
+	discordID := strings.TrimSuffix(name, ".svg")
+	if discordID == "" || discordID == name || strings.Contains(discordID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	badge, err := h.rankService.Badge(discordID, r.URL.Query().Get("playlist"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidRankLookup):
+			http.Error(w, "Invalid playlist", http.StatusBadRequest)
+		case errors.Is(err, services.ErrRankNotFound):
+			http.NotFound(w, r)
+		default:
+			log.Printf("[USL-HANDLER] Rank badge for %s unavailable: %v", discordID, err)
+			http.Error(w, "Failed to render badge", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	// Embeds refetch often; a short cache keeps badges fresh after tracker updates
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if _, err := w.Write(badge); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write rank badge for %s: %v", discordID, err)
+	}
+}
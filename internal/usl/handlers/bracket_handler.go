@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// BracketHandler serves the tournament bracket admin pages
+type BracketHandler struct {
+	bracketService *services.BracketService
+	seasonService  *services.SeasonService
+	guildRepo      *repositories.GuildRepository
+	userRepo       *repositories.UserRepository
+	templates      *template.Template
+}
+
+// BracketMatchView is a bracket match with its entrants attached for rendering
+type BracketMatchView struct {
+	models.BracketMatch
+	EntrantA  *models.BracketEntrant
+	EntrantB  *models.BracketEntrant
+	WinnerIsA bool
+	WinnerIsB bool
+}
+
+// BracketRoundView is one column of the rendered bracket
+type BracketRoundView struct {
+	Title   string
+	Matches []BracketMatchView
+}
+
+func NewBracketHandler(bracketService *services.BracketService, seasonService *services.SeasonService, guildRepo *repositories.GuildRepository, userRepo *repositories.UserRepository, templates *template.Template) *BracketHandler {
+	return &BracketHandler{
+		bracketService: bracketService,
+		seasonService:  seasonService,
+		guildRepo:      guildRepo,
+		userRepo:       userRepo,
+		templates:      templates,
+	}
+}
+
+// Brackets handles GET /usl/admin/brackets
+// Lists the guild's brackets with the form to create a new one
+func (h *BracketHandler) Brackets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	brackets, err := h.bracketService.GetBrackets(guildID)
+	if err != nil {
+		h.handleError(w, "load brackets", err)
+		return
+	}
+
+	data := struct {
+		Title       string
+		CurrentPage string
+		GuildID     int64
+		Brackets    []*models.Bracket
+	}{
+		Title:       "Brackets",
+		CurrentPage: "brackets",
+		GuildID:     guildID,
+		Brackets:    brackets,
+	}
+
+	h.renderTemplate(w, TemplateUSLBrackets, data)
+}
+
+// BracketDetail handles GET /usl/admin/brackets/detail?id=
+// Renders the bracket round by round with result forms on ready matches
+func (h *BracketHandler) BracketDetail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	bracketID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || bracketID <= 0 {
+		http.Error(w, "Invalid bracket ID", http.StatusBadRequest)
+		return
+	}
+
+	bracket, err := h.bracketService.GetBracket(bracketID)
+	if err != nil {
+		log.Printf("[USL-HANDLER] Bracket %d not found: %v", bracketID, err)
+		http.Error(w, "Bracket not found", http.StatusNotFound)
+		return
+	}
+
+	var standings []services.SwissStanding
+	if bracket.Format == models.BracketFormatSwiss {
+		standings = h.bracketService.SwissStandings(bracket)
+	}
+
+	data := struct {
+		Title       string
+		CurrentPage string
+		Bracket     *models.Bracket
+		Rounds      []BracketRoundView
+		Standings   []services.SwissStanding
+	}{
+		Title:       bracket.Name,
+		CurrentPage: "brackets",
+		Bracket:     bracket,
+		Rounds:      buildBracketRounds(bracket),
+		Standings:   standings,
+	}
+
+	h.renderTemplate(w, TemplateUSLBracketDetail, data)
+}
+
+// CreateBracket handles POST /usl/admin/brackets/create
+// Entrants are one per line: "Team Name: discord_id, discord_id" or a single Discord ID
+func (h *BracketHandler) CreateBracket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	swissRounds := 0
+	if value := r.FormValue("swiss_rounds"); value != "" {
+		if swissRounds, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid swiss rounds", http.StatusBadRequest)
+			return
+		}
+	}
+
+	request := models.BracketCreateRequest{
+		GuildID:       guildID,
+		Name:          r.FormValue("name"),
+		Format:        r.FormValue("format"),
+		Seeding:       r.FormValue("seeding"),
+		SwissRounds:   swissRounds,
+		RecordRatings: r.FormValue("record_ratings") == "on",
+		Entrants:      h.parseEntrants(r.FormValue("entrants")),
+	}
+
+	if season, err := h.seasonService.GetActiveSeason(guildID); err == nil && season != nil {
+		request.SeasonID = &season.ID
+	}
+
+	bracket, err := h.bracketService.CreateBracket(request)
+	if err != nil {
+		h.handleError(w, "create bracket", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/brackets/detail?id=%d", bracket.ID), http.StatusSeeOther)
+}
+
+// ReportResult handles POST /usl/admin/brackets/report
+func (h *BracketHandler) ReportResult(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	bracketID, err := strconv.ParseInt(r.FormValue("bracket_id"), 10, 64)
+	if err != nil || bracketID <= 0 {
+		http.Error(w, "Invalid bracket ID", http.StatusBadRequest)
+		return
+	}
+	matchID, err := strconv.ParseInt(r.FormValue("match_id"), 10, 64)
+	if err != nil || matchID <= 0 {
+		http.Error(w, "Invalid match ID", http.StatusBadRequest)
+		return
+	}
+	scoreA, errA := strconv.Atoi(r.FormValue("score_a"))
+	scoreB, errB := strconv.Atoi(r.FormValue("score_b"))
+	if errA != nil || errB != nil {
+		http.Error(w, "Invalid score", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.bracketService.ReportResult(bracketID, matchID, scoreA, scoreB, nil)
+	if err != nil {
+		h.handleError(w, "report result", err)
+		return
+	}
+
+	for _, message := range result.Errors {
+		log.Printf("[USL-HANDLER] Bracket %d result warning: %s", bracketID, message)
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/brackets/detail?id=%d", bracketID), http.StatusSeeOther)
+}
+
+// parseEntrants reads one entrant per line; a bare Discord ID is named after the user when known
+func (h *BracketHandler) parseEntrants(text string) []models.BracketEntrantRequest {
+	var entrants []models.BracketEntrantRequest
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var entrant models.BracketEntrantRequest
+		players := line
+		if name, ids, found := strings.Cut(line, ":"); found {
+			entrant.Name = strings.TrimSpace(name)
+			players = ids
+		}
+		for _, discordID := range strings.Split(players, ",") {
+			if discordID = strings.TrimSpace(discordID); discordID != "" {
+				entrant.DiscordIDs = append(entrant.DiscordIDs, discordID)
+			}
+		}
+
+		if entrant.Name == "" && len(entrant.DiscordIDs) == 1 {
+			if user, err := h.userRepo.FindUserByDiscordID(entrant.DiscordIDs[0]); err == nil && user != nil {
+				entrant.Name = user.Name
+			}
+		}
+		entrants = append(entrants, entrant)
+	}
+	return entrants
+}
+
+// buildBracketRounds groups matches into columns: winners rounds, losers rounds, then the grand final
+func buildBracketRounds(bracket *models.Bracket) []BracketRoundView {
+	sides := []string{models.BracketSideWinners, models.BracketSideLosers, models.BracketSideGrandFinal, models.BracketSideSwiss}
+
+	var rounds []BracketRoundView
+	for _, side := range sides {
+		lastRound := 0
+		for _, match := range bracket.Matches {
+			if match.Side == side && match.Round > lastRound {
+				lastRound = match.Round
+			}
+		}
+
+		for round := 1; round <= lastRound; round++ {
+			view := BracketRoundView{Title: bracketRoundTitle(bracket.Format, side, round, lastRound)}
+			for _, match := range bracket.Matches {
+				if match.Side != side || match.Round != round {
+					continue
+				}
+				matchView := BracketMatchView{BracketMatch: match}
+				if match.EntrantAID != nil {
+					matchView.EntrantA = bracket.EntrantByID(*match.EntrantAID)
+				}
+				if match.EntrantBID != nil {
+					matchView.EntrantB = bracket.EntrantByID(*match.EntrantBID)
+				}
+				if match.WinnerEntrantID != nil {
+					matchView.WinnerIsA = match.EntrantAID != nil && *match.WinnerEntrantID == *match.EntrantAID
+					matchView.WinnerIsB = match.EntrantBID != nil && *match.WinnerEntrantID == *match.EntrantBID
+				}
+				view.Matches = append(view.Matches, matchView)
+			}
+			rounds = append(rounds, view)
+		}
+	}
+	return rounds
+}
+
+// bracketRoundTitle names a bracket column
+func bracketRoundTitle(format, side string, round, lastRound int) string {
+	switch side {
+	case models.BracketSideGrandFinal:
+		if round > 1 {
+			return "Grand Final Reset"
+		}
+		return "Grand Final"
+	case models.BracketSideLosers:
+		if round == lastRound {
+			return "Losers Final"
+		}
+		return fmt.Sprintf("Losers Round %d", round)
+	case models.BracketSideSwiss:
+		return fmt.Sprintf("Round %d", round)
+	}
+
+	if format == models.BracketFormatDoubleElimination {
+		if round == lastRound {
+			return "Winners Final"
+		}
+		return fmt.Sprintf("Winners Round %d", round)
+	}
+	switch lastRound - round {
+	case 0:
+		return "Final"
+	case 1:
+		return "Semifinals"
+	default:
+		return fmt.Sprintf("Round %d", round)
+	}
+}
+
+// handleError maps bracket errors to client errors and everything else to a 500
+func (h *BracketHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrInvalidBracket) || errors.Is(err, services.ErrBracketMatchNotReady) ||
+		errors.Is(err, services.ErrBracketAlreadyDecided) || errors.Is(err, services.ErrInvalidMatchReport) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *BracketHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
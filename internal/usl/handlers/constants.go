@@ -4,24 +4,74 @@ package handlers
 const (
 	// HTTP method errors
 	msgMethodNotAllowed = "method not allowed"
+	msgRouteNotFound    = "route not found"
 
 	// Request validation errors
 	msgInvalidRequestBody = "invalid request body"
 	msgValidationFailed   = "validation failed"
 	msgInvalidSortField   = "invalid sort field"
+	msgInvalidGameSeason  = "invalid game season"
+	msgInvalidMMR         = "invalid mmr"
+	msgInvalidPlaylist    = "invalid playlist"
 
 	// Operation errors
-	msgBulkOperationFailed   = "bulk operation failed"
-	msgFailedToGetUsers      = "failed to get users"
-	msgFailedToCreateUser    = "failed to create user"
-	msgUserAlreadyExists     = "user already exists"
-	msgFailedToGetTrackers   = "failed to get trackers"
-	msgFailedToCreateTracker = "failed to create tracker"
-	msgTrackerAlreadyExists  = "tracker already exists"
+	msgBulkOperationFailed            = "bulk operation failed"
+	msgFailedToGetUsers               = "failed to get users"
+	msgFailedToCreateUser             = "failed to create user"
+	msgUserAlreadyExists              = "user already exists"
+	msgFailedToGetTrackers            = "failed to get trackers"
+	msgFailedToCreateTracker          = "failed to create tracker"
+	msgTrackerAlreadyExists           = "tracker already exists"
+	msgFailedToRecordMatch            = "failed to record match"
+	msgFailedToGetMatches             = "failed to get matches"
+	msgGuildRequired                  = "guild could not be resolved"
+	msgFailedToResolveRatings         = "failed to resolve player ratings"
+	msgBalanceFailed                  = "could not balance teams"
+	msgFailedToGetSchedule            = "failed to get schedule"
+	msgFailedToGenerateSchedule       = "failed to generate schedule"
+	msgFailedToGetPlacements          = "failed to get placements"
+	msgPlacementNotFound              = "player has not been placed"
+	msgFailedToGetTeams               = "failed to get teams"
+	msgRosterChangeRejected           = "roster change rejected"
+	msgFailedToChangeRoster           = "failed to change roster"
+	msgFailedToGetDrafts              = "failed to get drafts"
+	msgFailedToGetStandings           = "failed to get standings"
+	msgFailedToRecordResult           = "failed to record result"
+	msgFailedToImportReplays          = "failed to import replays"
+	msgFailedToGetStats               = "failed to get stats"
+	msgFailedToSaveStatLine           = "failed to save stat line"
+	msgFailedToGetRatingEngine        = "failed to get rating engine"
+	msgFailedToUpdateRatingEngine     = "failed to update rating engine"
+	msgFailedToGetDecayConfig         = "failed to get decay config"
+	msgFailedToUpdateDecayConfig      = "failed to update decay config"
+	msgFailedToRunDecay               = "failed to run decay"
+	msgFailedToGetRankDistribution    = "failed to get rank distribution"
+	msgFailedToUploadRankDistribution = "failed to upload rank distribution"
+	msgFailedToExplainRating          = "failed to explain rating"
+	msgFailedToRunSimulation          = "failed to run simulation"
+	msgFailedToGetRecalculations      = "failed to get recalculations"
+	msgFailedToCreateRecalculation    = "failed to create recalculation"
+	msgFailedToApplyRecalculation     = "failed to apply recalculation"
+	msgFailedToRevertRecalculation    = "failed to revert recalculation"
+	msgFailedToDiscardRecalculation   = "failed to discard recalculation"
+	msgFailedToLookUpRank             = "failed to look up rank"
+	msgFailedToGetLeaderboard         = "failed to get leaderboard"
 
 	// Success messages
-	msgUserCreatedSuccessfully    = "user created successfully"
-	msgTrackerCreatedSuccessfully = "tracker created successfully"
+	msgUserCreatedSuccessfully              = "user created successfully"
+	msgTrackerCreatedSuccessfully           = "tracker created successfully"
+	msgMatchRecordedSuccessfully            = "match recorded successfully"
+	msgScheduleGeneratedSuccessfully        = "schedule generated successfully"
+	msgRosterChangedSuccessfully            = "roster changed successfully"
+	msgResultRecordedSuccessfully           = "result recorded successfully"
+	msgStatLineSavedSuccessfully            = "stat line saved successfully"
+	msgRatingEngineUpdatedSuccessfully      = "rating engine updated successfully"
+	msgDecayConfigUpdatedSuccessfully       = "decay config updated successfully"
+	msgRankDistributionUploadedSuccessfully = "rank distribution uploaded successfully"
+	msgRecalculationCreatedSuccessfully     = "recalculation created, review and apply it to update ratings"
+	msgRecalculationAppliedSuccessfully     = "recalculation applied successfully"
+	msgRecalculationRevertedSuccessfully    = "recalculation reverted successfully"
+	msgRecalculationDiscardedSuccessfully   = "recalculation discarded successfully"
 
 	// Allowed sort fields
 	allowedUserSortFields    = "id, name, discord_id, mmr, trueskill_mu, trueskill_sigma, created_at, updated_at, trueskill_last_updated"
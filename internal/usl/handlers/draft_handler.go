@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// DraftHandler serves the draft room and its live board
+type DraftHandler struct {
+	draftService  *services.DraftService
+	rosterService *services.RosterService
+	guildRepo     *repositories.GuildRepository
+	templates     *template.Template
+}
+
+// DraftBoardView is the board fragment's data, re-rendered on every poll
+type DraftBoardView struct {
+	Board *services.DraftBoard
+	Error string // a refused pick, shown above the board
+}
+
+func NewDraftHandler(draftService *services.DraftService, rosterService *services.RosterService, guildRepo *repositories.GuildRepository, templates *template.Template) *DraftHandler {
+	return &DraftHandler{
+		draftService:  draftService,
+		rosterService: rosterService,
+		guildRepo:     guildRepo,
+		templates:     templates,
+	}
+}
+
+// Drafts handles GET /usl/admin/drafts?draft_id=
+// Lists the guild's drafts and opens the selected draft's room, whose board polls for updates
+func (h *DraftHandler) Drafts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	drafts, err := h.draftService.GetDrafts(guildID)
+	if err != nil {
+		h.handleError(w, "load drafts", err)
+		return
+	}
+
+	teams, err := h.rosterService.GetTeams(guildID)
+	if err != nil {
+		h.handleError(w, "load teams", err)
+		return
+	}
+
+	var selected *models.Draft
+	if draftIDParam := r.URL.Query().Get("draft_id"); draftIDParam != "" {
+		draftID, err := strconv.ParseInt(draftIDParam, 10, 64)
+		if err != nil || draftID <= 0 {
+			http.Error(w, "Invalid draft ID", http.StatusBadRequest)
+			return
+		}
+		for _, draft := range drafts {
+			if draft.ID == draftID {
+				selected = draft
+			}
+		}
+		if selected == nil {
+			http.Error(w, "Draft not found", http.StatusNotFound)
+			return
+		}
+	} else if len(drafts) > 0 {
+		selected = drafts[0]
+	}
+
+	data := struct {
+		Title       string
+		CurrentPage string
+		GuildID     int64
+		Drafts      []*models.Draft
+		Teams       []*models.Team
+		Draft       *models.Draft
+	}{
+		Title:       "Draft",
+		CurrentPage: "drafts",
+		GuildID:     guildID,
+		Drafts:      drafts,
+		Teams:       teams,
+		Draft:       selected,
+	}
+
+	h.renderTemplate(w, TemplateUSLDrafts, data)
+}
+
+// Board handles GET /usl/admin/drafts/board?draft_id=
+// Renders the board fragment that captains' browsers poll; an expired pick timer auto-picks here
+func (h *DraftHandler) Board(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	draftID, err := strconv.ParseInt(r.URL.Query().Get("draft_id"), 10, 64)
+	if err != nil || draftID <= 0 {
+		http.Error(w, "Invalid draft ID", http.StatusBadRequest)
+		return
+	}
+
+	h.renderBoard(w, draftID, "")
+}
+
+// CreateDraft handles POST /usl/admin/drafts/create
+// Teams join the draft in the order of their order_{team_id} fields; blank fields are left out
+func (h *DraftHandler) CreateDraft(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := models.DraftCreateRequest{
+		GuildID: guildID,
+		Name:    r.FormValue("name"),
+	}
+	if request.Rounds, err = strconv.Atoi(r.FormValue("rounds")); err != nil {
+		http.Error(w, "Invalid number of rounds", http.StatusBadRequest)
+		return
+	}
+	if value := strings.TrimSpace(r.FormValue("pick_seconds")); value != "" {
+		if request.PickSeconds, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid pick timer", http.StatusBadRequest)
+			return
+		}
+	}
+	if request.TeamOrder, err = parseDraftOrder(r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	draft, err := h.draftService.CreateDraft(request)
+	if err != nil {
+		h.handleError(w, "create draft", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/drafts?draft_id=%d", draft.ID), http.StatusSeeOther)
+}
+
+// StartDraft handles POST /usl/admin/drafts/start
+func (h *DraftHandler) StartDraft(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	draftID, err := strconv.ParseInt(r.FormValue("draft_id"), 10, 64)
+	if err != nil || draftID <= 0 {
+		http.Error(w, "Invalid draft ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.draftService.StartDraft(draftID); err != nil {
+		h.handleError(w, "start draft", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/drafts?draft_id=%d", draftID), http.StatusSeeOther)
+}
+
+// MakePick handles POST /usl/admin/drafts/pick
+// From the board a refused pick is shown on the re-rendered board rather than as an error page
+func (h *DraftHandler) MakePick(w http.ResponseWriter, r *http.Request) {
+	h.changeDraft(w, r, "make pick", func(draftID int64) error {
+		discordID := strings.TrimSpace(r.FormValue("discord_id"))
+		if discordID == "" {
+			return fmt.Errorf("%w: discord_id is required", services.ErrPlayerNotAvailable)
+		}
+		_, err := h.draftService.MakePick(draftID, discordID, nil)
+		return err
+	})
+}
+
+// UndoPick handles POST /usl/admin/drafts/undo
+func (h *DraftHandler) UndoPick(w http.ResponseWriter, r *http.Request) {
+	h.changeDraft(w, r, "undo pick", func(draftID int64) error {
+		_, err := h.draftService.UndoPick(draftID, nil)
+		return err
+	})
+}
+
+// changeDraft applies a pick or undo, answering HTMX with the board and other requests with a redirect
+func (h *DraftHandler) changeDraft(w http.ResponseWriter, r *http.Request, operation string, change func(draftID int64) error) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	draftID, err := strconv.ParseInt(r.FormValue("draft_id"), 10, 64)
+	if err != nil || draftID <= 0 {
+		http.Error(w, "Invalid draft ID", http.StatusBadRequest)
+		return
+	}
+
+	isHTMX := r.Header.Get("HX-Request") != ""
+	if err := change(draftID); err != nil {
+		if isHTMX && isDraftClientError(err) {
+			h.renderBoard(w, draftID, err.Error())
+			return
+		}
+		h.handleError(w, operation, err)
+		return
+	}
+
+	if isHTMX {
+		h.renderBoard(w, draftID, "")
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/drafts?draft_id=%d", draftID), http.StatusSeeOther)
+}
+
+// renderBoard renders the board fragment for a draft
+func (h *DraftHandler) renderBoard(w http.ResponseWriter, draftID int64, message string) {
+	board, err := h.draftService.GetBoard(draftID)
+	if err != nil {
+		if errors.Is(err, services.ErrDraftNotFound) {
+			http.Error(w, "Draft not found", http.StatusNotFound)
+			return
+		}
+		h.handleError(w, "load draft board", err)
+		return
+	}
+
+	h.renderTemplate(w, TemplateUSLDraftBoard, DraftBoardView{Board: board, Error: message})
+}
+
+// parseDraftOrder reads the order_{team_id} fields and returns the team IDs by ascending order number
+func parseDraftOrder(r *http.Request) ([]int64, error) {
+	type entry struct {
+		teamID int64
+		order  int
+	}
+
+	var entries []entry
+	for key, values := range r.PostForm {
+		teamIDParam, ok := strings.CutPrefix(key, "order_")
+		if !ok || len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+			continue
+		}
+
+		teamID, err := strconv.ParseInt(teamIDParam, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid team ID: %s", teamIDParam)
+		}
+		order, err := strconv.Atoi(strings.TrimSpace(values[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid draft position for team %d: %s", teamID, values[0])
+		}
+		entries = append(entries, entry{teamID: teamID, order: order})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].order != entries[j].order {
+			return entries[i].order < entries[j].order
+		}
+		return entries[i].teamID < entries[j].teamID
+	})
+
+	order := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		order = append(order, entry.teamID)
+	}
+	return order, nil
+}
+
+// isDraftClientError checks if a draft or roster error was caused by the request rather than the server
+func isDraftClientError(err error) bool {
+	return errors.Is(err, services.ErrInvalidDraft) || errors.Is(err, services.ErrDraftNotActive) ||
+		errors.Is(err, services.ErrPlayerNotAvailable) || errors.Is(err, services.ErrInvalidRoster) ||
+		errors.Is(err, services.ErrSalaryCapExceeded) || errors.Is(err, services.ErrPlayerIneligible) ||
+		errors.Is(err, services.ErrPlayerRostered)
+}
+
+// handleError maps a missing draft to a 404, draft and roster rule breaks to client errors and everything else to a 500
+func (h *DraftHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrDraftNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if isDraftClientError(err) || errors.Is(err, services.ErrNoActiveSeason) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *DraftHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"testing"
 	"time"
 	"usl-server/internal/config"
@@ -197,3 +198,126 @@ func TestConfigurationCompatibility(t *testing.T) {
 			mmrConfig.OnesWeight, mmrConfig.TwosWeight, mmrConfig.ThreesWeight)
 	})
 }
+
+// TestAggregateUSLTrackersCombinesEveryValidAccount checks the USL seed uses all of a user's valid trackers
+func TestAggregateUSLTrackersCombinesEveryValidAccount(t *testing.T) {
+	mainUpdated := "2025-08-01T12:00:00Z"
+	altUpdated := "2025-08-20T12:00:00Z"
+	trackers := []*usl.USLUserTracker{
+		{
+			DiscordID:                      "123456789012345678",
+			URL:                            "https://rocketleague.tracker.network/rocket-league/profile/steam/main",
+			TwosCurrentSeasonPeak:          1400,
+			TwosCurrentSeasonGamesPlayed:   40,
+			ThreesCurrentSeasonPeak:        1300,
+			ThreesCurrentSeasonGamesPlayed: 30,
+			LastUpdated:                    &mainUpdated,
+			Valid:                          true,
+		},
+		{
+			DiscordID:                      "123456789012345678",
+			URL:                            "https://rocketleague.tracker.network/rocket-league/profile/epic/alt",
+			TwosCurrentSeasonPeak:          1600,
+			TwosCurrentSeasonGamesPlayed:   60,
+			ThreesCurrentSeasonPeak:        1100,
+			ThreesCurrentSeasonGamesPlayed: 10,
+			LastUpdated:                    &altUpdated,
+			Valid:                          true,
+		},
+		{
+			DiscordID:                    "123456789012345678",
+			URL:                          "https://rocketleague.tracker.network/rocket-league/profile/psn/invalid",
+			TwosCurrentSeasonPeak:        2000,
+			TwosCurrentSeasonGamesPlayed: 100,
+			Valid:                        false,
+		},
+	}
+
+	handler := &MigrationHandler{
+		config:             &config.Config{MMR: config.MMRConfig{TrackerAggregation: services.TrackerAggregationPool}},
+		dataTransformation: services.NewDataTransformationService(),
+	}
+
+	trackerData, combined, err := handler.aggregateUSLTrackers(trackers)
+	if err != nil {
+		t.Fatalf("aggregateUSLTrackers returned error: %v", err)
+	}
+	if combined != 2 {
+		t.Errorf("combined %d trackers, want the 2 valid ones", combined)
+	}
+	if trackerData.TwosCurrentGames != 100 {
+		t.Errorf("TwosCurrentGames = %d, want the 100 pooled from both valid accounts", trackerData.TwosCurrentGames)
+	}
+	if sources := trackerData.Sources["twos"]; len(sources) != 2 || sources[0] != trackers[0].URL || sources[1] != trackers[1].URL {
+		t.Errorf("twos sources = %v, want both valid accounts", sources)
+	}
+	if want, _ := time.Parse(time.RFC3339, altUpdated); !trackerData.LastUpdated.Equal(want) {
+		t.Errorf("LastUpdated = %v, want the newest tracker's %v", trackerData.LastUpdated, want)
+	}
+
+	if _, _, err := handler.aggregateUSLTrackers(trackers[2:]); !errors.Is(err, services.ErrNoSeedTrackers) {
+		t.Errorf("only invalid trackers: err = %v, want ErrNoSeedTrackers", err)
+	}
+}
+
+// TestTransformUSLTrackerCopiesLastUpdated checks the tracker's timestamp survives the conversion
+func TestTransformUSLTrackerCopiesLastUpdated(t *testing.T) {
+	lastUpdated := "2025-08-20T12:00:00Z"
+	handler := &MigrationHandler{}
+
+	trackerData := handler.transformUSLTrackerToTrackerData(&usl.USLUserTracker{DiscordID: "123456789012345678", LastUpdated: &lastUpdated})
+
+	if want, _ := time.Parse(time.RFC3339, lastUpdated); !trackerData.LastUpdated.Equal(want) {
+		t.Errorf("LastUpdated = %v, want %v", trackerData.LastUpdated, want)
+	}
+}
+
+// TestLoadSeedExplanationUsesUSLData checks the user page explains the seed from the USL trackers and rating it shows
+func TestLoadSeedExplanationUsesUSLData(t *testing.T) {
+	cfg := &config.Config{
+		TrueSkill: config.TrueSkillConfig{InitialMu: 1000, InitialSigma: 8.333, SigmaMin: 2.5, SigmaMax: 8.333},
+		MMR:       config.MMRConfig{OnesWeight: 1.0, TwosWeight: 1.5, ThreesWeight: 1.2, TrackerAggregation: services.TrackerAggregationPool},
+	}
+	converter := services.NewPercentileConverter(cfg)
+	engine := services.NewTrueSkillEngine(services.NewMMRCalculator(cfg, converter), services.NewEnhancedUncertaintyCalculator(cfg, converter), cfg)
+	dataTransformation := services.NewDataTransformationService()
+	handler := &MigrationHandler{
+		trueskillService:   services.NewUserTrueSkillService(nil, nil, engine, dataTransformation, cfg),
+		config:             cfg,
+		dataTransformation: dataTransformation,
+	}
+
+	lastUpdated := "2025-08-20T12:00:00Z"
+	user := &usl.USLUser{DiscordID: "123456789012345678", TrueSkillMu: 1234.5, TrueSkillSigma: 4.2, TrueSkillLastUpdated: &lastUpdated}
+	trackers := []*usl.USLUserTracker{
+		{
+			DiscordID: user.DiscordID, URL: "https://rocketleague.tracker.network/rocket-league/profile/steam/main",
+			TwosCurrentSeasonPeak: 1250, TwosCurrentSeasonGamesPlayed: 300, LastUpdated: &lastUpdated, Valid: true,
+		},
+		{
+			DiscordID: user.DiscordID, URL: "https://rocketleague.tracker.network/rocket-league/profile/epic/alt",
+			TwosCurrentSeasonPeak: 1150, TwosCurrentSeasonGamesPlayed: 100, LastUpdated: &lastUpdated, Valid: true,
+		},
+	}
+
+	seed, rows := handler.loadSeedExplanation(user, trackers)
+	if seed == nil {
+		t.Fatal("expected a seed explanation for a user with valid USL trackers")
+	}
+	if seed.Trackers != 2 {
+		t.Errorf("Trackers = %d, want 2", seed.Trackers)
+	}
+	if seed.Inputs.TwosCurrentGames != 400 {
+		t.Errorf("TwosCurrentGames = %d, want 400 pooled from the USL trackers", seed.Inputs.TwosCurrentGames)
+	}
+	if seed.Current == nil || seed.Current.Mu != user.TrueSkillMu || seed.Current.Sigma != user.TrueSkillSigma {
+		t.Errorf("Current = %+v, want the usl_users rating %.1f/%.1f", seed.Current, user.TrueSkillMu, user.TrueSkillSigma)
+	}
+	if len(rows) != 3 {
+		t.Errorf("got %d playlist rows, want 3", len(rows))
+	}
+
+	if seed, _ := handler.loadSeedExplanation(user, nil); seed != nil {
+		t.Error("a user without USL trackers should have no seed explanation")
+	}
+}
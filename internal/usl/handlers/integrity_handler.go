@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"usl-server/internal/services"
+)
+
+// IntegrityHandler serves the smurf and sandbagging review page
+type IntegrityHandler struct {
+	detector  *services.SmurfDetector
+	templates *template.Template
+}
+
+func NewIntegrityHandler(detector *services.SmurfDetector, templates *template.Template) *IntegrityHandler {
+	return &IntegrityHandler{
+		detector:  detector,
+		templates: templates,
+	}
+}
+
+// Integrity handles GET /usl/admin/integrity?min_score=&signal=
+// Lists players whose tracker data looks like a smurf or sandbagging account, highest score first
+func (h *IntegrityHandler) Integrity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	minScore := 0.0
+	if value := strings.TrimSpace(r.URL.Query().Get("min_score")); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || parsed > services.SmurfMaxScore {
+			http.Error(w, "Invalid min_score", http.StatusBadRequest)
+			return
+		}
+		minScore = parsed
+	}
+
+	signal := r.URL.Query().Get("signal")
+	switch signal {
+	case "", services.SmurfSignalPeakDrop, services.SmurfSignalLowGames, services.SmurfSignalAccountMismatch:
+	default:
+		http.Error(w, "Invalid signal", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.detector.Detect(minScore)
+	if err != nil {
+		log.Printf("[USL-HANDLER] Database error during smurf detection: %v", err)
+		http.Error(w, "Failed to check trackers", http.StatusInternalServerError)
+		return
+	}
+
+	suspects := report.Suspects
+	if signal != "" {
+		suspects = make([]services.SmurfSuspect, 0, len(report.Suspects))
+		for _, suspect := range report.Suspects {
+			for _, flag := range suspect.Flags {
+				if flag.Signal == signal {
+					suspects = append(suspects, suspect)
+					break
+				}
+			}
+		}
+	}
+
+	data := struct {
+		Title       string
+		CurrentPage string
+		Report      *services.SmurfReport
+		Suspects    []services.SmurfSuspect
+		MinScore    float64
+		Signal      string
+		Signals     []string
+	}{
+		Title:       "Integrity Review",
+		CurrentPage: "integrity",
+		Report:      report,
+		Suspects:    suspects,
+		MinScore:    minScore,
+		Signal:      signal,
+		Signals:     []string{services.SmurfSignalPeakDrop, services.SmurfSignalLowGames, services.SmurfSignalAccountMismatch},
+	}
+
+	h.renderTemplate(w, TemplateUSLIntegrity, data)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *IntegrityHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
@@ -15,6 +16,7 @@ import (
 	"usl-server/internal/config"
 	"usl-server/internal/logger"
 	"usl-server/internal/models"
+	"usl-server/internal/repositories"
 	"usl-server/internal/services"
 	"usl-server/internal/usl"
 )
@@ -88,14 +90,28 @@ const (
 type TemplateName string
 
 const (
-	TemplateUSLUsers          TemplateName = "users-list-page"
-	TemplateUSLUsersTable     TemplateName = "users-table-fragment"
-	TemplateUSLUserDetail     TemplateName = "user-detail-page"
-	TemplateUSLTrackers       TemplateName = "trackers-list-page"
-	TemplateUSLTrackerDetail  TemplateName = "tracker-detail-page"
-	TemplateUSLTrackerNew     TemplateName = "tracker-new-page"
-	TemplateUSLTrackerEdit    TemplateName = "tracker-edit-page"
-	TemplateUSLAdminDashboard TemplateName = "admin-dashboard-page"
+	TemplateUSLUsers               TemplateName = "users-list-page"
+	TemplateUSLUsersTable          TemplateName = "users-table-fragment"
+	TemplateUSLUserDetail          TemplateName = "user-detail-page"
+	TemplateUSLTrackers            TemplateName = "trackers-list-page"
+	TemplateUSLTrackerDetail       TemplateName = "tracker-detail-page"
+	TemplateUSLTrackerNew          TemplateName = "tracker-new-page"
+	TemplateUSLTrackerEdit         TemplateName = "tracker-edit-page"
+	TemplateUSLAdminDashboard      TemplateName = "admin-dashboard-page"
+	TemplateUSLSeasons             TemplateName = "seasons-page"
+	TemplateUSLBrackets            TemplateName = "brackets-page"
+	TemplateUSLBracketDetail       TemplateName = "bracket-detail-page"
+	TemplateUSLSchedule            TemplateName = "schedule-page"
+	TemplateUSLPlacements          TemplateName = "placements-page"
+	TemplateUSLTeams               TemplateName = "teams-page"
+	TemplateUSLDrafts              TemplateName = "drafts-page"
+	TemplateUSLDraftBoard          TemplateName = "draft-board-fragment"
+	TemplateUSLStandings           TemplateName = "standings-page"
+	TemplateUSLReplays             TemplateName = "replays-page"
+	TemplateUSLSimulator           TemplateName = "simulator-page"
+	TemplateUSLRecalculations      TemplateName = "recalculations-page"
+	TemplateUSLRecalculationDetail TemplateName = "recalculation-detail-page"
+	TemplateUSLIntegrity           TemplateName = "integrity-page"
 )
 
 // Validation metrics and monitoring structures
@@ -674,20 +690,36 @@ type MigrationHandler struct {
 	uslRepo          *usl.USLRepository
 	templates        *template.Template
 	trueskillService *services.UserTrueSkillService
+	placementService *services.PlacementService
+	statsService     *services.StatsService
+	rankService      *services.RankService
+	guildRepo        *repositories.GuildRepository
 	config           *config.Config
+
+	dataTransformation *services.DataTransformationService
 }
 
 func NewMigrationHandler(
 	uslRepo *usl.USLRepository,
 	templates *template.Template,
 	trueskillService *services.UserTrueSkillService,
+	placementService *services.PlacementService,
+	statsService *services.StatsService,
+	rankService *services.RankService,
+	guildRepo *repositories.GuildRepository,
 	config *config.Config,
 ) *MigrationHandler {
 	return &MigrationHandler{
 		uslRepo:          uslRepo,
 		templates:        templates,
 		trueskillService: trueskillService,
+		placementService: placementService,
+		statsService:     statsService,
+		rankService:      rankService,
+		guildRepo:        guildRepo,
 		config:           config,
+
+		dataTransformation: services.NewDataTransformationService(),
 	}
 }
 
@@ -1000,21 +1032,112 @@ func (h *MigrationHandler) UserDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	seed, seedPlaylists := h.loadSeedExplanation(user, userTrackers)
+
 	data := struct {
-		Title        string
-		CurrentPage  string
-		User         *usl.USLUser
-		UserTrackers []*usl.USLUserTracker
+		Title         string
+		CurrentPage   string
+		User          *usl.USLUser
+		UserTrackers  []*usl.USLUserTracker
+		Stats         *services.PlayerStatsReport
+		Seed          *services.SeedExplanation
+		SeedPlaylists []seedPlaylistRow
 	}{
-		Title:        user.Name,
-		CurrentPage:  "users",
-		User:         user,
-		UserTrackers: userTrackers,
+		Title:         user.Name,
+		CurrentPage:   "users",
+		User:          user,
+		UserTrackers:  userTrackers,
+		Stats:         h.loadPlayerStats(user.DiscordID),
+		Seed:          seed,
+		SeedPlaylists: seedPlaylists,
 	}
 
 	h.renderTemplate(w, TemplateUSLUserDetail, data)
 }
 
+// loadPlayerStats returns the user's stats for the USL guild's current season
+// Stats are optional on the detail page, so a lookup failure is logged and the section hidden.
+func (h *MigrationHandler) loadPlayerStats(discordID string) *services.PlayerStatsReport {
+	if h.statsService == nil || h.guildRepo == nil {
+		return nil
+	}
+
+	guild, err := h.guildRepo.FindGuildByDiscordID(USLDiscordGuildID)
+	if err != nil || guild == nil {
+		log.Printf("[USL-HANDLER] USL guild not found for stats lookup: %v", err)
+		return nil
+	}
+
+	report, err := h.statsService.GetPlayerStats(guild.ID, discordID, nil)
+	if err != nil {
+		log.Printf("[USL-HANDLER] Failed to load stats for %s: %v", discordID, err)
+		return nil
+	}
+	return report
+}
+
+// seedPlaylistRow is one playlist of a seed explanation as shown on the user detail page
+type seedPlaylistRow struct {
+	Playlist        string
+	Games           int
+	EffectiveMMR    float64
+	Counted         bool // enough games for the playlist to count toward μ
+	Percentile      float64
+	NormalizedSkill float64
+	Weight          float64
+}
+
+// loadSeedExplanation explains the seed the user's USL trackers produce
+// The trackers are aggregated as updateUSLUserTrueSkillFromTrackers does, and Current is the
+// rating stored on the USL user, so the explanation matches the trackers and rating on the page.
+func (h *MigrationHandler) loadSeedExplanation(user *usl.USLUser, userTrackers []*usl.USLUserTracker) (*services.SeedExplanation, []seedPlaylistRow) {
+	if h.trueskillService == nil {
+		return nil, nil
+	}
+
+	trackerData, combined, err := h.aggregateUSLTrackers(userTrackers)
+	if err != nil {
+		if !errors.Is(err, services.ErrNoSeedTrackers) {
+			log.Printf("[USL-HANDLER] Failed to aggregate trackers for %s: %v", user.DiscordID, err)
+		}
+		return nil, nil
+	}
+
+	seed, err := h.trueskillService.ExplainTrackerData(trackerData)
+	if err != nil {
+		log.Printf("[USL-HANDLER] Failed to explain seed for %s: %v", user.DiscordID, err)
+		return nil, nil
+	}
+	seed.Trackers = combined
+	seed.Current = &services.StoredRating{
+		Mu:    user.TrueSkillMu,
+		Sigma: user.TrueSkillSigma,
+	}
+	if user.TrueSkillLastUpdated != nil {
+		if lastUpdated, err := time.Parse(time.RFC3339, *user.TrueSkillLastUpdated); err == nil {
+			seed.Current.LastUpdated = lastUpdated
+		}
+	}
+
+	var rows []seedPlaylistRow
+	for _, playlist := range []struct{ key, name string }{{"ones", "1v1"}, {"twos", "2v2"}, {"threes", "3v3"}} {
+		breakdown := seed.Skill.Breakdown[playlist.key]
+		row := seedPlaylistRow{
+			Playlist:     playlist.name,
+			Games:        breakdown.Games,
+			EffectiveMMR: breakdown.EffectiveMMR,
+			Weight:       seed.Skill.Weights[playlist.key],
+		}
+		if breakdown.Percentile != nil && breakdown.NormalizedSkill != nil {
+			row.Counted = true
+			row.Percentile = *breakdown.Percentile
+			row.NormalizedSkill = *breakdown.NormalizedSkill
+		}
+		rows = append(rows, row)
+	}
+	return seed, rows
+}
+
 // updateUSLUserTrueSkillFromTrackers updates TrueSkill for a USL user from their tracker data
 // This function manages USL data access and delegates calculation to the TrueSkill service
 func (h *MigrationHandler) updateUSLUserTrueSkillFromTrackers(discordID string) *services.TrueSkillUpdateResult {
@@ -1031,8 +1154,11 @@ func (h *MigrationHandler) updateUSLUserTrueSkillFromTrackers(discordID string)
 		}
 	}
 
-	// If no trackers, assign default values
-	if len(userTrackers) == 0 {
+	// Combine every valid account the same way the user_trackers seed does
+	trackerData, _, err := h.aggregateUSLTrackers(userTrackers)
+
+	// If no valid trackers, assign default values
+	if errors.Is(err, services.ErrNoSeedTrackers) {
 		defaultMu := 1500.0
 		defaultSigma := 8.333
 
@@ -1063,8 +1189,16 @@ func (h *MigrationHandler) updateUSLUserTrueSkillFromTrackers(discordID string)
 		}
 	}
 
-	// Transform USL tracker data to TrueSkill service format
-	trackerData := h.transformUSLTrackerToTrackerData(userTrackers[0])
+	if err != nil {
+		validationLogger.Error("Failed to aggregate USL trackers for TrueSkill calculation",
+			"discord_id", discordID,
+			"error", err)
+		return &services.TrueSkillUpdateResult{
+			Success:     false,
+			HadTrackers: true,
+			Error:       fmt.Sprintf("failed to aggregate trackers: %v", err),
+		}
+	}
 
 	// Use TrueSkill service to calculate values (no database access in service)
 	result := h.trueskillService.CalculateTrueSkillFromTrackerData(trackerData)
@@ -1118,7 +1252,69 @@ func (h *MigrationHandler) transformUSLTrackerToTrackerData(uslTracker *usl.USLU
 		ThreesAllTimePeak:   uslTracker.ThreesAllTimePeak,
 		ThreesCurrentGames:  uslTracker.ThreesCurrentSeasonGamesPlayed,
 		ThreesPreviousGames: uslTracker.ThreesPreviousSeasonGamesPlayed,
+		LastUpdated:         uslTrackerLastUpdated(uslTracker),
+	}
+}
+
+// aggregateUSLTrackers combines a user's valid USL trackers with the configured tracker aggregation
+// Returns the number of trackers combined; invalid trackers are skipped.
+func (h *MigrationHandler) aggregateUSLTrackers(uslTrackers []*usl.USLUserTracker) (*services.TrackerData, int, error) {
+	var trackers []*models.UserTracker
+	for _, uslTracker := range uslTrackers {
+		if uslTracker.Valid {
+			trackers = append(trackers, uslTrackerToUserTracker(uslTracker))
+		}
 	}
+	if len(trackers) == 0 {
+		return nil, 0, services.ErrNoSeedTrackers
+	}
+
+	trackerData, err := h.dataTransformation.AggregateTrackers(trackers, h.config.MMR.TrackerAggregation)
+	if err != nil {
+		return nil, 0, err
+	}
+	return trackerData, len(trackers), nil
+}
+
+// uslTrackerToUserTracker converts a USL tracker to the tracker model the rating services aggregate
+func uslTrackerToUserTracker(uslTracker *usl.USLUserTracker) *models.UserTracker {
+	return &models.UserTracker{
+		ID:                        int(uslTracker.ID),
+		DiscordID:                 uslTracker.DiscordID,
+		URL:                       uslTracker.URL,
+		OnesCurrentSeasonPeak:     uslTracker.OnesCurrentSeasonPeak,
+		OnesPreviousSeasonPeak:    uslTracker.OnesPreviousSeasonPeak,
+		OnesAllTimePeak:           uslTracker.OnesAllTimePeak,
+		OnesCurrentSeasonGames:    uslTracker.OnesCurrentSeasonGamesPlayed,
+		OnesPreviousSeasonGames:   uslTracker.OnesPreviousSeasonGamesPlayed,
+		TwosCurrentSeasonPeak:     uslTracker.TwosCurrentSeasonPeak,
+		TwosPreviousSeasonPeak:    uslTracker.TwosPreviousSeasonPeak,
+		TwosAllTimePeak:           uslTracker.TwosAllTimePeak,
+		TwosCurrentSeasonGames:    uslTracker.TwosCurrentSeasonGamesPlayed,
+		TwosPreviousSeasonGames:   uslTracker.TwosPreviousSeasonGamesPlayed,
+		ThreesCurrentSeasonPeak:   uslTracker.ThreesCurrentSeasonPeak,
+		ThreesPreviousSeasonPeak:  uslTracker.ThreesPreviousSeasonPeak,
+		ThreesAllTimePeak:         uslTracker.ThreesAllTimePeak,
+		ThreesCurrentSeasonGames:  uslTracker.ThreesCurrentSeasonGamesPlayed,
+		ThreesPreviousSeasonGames: uslTracker.ThreesPreviousSeasonGamesPlayed,
+		LastUpdated:               uslTrackerLastUpdated(uslTracker),
+		Valid:                     uslTracker.Valid,
+		CalculatedMMR:             uslTracker.MMR,
+		CreatedAt:                 uslTracker.CreatedAt,
+		UpdatedAt:                 uslTracker.UpdatedAt,
+	}
+}
+
+// uslTrackerLastUpdated parses a USL tracker's last-updated timestamp, zero when unset or unparseable
+func uslTrackerLastUpdated(uslTracker *usl.USLUserTracker) time.Time {
+	if uslTracker.LastUpdated == nil || *uslTracker.LastUpdated == "" {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339, *uslTracker.LastUpdated)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
 }
 
 // UpdateUserTrueSkill recalculates TrueSkill for a specific user
@@ -1446,6 +1642,12 @@ func (h *MigrationHandler) ListTrackersAPI(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if h.rankService != nil {
+		for _, tracker := range trackers {
+			tracker.Ranks = h.rankService.Ranks(h.transformUSLTrackerToTrackerData(tracker))
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(trackers); err != nil {
 		log.Printf("[USL-HANDLER] JSON encoding error for trackers API: %v", err)
@@ -1465,6 +1667,9 @@ func (h *MigrationHandler) GetLeaderboardAPI(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	h.attachTiers(users)
+	h.attachRanks(users)
+
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(users); err != nil {
 		log.Printf("[USL-HANDLER] JSON encoding error for leaderboard API: %v", err)
@@ -1472,6 +1677,56 @@ func (h *MigrationHandler) GetLeaderboardAPI(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// attachTiers sets each user's current tier placement in the USL guild
+// Tiers are optional on the leaderboard, so a lookup failure is logged and skipped.
+func (h *MigrationHandler) attachTiers(users []*usl.USLUser) {
+	if h.placementService == nil || h.guildRepo == nil {
+		return
+	}
+
+	guild, err := h.guildRepo.FindGuildByDiscordID(USLDiscordGuildID)
+	if err != nil || guild == nil {
+		log.Printf("[USL-HANDLER] USL guild not found for tier lookup: %v", err)
+		return
+	}
+
+	placements, err := h.placementService.GetCurrentPlacements(guild.ID)
+	if err != nil {
+		log.Printf("[USL-HANDLER] Failed to load tier placements: %v", err)
+		return
+	}
+
+	byDiscordID := make(map[string]*models.TierPlacement, len(placements))
+	for i := range placements {
+		byDiscordID[placements[i].DiscordID] = &placements[i]
+	}
+	for _, user := range users {
+		user.Tier = byDiscordID[user.DiscordID]
+	}
+}
+
+// attachRanks sets each user's best rank per playlist across their valid trackers
+// Ranks are optional on the leaderboard, so a lookup failure is logged and skipped.
+func (h *MigrationHandler) attachRanks(users []*usl.USLUser) {
+	if h.rankService == nil {
+		return
+	}
+
+	trackers, err := h.uslRepo.GetValidTrackers()
+	if err != nil {
+		log.Printf("[USL-HANDLER] Failed to load trackers for rank lookup: %v", err)
+		return
+	}
+
+	byDiscordID := make(map[string][]*services.TrackerData)
+	for _, tracker := range trackers {
+		byDiscordID[tracker.DiscordID] = append(byDiscordID[tracker.DiscordID], h.transformUSLTrackerToTrackerData(tracker))
+	}
+	for _, user := range users {
+		user.Ranks = h.rankService.Ranks(byDiscordID[user.DiscordID]...)
+	}
+}
+
 // performTrueSkillUpdate handles TrueSkill calculation and synchronization with comprehensive error handling
 // Reserved for future TrueSkill integration - currently unused but kept for planned implementation
 // func (h *MigrationHandler) performTrueSkillUpdate(tracker *usl.USLUserTracker) {
@@ -1507,14 +1762,8 @@ func (h *MigrationHandler) GetLeaderboardAPI(w http.ResponseWriter, r *http.Requ
 
 // mapUSLTrackerToTrackerData converts USL tracker format to TrueSkill service input format
 func (h *MigrationHandler) mapUSLTrackerToTrackerData(uslTracker *usl.USLUserTracker) *services.TrackerData {
-	var lastUpdated time.Time
-	if uslTracker.LastUpdated != nil && *uslTracker.LastUpdated != "" {
-		if parsed, err := time.Parse(time.RFC3339, *uslTracker.LastUpdated); err == nil {
-			lastUpdated = parsed
-		} else {
-			lastUpdated = time.Now()
-		}
-	} else {
+	lastUpdated := uslTrackerLastUpdated(uslTracker)
+	if lastUpdated.IsZero() {
 		lastUpdated = time.Now()
 	}
 
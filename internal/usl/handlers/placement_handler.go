@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// PlacementHandler serves the tier placement admin page
+type PlacementHandler struct {
+	placementService *services.PlacementService
+	guildRepo        *repositories.GuildRepository
+	templates        *template.Template
+}
+
+// PlacementForm is the tier rules as shown in the placement form
+type PlacementForm struct {
+	Method          string
+	SigmaMultiplier float64
+	Tiers           string // one "Name: cutoff" or "Name: size" per line, best tier first
+}
+
+func NewPlacementHandler(placementService *services.PlacementService, guildRepo *repositories.GuildRepository, templates *template.Template) *PlacementHandler {
+	return &PlacementHandler{
+		placementService: placementService,
+		guildRepo:        guildRepo,
+		templates:        templates,
+	}
+}
+
+// Placements handles GET /usl/admin/placements
+// Shows the guild's tier rules and its current placements
+func (h *PlacementHandler) Placements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	placement, err := h.placementService.GetPlacementConfig(guildID)
+	if err != nil && !errors.Is(err, services.ErrInvalidPlacement) {
+		h.handleError(w, "load placement rules", err)
+		return
+	}
+
+	current, err := h.placementService.GetCurrentPlacements(guildID)
+	if err != nil {
+		h.handleError(w, "load placements", err)
+		return
+	}
+
+	h.renderPlacements(w, guildID, newPlacementForm(placement), nil, current)
+}
+
+// PreviewPlacements handles POST /usl/admin/placements/preview
+// Places every eligible player with the submitted rules and lists the movers without saving
+func (h *PlacementHandler) PreviewPlacements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	placement, err := parsePlacementForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	preview, err := h.placementService.Preview(guildID, placement)
+	if err != nil {
+		h.handleError(w, "preview placements", err)
+		return
+	}
+
+	h.renderPlacements(w, guildID, newPlacementForm(preview.Config), preview, nil)
+}
+
+// CommitPlacements handles POST /usl/admin/placements/commit
+// Stores the placement for the active season and saves the rules to the guild config
+func (h *PlacementHandler) CommitPlacements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	placement, err := parsePlacementForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.placementService.Commit(guildID, placement); err != nil {
+		h.handleError(w, "commit placements", err)
+		return
+	}
+
+	http.Redirect(w, r, "/usl/admin/placements", http.StatusSeeOther)
+}
+
+// renderPlacements renders the placement page with either a preview or the stored placements
+func (h *PlacementHandler) renderPlacements(w http.ResponseWriter, guildID int64, form PlacementForm, preview *services.PlacementPreview, current []models.TierPlacement) {
+	data := struct {
+		Title       string
+		CurrentPage string
+		GuildID     int64
+		Form        PlacementForm
+		Preview     *services.PlacementPreview
+		Current     []models.TierPlacement
+	}{
+		Title:       "Placements",
+		CurrentPage: "placements",
+		GuildID:     guildID,
+		Form:        form,
+		Preview:     preview,
+		Current:     current,
+	}
+
+	h.renderTemplate(w, TemplateUSLPlacements, data)
+}
+
+// newPlacementForm writes tier rules back out in the form's line format
+func newPlacementForm(placement models.PlacementConfig) PlacementForm {
+	lines := make([]string, 0, len(placement.Tiers))
+	for i, tier := range placement.Tiers {
+		switch {
+		case i == len(placement.Tiers)-1:
+			lines = append(lines, tier.Name)
+		case placement.Method == models.PlacementMethodFixedSizes:
+			lines = append(lines, fmt.Sprintf("%s: %d", tier.Name, tier.Size))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %g", tier.Name, tier.MinSkill))
+		}
+	}
+
+	return PlacementForm{
+		Method:          placement.Method,
+		SigmaMultiplier: placement.SigmaMultiplier,
+		Tiers:           strings.Join(lines, "\n"),
+	}
+}
+
+// parsePlacementForm reads the method, sigma multiplier and tier lines from the form
+// The value after the colon is a skill cutoff or a tier size depending on the method.
+func parsePlacementForm(r *http.Request) (models.PlacementConfig, error) {
+	placement := models.PlacementConfig{Method: r.FormValue("method")}
+
+	if value := strings.TrimSpace(r.FormValue("sigma_multiplier")); value != "" {
+		multiplier, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return placement, fmt.Errorf("invalid sigma multiplier: %s", value)
+		}
+		placement.SigmaMultiplier = multiplier
+	}
+
+	for _, line := range strings.Split(r.FormValue("tiers"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(line, ":")
+		tier := models.TierConfig{Name: strings.TrimSpace(name)}
+		if value = strings.TrimSpace(value); value != "" {
+			switch placement.Method {
+			case models.PlacementMethodFixedSizes:
+				size, err := strconv.Atoi(value)
+				if err != nil {
+					return placement, fmt.Errorf("invalid size for tier %s: %s", tier.Name, value)
+				}
+				tier.Size = size
+			default:
+				cutoff, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return placement, fmt.Errorf("invalid cutoff for tier %s: %s", tier.Name, value)
+				}
+				tier.MinSkill = cutoff
+			}
+		}
+		placement.Tiers = append(placement.Tiers, tier)
+	}
+
+	return placement, nil
+}
+
+// handleError maps placement errors to client errors and everything else to a 500
+func (h *PlacementHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrInvalidPlacement) || errors.Is(err, services.ErrNoActiveSeason) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *PlacementHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
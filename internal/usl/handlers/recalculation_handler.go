@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// RecalculationHandler serves the rating recalculation admin pages
+type RecalculationHandler struct {
+	recalculationService *services.RecalculationService
+	guildRepo            *repositories.GuildRepository
+	templates            *template.Template
+	initialMu            float64
+}
+
+// RecalculationChangeView is a changeset row with the before values filled in for rendering
+// Players without a guild rating start from the default mu and sigma.
+type RecalculationChangeView struct {
+	models.RecalculationChange
+	New       bool
+	MuFrom    float64
+	SigmaFrom float64
+	MuDelta   float64
+	Rising    bool
+}
+
+func NewRecalculationHandler(recalculationService *services.RecalculationService, guildRepo *repositories.GuildRepository, initialMu float64, templates *template.Template) *RecalculationHandler {
+	return &RecalculationHandler{
+		recalculationService: recalculationService,
+		guildRepo:            guildRepo,
+		templates:            templates,
+		initialMu:            initialMu,
+	}
+}
+
+// Recalculations handles GET /usl/admin/recalculations
+// Lists the guild's recent recalculations with the button to start a new one
+func (h *RecalculationHandler) Recalculations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jobs, err := h.recalculationService.GetJobs(guildID, defaultRecalculationListLimit)
+	if err != nil {
+		h.handleError(w, "load recalculations", err)
+		return
+	}
+
+	data := struct {
+		Title       string
+		CurrentPage string
+		GuildID     int64
+		Jobs        []*models.RecalculationJob
+	}{
+		Title:       "Recalculations",
+		CurrentPage: "recalculations",
+		GuildID:     guildID,
+		Jobs:        jobs,
+	}
+
+	h.renderTemplate(w, TemplateUSLRecalculations, data)
+}
+
+// RecalculationDetail handles GET /usl/admin/recalculations/detail?id=
+// Shows the job's diff with apply and discard buttons while pending, or revert once applied
+func (h *RecalculationHandler) RecalculationDetail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || jobID <= 0 {
+		http.Error(w, "Invalid recalculation ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.recalculationService.GetJob(jobID)
+	if err != nil {
+		h.handleError(w, "load recalculation", err)
+		return
+	}
+
+	data := struct {
+		Title       string
+		CurrentPage string
+		Job         *models.RecalculationJob
+		Changes     []RecalculationChangeView
+	}{
+		Title:       fmt.Sprintf("Recalculation #%d", job.ID),
+		CurrentPage: "recalculations",
+		Job:         job,
+		Changes:     h.buildChangeViews(job),
+	}
+
+	h.renderTemplate(w, TemplateUSLRecalculationDetail, data)
+}
+
+// buildChangeViews prepares the job's changes for the diff table
+func (h *RecalculationHandler) buildChangeViews(job *models.RecalculationJob) []RecalculationChangeView {
+	views := make([]RecalculationChangeView, 0, len(job.Changes))
+	for _, change := range job.Changes {
+		view := RecalculationChangeView{
+			RecalculationChange: change,
+			New:                 change.MuBefore == nil,
+			MuDelta:             change.MuChange(h.initialMu),
+		}
+		if change.MuBefore != nil {
+			view.MuFrom = *change.MuBefore
+		}
+		if change.SigmaBefore != nil {
+			view.SigmaFrom = *change.SigmaBefore
+		}
+		view.Rising = view.MuDelta > 0
+		views = append(views, view)
+	}
+	return views
+}
+
+// CreateRecalculation handles POST /usl/admin/recalculations/create
+// Computes the changeset and shows it for review; ratings are untouched until it is applied
+func (h *RecalculationHandler) CreateRecalculation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.recalculationService.CreateJob(guildID, nil)
+	if err != nil {
+		h.handleError(w, "create recalculation", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/recalculations/detail?id=%d", job.ID), http.StatusSeeOther)
+}
+
+// ApplyRecalculation handles POST /usl/admin/recalculations/apply
+func (h *RecalculationHandler) ApplyRecalculation(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, "apply recalculation", func(jobID int64) (*models.RecalculationJob, error) {
+		return h.recalculationService.ApplyJob(jobID, nil)
+	})
+}
+
+// RevertRecalculation handles POST /usl/admin/recalculations/revert
+func (h *RecalculationHandler) RevertRecalculation(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, "revert recalculation", func(jobID int64) (*models.RecalculationJob, error) {
+		return h.recalculationService.RevertJob(jobID, nil)
+	})
+}
+
+// DiscardRecalculation handles POST /usl/admin/recalculations/discard
+func (h *RecalculationHandler) DiscardRecalculation(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, "discard recalculation", h.recalculationService.DiscardJob)
+}
+
+// handleAction reads the job_id form field, runs one state change and returns to the job
+func (h *RecalculationHandler) handleAction(w http.ResponseWriter, r *http.Request, operation string, action func(jobID int64) (*models.RecalculationJob, error)) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	jobID, err := strconv.ParseInt(r.FormValue("job_id"), 10, 64)
+	if err != nil || jobID <= 0 {
+		http.Error(w, "Invalid recalculation ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := action(jobID); err != nil {
+		h.handleError(w, operation, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/recalculations/detail?id=%d", jobID), http.StatusSeeOther)
+}
+
+// handleError maps unknown jobs to 404, jobs in the wrong state to 400 and everything else to a 500
+func (h *RecalculationHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, services.ErrRecalculationNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrInvalidRecalculation):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *RecalculationHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// maxReplayUploadBytes caps a replay upload request; ballchasing exports are well under 1 MB each
+const maxReplayUploadBytes = 32 << 20
+
+// ReplayHandler serves the replay import page
+type ReplayHandler struct {
+	replayService *services.ReplayImportService
+	guildRepo     *repositories.GuildRepository
+	importDir     string
+	templates     *template.Template
+}
+
+func NewReplayHandler(replayService *services.ReplayImportService, guildRepo *repositories.GuildRepository, importDir string, templates *template.Template) *ReplayHandler {
+	return &ReplayHandler{
+		replayService: replayService,
+		guildRepo:     guildRepo,
+		importDir:     importDir,
+		templates:     templates,
+	}
+}
+
+// Replays handles GET /usl/admin/replays
+func (h *ReplayHandler) Replays(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.renderPage(w, guildID, nil)
+}
+
+// ImportReplays handles POST /usl/admin/replays/import
+// Imports uploaded "replays" files, or the "directory" under the import directory when nothing is uploaded
+func (h *ReplayHandler) ImportReplays(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	files, err := readReplayUploads(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var summary *services.ReplayImportSummary
+	if len(files) > 0 {
+		summary, err = h.replayService.ImportReplays(guildID, files, nil)
+	} else {
+		summary, err = h.replayService.ImportDirectory(guildID, r.FormValue("directory"), nil)
+	}
+	if err != nil {
+		h.handleError(w, "import replays", err)
+		return
+	}
+
+	h.renderPage(w, guildID, summary)
+}
+
+// renderPage renders the import forms with the last import's results, if any
+func (h *ReplayHandler) renderPage(w http.ResponseWriter, guildID int64, summary *services.ReplayImportSummary) {
+	data := struct {
+		Title       string
+		CurrentPage string
+		GuildID     int64
+		ImportDir   string
+		Summary     *services.ReplayImportSummary
+	}{
+		Title:       "Replays",
+		CurrentPage: "replays",
+		GuildID:     guildID,
+		ImportDir:   h.importDir,
+		Summary:     summary,
+	}
+
+	h.renderTemplate(w, TemplateUSLReplays, data)
+}
+
+// readReplayUploads reads the files uploaded in the "replays" field of a multipart form
+// Requests that are not multipart have no uploads.
+func readReplayUploads(w http.ResponseWriter, r *http.Request) ([]services.ReplayFile, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxReplayUploadBytes)
+	if err := r.ParseMultipartForm(maxReplayUploadBytes); err != nil {
+		if errors.Is(err, http.ErrNotMultipart) {
+			return nil, r.ParseForm()
+		}
+		return nil, fmt.Errorf("invalid replay upload: %v", err)
+	}
+
+	var files []services.ReplayFile
+	for _, header := range r.MultipartForm.File["replays"] {
+		file, err := header.Open()
+		if err != nil {
+			return nil, fmt.Errorf("cannot open %s: %v", header.Filename, err)
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %s: %v", header.Filename, err)
+		}
+		files = append(files, services.ReplayFile{Name: header.Filename, Data: data})
+	}
+	return files, nil
+}
+
+// handleError maps refused import directories to client errors and everything else to a 500
+func (h *ReplayHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrInvalidReplayImport) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *ReplayHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
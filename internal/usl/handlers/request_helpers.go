@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"usl-server/internal/middleware"
+	"usl-server/internal/repositories"
+)
+
+// requestGuildID resolves the guild a request applies to
+// Precedence: explicit body value, guild_id query parameter, then the guild middleware context
+func requestGuildID(r *http.Request, explicitGuildID int64) (int64, error) {
+	if explicitGuildID > 0 {
+		return explicitGuildID, nil
+	}
+
+	if guildIDParam := r.URL.Query().Get("guild_id"); guildIDParam != "" {
+		guildID, err := strconv.ParseInt(guildIDParam, 10, 64)
+		if err != nil || guildID <= 0 {
+			return 0, fmt.Errorf("invalid guild_id: %s", guildIDParam)
+		}
+		return guildID, nil
+	}
+
+	if guild, ok := middleware.GetGuildFromRequest(r); ok && guild != nil {
+		return guild.ID, nil
+	}
+
+	return 0, fmt.Errorf("guild_id is required")
+}
+
+// adminGuildID uses the request's guild, falling back to the USL guild for the admin pages
+func adminGuildID(r *http.Request, guildRepo *repositories.GuildRepository) (int64, error) {
+	if guildIDParam := r.FormValue("guild_id"); guildIDParam != "" {
+		guildID, err := strconv.ParseInt(guildIDParam, 10, 64)
+		if err != nil || guildID <= 0 {
+			return 0, fmt.Errorf("invalid guild_id: %s", guildIDParam)
+		}
+		return guildID, nil
+	}
+
+	if guildID, err := requestGuildID(r, 0); err == nil {
+		return guildID, nil
+	}
+
+	guild, err := guildRepo.FindGuildByDiscordID(USLDiscordGuildID)
+	if err != nil || guild == nil {
+		return 0, fmt.Errorf("guild_id is required")
+	}
+	return guild.ID, nil
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// RosterHandler serves the team roster admin page
+type RosterHandler struct {
+	rosterService *services.RosterService
+	guildRepo     *repositories.GuildRepository
+	templates     *template.Template
+}
+
+func NewRosterHandler(rosterService *services.RosterService, guildRepo *repositories.GuildRepository, templates *template.Template) *RosterHandler {
+	return &RosterHandler{
+		rosterService: rosterService,
+		guildRepo:     guildRepo,
+		templates:     templates,
+	}
+}
+
+// Teams handles GET /usl/admin/teams?team_id=
+// Lists the guild's teams and renders the selected team's roster, cap room and history
+func (h *RosterHandler) Teams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rosterConfig, err := h.rosterService.GetRosterConfig(guildID)
+	if err != nil && !errors.Is(err, services.ErrInvalidRoster) {
+		h.handleError(w, "load roster rules", err)
+		return
+	}
+
+	teams, err := h.rosterService.GetTeams(guildID)
+	if err != nil {
+		h.handleError(w, "load teams", err)
+		return
+	}
+
+	var roster *services.TeamRoster
+	if teamIDParam := r.URL.Query().Get("team_id"); teamIDParam != "" {
+		teamID, err := strconv.ParseInt(teamIDParam, 10, 64)
+		if err != nil || teamID <= 0 {
+			http.Error(w, "Invalid team ID", http.StatusBadRequest)
+			return
+		}
+		if roster, err = h.rosterService.GetRoster(teamID); err != nil {
+			log.Printf("[USL-HANDLER] Team %d not found: %v", teamID, err)
+			http.Error(w, "Team not found", http.StatusNotFound)
+			return
+		}
+	} else if len(teams) > 0 {
+		if roster, err = h.rosterService.GetRoster(teams[0].ID); err != nil {
+			h.handleError(w, "load roster", err)
+			return
+		}
+	}
+
+	data := struct {
+		Title       string
+		CurrentPage string
+		GuildID     int64
+		Config      models.RosterConfig
+		Teams       []*models.Team
+		Roster      *services.TeamRoster
+	}{
+		Title:       "Teams",
+		CurrentPage: "teams",
+		GuildID:     guildID,
+		Config:      rosterConfig,
+		Teams:       teams,
+		Roster:      roster,
+	}
+
+	h.renderTemplate(w, TemplateUSLTeams, data)
+}
+
+// CreateTeam handles POST /usl/admin/teams/create
+// Creates a team in the active season, signing the captain if one is given
+func (h *RosterHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := models.TeamCreateRequest{
+		GuildID: guildID,
+		Name:    r.FormValue("name"),
+	}
+	if captain := strings.TrimSpace(r.FormValue("captain_discord_id")); captain != "" {
+		request.CaptainDiscordID = &captain
+	}
+
+	roster, err := h.rosterService.CreateTeam(request, nil)
+	if err != nil {
+		h.handleError(w, "create team", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/teams?team_id=%d", roster.Team.ID), http.StatusSeeOther)
+}
+
+// SignPlayer handles POST /usl/admin/teams/sign
+func (h *RosterHandler) SignPlayer(w http.ResponseWriter, r *http.Request) {
+	h.changeRoster(w, r, "sign player", h.rosterService.SignPlayer)
+}
+
+// ReleasePlayer handles POST /usl/admin/teams/release
+func (h *RosterHandler) ReleasePlayer(w http.ResponseWriter, r *http.Request) {
+	h.changeRoster(w, r, "release player", h.rosterService.ReleasePlayer)
+}
+
+// SetCaptain handles POST /usl/admin/teams/captain
+func (h *RosterHandler) SetCaptain(w http.ResponseWriter, r *http.Request) {
+	h.changeRoster(w, r, "set captain", h.rosterService.SetCaptain)
+}
+
+// UpdateSettings handles POST /usl/admin/teams/settings
+// Saves the guild's salary cap basis, cap and roster size limit
+func (h *RosterHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rosterConfig := models.RosterConfig{CapBasis: r.FormValue("cap_basis")}
+	if value := strings.TrimSpace(r.FormValue("salary_cap")); value != "" {
+		if rosterConfig.SalaryCap, err = strconv.ParseFloat(value, 64); err != nil {
+			http.Error(w, "Invalid salary cap", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := strings.TrimSpace(r.FormValue("max_roster_size")); value != "" {
+		if rosterConfig.MaxRosterSize, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid roster size", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := h.rosterService.UpdateRosterConfig(guildID, rosterConfig); err != nil {
+		h.handleError(w, "save roster rules", err)
+		return
+	}
+
+	http.Redirect(w, r, "/usl/admin/teams", http.StatusSeeOther)
+}
+
+// changeRoster reads the team and player from the form, applies the change and returns to the team
+func (h *RosterHandler) changeRoster(w http.ResponseWriter, r *http.Request, operation string, change func(teamID int64, discordID string, changedBy *int64) (*services.TeamRoster, error)) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	teamID, err := strconv.ParseInt(r.FormValue("team_id"), 10, 64)
+	if err != nil || teamID <= 0 {
+		http.Error(w, "Invalid team ID", http.StatusBadRequest)
+		return
+	}
+
+	discordID := strings.TrimSpace(r.FormValue("discord_id"))
+	if discordID == "" {
+		http.Error(w, "Discord ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := change(teamID, discordID, nil); err != nil {
+		h.handleError(w, operation, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/teams?team_id=%d", teamID), http.StatusSeeOther)
+}
+
+// handleError maps roster rule breaks to client errors and everything else to a 500
+func (h *RosterHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrInvalidRoster) || errors.Is(err, services.ErrSalaryCapExceeded) ||
+		errors.Is(err, services.ErrPlayerIneligible) || errors.Is(err, services.ErrPlayerRostered) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *RosterHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// matchNightLayouts are the accepted forms for a match night, read as UTC
+var matchNightLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", time.RFC3339, "2006-01-02"}
+
+// ScheduleHandler serves the league schedule admin pages and team calendar feeds
+type ScheduleHandler struct {
+	scheduleService *services.ScheduleService
+	seasonService   *services.SeasonService
+	guildRepo       *repositories.GuildRepository
+	templates       *template.Template
+}
+
+// ScheduleRoundView is one round of fixtures for rendering
+type ScheduleRoundView struct {
+	Round      int
+	MatchNight time.Time
+	Fixtures   []services.ScheduleFixture
+}
+
+func NewScheduleHandler(scheduleService *services.ScheduleService, seasonService *services.SeasonService, guildRepo *repositories.GuildRepository, templates *template.Template) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleService: scheduleService,
+		seasonService:   seasonService,
+		guildRepo:       guildRepo,
+		templates:       templates,
+	}
+}
+
+// Schedule handles GET /usl/admin/schedule?division_id=
+// Lists divisions and renders the selected division's teams and fixtures
+func (h *ScheduleHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	divisions, err := h.scheduleService.GetDivisions(guildID)
+	if err != nil {
+		h.handleError(w, "load divisions", err)
+		return
+	}
+
+	var schedule *services.DivisionSchedule
+	if divisionIDParam := r.URL.Query().Get("division_id"); divisionIDParam != "" {
+		divisionID, err := strconv.ParseInt(divisionIDParam, 10, 64)
+		if err != nil || divisionID <= 0 {
+			http.Error(w, "Invalid division ID", http.StatusBadRequest)
+			return
+		}
+		if schedule, err = h.scheduleService.GetSchedule(divisionID); err != nil {
+			log.Printf("[USL-HANDLER] Division %d not found: %v", divisionID, err)
+			http.Error(w, "Division not found", http.StatusNotFound)
+			return
+		}
+	} else if len(divisions) > 0 {
+		if schedule, err = h.scheduleService.GetSchedule(divisions[0].ID); err != nil {
+			h.handleError(w, "load schedule", err)
+			return
+		}
+	}
+
+	var rounds []ScheduleRoundView
+	if schedule != nil {
+		rounds = buildScheduleRounds(schedule.Fixtures)
+	}
+
+	data := struct {
+		Title       string
+		CurrentPage string
+		GuildID     int64
+		Divisions   []*models.Division
+		Schedule    *services.DivisionSchedule
+		Rounds      []ScheduleRoundView
+	}{
+		Title:       "Schedule",
+		CurrentPage: "schedule",
+		GuildID:     guildID,
+		Divisions:   divisions,
+		Schedule:    schedule,
+		Rounds:      rounds,
+	}
+
+	h.renderTemplate(w, TemplateUSLSchedule, data)
+}
+
+// CreateDivision handles POST /usl/admin/schedule/divisions
+// New divisions belong to the guild's active season when there is one
+func (h *ScheduleHandler) CreateDivision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := models.DivisionCreateRequest{
+		GuildID: guildID,
+		Name:    r.FormValue("name"),
+	}
+	if season, err := h.seasonService.GetActiveSeason(guildID); err == nil && season != nil {
+		request.SeasonID = &season.ID
+	}
+
+	division, err := h.scheduleService.CreateDivision(request)
+	if err != nil {
+		h.handleError(w, "create division", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/schedule?division_id=%d", division.ID), http.StatusSeeOther)
+}
+
+// AddTeam handles POST /usl/admin/schedule/teams
+func (h *ScheduleHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	divisionID, err := strconv.ParseInt(r.FormValue("division_id"), 10, 64)
+	if err != nil || divisionID <= 0 {
+		http.Error(w, "Invalid division ID", http.StatusBadRequest)
+		return
+	}
+
+	request := models.TeamCreateRequest{
+		GuildID:    guildID,
+		DivisionID: &divisionID,
+		Name:       r.FormValue("name"),
+	}
+
+	if _, err := h.scheduleService.AddTeam(request); err != nil {
+		h.handleError(w, "add team", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/schedule?division_id=%d", divisionID), http.StatusSeeOther)
+}
+
+// GenerateSchedule handles POST /usl/admin/schedule/generate
+// Match nights are one per line, e.g. "2025-09-01 20:00" (UTC)
+func (h *ScheduleHandler) GenerateSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	divisionID, err := strconv.ParseInt(r.FormValue("division_id"), 10, 64)
+	if err != nil || divisionID <= 0 {
+		http.Error(w, "Invalid division ID", http.StatusBadRequest)
+		return
+	}
+
+	nights, err := parseMatchNights(r.FormValue("match_nights"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := models.ScheduleGenerateRequest{
+		DivisionID:  divisionID,
+		MatchNights: nights,
+		Double:      r.FormValue("double") == "on",
+		Replace:     r.FormValue("replace") == "on",
+	}
+
+	if _, err := h.scheduleService.GenerateSchedule(request); err != nil {
+		h.handleError(w, "generate schedule", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/schedule?division_id=%d", divisionID), http.StatusSeeOther)
+}
+
+// TeamCalendar handles GET /calendar/teams/{team_id}.ics
+// Public so calendar clients can subscribe without a session
+func (h *ScheduleHandler) TeamCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/calendar/teams/")
+	teamID, err := strconv.ParseInt(strings.TrimSuffix(name, ".ics"), 10, 64)
+	if err != nil || teamID <= 0 || !strings.HasSuffix(name, ".ics") {
+		http.NotFound(w, r)
+		return
+	}
+
+	calendar, err := h.scheduleService.TeamCalendar(teamID)
+	if err != nil {
+		log.Printf("[USL-HANDLER] Calendar for team %d unavailable: %v", teamID, err)
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"team-%d.ics\"", teamID))
+	if _, err := w.Write(calendar); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write calendar for team %d: %v", teamID, err)
+	}
+}
+
+// parseMatchNights reads one date or date-time per line
+func parseMatchNights(text string) ([]time.Time, error) {
+	var nights []time.Time
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		night, err := parseMatchNight(line)
+		if err != nil {
+			return nil, err
+		}
+		nights = append(nights, night)
+	}
+	return nights, nil
+}
+
+// parseMatchNight tries each accepted layout in turn
+func parseMatchNight(value string) (time.Time, error) {
+	for _, layout := range matchNightLayouts {
+		if night, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return night.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid match night: %s", value)
+}
+
+// buildScheduleRounds groups fixtures by round, keeping each round's match night
+func buildScheduleRounds(fixtures []services.ScheduleFixture) []ScheduleRoundView {
+	var rounds []ScheduleRoundView
+	for _, fixture := range fixtures {
+		if len(rounds) == 0 || rounds[len(rounds)-1].Round != fixture.Round {
+			rounds = append(rounds, ScheduleRoundView{Round: fixture.Round, MatchNight: fixture.MatchNight})
+		}
+		rounds[len(rounds)-1].Fixtures = append(rounds[len(rounds)-1].Fixtures, fixture)
+	}
+	return rounds
+}
+
+// handleError maps schedule errors to client errors and everything else to a 500
+func (h *ScheduleHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrInvalidSchedule) || errors.Is(err, services.ErrScheduleExists) ||
+		errors.Is(err, services.ErrScheduleStarted) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *ScheduleHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// SeasonHandler serves the season admin pages
+type SeasonHandler struct {
+	seasonService *services.SeasonService
+	guildRepo     *repositories.GuildRepository
+	userRepo      *repositories.UserRepository
+	templates     *template.Template
+}
+
+// SeasonStandingRow is a snapshot entry with the player's display name
+type SeasonStandingRow struct {
+	models.SeasonLeaderboardEntry
+	Name string
+}
+
+func NewSeasonHandler(seasonService *services.SeasonService, guildRepo *repositories.GuildRepository, userRepo *repositories.UserRepository, templates *template.Template) *SeasonHandler {
+	return &SeasonHandler{
+		seasonService: seasonService,
+		guildRepo:     guildRepo,
+		userRepo:      userRepo,
+		templates:     templates,
+	}
+}
+
+// Seasons handles GET /usl/admin/seasons
+// Shows the active season, past seasons and the final standings of the season_id query parameter
+func (h *SeasonHandler) Seasons(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	seasons, err := h.seasonService.GetSeasons(guildID)
+	if err != nil {
+		h.handleError(w, "load seasons", err)
+		return
+	}
+
+	var activeSeason, selectedSeason *models.Season
+	selectedID, _ := strconv.ParseInt(r.URL.Query().Get("season_id"), 10, 64)
+	for _, season := range seasons {
+		if season.IsActive() {
+			activeSeason = season
+		}
+		if season.ID == selectedID || (selectedID == 0 && selectedSeason == nil && !season.IsActive()) {
+			selectedSeason = season
+		}
+	}
+
+	latestRollover, err := h.seasonService.GetLatestTrackerRollover()
+	if err != nil {
+		h.handleError(w, "load tracker rollover", err)
+		return
+	}
+
+	var standings []SeasonStandingRow
+	var selectedReset *services.SoftReset
+	if selectedSeason != nil && !selectedSeason.IsActive() {
+		standings, err = h.loadStandings(selectedSeason.ID)
+		if err != nil {
+			h.handleError(w, "load standings", err)
+			return
+		}
+		if selectedSeason.SoftResetMuFactor != nil && selectedSeason.SoftResetSigmaFactor != nil {
+			selectedReset = &services.SoftReset{
+				MuFactor:    *selectedSeason.SoftResetMuFactor,
+				SigmaFactor: *selectedSeason.SoftResetSigmaFactor,
+			}
+		}
+	}
+
+	data := struct {
+		Title          string
+		CurrentPage    string
+		GuildID        int64
+		ActiveSeason   *models.Season
+		Seasons        []*models.Season
+		SelectedSeason *models.Season
+		SelectedReset  *services.SoftReset
+		Standings      []SeasonStandingRow
+		DefaultReset   services.SoftReset
+		LatestRollover *models.TrackerSeasonRollover
+	}{
+		Title:          "Seasons",
+		CurrentPage:    "seasons",
+		GuildID:        guildID,
+		ActiveSeason:   activeSeason,
+		Seasons:        seasons,
+		SelectedSeason: selectedSeason,
+		SelectedReset:  selectedReset,
+		Standings:      standings,
+		DefaultReset:   h.seasonService.DefaultSoftReset(),
+		LatestRollover: latestRollover,
+	}
+
+	h.renderTemplate(w, TemplateUSLSeasons, data)
+}
+
+// StartSeason handles POST /usl/admin/seasons/start
+func (h *SeasonHandler) StartSeason(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.seasonService.StartSeason(guildID, strings.TrimSpace(r.FormValue("name"))); err != nil {
+		h.handleError(w, "start season", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/seasons?guild_id=%d", guildID), http.StatusSeeOther)
+}
+
+// CloseSeason handles POST /usl/admin/seasons/close
+func (h *SeasonHandler) CloseSeason(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	options := services.CloseSeasonOptions{}
+	if r.FormValue("apply_reset") == "on" {
+		reset := h.seasonService.DefaultSoftReset()
+		if value := r.FormValue("mu_factor"); value != "" {
+			if reset.MuFactor, err = strconv.ParseFloat(value, 64); err != nil {
+				http.Error(w, "Invalid mu factor", http.StatusBadRequest)
+				return
+			}
+		}
+		if value := r.FormValue("sigma_factor"); value != "" {
+			if reset.SigmaFactor, err = strconv.ParseFloat(value, 64); err != nil {
+				http.Error(w, "Invalid sigma factor", http.StatusBadRequest)
+				return
+			}
+		}
+		options.SoftReset = &reset
+	}
+
+	result, err := h.seasonService.CloseSeason(guildID, options)
+	if err != nil {
+		h.handleError(w, "close season", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/seasons?guild_id=%d&season_id=%d", guildID, result.Season.ID), http.StatusSeeOther)
+}
+
+// RollTrackers handles POST /usl/admin/seasons/roll-trackers
+// Rolls every tracker into the game_season Rocket League just started, at most once per game season
+func (h *SeasonHandler) RollTrackers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	gameSeason, err := strconv.Atoi(strings.TrimSpace(r.FormValue("game_season")))
+	if err != nil {
+		http.Error(w, "Invalid game season", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.seasonService.RollTrackerSeasons(gameSeason, nil); err != nil {
+		h.handleError(w, "roll trackers", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/seasons?guild_id=%d", guildID), http.StatusSeeOther)
+}
+
+// loadStandings returns a closed season's snapshot with player names attached
+func (h *SeasonHandler) loadStandings(seasonID int64) ([]SeasonStandingRow, error) {
+	entries, err := h.seasonService.GetFinalStandings(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string)
+	if users, err := h.userRepo.GetAllUsers(false); err == nil {
+		for _, user := range users {
+			names[int64(user.ID)] = user.Name
+		}
+	} else {
+		log.Printf("[USL-HANDLER] Failed to load user names for standings: %v", err)
+	}
+
+	rows := make([]SeasonStandingRow, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := names[entry.UserID]
+		if !ok {
+			name = fmt.Sprintf("User %d", entry.UserID)
+		}
+		rows = append(rows, SeasonStandingRow{SeasonLeaderboardEntry: entry, Name: name})
+	}
+	return rows, nil
+}
+
+// handleError maps season lifecycle errors to client errors and everything else to a 500
+func (h *SeasonHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrSeasonAlreadyActive) || errors.Is(err, services.ErrNoActiveSeason) || errors.Is(err, services.ErrInvalidSoftReset) ||
+		errors.Is(err, services.ErrInvalidGameSeason) || errors.Is(err, services.ErrTrackersRolled) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *SeasonHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
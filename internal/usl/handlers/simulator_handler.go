@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// SimulatorHandler serves the what-if rating simulator admin page
+type SimulatorHandler struct {
+	simulator *services.RatingSimulator
+	guildRepo *repositories.GuildRepository
+	templates *template.Template
+}
+
+// SimulationForm is the seeding values as shown in the simulator form
+type SimulationForm struct {
+	OnesWeight         float64
+	TwosWeight         float64
+	ThreesWeight       float64
+	SigmaMin           float64
+	SigmaMax           float64
+	RankSeason         int // 0 reads each tracker against the season it was updated in
+	TrackerAggregation string
+	SeasonPooling      string
+	SeasonHalfLifeDays float64
+	Limit              int
+}
+
+func NewSimulatorHandler(simulator *services.RatingSimulator, guildRepo *repositories.GuildRepository, templates *template.Template) *SimulatorHandler {
+	return &SimulatorHandler{
+		simulator: simulator,
+		guildRepo: guildRepo,
+		templates: templates,
+	}
+}
+
+// Simulator handles GET and POST /usl/admin/simulator
+// GET shows the form filled with the running config; POST recalculates with the submitted
+// values and shows the difference without saving anything
+func (h *SimulatorHandler) Simulator(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		guildID, err := adminGuildID(r, h.guildRepo)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		h.renderSimulator(w, guildID, newSimulationForm(h.simulator.CurrentSettings()), nil)
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+
+		guildID, err := adminGuildID(r, h.guildRepo)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		overrides, limit, err := parseSimulationForm(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, err := h.simulator.Run(guildID, overrides, limit)
+		if err != nil {
+			h.handleError(w, "run simulation", err)
+			return
+		}
+
+		form := newSimulationForm(result.Simulated)
+		form.Limit = limit
+		h.renderSimulator(w, guildID, form, result)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// renderSimulator renders the simulator page with an optional result
+func (h *SimulatorHandler) renderSimulator(w http.ResponseWriter, guildID int64, form SimulationForm, result *services.SimulationResult) {
+	data := struct {
+		Title        string
+		CurrentPage  string
+		GuildID      int64
+		Form         SimulationForm
+		Current      services.SimulationSettings
+		RankSeasons  []int
+		Aggregations []string
+		Poolings     []string
+		Result       *services.SimulationResult
+	}{
+		Title:        "Rating Simulator",
+		CurrentPage:  "simulator",
+		GuildID:      guildID,
+		Form:         form,
+		Current:      h.simulator.CurrentSettings(),
+		RankSeasons:  h.simulator.RankSeasons(),
+		Aggregations: []string{services.TrackerAggregationMax, services.TrackerAggregationPool, services.TrackerAggregationPrimary},
+		Poolings:     []string{services.SeasonPoolingGames, services.SeasonPoolingSeason, services.SeasonPoolingHybrid},
+		Result:       result,
+	}
+
+	h.renderTemplate(w, TemplateUSLSimulator, data)
+}
+
+// newSimulationForm fills the form from seeding values
+func newSimulationForm(settings services.SimulationSettings) SimulationForm {
+	return SimulationForm{
+		OnesWeight:         settings.OnesWeight,
+		TwosWeight:         settings.TwosWeight,
+		ThreesWeight:       settings.ThreesWeight,
+		SigmaMin:           settings.SigmaMin,
+		SigmaMax:           settings.SigmaMax,
+		RankSeason:         settings.RankSeason,
+		TrackerAggregation: settings.TrackerAggregation,
+		SeasonPooling:      settings.SeasonPooling,
+		SeasonHalfLifeDays: settings.SeasonHalfLifeDays,
+		Limit:              services.DefaultSimulationLimit,
+	}
+}
+
+// parseSimulationForm reads the overrides and list size from the form; blank fields keep the running config
+func parseSimulationForm(r *http.Request) (services.SimulationOverrides, int, error) {
+	overrides := services.SimulationOverrides{
+		TrackerAggregation: r.FormValue("tracker_aggregation"),
+		SeasonPooling:      r.FormValue("season_pooling"),
+	}
+
+	fields := []struct {
+		name   string
+		target **float64
+	}{
+		{"ones_weight", &overrides.OnesWeight},
+		{"twos_weight", &overrides.TwosWeight},
+		{"threes_weight", &overrides.ThreesWeight},
+		{"sigma_min", &overrides.SigmaMin},
+		{"sigma_max", &overrides.SigmaMax},
+		{"season_half_life_days", &overrides.SeasonHalfLifeDays},
+	}
+	for _, field := range fields {
+		value := strings.TrimSpace(r.FormValue(field.name))
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return overrides, 0, fmt.Errorf("invalid %s: %s", field.name, value)
+		}
+		*field.target = &parsed
+	}
+
+	if value := strings.TrimSpace(r.FormValue("rank_season")); value != "" {
+		season, err := strconv.Atoi(value)
+		if err != nil || season < 0 {
+			return overrides, 0, fmt.Errorf("invalid rank_season: %s", value)
+		}
+		overrides.RankSeason = season
+	}
+
+	limit := services.DefaultSimulationLimit
+	if value := strings.TrimSpace(r.FormValue("limit")); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return overrides, 0, fmt.Errorf("invalid limit: %s", value)
+		}
+		limit = parsed
+	}
+
+	return overrides, limit, nil
+}
+
+// handleError maps invalid overrides and placement rules to client errors and everything else to a 500
+func (h *SimulatorHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrInvalidSimulation) || errors.Is(err, services.ErrInvalidPlacement) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *SimulatorHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
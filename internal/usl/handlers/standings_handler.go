@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"usl-server/internal/models"
+	"usl-server/internal/repositories"
+	"usl-server/internal/services"
+)
+
+// standingsTiebreakerSlots is the number of tiebreak order fields on the settings form
+const standingsTiebreakerSlots = 5
+
+// StandingsHandler serves division standings and result entry
+type StandingsHandler struct {
+	standingsService *services.StandingsService
+	scheduleService  *services.ScheduleService
+	guildRepo        *repositories.GuildRepository
+	templates        *template.Template
+}
+
+func NewStandingsHandler(standingsService *services.StandingsService, scheduleService *services.ScheduleService, guildRepo *repositories.GuildRepository, templates *template.Template) *StandingsHandler {
+	return &StandingsHandler{
+		standingsService: standingsService,
+		scheduleService:  scheduleService,
+		guildRepo:        guildRepo,
+		templates:        templates,
+	}
+}
+
+// Standings handles GET /usl/admin/standings?division_id=
+// Lists divisions and renders the selected division's table, results and open fixtures
+func (h *StandingsHandler) Standings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	divisions, err := h.scheduleService.GetDivisions(guildID)
+	if err != nil {
+		h.handleError(w, "load divisions", err)
+		return
+	}
+
+	config, err := h.standingsService.GetStandingsConfig(guildID)
+	if err != nil {
+		h.handleError(w, "load standings settings", err)
+		return
+	}
+
+	var standings *services.DivisionStandings
+	if divisionIDParam := r.URL.Query().Get("division_id"); divisionIDParam != "" {
+		divisionID, err := strconv.ParseInt(divisionIDParam, 10, 64)
+		if err != nil || divisionID <= 0 {
+			http.Error(w, "Invalid division ID", http.StatusBadRequest)
+			return
+		}
+		if standings, err = h.standingsService.GetStandings(divisionID); err != nil {
+			log.Printf("[USL-HANDLER] Division %d not found: %v", divisionID, err)
+			http.Error(w, "Division not found", http.StatusNotFound)
+			return
+		}
+	} else if len(divisions) > 0 {
+		if standings, err = h.standingsService.GetStandings(divisions[0].ID); err != nil {
+			h.handleError(w, "load standings", err)
+			return
+		}
+	}
+
+	// Pad the configured order out to the form's slots so each renders as a select
+	slots := make([]string, standingsTiebreakerSlots)
+	copy(slots, config.Tiebreakers)
+
+	data := struct {
+		Title           string
+		CurrentPage     string
+		GuildID         int64
+		Divisions       []*models.Division
+		Standings       *services.DivisionStandings
+		TiebreakerSlots []string
+		Tiebreakers     []string
+	}{
+		Title:           "Standings",
+		CurrentPage:     "standings",
+		GuildID:         guildID,
+		Divisions:       divisions,
+		Standings:       standings,
+		TiebreakerSlots: slots,
+		Tiebreakers: []string{
+			models.TiebreakHeadToHead,
+			models.TiebreakGameDifferential,
+			models.TiebreakGoalDifferential,
+			models.TiebreakGoalsFor,
+			models.TiebreakStrengthOfSchedule,
+		},
+	}
+
+	h.renderTemplate(w, TemplateUSLStandings, data)
+}
+
+// RecordResult handles POST /usl/admin/standings/results
+// Picking a fixture takes its teams; otherwise home_team_id and away_team_id are used.
+// Games are home-away scores separated by commas, e.g. "3-1, 2-4, 1-0"
+func (h *StandingsHandler) RecordResult(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	divisionID, err := strconv.ParseInt(r.FormValue("division_id"), 10, 64)
+	if err != nil || divisionID <= 0 {
+		http.Error(w, "Invalid division ID", http.StatusBadRequest)
+		return
+	}
+
+	games, err := parseGameScores(r.FormValue("games"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := models.LeagueResultCreateRequest{
+		GuildID:    guildID,
+		DivisionID: divisionID,
+		Games:      games,
+	}
+
+	if fixtureParam := r.FormValue("scheduled_match_id"); fixtureParam != "" {
+		fixtureID, err := strconv.ParseInt(fixtureParam, 10, 64)
+		if err != nil || fixtureID <= 0 {
+			http.Error(w, "Invalid fixture ID", http.StatusBadRequest)
+			return
+		}
+		fixture, err := h.findOpenFixture(divisionID, fixtureID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		request.ScheduledMatchID = &fixture.ID
+		request.HomeTeamID = fixture.HomeTeamID
+		request.AwayTeamID = *fixture.AwayTeamID
+	} else {
+		if request.HomeTeamID, err = strconv.ParseInt(r.FormValue("home_team_id"), 10, 64); err != nil {
+			http.Error(w, "Invalid home team", http.StatusBadRequest)
+			return
+		}
+		if request.AwayTeamID, err = strconv.ParseInt(r.FormValue("away_team_id"), 10, 64); err != nil {
+			http.Error(w, "Invalid away team", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if playedAt := strings.TrimSpace(r.FormValue("played_at")); playedAt != "" {
+		played, err := parseMatchNight(playedAt)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		request.PlayedAt = &played
+	}
+
+	if _, err := h.standingsService.RecordResult(request); err != nil {
+		h.handleError(w, "record result", err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/usl/admin/standings?division_id=%d", divisionID), http.StatusSeeOther)
+}
+
+// UpdateSettings handles POST /usl/admin/standings/settings
+// The tiebreak order comes from the tiebreaker_1..tiebreaker_N selects; blank slots are skipped
+func (h *StandingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	guildID, err := adminGuildID(r, h.guildRepo)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var standings models.StandingsConfig
+	for slot := 1; slot <= standingsTiebreakerSlots; slot++ {
+		if tiebreaker := strings.TrimSpace(r.FormValue(fmt.Sprintf("tiebreaker_%d", slot))); tiebreaker != "" {
+			standings.Tiebreakers = append(standings.Tiebreakers, tiebreaker)
+		}
+	}
+
+	if err := h.standingsService.UpdateStandingsConfig(guildID, standings); err != nil {
+		h.handleError(w, "update standings settings", err)
+		return
+	}
+
+	redirect := "/usl/admin/standings"
+	if divisionID := r.FormValue("division_id"); divisionID != "" {
+		redirect += "?division_id=" + divisionID
+	}
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// findOpenFixture finds a fixture of the division that still needs a result
+func (h *StandingsHandler) findOpenFixture(divisionID, fixtureID int64) (*services.ScheduleFixture, error) {
+	standings, err := h.standingsService.GetStandings(divisionID)
+	if err != nil {
+		return nil, fmt.Errorf("division not found")
+	}
+	for _, fixture := range standings.OpenFixtures {
+		if fixture.ID == fixtureID {
+			return &fixture, nil
+		}
+	}
+	return nil, fmt.Errorf("fixture %d is not open in this division", fixtureID)
+}
+
+// parseGameScores reads comma-separated home-away game scores like "3-1, 2-4"
+func parseGameScores(text string) ([]models.LeagueGame, error) {
+	var games []models.LeagueGame
+	for _, score := range strings.Split(text, ",") {
+		score = strings.TrimSpace(score)
+		if score == "" {
+			continue
+		}
+
+		home, away, ok := strings.Cut(score, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid game score: %s", score)
+		}
+		homeGoals, err := strconv.Atoi(strings.TrimSpace(home))
+		if err != nil {
+			return nil, fmt.Errorf("invalid game score: %s", score)
+		}
+		awayGoals, err := strconv.Atoi(strings.TrimSpace(away))
+		if err != nil {
+			return nil, fmt.Errorf("invalid game score: %s", score)
+		}
+		games = append(games, models.LeagueGame{HomeGoals: homeGoals, AwayGoals: awayGoals})
+	}
+	return games, nil
+}
+
+// handleError maps rejected results and settings to client errors and everything else to a 500
+func (h *StandingsHandler) handleError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, services.ErrInvalidResult) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Database error during %s: %v", operation, err)
+	http.Error(w, fmt.Sprintf("Failed to %s", operation), http.StatusInternalServerError)
+}
+
+// renderTemplate renders into a buffer first so errors never produce partial output
+func (h *StandingsHandler) renderTemplate(w http.ResponseWriter, templateName TemplateName, data any) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, string(templateName), data); err != nil {
+		log.Printf("[USL-HANDLER] Template rendering error: template=%s, error=%v", templateName, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("[USL-HANDLER] Failed to write template output: %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"usl-server/internal/models"
+	"usl-server/internal/services"
+)
+
+// V2BalanceHandler handles API requests for splitting a lobby into balanced teams
+type V2BalanceHandler struct {
+	ratingResolver *services.PlayerRatingResolver
+	teamBalancer   *services.TeamBalancer
+}
+
+// balanceRequest is the JSON body accepted by POST /api/v2/balance
+type balanceRequest struct {
+	GuildID    int64      `json:"guild_id"`
+	DiscordIDs []string   `json:"discord_ids"`
+	TeamCount  int        `json:"team_count"`
+	TeamSize   int        `json:"team_size"`
+	Together   [][]string `json:"together"`
+	Apart      [][]string `json:"apart"`
+	Results    int        `json:"results"`
+	Playlist   string     `json:"playlist"` // ones, 2v2, doubles...; empty picks it by team size
+}
+
+func NewV2BalanceHandler(ratingResolver *services.PlayerRatingResolver, teamBalancer *services.TeamBalancer) *V2BalanceHandler {
+	return &V2BalanceHandler{
+		ratingResolver: ratingResolver,
+		teamBalancer:   teamBalancer,
+	}
+}
+
+// HandleBalance handles POST /api/v2/balance
+func (h *V2BalanceHandler) HandleBalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	request, err := h.parseBalanceRequest(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return
+	}
+
+	guildID, err := requestGuildID(r, request.GuildID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	playlist, err := request.ratingPlaylist()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidPlaylist, map[string]string{"error": err.Error()})
+		return
+	}
+
+	resolved, err := h.ratingResolver.ResolvePlaylist(guildID, request.DiscordIDs, playlist)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToResolveRatings, map[string]string{"error": err.Error()})
+		return
+	}
+
+	players := make([]services.BalancePlayer, 0, len(resolved))
+	for _, rating := range resolved {
+		players = append(players, services.BalancePlayer{
+			DiscordID: rating.DiscordID,
+			Rating:    rating.Rating,
+			Source:    rating.Source,
+		})
+	}
+
+	splits, err := h.teamBalancer.Balance(services.BalanceRequest{
+		Players:    players,
+		TeamCount:  request.TeamCount,
+		TeamSize:   request.TeamSize,
+		Together:   request.Together,
+		Apart:      request.Apart,
+		MaxResults: request.Results,
+	})
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, msgBalanceFailed, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"guild_id": guildID,
+		"playlist": playlist,
+		"splits":   splits,
+	})
+}
+
+// parseBalanceRequest parses a balance request from HTTP body
+func (h *V2BalanceHandler) parseBalanceRequest(r *http.Request) (*balanceRequest, error) {
+	var request balanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	if len(request.DiscordIDs) < 2 {
+		return nil, fmt.Errorf("discord_ids must list at least 2 players")
+	}
+	return &request, nil
+}
+
+// ratingPlaylist returns the playlist whose ratings balance the lobby: the one requested, else
+// the one matching the team size. Empty for other formats, which use combined ratings.
+func (request *balanceRequest) ratingPlaylist() (string, error) {
+	if request.Playlist != "" {
+		return services.ParsePlaylist(request.Playlist)
+	}
+
+	teamSize := request.TeamSize
+	if teamSize == 0 {
+		teamCount := request.TeamCount
+		if teamCount == 0 {
+			teamCount = services.DefaultBalanceTeamCount
+		}
+		if len(request.DiscordIDs)%teamCount != 0 {
+			return "", nil
+		}
+		teamSize = len(request.DiscordIDs) / teamCount
+	}
+
+	playlist, _ := models.RatingPlaylistForTeamSize(teamSize)
+	return playlist, nil
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2BalanceHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2BalanceHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"usl-server/internal/models"
+	"usl-server/internal/services"
+)
+
+// V2DecayHandler handles API requests for a guild's inactivity decay policy
+type V2DecayHandler struct {
+	decayService *services.DecayService
+}
+
+// decayConfigRequest is the JSON body accepted by PUT /api/v2/decay/config
+type decayConfigRequest struct {
+	GuildID int64              `json:"guild_id"`
+	Decay   models.DecayConfig `json:"decay"`
+}
+
+func NewV2DecayHandler(decayService *services.DecayService) *V2DecayHandler {
+	return &V2DecayHandler{
+		decayService: decayService,
+	}
+}
+
+// HandleConfig handles GET and PUT /api/v2/decay/config
+func (h *V2DecayHandler) HandleConfig(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		guildID, err := requestGuildID(r, 0)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+			return
+		}
+
+		decay, err := h.decayService.GetDecayConfig(guildID)
+		if err != nil {
+			h.writeDecayError(w, msgFailedToGetDecayConfig, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"guild_id": guildID,
+			"decay":    decay,
+		})
+	case http.MethodPut:
+		var request decayConfigRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+			return
+		}
+
+		guildID, err := requestGuildID(r, request.GuildID)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := h.decayService.UpdateDecayConfig(guildID, request.Decay); err != nil {
+			h.writeDecayError(w, msgFailedToUpdateDecayConfig, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"message":  msgDecayConfigUpdatedSuccessfully,
+			"guild_id": guildID,
+			"decay":    request.Decay,
+		})
+	default:
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+	}
+}
+
+// HandleRun handles POST /api/v2/decay/run?guild_id=
+// Admins use it to apply the guild's policy without waiting for the scheduled job
+func (h *V2DecayHandler) HandleRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	result, err := h.decayService.RunGuild(guildID, time.Now())
+	if err != nil {
+		h.writeDecayError(w, msgFailedToRunDecay, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, result)
+}
+
+// writeDecayError maps invalid policies to 400 and everything else to 500
+func (h *V2DecayHandler) writeDecayError(w http.ResponseWriter, message string, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, services.ErrInvalidDecayPolicy) {
+		status = http.StatusBadRequest
+	}
+	h.writeErrorResponse(w, status, message, map[string]string{"error": err.Error()})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2DecayHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2DecayHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/services"
+)
+
+// V2DraftsHandler handles API requests for drafts and their live boards
+type V2DraftsHandler struct {
+	draftService *services.DraftService
+}
+
+func NewV2DraftsHandler(draftService *services.DraftService) *V2DraftsHandler {
+	return &V2DraftsHandler{
+		draftService: draftService,
+	}
+}
+
+// HandleDrafts handles GET /api/v2/drafts
+// ?draft_id= returns the draft's board with the team on the clock and the free-agent pool,
+// otherwise the guild's drafts
+func (h *V2DraftsHandler) HandleDrafts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	if draftIDParam := r.URL.Query().Get("draft_id"); draftIDParam != "" {
+		draftID, err := strconv.ParseInt(draftIDParam, 10, 64)
+		if err != nil || draftID <= 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"draft_id": "must be a positive integer"})
+			return
+		}
+
+		board, err := h.draftService.GetBoard(draftID)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, services.ErrDraftNotFound) {
+				status = http.StatusNotFound
+			}
+			h.writeErrorResponse(w, status, msgFailedToGetDrafts, map[string]string{"error": err.Error()})
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, board)
+		return
+	}
+
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	drafts, err := h.draftService.GetDrafts(guildID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToGetDrafts, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"data":     drafts,
+		"count":    len(drafts),
+		"guild_id": guildID,
+	})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2DraftsHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2DraftsHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
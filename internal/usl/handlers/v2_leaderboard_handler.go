@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/services"
+)
+
+// V2LeaderboardHandler handles API requests for guild rating leaderboards
+type V2LeaderboardHandler struct {
+	playlistRatings *services.PlaylistRatingService
+}
+
+func NewV2LeaderboardHandler(playlistRatings *services.PlaylistRatingService) *V2LeaderboardHandler {
+	return &V2LeaderboardHandler{
+		playlistRatings: playlistRatings,
+	}
+}
+
+// HandleLeaderboard handles GET /api/v2/leaderboard?playlist=&limit=&guild_id=
+// Ranks players by their rating in one playlist (ones, 2v2, doubles...), or by combined
+// rating when no playlist is given
+func (h *V2LeaderboardHandler) HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	query := r.URL.Query()
+	playlist := ""
+	if playlistParam := query.Get("playlist"); playlistParam != "" {
+		if playlist, err = services.ParsePlaylist(playlistParam); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidPlaylist, map[string]string{"error": err.Error()})
+			return
+		}
+	}
+
+	limit := 0
+	if limitParam := query.Get("limit"); limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil || limit < 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"limit": limitParam})
+			return
+		}
+	}
+
+	entries, err := h.playlistRatings.Leaderboard(guildID, playlist, limit)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToGetLeaderboard, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"guild_id": guildID,
+		"playlist": playlist,
+		"entries":  entries,
+	})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2LeaderboardHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2LeaderboardHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
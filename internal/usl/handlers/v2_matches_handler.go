@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/services"
+)
+
+// V2MatchesHandler handles API requests for recording matches and listing match history
+type V2MatchesHandler struct {
+	matchService *services.MatchService
+}
+
+func NewV2MatchesHandler(matchService *services.MatchService) *V2MatchesHandler {
+	return &V2MatchesHandler{
+		matchService: matchService,
+	}
+}
+
+func (h *V2MatchesHandler) HandleMatches(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetMatches(w, r)
+	case http.MethodPost:
+		h.handleRecordMatch(w, r)
+	default:
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+	}
+}
+
+// handleGetMatches handles GET /api/v2/matches?guild_id=&limit=
+func (h *V2MatchesHandler) handleGetMatches(w http.ResponseWriter, r *http.Request) {
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	limit := 0
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit < 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"limit": limitParam})
+			return
+		}
+	}
+
+	matches, err := h.matchService.GetRecentMatches(guildID, limit)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToGetMatches, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"data":     matches,
+		"guild_id": guildID,
+	})
+}
+
+// handleRecordMatch handles POST /api/v2/matches
+func (h *V2MatchesHandler) handleRecordMatch(w http.ResponseWriter, r *http.Request) {
+	report, err := h.parseMatchReport(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return
+	}
+
+	report.GuildID, err = requestGuildID(r, report.GuildID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	result, err := h.matchService.RecordMatchReport(*report)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidMatchReport) {
+			status = http.StatusBadRequest
+		}
+		h.writeErrorResponse(w, status, msgFailedToRecordMatch, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
+		"result":  result,
+		"message": msgMatchRecordedSuccessfully,
+	})
+}
+
+// parseMatchReport parses a match report from HTTP body
+func (h *V2MatchesHandler) parseMatchReport(r *http.Request) (*services.MatchReport, error) {
+	var report services.MatchReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, err
+	}
+	if len(report.TeamA) == 0 || len(report.TeamB) == 0 {
+		return nil, fmt.Errorf("team_a and team_b must each list at least one Discord ID")
+	}
+	return &report, nil
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2MatchesHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2MatchesHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"usl-server/internal/services"
+)
+
+// V2PlacementsHandler handles API requests for players' tier placements
+type V2PlacementsHandler struct {
+	placementService *services.PlacementService
+}
+
+func NewV2PlacementsHandler(placementService *services.PlacementService) *V2PlacementsHandler {
+	return &V2PlacementsHandler{
+		placementService: placementService,
+	}
+}
+
+// HandlePlacements handles GET /api/v2/placements?guild_id=&discord_id=
+// With discord_id it returns that player's latest placement, otherwise the guild's latest placements
+func (h *V2PlacementsHandler) HandlePlacements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if discordID := r.URL.Query().Get("discord_id"); discordID != "" {
+		placement, err := h.placementService.FindPlacement(guildID, discordID)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToGetPlacements, map[string]string{"error": err.Error()})
+			return
+		}
+		if placement == nil {
+			h.writeErrorResponse(w, http.StatusNotFound, msgPlacementNotFound, map[string]string{"discord_id": discordID})
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"data":     placement,
+			"guild_id": guildID,
+		})
+		return
+	}
+
+	placements, err := h.placementService.GetCurrentPlacements(guildID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToGetPlacements, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"data":     placements,
+		"count":    len(placements),
+		"guild_id": guildID,
+	})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2PlacementsHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2PlacementsHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+	"usl-server/internal/services"
+)
+
+// errInvalidPredictTeam marks team input the client has to fix, as opposed to a failed lookup
+var errInvalidPredictTeam = errors.New("invalid team")
+
+// V2PredictHandler handles API requests for expected match outcomes
+type V2PredictHandler struct {
+	trueskillService *services.UserTrueSkillService
+	ratingResolver   *services.PlayerRatingResolver
+}
+
+// predictPlayer identifies a player by Discord ID or by raw μ/σ values
+type predictPlayer struct {
+	DiscordID string   `json:"discord_id,omitempty"`
+	Mu        *float64 `json:"mu,omitempty"`
+	Sigma     *float64 `json:"sigma,omitempty"`
+}
+
+// predictRequest is the JSON body accepted by POST /api/v2/predict
+type predictRequest struct {
+	GuildID int64           `json:"guild_id"`
+	TeamA   []predictPlayer `json:"team_a"`
+	TeamB   []predictPlayer `json:"team_b"`
+}
+
+func NewV2PredictHandler(trueskillService *services.UserTrueSkillService, ratingResolver *services.PlayerRatingResolver) *V2PredictHandler {
+	return &V2PredictHandler{
+		trueskillService: trueskillService,
+		ratingResolver:   ratingResolver,
+	}
+}
+
+// HandlePredict handles POST /api/v2/predict
+func (h *V2PredictHandler) HandlePredict(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	var request predictRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return
+	}
+
+	teamA, err := resolvePredictTeam(r, h.ratingResolver, request.GuildID, request.TeamA)
+	if err != nil {
+		h.writeTeamError(w, "team_a", err)
+		return
+	}
+	teamB, err := resolvePredictTeam(r, h.ratingResolver, request.GuildID, request.TeamB)
+	if err != nil {
+		h.writeTeamError(w, "team_b", err)
+		return
+	}
+
+	prediction, err := h.trueskillService.PredictMatchOutcome(teamA, teamB)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"prediction": prediction,
+		"team_a":     teamA,
+		"team_b":     teamB,
+	})
+}
+
+// resolvePredictTeam turns request players into ratings, looking up Discord IDs when no μ/σ was supplied
+// Input problems wrap errInvalidPredictTeam; lookup failures are returned as they are
+func resolvePredictTeam(r *http.Request, ratingResolver *services.PlayerRatingResolver, explicitGuildID int64, players []predictPlayer) ([]services.TrueSkillRating, error) {
+	if len(players) == 0 {
+		return nil, fmt.Errorf("%w: at least one player is required", errInvalidPredictTeam)
+	}
+
+	ratings := make([]services.TrueSkillRating, len(players))
+	lookupIDs := make([]string, 0, len(players))
+	lookupIndexes := make([]int, 0, len(players))
+
+	for i, player := range players {
+		switch {
+		case player.Mu != nil && player.Sigma != nil:
+			if *player.Sigma <= 0 {
+				return nil, fmt.Errorf("%w: player %d needs a positive sigma, got %.3f", errInvalidPredictTeam, i+1, *player.Sigma)
+			}
+			ratings[i] = services.TrueSkillRating{Mu: *player.Mu, Sigma: *player.Sigma}
+		case player.DiscordID != "":
+			lookupIDs = append(lookupIDs, player.DiscordID)
+			lookupIndexes = append(lookupIndexes, i)
+		default:
+			return nil, fmt.Errorf("%w: player %d needs either discord_id or both mu and sigma", errInvalidPredictTeam, i+1)
+		}
+	}
+
+	if len(lookupIDs) == 0 {
+		return ratings, nil
+	}
+
+	guildID, err := requestGuildID(r, explicitGuildID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidPredictTeam, err)
+	}
+
+	resolved, err := ratingResolver.Resolve(guildID, lookupIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i, rating := range resolved {
+		ratings[lookupIndexes[i]] = rating.Rating
+	}
+
+	return ratings, nil
+}
+
+// writeTeamError maps invalid team input to 400 and logs lookup failures as a 500
+func (h *V2PredictHandler) writeTeamError(w http.ResponseWriter, team string, err error) {
+	if errors.Is(err, errInvalidPredictTeam) {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{team: err.Error()})
+		return
+	}
+
+	log.Printf("[USL-HANDLER] Failed to resolve %s ratings for prediction: %v", team, err)
+	h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToResolveRatings, map[string]string{team: err.Error()})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2PredictHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2PredictHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
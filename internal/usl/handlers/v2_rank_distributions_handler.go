@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/services"
+)
+
+// maxRankDistributionBytes caps an uploaded distribution file
+const maxRankDistributionBytes = 1 << 20
+
+// V2RankDistributionsHandler handles API requests for per-season rank distributions
+type V2RankDistributionsHandler struct {
+	distributionService *services.RankDistributionService
+}
+
+func NewV2RankDistributionsHandler(distributionService *services.RankDistributionService) *V2RankDistributionsHandler {
+	return &V2RankDistributionsHandler{
+		distributionService: distributionService,
+	}
+}
+
+// HandleDistributions handles GET and POST /api/v2/rank-distributions
+// GET lists loaded seasons, or returns one season's bands with ?game_season=.
+// POST uploads a season's distribution as JSON, replacing any earlier upload for that season.
+func (h *V2RankDistributionsHandler) HandleDistributions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		seasonParam := r.URL.Query().Get("game_season")
+		if seasonParam == "" {
+			h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+				"distributions": h.distributionService.ListDistributions(),
+			})
+			return
+		}
+
+		gameSeason, err := strconv.Atoi(seasonParam)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidGameSeason, map[string]string{"game_season": seasonParam})
+			return
+		}
+
+		distribution, err := h.distributionService.GetDistribution(gameSeason)
+		if err != nil {
+			h.writeDistributionError(w, msgFailedToGetRankDistribution, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, distribution)
+	case http.MethodPost:
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRankDistributionBytes))
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+			return
+		}
+
+		distribution, err := h.distributionService.ParseDistribution(body)
+		if err != nil {
+			h.writeDistributionError(w, msgFailedToUploadRankDistribution, err)
+			return
+		}
+
+		saved, err := h.distributionService.Upload(distribution)
+		if err != nil {
+			h.writeDistributionError(w, msgFailedToUploadRankDistribution, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
+			"message":      msgRankDistributionUploadedSuccessfully,
+			"distribution": saved,
+		})
+	default:
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+	}
+}
+
+// writeDistributionError maps invalid uploads to 400, unknown seasons to 404 and everything else to 500
+func (h *V2RankDistributionsHandler) writeDistributionError(w http.ResponseWriter, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidRankDistribution):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrRankDistributionNotFound):
+		status = http.StatusNotFound
+	}
+	h.writeErrorResponse(w, status, message, map[string]string{"error": err.Error()})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2RankDistributionsHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2RankDistributionsHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
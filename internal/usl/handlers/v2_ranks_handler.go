@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/services"
+)
+
+// V2RanksHandler handles API requests for looking up the rank of an MMR
+type V2RanksHandler struct {
+	rankService *services.RankService
+}
+
+func NewV2RanksHandler(rankService *services.RankService) *V2RanksHandler {
+	return &V2RanksHandler{
+		rankService: rankService,
+	}
+}
+
+// HandleRanks handles GET /api/v2/ranks?mmr=&playlist=&game_season=
+// Returns the rank and division of the MMR in one playlist, or in every playlist when none is
+// given. game_season defaults to the latest season.
+func (h *V2RanksHandler) HandleRanks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	query := r.URL.Query()
+	mmr, err := strconv.ParseFloat(query.Get("mmr"), 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidMMR, map[string]string{"mmr": query.Get("mmr")})
+		return
+	}
+
+	gameSeason := 0
+	if seasonParam := query.Get("game_season"); seasonParam != "" {
+		if gameSeason, err = strconv.Atoi(seasonParam); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidGameSeason, map[string]string{"game_season": seasonParam})
+			return
+		}
+	}
+
+	ranks, err := h.rankService.Lookup(mmr, query.Get("playlist"), gameSeason)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidRankLookup) {
+			status = http.StatusBadRequest
+		}
+		h.writeErrorResponse(w, status, msgFailedToLookUpRank, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"ranks": ranks,
+	})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2RanksHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2RanksHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
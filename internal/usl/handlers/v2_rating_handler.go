@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"usl-server/internal/services"
+)
+
+// V2RatingHandler handles API requests for the guild's rating engine and engine comparisons
+type V2RatingHandler struct {
+	engines          *services.RatingEngineSelector
+	ratingResolver   *services.PlayerRatingResolver
+	trueSkillService *services.UserTrueSkillService
+}
+
+// ratingEngineRequest is the JSON body accepted by PUT /api/v2/rating/engine
+type ratingEngineRequest struct {
+	GuildID int64  `json:"guild_id"`
+	Engine  string `json:"engine"`
+}
+
+// ratingCompareRequest is the JSON body accepted by POST /api/v2/rating/compare
+type ratingCompareRequest struct {
+	GuildID int64           `json:"guild_id"`
+	TeamA   []predictPlayer `json:"team_a"`
+	TeamB   []predictPlayer `json:"team_b"`
+	Outcome string          `json:"outcome"` // team_a_wins, team_b_wins or draw
+}
+
+func NewV2RatingHandler(engines *services.RatingEngineSelector, ratingResolver *services.PlayerRatingResolver, trueSkillService *services.UserTrueSkillService) *V2RatingHandler {
+	return &V2RatingHandler{
+		engines:          engines,
+		ratingResolver:   ratingResolver,
+		trueSkillService: trueSkillService,
+	}
+}
+
+// HandleEngine handles GET and PUT /api/v2/rating/engine
+// A new engine rates the guild's future matches; stored ratings are kept as they are.
+func (h *V2RatingHandler) HandleEngine(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		guildID, err := requestGuildID(r, 0)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+			return
+		}
+
+		engine, err := h.engines.GetGuildEngine(guildID)
+		if err != nil {
+			h.writeEngineError(w, msgFailedToGetRatingEngine, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"guild_id":  guildID,
+			"engine":    engine,
+			"available": h.engines.Names(),
+		})
+	case http.MethodPut:
+		var request ratingEngineRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+			return
+		}
+
+		guildID, err := requestGuildID(r, request.GuildID)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+			return
+		}
+
+		if err := h.engines.SetGuildEngine(guildID, request.Engine); err != nil {
+			h.writeEngineError(w, msgFailedToUpdateRatingEngine, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"message":   msgRatingEngineUpdatedSuccessfully,
+			"guild_id":  guildID,
+			"engine":    request.Engine,
+			"available": h.engines.Names(),
+		})
+	default:
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+	}
+}
+
+// HandleCompare handles POST /api/v2/rating/compare
+// Every engine rates the same match so their expectations and updates can be compared side by side
+func (h *V2RatingHandler) HandleCompare(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	var request ratingCompareRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return
+	}
+
+	outcome, err := services.ParseMatchOutcome(request.Outcome)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"outcome": err.Error()})
+		return
+	}
+
+	teamA, err := resolvePredictTeam(r, h.ratingResolver, request.GuildID, request.TeamA)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"team_a": err.Error()})
+		return
+	}
+	teamB, err := resolvePredictTeam(r, h.ratingResolver, request.GuildID, request.TeamB)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"team_b": err.Error()})
+		return
+	}
+
+	explanations := make([]*services.RatingExplanation, 0, len(h.engines.Names()))
+	for _, name := range h.engines.Names() {
+		engine, err := h.engines.Engine(name)
+		if err != nil {
+			continue
+		}
+		explanations = append(explanations, engine.Explain(teamA, teamB, outcome))
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"outcome": outcome.String(),
+		"engines": explanations,
+	})
+}
+
+// HandleExplain handles GET /api/v2/users/{discord_id}/rating/explain
+// The seed is recalculated from the user's current trackers and not saved; the stored
+// rating is returned alongside it because matches move it away from the seed.
+func (h *V2RatingHandler) HandleExplain(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2/users/"), "/"), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] != "rating" || parts[2] != "explain" {
+		h.writeErrorResponse(w, http.StatusNotFound, msgRouteNotFound, map[string]string{"path": r.URL.Path})
+		return
+	}
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	discordID := parts[0]
+	explanation, err := h.trueSkillService.ExplainUserRating(discordID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrNoSeedTrackers) {
+			status = http.StatusNotFound
+		}
+		h.writeErrorResponse(w, status, msgFailedToExplainRating, map[string]string{"discord_id": discordID, "error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, explanation)
+}
+
+// writeEngineError maps unknown engines to 400 and everything else to 500
+func (h *V2RatingHandler) writeEngineError(w http.ResponseWriter, message string, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, services.ErrInvalidRatingEngine) {
+		status = http.StatusBadRequest
+	}
+	h.writeErrorResponse(w, status, message, map[string]string{"error": err.Error()})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2RatingHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2RatingHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/models"
+	"usl-server/internal/services"
+)
+
+// defaultRecalculationListLimit is how many recent jobs the list endpoint returns
+const defaultRecalculationListLimit = 20
+
+// V2RecalculationsHandler handles API requests for rating recalculation jobs
+type V2RecalculationsHandler struct {
+	recalculationService *services.RecalculationService
+}
+
+// recalculationRequest is the JSON body accepted by the recalculation endpoints
+// Creating a job reads guild_id; apply, revert and discard read job_id.
+type recalculationRequest struct {
+	GuildID int64 `json:"guild_id"`
+	JobID   int64 `json:"job_id"`
+}
+
+func NewV2RecalculationsHandler(recalculationService *services.RecalculationService) *V2RecalculationsHandler {
+	return &V2RecalculationsHandler{
+		recalculationService: recalculationService,
+	}
+}
+
+// HandleRecalculations handles GET and POST /api/v2/recalculations
+// GET lists the guild's recent jobs, or returns one job with its changeset with ?job_id=.
+// POST recalculates every rating in the guild and stores the result as a pending job without applying it.
+func (h *V2RecalculationsHandler) HandleRecalculations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if jobIDParam := r.URL.Query().Get("job_id"); jobIDParam != "" {
+			jobID, err := strconv.ParseInt(jobIDParam, 10, 64)
+			if err != nil || jobID <= 0 {
+				h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"job_id": "must be a positive integer"})
+				return
+			}
+
+			job, err := h.recalculationService.GetJob(jobID)
+			if err != nil {
+				h.writeRecalculationError(w, msgFailedToGetRecalculations, err)
+				return
+			}
+
+			h.writeJSONResponse(w, http.StatusOK, job)
+			return
+		}
+
+		guildID, err := requestGuildID(r, 0)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+			return
+		}
+
+		jobs, err := h.recalculationService.GetJobs(guildID, defaultRecalculationListLimit)
+		if err != nil {
+			h.writeRecalculationError(w, msgFailedToGetRecalculations, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"data":     jobs,
+			"count":    len(jobs),
+			"guild_id": guildID,
+		})
+	case http.MethodPost:
+		request, ok := h.decodeRequest(w, r)
+		if !ok {
+			return
+		}
+
+		guildID, err := requestGuildID(r, request.GuildID)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+			return
+		}
+
+		job, err := h.recalculationService.CreateJob(guildID, nil)
+		if err != nil {
+			h.writeRecalculationError(w, msgFailedToCreateRecalculation, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
+			"message": msgRecalculationCreatedSuccessfully,
+			"job":     job,
+		})
+	default:
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+	}
+}
+
+// HandleApply handles POST /api/v2/recalculations/apply
+func (h *V2RecalculationsHandler) HandleApply(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, msgFailedToApplyRecalculation, msgRecalculationAppliedSuccessfully, func(jobID int64) (*models.RecalculationJob, error) {
+		return h.recalculationService.ApplyJob(jobID, nil)
+	})
+}
+
+// HandleRevert handles POST /api/v2/recalculations/revert
+func (h *V2RecalculationsHandler) HandleRevert(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, msgFailedToRevertRecalculation, msgRecalculationRevertedSuccessfully, func(jobID int64) (*models.RecalculationJob, error) {
+		return h.recalculationService.RevertJob(jobID, nil)
+	})
+}
+
+// HandleDiscard handles POST /api/v2/recalculations/discard
+func (h *V2RecalculationsHandler) HandleDiscard(w http.ResponseWriter, r *http.Request) {
+	h.handleAction(w, r, msgFailedToDiscardRecalculation, msgRecalculationDiscardedSuccessfully, h.recalculationService.DiscardJob)
+}
+
+// handleAction reads the job_id from the body and runs one state change on the job
+func (h *V2RecalculationsHandler) handleAction(w http.ResponseWriter, r *http.Request, failure, success string, action func(jobID int64) (*models.RecalculationJob, error)) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	request, ok := h.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if request.JobID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"job_id": "must be a positive integer"})
+		return
+	}
+
+	job, err := action(request.JobID)
+	if err != nil {
+		h.writeRecalculationError(w, failure, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"message": success,
+		"job":     job,
+	})
+}
+
+// decodeRequest reads the optional JSON body; an empty body is an empty request
+func (h *V2RecalculationsHandler) decodeRequest(w http.ResponseWriter, r *http.Request) (recalculationRequest, bool) {
+	var request recalculationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return request, false
+	}
+	return request, true
+}
+
+// writeRecalculationError maps unknown jobs to 404, jobs in the wrong state to 400 and everything else to 500
+func (h *V2RecalculationsHandler) writeRecalculationError(w http.ResponseWriter, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrRecalculationNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidRecalculation):
+		status = http.StatusBadRequest
+	}
+	h.writeErrorResponse(w, status, message, map[string]string{"error": err.Error()})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2RecalculationsHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2RecalculationsHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"usl-server/internal/services"
+)
+
+// V2ReplaysHandler handles API requests for importing matches from replay exports
+type V2ReplaysHandler struct {
+	replayService *services.ReplayImportService
+}
+
+func NewV2ReplaysHandler(replayService *services.ReplayImportService) *V2ReplaysHandler {
+	return &V2ReplaysHandler{
+		replayService: replayService,
+	}
+}
+
+// HandleReplays handles POST /api/v2/matches/replays?guild_id=
+// Accepts ballchasing replay JSON as multipart "replays" files, or a JSON body
+// {"directory": "week1"} naming a directory under the configured import directory
+func (h *V2ReplaysHandler) HandleReplays(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	var summary *services.ReplayImportSummary
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var request struct {
+			Directory string `json:"directory"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+			return
+		}
+		summary, err = h.replayService.ImportDirectory(guildID, request.Directory, nil)
+	} else {
+		files, readErr := readReplayUploads(w, r)
+		if readErr != nil || len(files) == 0 {
+			details := map[string]string{"replays": "upload at least one replay file"}
+			if readErr != nil {
+				details["error"] = readErr.Error()
+			}
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, details)
+			return
+		}
+		summary, err = h.replayService.ImportReplays(guildID, files, nil)
+	}
+
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidReplayImport) {
+			status = http.StatusBadRequest
+		}
+		h.writeErrorResponse(w, status, msgFailedToImportReplays, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"data":     summary,
+		"guild_id": guildID,
+	})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2ReplaysHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2ReplaysHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/models"
+	"usl-server/internal/services"
+)
+
+// V2ScheduleHandler handles API requests for league schedules
+type V2ScheduleHandler struct {
+	scheduleService *services.ScheduleService
+}
+
+func NewV2ScheduleHandler(scheduleService *services.ScheduleService) *V2ScheduleHandler {
+	return &V2ScheduleHandler{
+		scheduleService: scheduleService,
+	}
+}
+
+// HandleSchedule handles GET and POST /api/v2/schedule
+// GET ?division_id= returns the division's fixtures, or ?team_id= one team's fixtures;
+// POST generates a round-robin from a models.ScheduleGenerateRequest body
+func (h *V2ScheduleHandler) HandleSchedule(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getSchedule(w, r)
+	case http.MethodPost:
+		h.generateSchedule(w, r)
+	default:
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+	}
+}
+
+func (h *V2ScheduleHandler) getSchedule(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if teamIDParam := query.Get("team_id"); teamIDParam != "" {
+		teamID, err := strconv.ParseInt(teamIDParam, 10, 64)
+		if err != nil || teamID <= 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"team_id": "must be a positive integer"})
+			return
+		}
+
+		team, schedule, err := h.scheduleService.GetTeamSchedule(teamID)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusNotFound, msgFailedToGetSchedule, map[string]string{"error": err.Error()})
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"team":         team,
+			"division":     schedule.Division,
+			"fixtures":     schedule.Fixtures,
+			"calendar_url": fmt.Sprintf("/calendar/teams/%d.ics", team.ID),
+		})
+		return
+	}
+
+	divisionID, err := strconv.ParseInt(query.Get("division_id"), 10, 64)
+	if err != nil || divisionID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"division_id": "division_id or team_id is required"})
+		return
+	}
+
+	schedule, err := h.scheduleService.GetSchedule(divisionID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusNotFound, msgFailedToGetSchedule, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, schedule)
+}
+
+func (h *V2ScheduleHandler) generateSchedule(w http.ResponseWriter, r *http.Request) {
+	var request models.ScheduleGenerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return
+	}
+
+	schedule, err := h.scheduleService.GenerateSchedule(request)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrScheduleExists), errors.Is(err, services.ErrScheduleStarted):
+			h.writeErrorResponse(w, http.StatusConflict, msgFailedToGenerateSchedule, map[string]string{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidSchedule):
+			h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"error": err.Error()})
+		default:
+			h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToGenerateSchedule, map[string]string{"error": err.Error()})
+		}
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
+		"message":  msgScheduleGeneratedSuccessfully,
+		"schedule": schedule,
+	})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2ScheduleHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2ScheduleHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
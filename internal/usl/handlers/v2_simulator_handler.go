@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"usl-server/internal/services"
+)
+
+// V2SimulatorHandler handles API requests for what-if rating simulations
+type V2SimulatorHandler struct {
+	simulator *services.RatingSimulator
+}
+
+// simulationRequest is the JSON body accepted by POST /api/v2/rating/simulate
+type simulationRequest struct {
+	GuildID   int64                        `json:"guild_id"`
+	Overrides services.SimulationOverrides `json:"overrides"`
+	Limit     int                          `json:"limit"`
+}
+
+func NewV2SimulatorHandler(simulator *services.RatingSimulator) *V2SimulatorHandler {
+	return &V2SimulatorHandler{
+		simulator: simulator,
+	}
+}
+
+// HandleSimulate handles GET and POST /api/v2/rating/simulate
+// GET returns the running settings and rank seasons to start from; POST runs a simulation
+func (h *V2SimulatorHandler) HandleSimulate(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"current":      h.simulator.CurrentSettings(),
+			"rank_seasons": h.simulator.RankSeasons(),
+		})
+	case http.MethodPost:
+		var request simulationRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+			return
+		}
+
+		guildID, err := requestGuildID(r, request.GuildID)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+			return
+		}
+
+		result, err := h.simulator.Run(guildID, request.Overrides, request.Limit)
+		if err != nil {
+			h.writeSimulationError(w, msgFailedToRunSimulation, err)
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, result)
+	default:
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+	}
+}
+
+// writeSimulationError maps invalid overrides and placement rules to 400 and everything else to 500
+func (h *V2SimulatorHandler) writeSimulationError(w http.ResponseWriter, message string, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, services.ErrInvalidSimulation) || errors.Is(err, services.ErrInvalidPlacement) {
+		status = http.StatusBadRequest
+	}
+	h.writeErrorResponse(w, status, message, map[string]string{"error": err.Error()})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2SimulatorHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2SimulatorHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/models"
+	"usl-server/internal/services"
+)
+
+// V2StandingsHandler handles API requests for division standings and league results
+type V2StandingsHandler struct {
+	standingsService *services.StandingsService
+}
+
+func NewV2StandingsHandler(standingsService *services.StandingsService) *V2StandingsHandler {
+	return &V2StandingsHandler{
+		standingsService: standingsService,
+	}
+}
+
+// HandleStandings handles GET /api/v2/standings?division_id=
+// Returns the division's ranked table with the tiebreak order used, its results and open fixtures
+func (h *V2StandingsHandler) HandleStandings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	divisionID, ok := h.divisionID(w, r)
+	if !ok {
+		return
+	}
+
+	standings, err := h.standingsService.GetStandings(divisionID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusNotFound, msgFailedToGetStandings, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, standings)
+}
+
+// HandleResults handles GET and POST /api/v2/standings/results
+// GET ?division_id= lists the division's results; POST records a models.LeagueResultCreateRequest body
+func (h *V2StandingsHandler) HandleResults(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getResults(w, r)
+	case http.MethodPost:
+		h.recordResult(w, r)
+	default:
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+	}
+}
+
+func (h *V2StandingsHandler) getResults(w http.ResponseWriter, r *http.Request) {
+	divisionID, ok := h.divisionID(w, r)
+	if !ok {
+		return
+	}
+
+	results, err := h.standingsService.GetResults(divisionID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToGetStandings, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"data":        results,
+		"count":       len(results),
+		"division_id": divisionID,
+	})
+}
+
+func (h *V2StandingsHandler) recordResult(w http.ResponseWriter, r *http.Request) {
+	var request models.LeagueResultCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return
+	}
+
+	result, err := h.standingsService.RecordResult(request)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidResult) {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"error": err.Error()})
+			return
+		}
+		h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToRecordResult, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, map[string]interface{}{
+		"message": msgResultRecordedSuccessfully,
+		"result":  result,
+	})
+}
+
+// divisionID reads the required division_id query parameter, writing the error response when it is invalid
+func (h *V2StandingsHandler) divisionID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	divisionID, err := strconv.ParseInt(r.URL.Query().Get("division_id"), 10, 64)
+	if err != nil || divisionID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"division_id": "must be a positive integer"})
+		return 0, false
+	}
+	return divisionID, true
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2StandingsHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2StandingsHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
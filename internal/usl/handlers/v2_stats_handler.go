@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"usl-server/internal/models"
+	"usl-server/internal/services"
+)
+
+// V2StatsHandler handles API requests for player stat lines, season stats and stat leaderboards
+type V2StatsHandler struct {
+	statsService *services.StatsService
+}
+
+// StatLineRequest enters or corrects one player's stat line in a recorded match
+type StatLineRequest struct {
+	MatchID   int64                   `json:"match_id"`
+	DiscordID string                  `json:"discord_id"`
+	Stats     models.MatchPlayerStats `json:"stats"`
+}
+
+func NewV2StatsHandler(statsService *services.StatsService) *V2StatsHandler {
+	return &V2StatsHandler{
+		statsService: statsService,
+	}
+}
+
+// HandleLeaders handles GET /api/v2/stats/leaders?stat=&season_id=&limit=&min_games=&guild_id=
+// Without season_id the active season is ranked, or all time when the guild has none
+func (h *V2StatsHandler) HandleLeaders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	seasonID, ok := h.seasonID(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	stat := query.Get("stat")
+	if stat == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"stat": "one of " + strings.Join(services.StatKeys(), ", ")})
+		return
+	}
+
+	limit, ok := h.nonNegativeInt(w, r, "limit")
+	if !ok {
+		return
+	}
+	minGames, ok := h.nonNegativeInt(w, r, "min_games")
+	if !ok {
+		return
+	}
+
+	board, err := h.statsService.GetLeaders(guildID, stat, seasonID, limit, minGames)
+	if err != nil {
+		h.writeStatsError(w, msgFailedToGetStats, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, board)
+}
+
+// HandlePlayer handles GET /api/v2/stats/player?discord_id=&season_id=&guild_id=
+func (h *V2StatsHandler) HandlePlayer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	discordID := strings.TrimSpace(r.URL.Query().Get("discord_id"))
+	if discordID == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"discord_id": "required"})
+		return
+	}
+
+	seasonID, ok := h.seasonID(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.statsService.GetPlayerStats(guildID, discordID, seasonID)
+	if err != nil {
+		h.writeStatsError(w, msgFailedToGetStats, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, report)
+}
+
+// HandleStatLines handles POST /api/v2/stats/lines
+// Admins use it to enter or correct a stat line on a match recorded without one
+func (h *V2StatsHandler) HandleStatLines(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	var request StatLineRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return
+	}
+	if request.MatchID <= 0 || strings.TrimSpace(request.DiscordID) == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"error": "match_id and discord_id are required"})
+		return
+	}
+
+	if err := h.statsService.SetStatLine(request.MatchID, strings.TrimSpace(request.DiscordID), request.Stats); err != nil {
+		h.writeStatsError(w, msgFailedToSaveStatLine, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"message": msgStatLineSavedSuccessfully,
+		"line":    request,
+	})
+}
+
+// seasonID reads the optional season_id query parameter, writing the error response when it is invalid
+func (h *V2StatsHandler) seasonID(w http.ResponseWriter, r *http.Request) (*int64, bool) {
+	seasonIDParam := r.URL.Query().Get("season_id")
+	if seasonIDParam == "" {
+		return nil, true
+	}
+
+	seasonID, err := strconv.ParseInt(seasonIDParam, 10, 64)
+	if err != nil || seasonID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"season_id": seasonIDParam})
+		return nil, false
+	}
+	return &seasonID, true
+}
+
+// nonNegativeInt reads an optional integer query parameter, returning 0 when it is absent
+func (h *V2StatsHandler) nonNegativeInt(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return 0, true
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil || value < 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{name: param})
+		return 0, false
+	}
+	return value, true
+}
+
+// writeStatsError maps bad stat queries to 400 and everything else to 500
+func (h *V2StatsHandler) writeStatsError(w http.ResponseWriter, message string, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, services.ErrInvalidStatsQuery) {
+		status = http.StatusBadRequest
+	}
+	h.writeErrorResponse(w, status, message, map[string]string{"error": err.Error()})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2StatsHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2StatsHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"usl-server/internal/models"
+	"usl-server/internal/services"
+)
+
+// V2TeamsHandler handles API requests for teams and their rosters
+type V2TeamsHandler struct {
+	rosterService *services.RosterService
+}
+
+func NewV2TeamsHandler(rosterService *services.RosterService) *V2TeamsHandler {
+	return &V2TeamsHandler{
+		rosterService: rosterService,
+	}
+}
+
+// HandleTeams handles GET /api/v2/teams
+// ?team_id= returns the team's roster with salaries and history, otherwise the guild's teams
+func (h *V2TeamsHandler) HandleTeams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	if teamIDParam := r.URL.Query().Get("team_id"); teamIDParam != "" {
+		teamID, err := strconv.ParseInt(teamIDParam, 10, 64)
+		if err != nil || teamID <= 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"team_id": "must be a positive integer"})
+			return
+		}
+
+		roster, err := h.rosterService.GetRoster(teamID)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusNotFound, msgFailedToGetTeams, map[string]string{"error": err.Error()})
+			return
+		}
+
+		h.writeJSONResponse(w, http.StatusOK, roster)
+		return
+	}
+
+	guildID, err := requestGuildID(r, 0)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgGuildRequired, map[string]string{"error": err.Error()})
+		return
+	}
+
+	teams, err := h.rosterService.GetTeams(guildID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToGetTeams, map[string]string{"error": err.Error()})
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"data":     teams,
+		"count":    len(teams),
+		"guild_id": guildID,
+	})
+}
+
+// HandleRoster handles POST /api/v2/teams/roster
+// Signs, releases or names the captain from a models.RosterChangeRequest body
+func (h *V2TeamsHandler) HandleRoster(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, msgMethodNotAllowed, nil)
+		return
+	}
+
+	var request models.RosterChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgInvalidRequestBody, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, msgValidationFailed, map[string]string{"error": err.Error()})
+		return
+	}
+
+	var roster *services.TeamRoster
+	var err error
+	switch request.Action {
+	case models.RosterActionSigned:
+		roster, err = h.rosterService.SignPlayer(request.TeamID, request.DiscordID, request.ChangedByUserID)
+	case models.RosterActionReleased:
+		roster, err = h.rosterService.ReleasePlayer(request.TeamID, request.DiscordID, request.ChangedByUserID)
+	case models.RosterActionCaptain:
+		roster, err = h.rosterService.SetCaptain(request.TeamID, request.DiscordID, request.ChangedByUserID)
+	}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSalaryCapExceeded), errors.Is(err, services.ErrPlayerRostered):
+			h.writeErrorResponse(w, http.StatusConflict, msgRosterChangeRejected, map[string]string{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidRoster), errors.Is(err, services.ErrPlayerIneligible):
+			h.writeErrorResponse(w, http.StatusBadRequest, msgRosterChangeRejected, map[string]string{"error": err.Error()})
+		default:
+			h.writeErrorResponse(w, http.StatusInternalServerError, msgFailedToChangeRoster, map[string]string{"error": err.Error()})
+		}
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"message": msgRosterChangedSuccessfully,
+		"roster":  roster,
+	})
+}
+
+// writeJSONResponse writes a JSON response with proper headers
+func (h *V2TeamsHandler) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		// If encoding fails, log error but don't try to write another response
+		fmt.Printf("[ERROR] Failed to encode JSON response: %v\n", err)
+	}
+}
+
+// writeErrorResponse writes a standardized error response
+func (h *V2TeamsHandler) writeErrorResponse(w http.ResponseWriter, status int, message string, details interface{}) {
+	errorResponse := map[string]interface{}{
+		"error":     message,
+		"status":    status,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if details != nil {
+		errorResponse["details"] = details
+	}
+
+	h.writeJSONResponse(w, status, errorResponse)
+}
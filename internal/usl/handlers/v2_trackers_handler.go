@@ -7,16 +7,19 @@ import (
 	"time"
 	"usl-server/internal/models"
 	"usl-server/internal/repositories"
+	"usl-server/internal/services"
 )
 
 // V2TrackersHandler handles modern API requests for trackers with pagination, filtering, and bulk operations
 type V2TrackersHandler struct {
 	trackerRepo *repositories.TrackerRepository
+	rankService *services.RankService
 }
 
-func NewV2TrackersHandler(trackerRepo *repositories.TrackerRepository) *V2TrackersHandler {
+func NewV2TrackersHandler(trackerRepo *repositories.TrackerRepository, rankService *services.RankService) *V2TrackersHandler {
 	return &V2TrackersHandler{
 		trackerRepo: trackerRepo,
+		rankService: rankService,
 	}
 }
 
@@ -71,6 +74,9 @@ func (h *V2TrackersHandler) handleGetTrackers(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Name the rank behind each playlist's peak
+	h.rankService.AnnotateTrackers(trackers)
+
 	// Build and send response
 	response := h.buildPaginatedTrackersResponse(trackers, paginationMetadata, paginationParams, trackerFilters, requestStartTime)
 	h.writeJSONResponse(w, http.StatusOK, response)
@@ -3,6 +3,7 @@ package usl
 import (
 	"time"
 	"usl-server/internal/config"
+	"usl-server/internal/models"
 )
 
 // USLUser represents a user in the USL-specific migration table
@@ -19,6 +20,10 @@ type USLUser struct {
 	TrueSkillLastUpdated *string   `json:"trueskill_last_updated" db:"trueskill_last_updated"`
 	CreatedAt            time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`
+
+	// Populated by handlers for display purposes
+	Tier  *models.TierPlacement `json:"tier,omitempty" db:"-"`
+	Ranks *models.PlaylistRanks `json:"ranks,omitempty" db:"-"`
 }
 
 func (u *USLUser) GetTrueSkillLastUpdatedFormatted() string {
@@ -63,7 +68,8 @@ type USLUserTracker struct {
 	UpdatedAt                       time.Time `json:"updated_at" db:"updated_at"`
 
 	// Populated by handlers for display purposes
-	User *USLUser `json:"user,omitempty" db:"-"`
+	User  *USLUser              `json:"user,omitempty" db:"-"`
+	Ranks *models.PlaylistRanks `json:"ranks,omitempty" db:"-"`
 }
 
 type USLUserCSV struct {
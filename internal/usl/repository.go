@@ -359,6 +359,18 @@ func (r *USLRepository) UpdateUserTrueSkill(discordID string, mu, sigma float64)
 	return nil
 }
 
+// GetSeedRating returns the tracker-seeded TrueSkill values for a USL user
+// Used as a fallback rating for players who have not played rated matches yet
+func (r *USLRepository) GetSeedRating(discordID string) (float64, float64, bool) {
+	user, err := r.GetUserByDiscordID(discordID)
+	if err != nil {
+		r.logger.Debug("No USL seed rating found", "discord_id", discordID, "error", err)
+		return 0, 0, false
+	}
+
+	return user.TrueSkillMu, user.TrueSkillSigma, true
+}
+
 func (r *USLRepository) GetTrackerByID(id int64) (*USLUserTracker, error) {
 	var tracker USLUserTracker
 	_, err := r.client.
@@ -0,0 +1,69 @@
+-- Matches Migration
+-- Stores completed matches and the players on each team so TrueSkill updates
+-- in player_historical_mmr can be traced back to the match that caused them
+
+-- Completed matches per guild
+CREATE TABLE matches (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    team_a_score INTEGER NOT NULL DEFAULT 0 CHECK (team_a_score >= 0),
+    team_b_score INTEGER NOT NULL DEFAULT 0 CHECK (team_b_score >= 0),
+    played_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    reported_by_user_id BIGINT REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Players on each side of a match (team 0 = team A, team 1 = team B)
+CREATE TABLE match_players (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    match_id BIGINT NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    team SMALLINT NOT NULL CHECK (team IN (0, 1)),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(match_id, user_id)
+);
+
+-- Link MMR history rows to the match that produced them
+ALTER TABLE player_historical_mmr
+    ADD CONSTRAINT player_historical_mmr_match_id_fkey
+    FOREIGN KEY (match_id) REFERENCES matches(id) ON DELETE SET NULL;
+
+-- Indexes for performance
+CREATE INDEX idx_matches_guild_played_at ON matches(guild_id, played_at DESC);
+CREATE INDEX idx_match_players_match_id ON match_players(match_id);
+CREATE INDEX idx_match_players_user_id ON match_players(user_id);
+CREATE INDEX idx_player_historical_mmr_match_id ON player_historical_mmr(match_id) WHERE match_id IS NOT NULL;
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE matches ENABLE ROW LEVEL SECURITY;
+ALTER TABLE match_players ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view matches in their guilds
+CREATE POLICY "Guild members can view matches" ON matches
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = matches.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Guild members can view match rosters in their guilds
+CREATE POLICY "Guild members can view match players" ON match_players
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM matches m
+            JOIN user_guild_memberships ugm ON ugm.guild_id = m.guild_id
+            JOIN users u ON u.id = ugm.user_id
+            WHERE m.id = match_players.match_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Apply update triggers
+CREATE TRIGGER update_matches_updated_at BEFORE UPDATE ON matches
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,75 @@
+-- Seasons Migration
+-- Tracks league seasons per guild and keeps a frozen copy of the final
+-- leaderboard when a season is closed
+
+-- League seasons per guild
+CREATE TABLE seasons (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    number INTEGER NOT NULL CHECK (number > 0),
+    name TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'closed')),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ended_at TIMESTAMPTZ,
+    soft_reset_mu_factor NUMERIC(4,3),
+    soft_reset_sigma_factor NUMERIC(4,3),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(guild_id, number),
+    CHECK (status = 'active' OR ended_at IS NOT NULL)
+);
+
+-- Final standings captured when a season closes
+CREATE TABLE season_leaderboard_snapshots (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    season_id BIGINT NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rank INTEGER NOT NULL CHECK (rank > 0),
+    mmr INTEGER NOT NULL DEFAULT 0,
+    trueskill_mu NUMERIC(8,3) NOT NULL,
+    trueskill_sigma NUMERIC(6,3) NOT NULL,
+    games_played INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(season_id, user_id)
+);
+
+-- Only one season per guild may be running at a time
+CREATE UNIQUE INDEX idx_seasons_one_active_per_guild ON seasons(guild_id) WHERE status = 'active';
+
+-- Indexes for performance
+CREATE INDEX idx_seasons_guild_number ON seasons(guild_id, number DESC);
+CREATE INDEX idx_season_leaderboard_snapshots_season_rank ON season_leaderboard_snapshots(season_id, rank);
+CREATE INDEX idx_season_leaderboard_snapshots_user_id ON season_leaderboard_snapshots(user_id);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE seasons ENABLE ROW LEVEL SECURITY;
+ALTER TABLE season_leaderboard_snapshots ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view seasons in their guilds
+CREATE POLICY "Guild members can view seasons" ON seasons
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = seasons.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Guild members can view final standings in their guilds
+CREATE POLICY "Guild members can view season snapshots" ON season_leaderboard_snapshots
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM seasons s
+            JOIN user_guild_memberships ugm ON ugm.guild_id = s.guild_id
+            JOIN users u ON u.id = ugm.user_id
+            WHERE s.id = season_leaderboard_snapshots.season_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Apply update triggers
+CREATE TRIGGER update_seasons_updated_at BEFORE UPDATE ON seasons
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
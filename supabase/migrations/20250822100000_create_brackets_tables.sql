@@ -0,0 +1,108 @@
+-- Brackets Migration
+-- Tournament brackets (single elimination, double elimination and Swiss) with
+-- seeded entrants and per-match results
+
+-- Tournaments per guild
+CREATE TABLE brackets (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    season_id BIGINT REFERENCES seasons(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('single_elimination', 'double_elimination', 'swiss')),
+    seeding TEXT NOT NULL CHECK (seeding IN ('skill_estimate', 'team_average', 'manual')),
+    swiss_rounds INTEGER NOT NULL DEFAULT 0 CHECK (swiss_rounds >= 0),
+    record_ratings BOOLEAN NOT NULL DEFAULT false,
+    status TEXT NOT NULL DEFAULT 'in_progress' CHECK (status IN ('in_progress', 'completed')),
+    created_by_user_id BIGINT REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Seeded players or teams in a bracket
+CREATE TABLE bracket_entrants (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    bracket_id BIGINT NOT NULL REFERENCES brackets(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    seed INTEGER NOT NULL CHECK (seed > 0),
+    seed_rating NUMERIC(8,3) NOT NULL DEFAULT 0,
+    discord_ids TEXT[] NOT NULL DEFAULT '{}',
+    user_ids BIGINT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(bracket_id, seed)
+);
+
+-- Bracket matches addressed by side, round and position
+-- Elimination brackets create every match up front; Swiss adds a round at a time
+CREATE TABLE bracket_matches (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    bracket_id BIGINT NOT NULL REFERENCES brackets(id) ON DELETE CASCADE,
+    side TEXT NOT NULL CHECK (side IN ('winners', 'losers', 'grand_final', 'swiss')),
+    round INTEGER NOT NULL CHECK (round > 0),
+    position INTEGER NOT NULL CHECK (position >= 0),
+    entrant_a_id BIGINT REFERENCES bracket_entrants(id) ON DELETE SET NULL,
+    entrant_b_id BIGINT REFERENCES bracket_entrants(id) ON DELETE SET NULL,
+    score_a INTEGER CHECK (score_a >= 0),
+    score_b INTEGER CHECK (score_b >= 0),
+    winner_entrant_id BIGINT REFERENCES bracket_entrants(id) ON DELETE SET NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ready', 'completed', 'bye')),
+    match_id BIGINT REFERENCES matches(id) ON DELETE SET NULL,
+    reported_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(bracket_id, side, round, position)
+);
+
+-- Indexes for performance
+CREATE INDEX idx_brackets_guild_created_at ON brackets(guild_id, created_at DESC);
+CREATE INDEX idx_bracket_entrants_bracket_id ON bracket_entrants(bracket_id);
+CREATE INDEX idx_bracket_matches_bracket_id ON bracket_matches(bracket_id);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE brackets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE bracket_entrants ENABLE ROW LEVEL SECURITY;
+ALTER TABLE bracket_matches ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view brackets in their guilds
+CREATE POLICY "Guild members can view brackets" ON brackets
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = brackets.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Guild members can view bracket entrants in their guilds
+CREATE POLICY "Guild members can view bracket entrants" ON bracket_entrants
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM brackets b
+            JOIN user_guild_memberships ugm ON ugm.guild_id = b.guild_id
+            JOIN users u ON u.id = ugm.user_id
+            WHERE b.id = bracket_entrants.bracket_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Guild members can view bracket matches in their guilds
+CREATE POLICY "Guild members can view bracket matches" ON bracket_matches
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM brackets b
+            JOIN user_guild_memberships ugm ON ugm.guild_id = b.guild_id
+            JOIN users u ON u.id = ugm.user_id
+            WHERE b.id = bracket_matches.bracket_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Apply update triggers
+CREATE TRIGGER update_brackets_updated_at BEFORE UPDATE ON brackets
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_bracket_matches_updated_at BEFORE UPDATE ON bracket_matches
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
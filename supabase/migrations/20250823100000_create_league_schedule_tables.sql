@@ -0,0 +1,97 @@
+-- League Schedule Migration
+-- Divisions, the teams playing in them and round-robin fixtures on match nights
+
+-- League divisions per guild, optionally tied to a season
+CREATE TABLE divisions (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    season_id BIGINT REFERENCES seasons(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- League teams per guild
+CREATE TABLE teams (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    season_id BIGINT REFERENCES seasons(id) ON DELETE SET NULL,
+    division_id BIGINT REFERENCES divisions(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Fixtures generated for a division; a bye has no away team
+CREATE TABLE scheduled_matches (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    division_id BIGINT NOT NULL REFERENCES divisions(id) ON DELETE CASCADE,
+    round INTEGER NOT NULL CHECK (round > 0),
+    match_night TIMESTAMPTZ NOT NULL,
+    home_team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    away_team_id BIGINT REFERENCES teams(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'completed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (away_team_id IS NULL OR away_team_id <> home_team_id)
+);
+
+-- Indexes for performance
+CREATE INDEX idx_divisions_guild_id ON divisions(guild_id);
+CREATE INDEX idx_teams_guild_id ON teams(guild_id);
+CREATE INDEX idx_teams_division_id ON teams(division_id);
+CREATE INDEX idx_scheduled_matches_division_round ON scheduled_matches(division_id, round);
+CREATE INDEX idx_scheduled_matches_home_team_id ON scheduled_matches(home_team_id);
+CREATE INDEX idx_scheduled_matches_away_team_id ON scheduled_matches(away_team_id);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE divisions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE teams ENABLE ROW LEVEL SECURITY;
+ALTER TABLE scheduled_matches ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view divisions in their guilds
+CREATE POLICY "Guild members can view divisions" ON divisions
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = divisions.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Guild members can view teams in their guilds
+CREATE POLICY "Guild members can view teams" ON teams
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = teams.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Guild members can view the schedule in their guilds
+CREATE POLICY "Guild members can view scheduled matches" ON scheduled_matches
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = scheduled_matches.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Apply update triggers
+CREATE TRIGGER update_divisions_updated_at BEFORE UPDATE ON divisions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_teams_updated_at BEFORE UPDATE ON teams
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_scheduled_matches_updated_at BEFORE UPDATE ON scheduled_matches
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
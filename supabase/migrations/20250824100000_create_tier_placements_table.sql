@@ -0,0 +1,39 @@
+-- Tier Placements Migration
+-- Stores the tier each eligible player was placed in for a season, so the
+-- leaderboard and bot can show "Tier 2 – Premier" instead of a raw rating
+
+-- One row per player per season; re-running placement replaces the season's rows
+CREATE TABLE tier_placements (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    season_id BIGINT NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    discord_id TEXT NOT NULL,
+    player_name TEXT NOT NULL DEFAULT '',
+    tier_number INTEGER NOT NULL CHECK (tier_number > 0),
+    tier_name TEXT NOT NULL,
+    rank INTEGER NOT NULL CHECK (rank > 0),
+    trueskill_mu NUMERIC(8,3) NOT NULL,
+    trueskill_sigma NUMERIC(6,3) NOT NULL,
+    skill_estimate NUMERIC(8,3) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(season_id, discord_id)
+);
+
+-- Indexes for performance
+CREATE INDEX idx_tier_placements_season_rank ON tier_placements(season_id, rank);
+CREATE INDEX idx_tier_placements_guild_discord ON tier_placements(guild_id, discord_id, season_id DESC);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE tier_placements ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view tier placements in their guilds
+CREATE POLICY "Guild members can view tier placements" ON tier_placements
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = tier_placements.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
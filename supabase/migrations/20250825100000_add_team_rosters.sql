@@ -0,0 +1,69 @@
+-- Team Rosters Migration
+-- Extends league teams with a captain and a roster of Discord IDs, plus an
+-- append-only history of signings, releases and captaincy changes
+
+-- Captain must be on the roster; enforced by the roster service
+ALTER TABLE teams ADD COLUMN captain_discord_id TEXT;
+
+-- Current roster of each team
+CREATE TABLE team_members (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    season_id BIGINT REFERENCES seasons(id) ON DELETE SET NULL,
+    discord_id TEXT NOT NULL,
+    signed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(team_id, discord_id)
+);
+
+-- Signings, releases and captaincy changes with the salary at the time
+CREATE TABLE roster_transactions (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    season_id BIGINT REFERENCES seasons(id) ON DELETE SET NULL,
+    discord_id TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('signed', 'released', 'captain')),
+    salary NUMERIC(10,3) NOT NULL DEFAULT 0,
+    cap_basis TEXT NOT NULL DEFAULT 'mu' CHECK (cap_basis IN ('mu', 'mmr')),
+    team_salary_after NUMERIC(10,3) NOT NULL DEFAULT 0,
+    changed_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- A player may only be on one team per season in a guild
+CREATE UNIQUE INDEX idx_team_members_one_team_per_season
+    ON team_members(guild_id, COALESCE(season_id, 0), discord_id);
+
+-- Indexes for performance
+CREATE INDEX idx_team_members_team_id ON team_members(team_id);
+CREATE INDEX idx_roster_transactions_team_created ON roster_transactions(team_id, created_at DESC);
+CREATE INDEX idx_roster_transactions_discord_id ON roster_transactions(discord_id);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE team_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE roster_transactions ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view rosters in their guilds
+CREATE POLICY "Guild members can view team members" ON team_members
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = team_members.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Guild members can view roster history in their guilds
+CREATE POLICY "Guild members can view roster transactions" ON roster_transactions
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = roster_transactions.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
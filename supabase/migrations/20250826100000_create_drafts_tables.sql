@@ -0,0 +1,69 @@
+-- Drafts Migration
+-- Snake drafts that place free agents onto team rosters, one timed pick at a time
+
+CREATE TABLE drafts (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    season_id BIGINT REFERENCES seasons(id) ON DELETE SET NULL,
+    name TEXT NOT NULL CHECK (length(name) <= 100),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'completed')),
+    rounds INTEGER NOT NULL CHECK (rounds > 0),
+    pick_seconds INTEGER NOT NULL DEFAULT 0 CHECK (pick_seconds >= 0), -- 0 for no pick timer
+    team_order BIGINT[] NOT NULL, -- first-round order; even rounds run in reverse
+    current_pick INTEGER NOT NULL DEFAULT 1, -- overall number of the pick on the clock
+    pick_started_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE draft_picks (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    draft_id BIGINT NOT NULL REFERENCES drafts(id) ON DELETE CASCADE,
+    pick_number INTEGER NOT NULL,
+    round INTEGER NOT NULL,
+    team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    discord_id TEXT NOT NULL,
+    auto_pick BOOLEAN NOT NULL DEFAULT false, -- made for the team when its pick timer ran out
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(draft_id, pick_number),
+    UNIQUE(draft_id, discord_id)
+);
+
+-- Indexes for performance
+CREATE INDEX idx_drafts_guild_id ON drafts(guild_id);
+CREATE INDEX idx_draft_picks_draft_id ON draft_picks(draft_id, pick_number);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE drafts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE draft_picks ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view drafts in their guilds
+CREATE POLICY "Guild members can view drafts" ON drafts
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = drafts.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Guild members can view picks of drafts in their guilds
+CREATE POLICY "Guild members can view draft picks" ON draft_picks
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM drafts d
+            JOIN user_guild_memberships ugm ON ugm.guild_id = d.guild_id
+            JOIN users u ON u.id = ugm.user_id
+            WHERE d.id = draft_picks.draft_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Trigger for updated_at
+CREATE TRIGGER update_drafts_updated_at
+    BEFORE UPDATE ON drafts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,35 @@
+-- League Results Migration
+-- Series results between league teams, with the goals of every game, for division standings
+
+CREATE TABLE league_results (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    division_id BIGINT NOT NULL REFERENCES divisions(id) ON DELETE CASCADE,
+    scheduled_match_id BIGINT UNIQUE REFERENCES scheduled_matches(id) ON DELETE SET NULL,
+    home_team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    away_team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    home_goals INTEGER[] NOT NULL, -- goals per game, in game order
+    away_goals INTEGER[] NOT NULL,
+    played_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (home_team_id <> away_team_id),
+    CHECK (cardinality(home_goals) > 0 AND cardinality(home_goals) = cardinality(away_goals))
+);
+
+-- Indexes for performance
+CREATE INDEX idx_league_results_division_id ON league_results(division_id, played_at);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE league_results ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view results in their guilds
+CREATE POLICY "Guild members can view league results" ON league_results
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = league_results.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
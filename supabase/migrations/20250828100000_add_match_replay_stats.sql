@@ -0,0 +1,15 @@
+-- Match Replay Stats Migration
+-- Per-player stat lines for matches imported from ballchasing-format replay exports,
+-- and the replay each match came from so re-imports are skipped
+
+-- Ballchasing replay ID; hand-entered matches have none
+ALTER TABLE matches ADD COLUMN replay_id TEXT;
+CREATE UNIQUE INDEX idx_matches_guild_replay_id ON matches(guild_id, replay_id) WHERE replay_id IS NOT NULL;
+
+-- Stat lines are null for matches recorded without a replay
+ALTER TABLE match_players ADD COLUMN goals INTEGER;
+ALTER TABLE match_players ADD COLUMN assists INTEGER;
+ALTER TABLE match_players ADD COLUMN saves INTEGER;
+ALTER TABLE match_players ADD COLUMN shots INTEGER;
+ALTER TABLE match_players ADD COLUMN score INTEGER;
+ALTER TABLE match_players ADD COLUMN mvp BOOLEAN;
@@ -0,0 +1,21 @@
+-- Rank Distributions Migration
+-- Rank population and MMR ranges per Rocket League game season, uploaded by admins so
+-- a new game season does not need a code change. Seasons shipped with the server are
+-- built in; a row here for the same season replaces the built-in table.
+
+CREATE TABLE rank_distributions (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    game_season INTEGER NOT NULL UNIQUE CHECK (game_season > 0),
+    name TEXT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL, -- tracker data from this date on is read against this season
+    playlists JSONB NOT NULL, -- {"soloDuel": [{"rank", "percent", "min_mmr", "max_mmr"}, ...], ...}
+    created_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE rank_distributions ENABLE ROW LEVEL SECURITY;
+
+-- Distributions are game-wide public data
+CREATE POLICY "Anyone can view rank distributions" ON rank_distributions
+    FOR SELECT USING (true);
@@ -0,0 +1,7 @@
+-- Tracker Primary Flag Migration
+-- Players with alt accounts mark one tracker as their main account; the "primary"
+-- tracker aggregation seeds ratings from it and ignores the others
+
+ALTER TABLE user_trackers ADD COLUMN is_primary BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_user_trackers_discord_id_primary ON user_trackers(discord_id) WHERE is_primary = true;
@@ -0,0 +1,43 @@
+-- Recalculation Jobs Migration
+-- Rating recalculations from tracker data are stored as a pending changeset and only
+-- written to player_effective_mmr once an admin has reviewed the diff. History rows
+-- written by a job point back at it so an applied job can be reverted.
+
+CREATE TABLE recalculation_jobs (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'applied', 'reverted', 'discarded')),
+    changes JSONB NOT NULL DEFAULT '[]', -- [{"user_id", "discord_id", "name", "mu_before", "sigma_before", "mu_after", "sigma_after", "applied", "reverted"}, ...]
+    players INTEGER NOT NULL DEFAULT 0,
+    unchanged INTEGER NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',
+    created_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    applied_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    reverted_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    applied_at TIMESTAMPTZ,
+    reverted_at TIMESTAMPTZ
+);
+
+ALTER TABLE player_historical_mmr
+    ADD COLUMN recalculation_job_id BIGINT REFERENCES recalculation_jobs(id) ON DELETE SET NULL;
+
+-- Indexes for performance
+CREATE INDEX idx_recalculation_jobs_guild_id ON recalculation_jobs(guild_id, created_at DESC);
+CREATE INDEX idx_player_historical_mmr_recalculation_job_id ON player_historical_mmr(recalculation_job_id)
+    WHERE recalculation_job_id IS NOT NULL;
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE recalculation_jobs ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view recalculations in their guilds
+CREATE POLICY "Guild members can view recalculation jobs" ON recalculation_jobs
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = recalculation_jobs.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
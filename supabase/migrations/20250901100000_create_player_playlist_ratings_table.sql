@@ -0,0 +1,44 @@
+-- Playlist Ratings Migration
+-- Each player keeps a separate mu/sigma per game mode (1v1, 2v2, 3v3), seeded from the
+-- matching tracker playlist and moved only by matches of that mode. player_effective_mmr
+-- stays as the combined rating that every match updates.
+
+CREATE TABLE player_playlist_ratings (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    guild_id BIGINT NOT NULL REFERENCES guilds(id) ON DELETE CASCADE,
+    playlist TEXT NOT NULL CHECK (playlist IN ('ones', 'twos', 'threes')),
+    trueskill_mu DECIMAL(10,3) NOT NULL DEFAULT 1000.0,
+    trueskill_sigma DECIMAL(10,3) NOT NULL DEFAULT 8.333,
+    games_played INTEGER NOT NULL DEFAULT 0,
+    last_updated TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE(user_id, guild_id, playlist)
+);
+
+-- The game mode a match was played in; null for formats without a rating track (e.g. 4v4)
+ALTER TABLE matches
+    ADD COLUMN playlist TEXT CHECK (playlist IN ('ones', 'twos', 'threes'));
+
+-- Indexes for performance
+CREATE INDEX idx_player_playlist_ratings_guild_playlist_mu ON player_playlist_ratings(guild_id, playlist, trueskill_mu DESC);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE player_playlist_ratings ENABLE ROW LEVEL SECURITY;
+
+-- Guild members can view playlist ratings in their guilds
+CREATE POLICY "Guild members can view playlist ratings" ON player_playlist_ratings
+    FOR SELECT USING (
+        EXISTS (
+            SELECT 1 FROM user_guild_memberships ugm
+            JOIN users u ON u.id = ugm.user_id
+            WHERE ugm.guild_id = player_playlist_ratings.guild_id
+            AND u.discord_id = auth.uid()::text
+            AND ugm.active = true
+        )
+    );
+
+-- Apply update triggers
+CREATE TRIGGER update_player_playlist_ratings_updated_at BEFORE UPDATE ON player_playlist_ratings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,24 @@
+-- Tracker Season Rollovers Migration
+-- Records each Rocket League season that tracker data was rolled into. The game season
+-- flip is global, so a rollover covers every tracker in every guild and can only happen
+-- once per game season, no matter how many guilds close their league seasons.
+
+CREATE TABLE tracker_season_rollovers (
+    game_season INTEGER PRIMARY KEY CHECK (game_season > 0),
+    trackers_rolled INTEGER NOT NULL DEFAULT 0,
+    rolled_by_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    rolled_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- RLS Policies (Row Level Security)
+ALTER TABLE tracker_season_rollovers ENABLE ROW LEVEL SECURITY;
+
+-- Authenticated users can view rollovers, tracker data is shared across guilds
+CREATE POLICY "Tracker rollovers are viewable by authenticated users" ON tracker_season_rollovers
+    FOR SELECT USING (auth.role() = 'authenticated');
+
+-- Apply update triggers
+CREATE TRIGGER update_tracker_season_rollovers_updated_at BEFORE UPDATE ON tracker_season_rollovers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
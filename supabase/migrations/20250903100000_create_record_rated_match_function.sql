@@ -0,0 +1,78 @@
+-- Record Rated Match Migration
+-- A rated match touches matches, match_players, player_effective_mmr, player_historical_mmr
+-- and player_playlist_ratings. Writing them one request at a time could leave a match with
+-- only some players rated, so record_rated_match writes them all in one transaction: either
+-- the match exists with every rating and history row, or nothing was written.
+
+CREATE OR REPLACE FUNCTION record_rated_match(
+    p_match JSONB,                          -- matches columns
+    p_players JSONB,                        -- [{"user_id", "team", "goals", "assists", "saves", "shots", "score", "mvp"}, ...]
+    p_ratings JSONB,                        -- [{"user_id", "guild_id", "mmr", "trueskill_mu", "trueskill_sigma", "games_played", "last_updated"}, ...]
+    p_history JSONB,                        -- player_historical_mmr rows; match_id is filled in here
+    p_playlist_ratings JSONB DEFAULT '[]'   -- player_playlist_ratings rows, empty for formats without one
+)
+RETURNS JSONB AS $$
+DECLARE
+    recorded matches%ROWTYPE;
+BEGIN
+    INSERT INTO matches (guild_id, team_a_score, team_b_score, played_at, reported_by_user_id, replay_id, playlist)
+    VALUES (
+        (p_match->>'guild_id')::BIGINT,
+        (p_match->>'team_a_score')::INTEGER,
+        (p_match->>'team_b_score')::INTEGER,
+        COALESCE((p_match->>'played_at')::TIMESTAMPTZ, now()),
+        (p_match->>'reported_by_user_id')::BIGINT,
+        p_match->>'replay_id',
+        p_match->>'playlist'
+    )
+    RETURNING * INTO recorded;
+
+    INSERT INTO match_players (match_id, user_id, team, goals, assists, saves, shots, score, mvp)
+    SELECT recorded.id, p.user_id, p.team, p.goals, p.assists, p.saves, p.shots, p.score, p.mvp
+    FROM jsonb_to_recordset(p_players) AS p(
+        user_id BIGINT, team SMALLINT, goals INTEGER, assists INTEGER, saves INTEGER, shots INTEGER, score INTEGER, mvp BOOLEAN
+    );
+
+    INSERT INTO player_effective_mmr (user_id, guild_id, mmr, trueskill_mu, trueskill_sigma, games_played, last_updated)
+    SELECT r.user_id, r.guild_id, r.mmr, r.trueskill_mu, r.trueskill_sigma, r.games_played, r.last_updated
+    FROM jsonb_to_recordset(p_ratings) AS r(
+        user_id BIGINT, guild_id BIGINT, mmr INTEGER, trueskill_mu DECIMAL(10,3), trueskill_sigma DECIMAL(10,3),
+        games_played INTEGER, last_updated TIMESTAMPTZ
+    )
+    ON CONFLICT (user_id, guild_id) DO UPDATE SET
+        mmr = EXCLUDED.mmr,
+        trueskill_mu = EXCLUDED.trueskill_mu,
+        trueskill_sigma = EXCLUDED.trueskill_sigma,
+        games_played = EXCLUDED.games_played,
+        last_updated = EXCLUDED.last_updated;
+
+    INSERT INTO player_historical_mmr (
+        user_id, guild_id, mmr_before, mmr_after, trueskill_mu_before, trueskill_mu_after,
+        trueskill_sigma_before, trueskill_sigma_after, change_reason, match_id, changed_by_user_id
+    )
+    SELECT h.user_id, h.guild_id, h.mmr_before, h.mmr_after, h.trueskill_mu_before, h.trueskill_mu_after,
+        h.trueskill_sigma_before, h.trueskill_sigma_after, h.change_reason, recorded.id, h.changed_by_user_id
+    FROM jsonb_to_recordset(p_history) AS h(
+        user_id BIGINT, guild_id BIGINT, mmr_before INTEGER, mmr_after INTEGER, trueskill_mu_before DECIMAL(10,3),
+        trueskill_mu_after DECIMAL(10,3), trueskill_sigma_before DECIMAL(10,3), trueskill_sigma_after DECIMAL(10,3),
+        change_reason TEXT, changed_by_user_id BIGINT
+    );
+
+    INSERT INTO player_playlist_ratings (user_id, guild_id, playlist, trueskill_mu, trueskill_sigma, games_played, last_updated)
+    SELECT pr.user_id, pr.guild_id, pr.playlist, pr.trueskill_mu, pr.trueskill_sigma, pr.games_played, pr.last_updated
+    FROM jsonb_to_recordset(p_playlist_ratings) AS pr(
+        user_id BIGINT, guild_id BIGINT, playlist TEXT, trueskill_mu DECIMAL(10,3), trueskill_sigma DECIMAL(10,3),
+        games_played INTEGER, last_updated TIMESTAMPTZ
+    )
+    ON CONFLICT (user_id, guild_id, playlist) DO UPDATE SET
+        trueskill_mu = EXCLUDED.trueskill_mu,
+        trueskill_sigma = EXCLUDED.trueskill_sigma,
+        games_played = EXCLUDED.games_played,
+        last_updated = EXCLUDED.last_updated;
+
+    -- The match row with its roster under "players"
+    RETURN to_jsonb(recorded) || jsonb_build_object(
+        'players', (SELECT jsonb_agg(to_jsonb(mp)) FROM match_players mp WHERE mp.match_id = recorded.id)
+    );
+END;
+$$ language 'plpgsql';
@@ -0,0 +1,65 @@
+-- Roll Tracker Seasons Migration
+-- Claiming a game season and moving tracker data used to be separate requests, so a failure
+-- partway left some trackers rolled and the game season claimed, refusing every retry.
+-- roll_tracker_seasons claims the game season and rolls every user_trackers and
+-- usl_user_trackers row in one transaction: either all trackers move and the claim exists,
+-- or nothing was written and the game season can be rolled again.
+
+CREATE OR REPLACE FUNCTION roll_tracker_seasons(
+    p_game_season INTEGER,
+    p_rolled_by_user_id BIGINT DEFAULT NULL
+)
+RETURNS JSONB AS $$
+DECLARE
+    rollover tracker_season_rollovers%ROWTYPE;
+    rolled_count INTEGER;
+    usl_rolled_count INTEGER;
+BEGIN
+    IF EXISTS (SELECT 1 FROM tracker_season_rollovers WHERE game_season >= p_game_season) THEN
+        RAISE EXCEPTION 'trackers already rolled into game season % or later', p_game_season;
+    END IF;
+
+    -- game_season is the primary key, so a concurrent claim for the same season fails here
+    INSERT INTO tracker_season_rollovers (game_season, rolled_by_user_id)
+    VALUES (p_game_season, p_rolled_by_user_id);
+
+    -- last_updated is left alone, the tracker data itself was not refreshed
+    UPDATE user_trackers SET
+        ones_previous_season_peak = ones_current_season_peak,
+        ones_previous_season_games = ones_current_season_games,
+        twos_previous_season_peak = twos_current_season_peak,
+        twos_previous_season_games = twos_current_season_games,
+        threes_previous_season_peak = threes_current_season_peak,
+        threes_previous_season_games = threes_current_season_games,
+        ones_current_season_peak = 0,
+        ones_current_season_games = 0,
+        twos_current_season_peak = 0,
+        twos_current_season_games = 0,
+        threes_current_season_peak = 0,
+        threes_current_season_games = 0,
+        updated_at = now();
+    GET DIAGNOSTICS rolled_count = ROW_COUNT;
+
+    UPDATE usl_user_trackers SET
+        ones_previous_season_peak = ones_current_season_peak,
+        ones_previous_season_games_played = ones_current_season_games_played,
+        twos_previous_season_peak = twos_current_season_peak,
+        twos_previous_season_games_played = twos_current_season_games_played,
+        threes_previous_season_peak = threes_current_season_peak,
+        threes_previous_season_games_played = threes_current_season_games_played,
+        ones_current_season_peak = 0,
+        ones_current_season_games_played = 0,
+        twos_current_season_peak = 0,
+        twos_current_season_games_played = 0,
+        threes_current_season_peak = 0,
+        threes_current_season_games_played = 0;
+    GET DIAGNOSTICS usl_rolled_count = ROW_COUNT;
+
+    UPDATE tracker_season_rollovers
+    SET trackers_rolled = rolled_count + usl_rolled_count
+    WHERE game_season = p_game_season
+    RETURNING * INTO rollover;
+
+    RETURN to_jsonb(rollover);
+END;
+$$ language 'plpgsql';
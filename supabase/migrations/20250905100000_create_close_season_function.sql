@@ -0,0 +1,76 @@
+-- Close Season Migration
+-- Closing a season used to mark it closed and then reset each player's rating one request at
+-- a time, so a failed reset left that player on their old rating with no way to reapply it.
+-- close_season writes the final standings, closes the season and applies every soft reset in
+-- one transaction: either the season is closed with every rating reset, or nothing was written
+-- and the close can be retried.
+
+-- Writes rating changes with their history rows in one transaction
+CREATE OR REPLACE FUNCTION apply_rating_changes(
+    p_ratings JSONB,    -- [{"user_id", "guild_id", "mmr", "trueskill_mu", "trueskill_sigma", "games_played", "last_updated"}, ...]
+    p_history JSONB     -- player_historical_mmr rows
+)
+RETURNS VOID AS $$
+BEGIN
+    INSERT INTO player_effective_mmr (user_id, guild_id, mmr, trueskill_mu, trueskill_sigma, games_played, last_updated)
+    SELECT r.user_id, r.guild_id, r.mmr, r.trueskill_mu, r.trueskill_sigma, r.games_played, r.last_updated
+    FROM jsonb_to_recordset(p_ratings) AS r(
+        user_id BIGINT, guild_id BIGINT, mmr INTEGER, trueskill_mu DECIMAL(10,3), trueskill_sigma DECIMAL(10,3),
+        games_played INTEGER, last_updated TIMESTAMPTZ
+    )
+    ON CONFLICT (user_id, guild_id) DO UPDATE SET
+        mmr = EXCLUDED.mmr,
+        trueskill_mu = EXCLUDED.trueskill_mu,
+        trueskill_sigma = EXCLUDED.trueskill_sigma,
+        games_played = EXCLUDED.games_played,
+        last_updated = EXCLUDED.last_updated;
+
+    INSERT INTO player_historical_mmr (
+        user_id, guild_id, mmr_before, mmr_after, trueskill_mu_before, trueskill_mu_after,
+        trueskill_sigma_before, trueskill_sigma_after, change_reason, match_id, changed_by_user_id, recalculation_job_id
+    )
+    SELECT h.user_id, h.guild_id, h.mmr_before, h.mmr_after, h.trueskill_mu_before, h.trueskill_mu_after,
+        h.trueskill_sigma_before, h.trueskill_sigma_after, h.change_reason, h.match_id, h.changed_by_user_id, h.recalculation_job_id
+    FROM jsonb_to_recordset(p_history) AS h(
+        user_id BIGINT, guild_id BIGINT, mmr_before INTEGER, mmr_after INTEGER, trueskill_mu_before DECIMAL(10,3),
+        trueskill_mu_after DECIMAL(10,3), trueskill_sigma_before DECIMAL(10,3), trueskill_sigma_after DECIMAL(10,3),
+        change_reason TEXT, match_id BIGINT, changed_by_user_id BIGINT, recalculation_job_id BIGINT
+    );
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION close_season(
+    p_season_id BIGINT,
+    p_mu_factor NUMERIC,                -- NULL when the season closes without a soft reset
+    p_sigma_factor NUMERIC,
+    p_snapshot JSONB,                   -- [{"season_id", "user_id", "rank", "mmr", "trueskill_mu", "trueskill_sigma", "games_played"}, ...]
+    p_ratings JSONB DEFAULT '[]',       -- reset ratings, empty without a soft reset
+    p_history JSONB DEFAULT '[]'        -- season_reset history rows
+)
+RETURNS JSONB AS $$
+DECLARE
+    closed seasons%ROWTYPE;
+BEGIN
+    UPDATE seasons SET
+        status = 'closed',
+        ended_at = now(),
+        soft_reset_mu_factor = p_mu_factor,
+        soft_reset_sigma_factor = p_sigma_factor
+    WHERE id = p_season_id AND status = 'active'
+    RETURNING * INTO closed;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'season % is not active', p_season_id;
+    END IF;
+
+    INSERT INTO season_leaderboard_snapshots (season_id, user_id, rank, mmr, trueskill_mu, trueskill_sigma, games_played)
+    SELECT p_season_id, s.user_id, s.rank, s.mmr, s.trueskill_mu, s.trueskill_sigma, s.games_played
+    FROM jsonb_to_recordset(p_snapshot) AS s(
+        user_id BIGINT, rank INTEGER, mmr INTEGER, trueskill_mu NUMERIC(8,3), trueskill_sigma NUMERIC(6,3), games_played INTEGER
+    );
+
+    PERFORM apply_rating_changes(p_ratings, p_history);
+
+    RETURN to_jsonb(closed);
+END;
+$$ language 'plpgsql';
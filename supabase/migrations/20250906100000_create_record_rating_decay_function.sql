@@ -0,0 +1,17 @@
+-- Record Rating Decay Migration
+-- The decay job wrote the decayed rating and its inactivity_decay history row separately. When
+-- the history insert failed the next run could not see the decay and decayed the same period
+-- again, so record_rating_decay writes both in one transaction through apply_rating_changes.
+
+CREATE OR REPLACE FUNCTION record_rating_decay(
+    p_rating JSONB,     -- player_effective_mmr row: {"user_id", "guild_id", "mmr", "trueskill_mu", "trueskill_sigma", "games_played", "last_updated"}
+    p_history JSONB     -- the matching inactivity_decay player_historical_mmr row
+)
+RETURNS JSONB AS $$
+BEGIN
+    PERFORM apply_rating_changes(jsonb_build_array(p_rating), jsonb_build_array(p_history));
+
+    -- Echo the rating so callers can tell success from PostgREST's error object
+    RETURN p_rating;
+END;
+$$ language 'plpgsql';
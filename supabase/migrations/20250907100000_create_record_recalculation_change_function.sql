@@ -0,0 +1,25 @@
+-- Record Recalculation Change Migration
+-- Applying or reverting a recalculation wrote each player's rating and then its history row.
+-- When the history insert failed the rating had changed with no row linked to the job, so the
+-- job could not revert that player. record_recalculation_change writes the rating, or removes
+-- it, together with the history row in one transaction.
+
+CREATE OR REPLACE FUNCTION record_recalculation_change(
+    p_user_id BIGINT,
+    p_guild_id BIGINT,
+    p_rating JSONB,     -- player_effective_mmr row, or NULL to return the player to the defaults
+    p_history JSONB     -- the player_historical_mmr row linked to the recalculation job
+)
+RETURNS JSONB AS $$
+BEGIN
+    IF p_rating IS NULL THEN
+        DELETE FROM player_effective_mmr WHERE user_id = p_user_id AND guild_id = p_guild_id;
+        PERFORM apply_rating_changes('[]'::JSONB, jsonb_build_array(p_history));
+    ELSE
+        PERFORM apply_rating_changes(jsonb_build_array(p_rating), jsonb_build_array(p_history));
+    END IF;
+
+    -- Echo the history row so callers can tell success from PostgREST's error object
+    RETURN p_history;
+END;
+$$ language 'plpgsql';
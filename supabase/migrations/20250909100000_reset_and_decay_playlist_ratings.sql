@@ -0,0 +1,82 @@
+-- Reset And Decay Playlist Ratings Migration
+-- The soft reset at season close and inactivity decay only moved player_effective_mmr, so a
+-- player's per-playlist ratings kept last season's μ and σ. close_season and record_rating_decay
+-- now take the player_playlist_ratings rows the same reset or decay produced and write them in
+-- the same transaction as the combined rating.
+
+-- Writes per-playlist rating changes; no history rows, the combined rating keeps the audit trail
+CREATE OR REPLACE FUNCTION apply_playlist_rating_changes(
+    p_playlist_ratings JSONB    -- [{"user_id", "guild_id", "playlist", "trueskill_mu", "trueskill_sigma", "games_played", "last_updated"}, ...]
+)
+RETURNS VOID AS $$
+BEGIN
+    INSERT INTO player_playlist_ratings (user_id, guild_id, playlist, trueskill_mu, trueskill_sigma, games_played, last_updated)
+    SELECT pr.user_id, pr.guild_id, pr.playlist, pr.trueskill_mu, pr.trueskill_sigma, pr.games_played, pr.last_updated
+    FROM jsonb_to_recordset(p_playlist_ratings) AS pr(
+        user_id BIGINT, guild_id BIGINT, playlist TEXT, trueskill_mu DECIMAL(10,3), trueskill_sigma DECIMAL(10,3),
+        games_played INTEGER, last_updated TIMESTAMPTZ
+    )
+    ON CONFLICT (user_id, guild_id, playlist) DO UPDATE SET
+        trueskill_mu = EXCLUDED.trueskill_mu,
+        trueskill_sigma = EXCLUDED.trueskill_sigma,
+        games_played = EXCLUDED.games_played,
+        last_updated = EXCLUDED.last_updated;
+END;
+$$ language 'plpgsql';
+
+-- Adding a parameter creates an overload, so the old signatures are dropped first
+DROP FUNCTION IF EXISTS close_season(BIGINT, NUMERIC, NUMERIC, JSONB, JSONB, JSONB);
+DROP FUNCTION IF EXISTS record_rating_decay(JSONB, JSONB);
+
+CREATE OR REPLACE FUNCTION close_season(
+    p_season_id BIGINT,
+    p_mu_factor NUMERIC,                    -- NULL when the season closes without a soft reset
+    p_sigma_factor NUMERIC,
+    p_snapshot JSONB,                       -- [{"season_id", "user_id", "rank", "mmr", "trueskill_mu", "trueskill_sigma", "games_played"}, ...]
+    p_ratings JSONB DEFAULT '[]',           -- reset ratings, empty without a soft reset
+    p_history JSONB DEFAULT '[]',           -- season_reset history rows
+    p_playlist_ratings JSONB DEFAULT '[]'   -- reset per-playlist ratings, empty without a soft reset
+)
+RETURNS JSONB AS $$
+DECLARE
+    closed seasons%ROWTYPE;
+BEGIN
+    UPDATE seasons SET
+        status = 'closed',
+        ended_at = now(),
+        soft_reset_mu_factor = p_mu_factor,
+        soft_reset_sigma_factor = p_sigma_factor
+    WHERE id = p_season_id AND status = 'active'
+    RETURNING * INTO closed;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'season % is not active', p_season_id;
+    END IF;
+
+    INSERT INTO season_leaderboard_snapshots (season_id, user_id, rank, mmr, trueskill_mu, trueskill_sigma, games_played)
+    SELECT p_season_id, s.user_id, s.rank, s.mmr, s.trueskill_mu, s.trueskill_sigma, s.games_played
+    FROM jsonb_to_recordset(p_snapshot) AS s(
+        user_id BIGINT, rank INTEGER, mmr INTEGER, trueskill_mu NUMERIC(8,3), trueskill_sigma NUMERIC(6,3), games_played INTEGER
+    );
+
+    PERFORM apply_rating_changes(p_ratings, p_history);
+    PERFORM apply_playlist_rating_changes(p_playlist_ratings);
+
+    RETURN to_jsonb(closed);
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION record_rating_decay(
+    p_rating JSONB,                         -- player_effective_mmr row: {"user_id", "guild_id", "mmr", "trueskill_mu", "trueskill_sigma", "games_played", "last_updated"}
+    p_history JSONB,                        -- the matching inactivity_decay player_historical_mmr row
+    p_playlist_ratings JSONB DEFAULT '[]'   -- the player's decayed per-playlist ratings
+)
+RETURNS JSONB AS $$
+BEGIN
+    PERFORM apply_rating_changes(jsonb_build_array(p_rating), jsonb_build_array(p_history));
+    PERFORM apply_playlist_rating_changes(p_playlist_ratings);
+
+    -- Echo the rating so callers can tell success from PostgREST's error object
+    RETURN p_rating;
+END;
+$$ language 'plpgsql';
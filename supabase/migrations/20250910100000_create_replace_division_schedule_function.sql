@@ -0,0 +1,37 @@
+-- Replace Division Schedule Migration
+-- Regenerating a schedule deleted the division's fixtures and then inserted the new ones as two
+-- requests, so a failed insert left the division with no schedule at all. replace_division_schedule
+-- swaps the fixtures in one transaction: either the new schedule is in place, or the old one is kept.
+
+CREATE OR REPLACE FUNCTION replace_division_schedule(
+    p_division_id BIGINT,
+    p_matches JSONB     -- [{"guild_id", "division_id", "round", "match_night", "home_team_id", "away_team_id", "status"}, ...]
+)
+RETURNS JSONB AS $$
+DECLARE
+    created JSONB;
+BEGIN
+    -- A result reported since the schedule was checked means the season has started
+    IF EXISTS (SELECT 1 FROM scheduled_matches WHERE division_id = p_division_id AND status <> 'scheduled') THEN
+        RAISE EXCEPTION 'division % has played fixtures, its schedule cannot be replaced', p_division_id;
+    END IF;
+
+    DELETE FROM scheduled_matches WHERE division_id = p_division_id;
+
+    WITH inserted AS (
+        INSERT INTO scheduled_matches (guild_id, division_id, round, match_night, home_team_id, away_team_id, status)
+        SELECT m.guild_id, m.division_id, m.round, m.match_night, m.home_team_id, m.away_team_id, COALESCE(m.status, 'scheduled')
+        FROM jsonb_to_recordset(p_matches) AS m(
+            guild_id BIGINT, division_id BIGINT, round INTEGER, match_night TIMESTAMPTZ,
+            home_team_id BIGINT, away_team_id BIGINT, status TEXT
+        )
+        RETURNING *
+    )
+    SELECT COALESCE(jsonb_agg(to_jsonb(inserted) ORDER BY inserted.round, inserted.id), '[]'::JSONB)
+    INTO created
+    FROM inserted;
+
+    -- The new fixtures, so callers can tell success from PostgREST's error object
+    RETURN created;
+END;
+$$ language 'plpgsql';